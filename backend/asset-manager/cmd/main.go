@@ -8,10 +8,17 @@ import (
 	"syscall"
 	"time"
 
+	applifecycle "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/lifecycle"
+	appperson "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/person"
 	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/bootstrap"
+	neo4jinfra "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/infrastructure/neo4j"
+	neo4jasset "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/infrastructure/neo4j/asset"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/infrastructure/neo4j/lease"
+	neo4jperson "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/infrastructure/neo4j/person"
 	outbox "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/infrastructure/outbox"
 	bootstrap_events "github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/operations"
 )
 
 func main() {
@@ -37,6 +44,30 @@ func main() {
 
 	assetCmdService, assetQryService, bucketCmdService, bucketQryService, pipelineService, _ := bootstrap.InitServices(neo4jDriver)
 
+	assetRepo := neo4jasset.NewRepository(neo4jDriver)
+	if err := assetRepo.EnsureSearchIndexes(ctx); err != nil {
+		slog.WithError(err).Warn("Full-text search indexes are not ready")
+	}
+	if err := lease.EnsureConstraint(ctx, neo4jDriver); err != nil {
+		slog.WithError(err).Error("Failed to create lease constraint")
+		os.Exit(1)
+	}
+	assetRepoAdapter := neo4jinfra.NewAssetRepositoryAdapter(assetRepo)
+	assetQryService.WithSearcher(assetRepoAdapter)
+	assetCmdService.WithStatusWriter(assetRepoAdapter)
+
+	personRepo := neo4jperson.NewRepository(neo4jDriver)
+	if err := personRepo.EnsureConstraint(ctx); err != nil {
+		slog.WithError(err).Error("Failed to create person constraint")
+		os.Exit(1)
+	}
+	personLogger := logger.WithService("person-service")
+	personCmdService := appperson.NewCommandService(personRepo, personRepo, personRepo, personLogger)
+	personQryService := appperson.NewQueryService(personRepo, personRepo, personRepo, personLogger)
+	if dynamicCfg.GetBoolFromComponent("credits", "backfill_inline") {
+		go backfillInlineCredits(ctx, personCmdService, assetRepoAdapter, lease.New(neo4jDriver, "credit-backfill", cfg.Service+"-"+operations.GenerateID(), 10*time.Minute), slog)
+	}
+
 	assetEventConsumer := bootstrap.InitKafkaConsumer(ctx, assetCmdService, assetQryService, domainProducer, cdnService, pipelineService, dynamicCfg, neo4jDriver)
 	defer assetEventConsumer.Stop()
 
@@ -51,7 +82,25 @@ func main() {
 	} else {
 		gqlPublisher = jobProducer
 	}
-	gqlHandler := bootstrap.InitGraphQL(assetCmdService, assetQryService, bucketCmdService, bucketQryService, cdnService, pipelineService, gqlPublisher, cfg)
+	if dynamicCfg.GetBoolFromComponent("scheduler", "enabled") {
+		owner := cfg.Service + "-" + operations.GenerateID()
+		lock := lease.New(neo4jDriver, "publish-scheduler", owner, dynamicCfg.GetDurationFromComponent("scheduler", "lock_ttl", 2*time.Minute))
+		batchSize := dynamicCfg.GetIntFromComponent("scheduler", "batch_size")
+		if batchSize <= 0 {
+			batchSize = 50
+		}
+		scheduler := applifecycle.NewScheduler(
+			applifecycle.NewService(assetCmdService, gqlPublisher),
+			assetRepoAdapter,
+			lock,
+			dynamicCfg.GetDurationFromComponent("scheduler", "interval", 30*time.Second),
+			batchSize,
+		)
+		scheduler.Start(ctx)
+		defer scheduler.Stop()
+	}
+
+	gqlHandler := bootstrap.InitGraphQL(assetCmdService, assetQryService, bucketCmdService, bucketQryService, personCmdService, personQryService, cdnService, pipelineService, gqlPublisher, cfg)
 	authHandlerFunc := bootstrap.InitAuth(dynamicCfg)
 	router := bootstrap.InitRouter(gqlHandler, authHandlerFunc)
 	handler := bootstrap.InitMiddleware(router, cfg)
@@ -81,3 +130,25 @@ func main() {
 
 	slog.Info("Server exited")
 }
+
+// backfillInlineCredits links the credits stored inline on older assets to
+// people. The lease keeps instances starting together from racing each
+// other into duplicate people; the rest skip it.
+func backfillInlineCredits(ctx context.Context, people *appperson.CommandService, assets appperson.AssetLister, lock *lease.Lease, log *logger.Logger) {
+	held, err := lock.Acquire(ctx)
+	if err != nil {
+		log.WithError(err).Error("Failed to acquire credit backfill lock")
+		return
+	}
+	if !held {
+		return
+	}
+	defer func() {
+		if err := lock.Release(context.Background()); err != nil {
+			log.WithError(err).Warn("Failed to release credit backfill lock")
+		}
+	}()
+	if _, err := people.BackfillInlineCredits(ctx, assets, 100); err != nil {
+		log.WithError(err).Error("Failed to backfill inline credits")
+	}
+}
@@ -12,9 +12,10 @@ import (
 )
 
 type CommandService struct {
-	saver  asset.Saver
-	finder asset.Finder
-	logger *logger.Logger
+	saver        asset.Saver
+	finder       asset.Finder
+	statusWriter asset.StatusWriter
+	logger       *logger.Logger
 }
 
 func NewCommandService(
@@ -29,6 +30,14 @@ func NewCommandService(
 	}
 }
 
+// WithStatusWriter makes ChangeAssetStatus save only when the status it
+// read is still stored, so concurrent transitions cannot both succeed.
+// Without it the whole asset is saved unconditionally.
+func (s *CommandService) WithStatusWriter(writer asset.StatusWriter) *CommandService {
+	s.statusWriter = writer
+	return s
+}
+
 func (s *CommandService) CreateAsset(ctx context.Context, cmd commands.CreateAssetCommand) (*entity.Asset, error) {
 	asset, err := entity.NewAsset(cmd.Slug, cmd.Title, cmd.AssetType)
 	if err != nil {
@@ -48,8 +57,24 @@ func (s *CommandService) CreateAsset(ctx context.Context, cmd commands.CreateAss
 	if cmd.OwnerID != nil {
 		asset.SetOwnerID(cmd.OwnerID)
 	}
+	var parent *entity.Asset
 	if cmd.ParentID != nil {
-		asset.SetParentID(cmd.ParentID)
+		parent, err = s.finder.FindByID(ctx, *cmd.ParentID)
+		if err != nil {
+			return nil, errors.NewInternalError("failed to find parent asset", err)
+		}
+		if parent == nil {
+			return nil, errors.NewNotFoundError("parent asset not found", nil)
+		}
+	}
+	if err := asset.SetParent(parent); err != nil {
+		return nil, errors.NewValidationError("invalid asset hierarchy", err)
+	}
+	if err := asset.SetSeasonNumber(cmd.SeasonNumber); err != nil {
+		return nil, errors.NewValidationError("invalid season number", err)
+	}
+	if err := asset.SetEpisodeNumber(cmd.EpisodeNumber); err != nil {
+		return nil, errors.NewValidationError("invalid episode number", err)
 	}
 
 	if err := s.saver.Save(ctx, asset); err != nil {
@@ -71,6 +96,11 @@ func (s *CommandService) UpsertVideo(ctx context.Context, cmd commands.UpsertVid
 	if asset == nil {
 		return nil, nil, errors.NewNotFoundError("asset not found", nil)
 	}
+	if cmd.Variant {
+		if _, err := asset.AddVideoVariant(cmd.Label, cmd.Format, cmd.StorageLocation, cmd.ContentType, cmd.InitialStatus); err != nil {
+			return nil, nil, errors.NewValidationError("failed to add video variant", err)
+		}
+	}
 	video, err := asset.UpsertVideo(
 		cmd.Label,
 		cmd.Format,
@@ -96,6 +126,13 @@ func (s *CommandService) UpsertVideo(ctx context.Context, cmd commands.UpsertVid
 	if cmd.SegmentCount > 0 || cmd.AvgSegmentDuration > 0 || len(cmd.Segments) > 0 {
 		video.UpdateStreamingDetails(cmd.SegmentCount, cmd.AvgSegmentDuration, cmd.Segments)
 	}
+	if len(cmd.QualityScores) > 0 {
+		video.SetQualityScores(cmd.QualityScores)
+	}
+	if len(cmd.Ladder) > 0 {
+		video.SetLadder(cmd.Ladder)
+		video.SetComplexity(cmd.Complexity)
+	}
 	if err := s.saver.Update(ctx, asset); err != nil {
 		return nil, nil, errors.NewInternalError("failed to save asset", err)
 	}
@@ -146,10 +183,72 @@ func (s *CommandService) UpdateVideoMetadata(ctx context.Context, cmd commands.U
 	if err != nil {
 		return errors.NewValidationError("invalid content type", err)
 	}
-	transcodingInfo := valueobjects.NewMediaInfo(cmd.Width, cmd.Height, cmd.Duration, cmd.Bitrate, cmd.Codec, cmd.Size, *contentTypeVO, "", "", "", 0, 0)
+	var audioCodec string
+	var audioChannels, audioSampleRate int
+	for i, track := range cmd.AudioTracks {
+		if i == 0 || track.IsDefault() {
+			audioCodec, audioChannels, audioSampleRate = track.Codec(), track.Channels(), track.SampleRate()
+		}
+		if track.IsDefault() {
+			break
+		}
+	}
+	transcodingInfo := valueobjects.NewMediaInfo(cmd.Width, cmd.Height, cmd.Duration, cmd.Bitrate, cmd.Codec, cmd.Size, *contentTypeVO, "", audioCodec, "", audioChannels, audioSampleRate)
 	if err := asset.UpdateVideoMediaInfo(cmd.VideoID, *transcodingInfo); err != nil {
 		return errors.NewValidationError("failed to update video metadata", err)
 	}
+	if len(cmd.AudioTracks) > 0 {
+		if err := asset.SetVideoAudioTracks(cmd.VideoID, cmd.AudioTracks); err != nil {
+			return errors.NewValidationError("failed to update video audio tracks", err)
+		}
+	}
+	if cmd.Complexity > 0 {
+		if err := asset.SetVideoComplexity(cmd.VideoID, cmd.Complexity); err != nil {
+			return errors.NewValidationError("failed to update video complexity", err)
+		}
+	}
+	return s.saver.Update(ctx, asset)
+}
+
+func (s *CommandService) SetVideoMarkers(ctx context.Context, cmd commands.SetVideoMarkersCommand) error {
+	asset, err := s.finder.FindByID(ctx, cmd.AssetID)
+	if err != nil {
+		return errors.NewInternalError("failed to find asset", err)
+	}
+	if asset == nil {
+		return errors.NewNotFoundError("asset not found", nil)
+	}
+	if err := asset.SetVideoMarkers(cmd.VideoID, cmd.Markers); err != nil {
+		return errors.NewValidationError("failed to set video markers", err)
+	}
+	return s.saver.Update(ctx, asset)
+}
+
+func (s *CommandService) RejectVideoInput(ctx context.Context, cmd commands.RejectVideoInputCommand) error {
+	asset, err := s.finder.FindByID(ctx, cmd.AssetID)
+	if err != nil {
+		return errors.NewInternalError("failed to find asset", err)
+	}
+	if asset == nil {
+		return errors.NewNotFoundError("asset not found", nil)
+	}
+	if err := asset.RejectVideoInput(cmd.VideoID, cmd.Rejections); err != nil {
+		return errors.NewValidationError("failed to reject video input", err)
+	}
+	return s.saver.Update(ctx, asset)
+}
+
+func (s *CommandService) SetDefaultAudioLanguage(ctx context.Context, cmd commands.SetDefaultAudioLanguageCommand) error {
+	asset, err := s.finder.FindByID(ctx, cmd.AssetID)
+	if err != nil {
+		return errors.NewInternalError("failed to find asset", err)
+	}
+	if asset == nil {
+		return errors.NewNotFoundError("asset not found", nil)
+	}
+	if err := asset.SetVideoDefaultAudioLanguage(cmd.VideoID, cmd.Language); err != nil {
+		return errors.NewValidationError("failed to set default audio language", err)
+	}
 	return s.saver.Update(ctx, asset)
 }
 
@@ -167,6 +266,58 @@ func (s *CommandService) AddImage(ctx context.Context, cmd commands.AddImageComm
 	return s.saver.Update(ctx, asset)
 }
 
+func (s *CommandService) AddSubtitle(ctx context.Context, cmd commands.AddSubtitleCommand) error {
+	asset, err := s.finder.FindByID(ctx, cmd.AssetID)
+	if err != nil {
+		return errors.NewInternalError("failed to find asset", err)
+	}
+	if asset == nil {
+		return errors.NewNotFoundError("asset not found", nil)
+	}
+
+	subtitle, err := entity.NewSubtitle(cmd.Language, cmd.Kind, cmd.Label, cmd.IsDefault, cmd.StorageLocation, cmd.ContentType)
+	if err != nil {
+		return errors.NewValidationError("invalid subtitle", err)
+	}
+	if err := asset.AddSubtitle(subtitle); err != nil {
+		return errors.NewValidationError("failed to add subtitle", err)
+	}
+
+	return s.saver.Update(ctx, asset)
+}
+
+func (s *CommandService) RemoveSubtitle(ctx context.Context, cmd commands.RemoveSubtitleCommand) error {
+	asset, err := s.finder.FindByID(ctx, cmd.AssetID)
+	if err != nil {
+		return errors.NewInternalError("failed to find asset", err)
+	}
+	if asset == nil {
+		return errors.NewNotFoundError("asset not found", nil)
+	}
+
+	if err := asset.RemoveSubtitle(cmd.SubtitleID); err != nil {
+		return errors.NewValidationError("failed to remove subtitle", err)
+	}
+
+	return s.saver.Update(ctx, asset)
+}
+
+func (s *CommandService) AttachVideoImages(ctx context.Context, cmd commands.AttachVideoImagesCommand) error {
+	asset, err := s.finder.FindByID(ctx, cmd.AssetID)
+	if err != nil {
+		return errors.NewInternalError("failed to find asset", err)
+	}
+	if asset == nil {
+		return errors.NewNotFoundError("asset not found", nil)
+	}
+
+	if err := asset.AttachVideoImages(cmd.VideoID, cmd.VideoImages, cmd.AssetImages); err != nil {
+		return errors.NewValidationError("failed to attach video images", err)
+	}
+
+	return s.saver.Update(ctx, asset)
+}
+
 func (s *CommandService) RemoveImage(ctx context.Context, cmd commands.RemoveImageCommand) error {
 	asset, err := s.finder.FindByID(ctx, cmd.AssetID)
 	if err != nil {
@@ -211,6 +362,62 @@ func (s *CommandService) ClearPublishRule(ctx context.Context, cmd commands.Clea
 	return s.saver.Update(ctx, asset)
 }
 
+// ChangeAssetStatus returns the asset with the domain events of the change
+// still pending, so the caller can publish them once it is saved.
+func (s *CommandService) ChangeAssetStatus(ctx context.Context, cmd commands.ChangeAssetStatusCommand) (*entity.Asset, error) {
+	asset, err := s.finder.FindByID(ctx, cmd.AssetID)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to find asset", err)
+	}
+	if asset == nil {
+		return nil, errors.NewNotFoundError("asset not found", nil)
+	}
+	if cmd.From != "" && asset.Status() != cmd.From.Value() {
+		return nil, errors.NewValidationError("asset is "+asset.Status()+", not "+cmd.From.Value(), nil)
+	}
+	current, err := valueobjects.NewAssetStatus(asset.Status())
+	if err != nil {
+		return nil, errors.NewInternalError("asset has an unknown status", err)
+	}
+	if err := asset.TransitionTo(cmd.Status, cmd.Note, cmd.At); err != nil {
+		return nil, errors.NewValidationError("failed to change asset status", err)
+	}
+	if s.statusWriter == nil {
+		if err := s.saver.Update(ctx, asset); err != nil {
+			return nil, errors.NewInternalError("failed to save asset", err)
+		}
+		return asset, nil
+	}
+	updated, err := s.statusWriter.UpdateStatus(ctx, asset, current)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to save asset status", err)
+	}
+	if !updated {
+		return nil, errors.NewConflictError("asset status changed while this change was being made", nil)
+	}
+	return asset, nil
+}
+
+func (s *CommandService) SetAssetNumbering(ctx context.Context, cmd commands.SetAssetNumberingCommand) (*entity.Asset, error) {
+	asset, err := s.finder.FindByID(ctx, cmd.AssetID)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to find asset", err)
+	}
+	if asset == nil {
+		return nil, errors.NewNotFoundError("asset not found", nil)
+	}
+	if err := asset.SetSeasonNumber(cmd.SeasonNumber); err != nil {
+		return nil, errors.NewValidationError("invalid season number", err)
+	}
+	if err := asset.SetEpisodeNumber(cmd.EpisodeNumber); err != nil {
+		return nil, errors.NewValidationError("invalid episode number", err)
+	}
+	if err := s.saver.Update(ctx, asset); err != nil {
+		return nil, errors.NewInternalError("failed to save asset", err)
+	}
+	return asset, nil
+}
+
 func (s *CommandService) UpdateAssetTitle(ctx context.Context, cmd commands.UpdateAssetTitleCommand) error {
 	asset, err := s.finder.FindByID(ctx, cmd.AssetID)
 	if err != nil {
@@ -234,3 +441,121 @@ func (s *CommandService) UpdateAssetDescription(ctx context.Context, cmd command
 	asset.UpdateDescription(&cmd.Description)
 	return s.saver.Update(ctx, asset)
 }
+
+func (s *CommandService) CreateStreamKey(ctx context.Context, cmd commands.CreateStreamKeyCommand) (*entity.Asset, error) {
+	asset, err := s.finder.FindByID(ctx, cmd.AssetID)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to find asset", err)
+	}
+	if asset == nil {
+		return nil, errors.NewNotFoundError("asset not found", nil)
+	}
+	if _, err := asset.CreateStreamKey(cmd.Protocol); err != nil {
+		return nil, errors.NewValidationError("failed to create stream key", err)
+	}
+	if err := s.saver.Update(ctx, asset); err != nil {
+		return nil, errors.NewInternalError("failed to save asset", err)
+	}
+	return asset, nil
+}
+
+func (s *CommandService) StartLiveEvent(ctx context.Context, cmd commands.StartLiveEventCommand) (*entity.Asset, error) {
+	asset, err := s.finder.FindByID(ctx, cmd.AssetID)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to find asset", err)
+	}
+	if asset == nil {
+		return nil, errors.NewNotFoundError("asset not found", nil)
+	}
+	if err := asset.StartLiveEvent(cmd.SessionID); err != nil {
+		return nil, errors.NewValidationError("failed to start live event", err)
+	}
+	if err := s.saver.Update(ctx, asset); err != nil {
+		return nil, errors.NewInternalError("failed to save asset", err)
+	}
+	return asset, nil
+}
+
+func (s *CommandService) StopLiveEvent(ctx context.Context, cmd commands.StopLiveEventCommand) (*entity.Asset, error) {
+	asset, err := s.finder.FindByID(ctx, cmd.AssetID)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to find asset", err)
+	}
+	if asset == nil {
+		return nil, errors.NewNotFoundError("asset not found", nil)
+	}
+	if err := asset.StopLiveEvent(); err != nil {
+		return nil, errors.NewValidationError("failed to stop live event", err)
+	}
+	if err := s.saver.Update(ctx, asset); err != nil {
+		return nil, errors.NewInternalError("failed to save asset", err)
+	}
+	return asset, nil
+}
+
+func (s *CommandService) MarkLiveReady(ctx context.Context, cmd commands.MarkLiveReadyCommand) error {
+	asset, err := s.finder.FindByID(ctx, cmd.AssetID)
+	if err != nil {
+		return errors.NewInternalError("failed to find asset", err)
+	}
+	if asset == nil {
+		return errors.NewNotFoundError("asset not found", nil)
+	}
+	if err := asset.MarkLiveReady(cmd.SessionID, cmd.IngestURL); err != nil {
+		return errors.NewValidationError("failed to mark live event ready", err)
+	}
+	return s.saver.Update(ctx, asset)
+}
+
+func (s *CommandService) MarkLiveStarted(ctx context.Context, cmd commands.MarkLiveStartedCommand) error {
+	asset, err := s.finder.FindByID(ctx, cmd.AssetID)
+	if err != nil {
+		return errors.NewInternalError("failed to find asset", err)
+	}
+	if asset == nil {
+		return errors.NewNotFoundError("asset not found", nil)
+	}
+	if err := asset.MarkLiveStarted(cmd.SessionID, cmd.PlaybackURL, cmd.StartedAt); err != nil {
+		return errors.NewValidationError("failed to mark live event started", err)
+	}
+	return s.saver.Update(ctx, asset)
+}
+
+// EndLiveEvent returns the archive video when one was added. The archive
+// replaces the recording of the previous event, since both share a label.
+func (s *CommandService) EndLiveEvent(ctx context.Context, cmd commands.EndLiveEventCommand) (*entity.Asset, *entity.Video, error) {
+	asset, err := s.finder.FindByID(ctx, cmd.AssetID)
+	if err != nil {
+		return nil, nil, errors.NewInternalError("failed to find asset", err)
+	}
+	if asset == nil {
+		return nil, nil, errors.NewNotFoundError("asset not found", nil)
+	}
+	if err := asset.EndLiveEvent(cmd.SessionID, cmd.Success, cmd.ErrorMessage, cmd.PlaybackURL, cmd.EndedAt); err != nil {
+		return nil, nil, errors.NewValidationError("failed to end live event", err)
+	}
+
+	var video *entity.Video
+	if cmd.Archive != nil {
+		format := valueobjects.VideoFormatRaw
+		initial := valueobjects.VideoStatusReady
+		video, err = asset.UpsertVideo(
+			liveArchiveLabel, &format, *cmd.Archive,
+			0, 0, cmd.Duration, 0, "", 0, "video/mp2t", "", "", "", 0, 0, nil, &initial,
+		)
+		if err != nil {
+			// The event has ended either way; the recording just stays in
+			// storage without a video.
+			s.logger.WithError(err).Warn("Failed to add live archive video", "asset_id", cmd.AssetID.Value())
+			video = nil
+		} else if err := asset.SetLiveArchiveVideo(video.ID().Value()); err != nil {
+			return nil, nil, errors.NewValidationError("failed to link live archive", err)
+		}
+	}
+	if err := s.saver.Update(ctx, asset); err != nil {
+		return nil, nil, errors.NewInternalError("failed to save asset", err)
+	}
+	return asset, video, nil
+}
+
+const liveArchiveLabel = "live-archive"
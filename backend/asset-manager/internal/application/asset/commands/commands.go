@@ -1,6 +1,8 @@
 package commands
 
 import (
+	"time"
+
 	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
 )
 
@@ -13,6 +15,9 @@ type CreateAssetCommand struct {
 	Tags      *valueobjects.Tags
 	OwnerID   *valueobjects.OwnerID
 	ParentID  *valueobjects.AssetID
+
+	SeasonNumber  *int
+	EpisodeNumber *int
 }
 
 type DeleteAssetCommand struct {
@@ -61,6 +66,12 @@ type UpsertVideoCommand struct {
 	SegmentCount       int
 	AvgSegmentDuration float64
 	Segments           []string
+	QualityScores      []valueobjects.RenditionQuality
+	Complexity         float64
+	Ladder             []valueobjects.LadderRung
+	// Variant stores the video as an overlay variant under Label instead of
+	// as the format's main video.
+	Variant bool
 }
 
 type RemoveVideoCommand struct {
@@ -84,6 +95,26 @@ type UpdateVideoMetadataCommand struct {
 	Codec       string
 	Size        int64
 	ContentType string
+	AudioTracks []valueobjects.AudioTrack
+	Complexity  float64
+}
+
+type SetDefaultAudioLanguageCommand struct {
+	AssetID  valueobjects.AssetID
+	VideoID  string
+	Language string
+}
+
+type RejectVideoInputCommand struct {
+	AssetID    valueobjects.AssetID
+	VideoID    string
+	Rejections []valueobjects.InputRejection
+}
+
+type SetVideoMarkersCommand struct {
+	AssetID valueobjects.AssetID
+	VideoID string
+	Markers []valueobjects.Marker
 }
 
 type AddImageCommand struct {
@@ -91,6 +122,28 @@ type AddImageCommand struct {
 	Image   valueobjects.Image
 }
 
+type AddSubtitleCommand struct {
+	AssetID         valueobjects.AssetID
+	Language        string
+	Kind            string
+	Label           string
+	IsDefault       bool
+	StorageLocation valueobjects.S3Object
+	ContentType     string
+}
+
+type RemoveSubtitleCommand struct {
+	AssetID    valueobjects.AssetID
+	SubtitleID string
+}
+
+type AttachVideoImagesCommand struct {
+	AssetID     valueobjects.AssetID
+	VideoID     string
+	VideoImages []valueobjects.Image
+	AssetImages []valueobjects.Image
+}
+
 type RemoveImageCommand struct {
 	AssetID valueobjects.AssetID
 	ImageID string
@@ -110,6 +163,24 @@ type ClearAssetPublishRuleCommand struct {
 	AssetID valueobjects.AssetID
 }
 
+// ChangeAssetStatusCommand moves an asset to Status. A non-empty From makes
+// the change apply only to an asset currently in that status.
+type ChangeAssetStatusCommand struct {
+	AssetID valueobjects.AssetID
+	From    valueobjects.AssetStatus
+	Status  valueobjects.AssetStatus
+	Note    string
+	At      time.Time
+}
+
+// SetAssetNumberingCommand numbers a season or an episode. A nil number
+// clears it.
+type SetAssetNumberingCommand struct {
+	AssetID       valueobjects.AssetID
+	SeasonNumber  *int
+	EpisodeNumber *int
+}
+
 type UpdateAssetTitleCommand struct {
 	AssetID valueobjects.AssetID
 	Title   valueobjects.Title
@@ -119,3 +190,43 @@ type UpdateAssetDescriptionCommand struct {
 	AssetID     valueobjects.AssetID
 	Description valueobjects.Description
 }
+
+type CreateStreamKeyCommand struct {
+	AssetID  valueobjects.AssetID
+	Protocol valueobjects.LiveProtocol
+}
+
+type StartLiveEventCommand struct {
+	AssetID   valueobjects.AssetID
+	SessionID string
+}
+
+type StopLiveEventCommand struct {
+	AssetID valueobjects.AssetID
+}
+
+type MarkLiveReadyCommand struct {
+	AssetID   valueobjects.AssetID
+	SessionID string
+	IngestURL string
+}
+
+type MarkLiveStartedCommand struct {
+	AssetID     valueobjects.AssetID
+	SessionID   string
+	PlaybackURL string
+	StartedAt   time.Time
+}
+
+// EndLiveEventCommand records the end of an event. With an Archive location
+// the recording is added as a raw video in the same update.
+type EndLiveEventCommand struct {
+	AssetID      valueobjects.AssetID
+	SessionID    string
+	Success      bool
+	ErrorMessage string
+	PlaybackURL  string
+	EndedAt      time.Time
+	Archive      *valueobjects.S3Object
+	Duration     float64
+}
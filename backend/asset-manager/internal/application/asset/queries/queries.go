@@ -15,3 +15,21 @@ type SearchAssetsQuery struct {
 	Limit  *int   `json:"limit"`
 	Offset *int   `json:"offset"`
 }
+
+// FullTextSearchQuery searches assets by relevance. The optional fields
+// filter the hits by facet value.
+type FullTextSearchQuery struct {
+	Query  string  `json:"query"`
+	Type   *string `json:"type,omitempty"`
+	Genre  *string `json:"genre,omitempty"`
+	Year   *int    `json:"year,omitempty"`
+	Status *string `json:"status,omitempty"`
+	Limit  *int    `json:"limit"`
+	Offset *int    `json:"offset"`
+}
+
+// GetSeriesTreeQuery looks up the series containing ID, which may be the
+// series itself or one of its seasons or episodes.
+type GetSeriesTreeQuery struct {
+	ID string `json:"id"`
+}
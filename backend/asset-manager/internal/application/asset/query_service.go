@@ -3,18 +3,31 @@ package asset
 import (
 	"context"
 	"strconv"
+	"strings"
 
 	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/asset/queries"
 	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset"
 	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/entity"
 	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/constants"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
 )
 
+// maxSeriesChildren bounds how many children are read at each level of a
+// series tree.
+const maxSeriesChildren = 500
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
 type QueryService struct {
-	finder  asset.Finder
-	querier asset.Querier
-	logger  *logger.Logger
+	finder   asset.Finder
+	querier  asset.Querier
+	searcher asset.Searcher
+	logger   *logger.Logger
 }
 
 func NewQueryService(
@@ -29,6 +42,12 @@ func NewQueryService(
 	}
 }
 
+// WithSearcher enables FullTextSearch.
+func (s *QueryService) WithSearcher(searcher asset.Searcher) *QueryService {
+	s.searcher = searcher
+	return s
+}
+
 func (s *QueryService) GetAsset(ctx context.Context, query queries.GetAssetQuery) (*entity.Asset, error) {
 	assetID, err := valueobjects.NewAssetID(query.ID)
 	if err != nil {
@@ -37,6 +56,51 @@ func (s *QueryService) GetAsset(ctx context.Context, query queries.GetAssetQuery
 	return s.finder.FindByID(ctx, *assetID)
 }
 
+// GetSeriesTree returns the series containing the queried asset, with its
+// seasons and episodes in order.
+func (s *QueryService) GetSeriesTree(ctx context.Context, query queries.GetSeriesTreeQuery) (*entity.SeriesTree, error) {
+	assetID, err := valueobjects.NewAssetID(query.ID)
+	if err != nil {
+		return nil, err
+	}
+	series, err := s.finder.FindByID(ctx, *assetID)
+	if err != nil {
+		return nil, err
+	}
+	// An episode is two levels below its series and a season one.
+	for i := 0; i < 2 && series != nil && !isAssetType(series, constants.AssetTypeSeries) && series.ParentID() != nil; i++ {
+		series, err = s.finder.FindByID(ctx, *series.ParentID())
+		if err != nil {
+			return nil, err
+		}
+	}
+	if series == nil || !isAssetType(series, constants.AssetTypeSeries) {
+		return nil, errors.NewValidationError("asset is not part of a series", nil)
+	}
+
+	limit := maxSeriesChildren
+	seasons, err := s.querier.FindByParentID(ctx, series.ID(), &limit, nil)
+	if err != nil {
+		return nil, err
+	}
+	episodes := make(map[string][]*entity.Asset, len(seasons))
+	for _, season := range seasons {
+		if !isAssetType(season, constants.AssetTypeSeason) {
+			continue
+		}
+		children, err := s.querier.FindByParentID(ctx, season.ID(), &limit, nil)
+		if err != nil {
+			return nil, err
+		}
+		episodes[season.ID().Value()] = children
+	}
+	return entity.NewSeriesTree(series, seasons, episodes), nil
+}
+
+func isAssetType(a *entity.Asset, assetType string) bool {
+	return a.Type() != nil && a.Type().Value() == assetType
+}
+
 func (s *QueryService) ListAssets(ctx context.Context, query queries.ListAssetsQuery) ([]*entity.Asset, error) {
 	return s.querier.List(ctx, query.Limit, query.Offset)
 }
@@ -45,6 +109,40 @@ func (s *QueryService) SearchAssets(ctx context.Context, query queries.SearchAss
 	return s.querier.Search(ctx, query.Query, query.Limit, query.Offset)
 }
 
+// FullTextSearch returns a page of assets ranked by relevance to the query,
+// with facet counts by type, genre and release year.
+func (s *QueryService) FullTextSearch(ctx context.Context, query queries.FullTextSearchQuery) (*entity.SearchResult, error) {
+	if s.searcher == nil {
+		return nil, errors.NewInternalError("full-text search is not configured", nil)
+	}
+	text := strings.TrimSpace(query.Query)
+	if text == "" {
+		return nil, errors.NewValidationError("search query cannot be empty", nil)
+	}
+
+	limit := defaultSearchLimit
+	if query.Limit != nil && *query.Limit > 0 {
+		limit = *query.Limit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+	offset := 0
+	if query.Offset != nil && *query.Offset > 0 {
+		offset = *query.Offset
+	}
+
+	return s.searcher.FullTextSearch(ctx, entity.SearchCriteria{
+		Query:  text,
+		Type:   query.Type,
+		Genre:  query.Genre,
+		Year:   query.Year,
+		Status: query.Status,
+		Limit:  limit,
+		Offset: offset,
+	})
+}
+
 // ListAssetsPage handles pagination logic for listing assets.
 func (s *QueryService) ListAssetsPage(ctx context.Context, query queries.ListAssetsQuery) (*entity.AssetPage, error) {
 	items, err := s.querier.List(ctx, query.Limit, query.Offset)
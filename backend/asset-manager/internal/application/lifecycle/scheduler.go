@@ -0,0 +1,127 @@
+package lifecycle
+
+import (
+	"context"
+	"time"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset"
+	assetvo "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
+)
+
+// Lock keeps more than one asset-manager instance from running the
+// scheduler at once.
+type Lock interface {
+	Acquire(ctx context.Context) (bool, error)
+	Release(ctx context.Context) error
+}
+
+// Scheduler publishes scheduled assets once their publish date passes and
+// unpublishes published assets once their unpublish date passes. Due assets
+// are looked up from stored state on every tick, so dates that passed while
+// no instance was running are caught up after a restart. The lock and the
+// status check on each transition make every transition happen once, and
+// only the instance that made it publishes its event.
+type Scheduler struct {
+	service   *Service
+	finder    asset.ScheduleFinder
+	lock      Lock
+	interval  time.Duration
+	batchSize int
+	logger    *logger.Logger
+	quitCh    chan struct{}
+	closed    bool
+}
+
+func NewScheduler(service *Service, finder asset.ScheduleFinder, lock Lock, interval time.Duration, batchSize int) *Scheduler {
+	return &Scheduler{
+		service:   service,
+		finder:    finder,
+		lock:      lock,
+		interval:  interval,
+		batchSize: batchSize,
+		logger:    logger.WithService("publish-scheduler"),
+		quitCh:    make(chan struct{}, 1),
+	}
+}
+
+// Start runs a first pass straight away, so transitions that came due while
+// no instance was running are not held back for a whole interval.
+func (s *Scheduler) Start(ctx context.Context) {
+	go func() {
+		s.tick(ctx)
+		t := time.NewTicker(s.interval)
+		defer t.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-s.quitCh:
+				if err := s.lock.Release(context.Background()); err != nil {
+					s.logger.WithError(err).Warn("Failed to release scheduler lock")
+				}
+				return
+			case <-t.C:
+				s.tick(ctx)
+			}
+		}
+	}()
+}
+
+func (s *Scheduler) tick(ctx context.Context) {
+	held, err := s.lock.Acquire(ctx)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to acquire scheduler lock")
+		return
+	}
+	if held {
+		s.RunOnce(ctx, time.Now().UTC())
+	}
+}
+
+func (s *Scheduler) Stop() {
+	if !s.closed {
+		s.closed = true
+		s.quitCh <- struct{}{}
+	}
+}
+
+// RunOnce applies every transition due at now. Publishing runs first, so an
+// asset whose whole window passed during downtime is published and then
+// unpublished, and both events go out.
+func (s *Scheduler) RunOnce(ctx context.Context, now time.Time) {
+	s.apply(ctx, now, s.finder.FindDueForPublish, assetvo.AssetStatusScheduled, assetvo.AssetStatusPublished, "publish date reached")
+	s.apply(ctx, now, s.finder.FindDueForUnpublish, assetvo.AssetStatusPublished, assetvo.AssetStatusUnpublished, "unpublish date reached")
+}
+
+func (s *Scheduler) apply(
+	ctx context.Context,
+	now time.Time,
+	find func(context.Context, time.Time, int, int) ([]assetvo.AssetID, error),
+	from, to assetvo.AssetStatus,
+	note string,
+) {
+	// A changed asset leaves the due set. One whose guard fails, such as a
+	// poster removed after scheduling, stays due and is retried next tick;
+	// later pages skip past it, so a batch of stuck assets cannot hold back
+	// the ones due after them.
+	failed := 0
+	for ctx.Err() == nil {
+		ids, err := find(ctx, now, failed, s.batchSize)
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to find due assets", "status", to.Value())
+			return
+		}
+		for _, id := range ids {
+			if _, err := s.service.change(ctx, id.Value(), from, to, note); err != nil {
+				s.logger.WithError(err).Warn("Scheduled status change failed", "asset_id", id.Value(), "status", to.Value())
+				failed++
+				continue
+			}
+			s.logger.Info("Applied scheduled status change", "asset_id", id.Value(), "status", to.Value())
+		}
+		if len(ids) < s.batchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package lifecycle
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	appasset "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/asset"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/constants"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
+)
+
+// fakeAssets stores assets in memory and answers the scheduler's due
+// queries from their stored status and publish rule. Statuses are kept apart
+// from the shared entities so UpdateStatus can check them like the database.
+type fakeAssets struct {
+	mu       sync.Mutex
+	assets   map[string]*entity.Asset
+	statuses map[string]string
+	finds    chan struct{}
+}
+
+func newFakeAssets(assets ...*entity.Asset) *fakeAssets {
+	f := &fakeAssets{assets: map[string]*entity.Asset{}, statuses: map[string]string{}, finds: make(chan struct{}, 16)}
+	for _, a := range assets {
+		f.assets[a.ID().Value()] = a
+		f.statuses[a.ID().Value()] = a.Status()
+	}
+	return f
+}
+
+func (f *fakeAssets) UpdateStatus(ctx context.Context, a *entity.Asset, expected valueobjects.AssetStatus) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.statuses[a.ID().Value()] != expected.Value() {
+		return false, nil
+	}
+	f.statuses[a.ID().Value()] = a.Status()
+	return true, nil
+}
+
+func (f *fakeAssets) status(id valueobjects.AssetID) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.statuses[id.Value()]
+}
+
+func (f *fakeAssets) Save(ctx context.Context, a *entity.Asset) error           { return f.Update(ctx, a) }
+func (f *fakeAssets) Delete(ctx context.Context, id valueobjects.AssetID) error { return nil }
+
+func (f *fakeAssets) Update(ctx context.Context, a *entity.Asset) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.assets[a.ID().Value()] = a
+	f.statuses[a.ID().Value()] = a.Status()
+	return nil
+}
+
+func (f *fakeAssets) FindByID(ctx context.Context, id valueobjects.AssetID) (*entity.Asset, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.assets[id.Value()], nil
+}
+
+func (f *fakeAssets) FindBySlug(ctx context.Context, slug valueobjects.Slug) (*entity.Asset, error) {
+	return nil, nil
+}
+
+func (f *fakeAssets) FindDueForPublish(ctx context.Context, now time.Time, offset, limit int) ([]valueobjects.AssetID, error) {
+	return f.due(valueobjects.AssetStatusScheduled, now, (*valueobjects.PublishRule).PublishAt, offset, limit), nil
+}
+
+func (f *fakeAssets) FindDueForUnpublish(ctx context.Context, now time.Time, offset, limit int) ([]valueobjects.AssetID, error) {
+	return f.due(valueobjects.AssetStatusPublished, now, (*valueobjects.PublishRule).UnpublishAt, offset, limit), nil
+}
+
+// due pages through the due assets in the query's order: date, then ID.
+func (f *fakeAssets) due(status valueobjects.AssetStatus, now time.Time, date func(*valueobjects.PublishRule) *time.Time, offset, limit int) []valueobjects.AssetID {
+	select {
+	case f.finds <- struct{}{}:
+	default:
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var due []*entity.Asset
+	for id, a := range f.assets {
+		rule := a.PublishRule()
+		if f.statuses[id] != status.Value() || rule == nil {
+			continue
+		}
+		if at := date(rule); at != nil && !at.After(now) {
+			due = append(due, a)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		di, dj := date(due[i].PublishRule()), date(due[j].PublishRule())
+		if !di.Equal(*dj) {
+			return di.Before(*dj)
+		}
+		return due[i].ID().Value() < due[j].ID().Value()
+	})
+	var ids []valueobjects.AssetID
+	for i := offset; i < len(due) && i < offset+limit; i++ {
+		ids = append(ids, due[i].ID())
+	}
+	return ids
+}
+
+// staleFinder reports an asset as due for publishing whatever its status.
+type staleFinder struct {
+	*fakeAssets
+	id valueobjects.AssetID
+}
+
+func (f *staleFinder) FindDueForPublish(ctx context.Context, now time.Time, offset, limit int) ([]valueobjects.AssetID, error) {
+	if offset > 0 {
+		return nil, nil
+	}
+	return []valueobjects.AssetID{f.id}, nil
+}
+
+type fakeLock struct {
+	held     bool
+	released chan struct{}
+}
+
+func (l *fakeLock) Acquire(ctx context.Context) (bool, error) { return l.held, nil }
+
+func (l *fakeLock) Release(ctx context.Context) error {
+	close(l.released)
+	return nil
+}
+
+type recordingPublisher struct {
+	mu    sync.Mutex
+	types []string
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, topic string, ev *events.Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.types = append(p.types, ev.Type)
+	return nil
+}
+
+func scheduledAsset(t *testing.T, publishAt, unpublishAt *time.Time, withPoster bool) *entity.Asset {
+	t.Helper()
+	slug, _ := valueobjects.NewSlug("scheduled-movie")
+	title, _ := valueobjects.NewTitle("Scheduled Movie")
+	assetType, _ := valueobjects.NewAssetType("movie")
+	a, err := entity.NewAsset(*slug, title, assetType)
+	require.NoError(t, err)
+
+	s3Object, _ := valueobjects.NewS3Object("test-bucket", "videos/main.m3u8", "")
+	hls := valueobjects.VideoFormat(constants.VideoStreamingFormatHLS)
+	ready := valueobjects.VideoStatusReady
+	_, err = a.UpsertVideo("main", &hls, *s3Object, 1920, 1080, 60, 0, "", 0, "application/x-mpegURL", "", "", "", 0, 0, nil, &ready)
+	require.NoError(t, err)
+	if withPoster {
+		addPoster(a)
+	}
+	rule, err := valueobjects.NewPublishRule(publishAt, unpublishAt, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, a.SetPublishRule(rule))
+	a.RestoreStatus(valueobjects.AssetStatusScheduled, nil)
+	return a
+}
+
+func addPoster(a *entity.Asset) {
+	poster, _ := valueobjects.NewImage("poster.jpg", "https://cdn.example.com/poster.jpg", valueobjects.ImageTypePoster, "image/jpeg")
+	a.AddImage(*poster)
+}
+
+func newTestScheduler(assets *fakeAssets, lock Lock, publisher Publisher, interval time.Duration) *Scheduler {
+	cmd := appasset.NewCommandService(assets, assets, logger.WithService("test")).WithStatusWriter(assets)
+	return NewScheduler(NewService(cmd, publisher), assets, lock, interval, 10)
+}
+
+func TestScheduler(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("PublishesBeforeUnpublishing", func(t *testing.T) {
+		// The whole window passed while no instance was running.
+		publishAt, unpublishAt := now.Add(-2*time.Hour), now.Add(-time.Hour)
+		a := scheduledAsset(t, &publishAt, &unpublishAt, true)
+		publisher := &recordingPublisher{}
+		assets := newFakeAssets(a)
+		scheduler := newTestScheduler(assets, &fakeLock{held: true}, publisher, time.Hour)
+
+		scheduler.RunOnce(context.Background(), now)
+
+		assert.Equal(t, constants.AssetStatusUnpublished, assets.status(a.ID()))
+		assert.Equal(t, []string{events.AssetPublishedEventType, events.AssetUnpublishedEventType}, publisher.types)
+		history := a.StatusHistory()
+		require.Len(t, history, 2)
+		assert.Equal(t, valueobjects.AssetStatusPublished, history[0].To())
+		assert.Equal(t, valueobjects.AssetStatusUnpublished, history[1].To())
+	})
+
+	t.Run("LeavesNotYetDueAssets", func(t *testing.T) {
+		publishAt := now.Add(time.Hour)
+		a := scheduledAsset(t, &publishAt, nil, true)
+		publisher := &recordingPublisher{}
+		assets := newFakeAssets(a)
+		scheduler := newTestScheduler(assets, &fakeLock{held: true}, publisher, time.Hour)
+
+		scheduler.RunOnce(context.Background(), now)
+
+		assert.Equal(t, constants.AssetStatusScheduled, assets.status(a.ID()))
+		assert.Empty(t, publisher.types)
+	})
+
+	t.Run("SkipsAssetChangedElsewhere", func(t *testing.T) {
+		publishAt := now.Add(-time.Minute)
+		a := scheduledAsset(t, &publishAt, nil, true)
+		assets := newFakeAssets(a)
+		publisher := &recordingPublisher{}
+		scheduler := newTestScheduler(assets, &fakeLock{held: true}, publisher, time.Hour)
+
+		// Another writer moves the asset after it was found due.
+		scheduler.finder = &staleFinder{fakeAssets: assets, id: a.ID()}
+		assets.statuses[a.ID().Value()] = constants.AssetStatusDraft
+
+		scheduler.RunOnce(context.Background(), now)
+
+		assert.Equal(t, constants.AssetStatusDraft, assets.status(a.ID()))
+		assert.Empty(t, publisher.types)
+	})
+
+	t.Run("RetriesFailedGuardNextRun", func(t *testing.T) {
+		publishAt := now.Add(-time.Minute)
+		a := scheduledAsset(t, &publishAt, nil, false)
+		publisher := &recordingPublisher{}
+		assets := newFakeAssets(a)
+		scheduler := newTestScheduler(assets, &fakeLock{held: true}, publisher, time.Hour)
+
+		// Without a poster the asset cannot be published and stays due.
+		scheduler.RunOnce(context.Background(), now)
+		assert.Equal(t, constants.AssetStatusScheduled, assets.status(a.ID()))
+		assert.Empty(t, publisher.types)
+
+		addPoster(a)
+		scheduler.RunOnce(context.Background(), now.Add(time.Minute))
+		assert.Equal(t, constants.AssetStatusPublished, assets.status(a.ID()))
+		assert.Equal(t, []string{events.AssetPublishedEventType}, publisher.types)
+	})
+
+	t.Run("PagesPastStuckAssets", func(t *testing.T) {
+		// A full batch of assets that cannot be published comes first.
+		var stuck []*entity.Asset
+		for i := 0; i < 10; i++ {
+			publishAt := now.Add(-time.Hour - time.Duration(i)*time.Minute)
+			stuck = append(stuck, scheduledAsset(t, &publishAt, nil, false))
+		}
+		publishAt := now.Add(-time.Minute)
+		later := scheduledAsset(t, &publishAt, nil, true)
+		assets := newFakeAssets(append(stuck, later)...)
+		publisher := &recordingPublisher{}
+		scheduler := newTestScheduler(assets, &fakeLock{held: true}, publisher, time.Hour)
+
+		scheduler.RunOnce(context.Background(), now)
+
+		assert.Equal(t, constants.AssetStatusPublished, assets.status(later.ID()))
+		assert.Equal(t, []string{events.AssetPublishedEventType}, publisher.types)
+		for _, a := range stuck {
+			assert.Equal(t, constants.AssetStatusScheduled, assets.status(a.ID()))
+		}
+	})
+
+	t.Run("RunsAtStartWhenLockHeld", func(t *testing.T) {
+		publishAt := now.Add(-time.Minute)
+		a := scheduledAsset(t, &publishAt, nil, true)
+		assets := newFakeAssets(a)
+		lock := &fakeLock{held: true, released: make(chan struct{})}
+		scheduler := newTestScheduler(assets, lock, &recordingPublisher{}, time.Hour)
+
+		scheduler.Start(context.Background())
+		select {
+		case <-assets.finds:
+		case <-time.After(time.Second):
+			t.Fatal("scheduler did not run before its first interval")
+		}
+		scheduler.Stop()
+		<-lock.released
+		assert.Equal(t, constants.AssetStatusPublished, assets.status(a.ID()))
+	})
+
+	t.Run("SkipsRunWithoutLock", func(t *testing.T) {
+		publishAt := now.Add(-time.Minute)
+		a := scheduledAsset(t, &publishAt, nil, true)
+		assets := newFakeAssets(a)
+		scheduler := newTestScheduler(assets, &fakeLock{}, &recordingPublisher{}, time.Hour)
+
+		scheduler.tick(context.Background())
+
+		assert.Empty(t, assets.finds)
+		assert.Equal(t, constants.AssetStatusScheduled, assets.status(a.ID()))
+	})
+}
@@ -0,0 +1,139 @@
+package lifecycle
+
+import (
+	"context"
+	"time"
+
+	appasset "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/asset"
+	assetCommands "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/asset/commands"
+	assetentity "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/entity"
+	assetevents "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/events"
+	assetvo "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
+)
+
+type Publisher interface {
+	Publish(ctx context.Context, topic string, ev *events.Event) error
+}
+
+const (
+	publishAttempts = 5
+	publishBackoff  = 200 * time.Millisecond
+)
+
+// Service moves assets through the editorial lifecycle and publishes the
+// resulting domain events on the asset events topic. With the outbox enabled
+// the publisher stores them and the dispatcher delivers them.
+type Service struct {
+	assetCmd  *appasset.CommandService
+	publisher Publisher
+	logger    *logger.Logger
+}
+
+func NewService(assetCmd *appasset.CommandService, publisher Publisher) *Service {
+	return &Service{assetCmd: assetCmd, publisher: publisher, logger: logger.WithService("asset-lifecycle")}
+}
+
+func (s *Service) SubmitForReview(ctx context.Context, assetID, note string) (*assetentity.Asset, error) {
+	return s.change(ctx, assetID, "", assetvo.AssetStatusInReview, note)
+}
+
+func (s *Service) Approve(ctx context.Context, assetID, note string) (*assetentity.Asset, error) {
+	return s.change(ctx, assetID, assetvo.AssetStatusInReview, assetvo.AssetStatusApproved, note)
+}
+
+// Reject sends an asset under review back to draft; the note says why.
+func (s *Service) Reject(ctx context.Context, assetID, note string) (*assetentity.Asset, error) {
+	return s.change(ctx, assetID, assetvo.AssetStatusInReview, assetvo.AssetStatusDraft, note)
+}
+
+// Schedule waits for the publish date of the asset's publish rule.
+func (s *Service) Schedule(ctx context.Context, assetID, note string) (*assetentity.Asset, error) {
+	return s.change(ctx, assetID, "", assetvo.AssetStatusScheduled, note)
+}
+
+func (s *Service) Unschedule(ctx context.Context, assetID, note string) (*assetentity.Asset, error) {
+	return s.change(ctx, assetID, assetvo.AssetStatusScheduled, assetvo.AssetStatusApproved, note)
+}
+
+func (s *Service) Publish(ctx context.Context, assetID, note string) (*assetentity.Asset, error) {
+	return s.change(ctx, assetID, "", assetvo.AssetStatusPublished, note)
+}
+
+func (s *Service) Unpublish(ctx context.Context, assetID, note string) (*assetentity.Asset, error) {
+	return s.change(ctx, assetID, "", assetvo.AssetStatusUnpublished, note)
+}
+
+// ReturnToDraft reopens an approved or unpublished asset for editing.
+func (s *Service) ReturnToDraft(ctx context.Context, assetID, note string) (*assetentity.Asset, error) {
+	return s.change(ctx, assetID, "", assetvo.AssetStatusDraft, note)
+}
+
+func (s *Service) Archive(ctx context.Context, assetID, note string) (*assetentity.Asset, error) {
+	return s.change(ctx, assetID, "", assetvo.AssetStatusArchived, note)
+}
+
+func (s *Service) Restore(ctx context.Context, assetID, note string) (*assetentity.Asset, error) {
+	return s.change(ctx, assetID, assetvo.AssetStatusArchived, assetvo.AssetStatusDraft, note)
+}
+
+func (s *Service) change(ctx context.Context, assetID string, from, to assetvo.AssetStatus, note string) (*assetentity.Asset, error) {
+	idVO, err := assetvo.NewAssetID(assetID)
+	if err != nil {
+		return nil, err
+	}
+	a, err := s.assetCmd.ChangeAssetStatus(ctx, assetCommands.ChangeAssetStatusCommand{
+		AssetID: *idVO,
+		From:    from,
+		Status:  to,
+		Note:    note,
+		At:      time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.publishEvents(ctx, a)
+	return a, nil
+}
+
+// publishEvents sends the domain events recorded by the transition. The
+// status change is already saved by then and will not be made again, so each
+// event is retried with backoff before it is given up on and logged.
+func (s *Service) publishEvents(ctx context.Context, a *assetentity.Asset) {
+	slug := a.Slug().Value()
+	for _, domainEvent := range a.PullEvents() {
+		var evt *events.Event
+		switch domainEvent.(type) {
+		case *assetevents.AssetPublishedEvent:
+			evt = events.NewAssetPublishedEvent(domainEvent.AssetID(), slug)
+		case *assetevents.AssetUnpublishedEvent:
+			evt = events.NewAssetUnpublishedEvent(domainEvent.AssetID(), slug)
+		default:
+			continue
+		}
+		evt.SetSource("asset-manager").SetEventVersion("1").SetCorrelationID(domainEvent.AssetID())
+		if err := s.publishWithRetry(ctx, evt); err != nil {
+			s.logger.WithError(err).Error("Failed to publish asset lifecycle event", "asset_id", domainEvent.AssetID(), "type", domainEvent.EventType())
+		}
+	}
+}
+
+// publishWithRetry keeps going after ctx is cancelled: the transition it
+// reports has already been saved.
+func (s *Service) publishWithRetry(ctx context.Context, evt *events.Event) error {
+	ctx = context.WithoutCancel(ctx)
+	backoff := publishBackoff
+	var err error
+	for attempt := 1; attempt <= publishAttempts; attempt++ {
+		if err = s.publisher.Publish(ctx, events.AssetEventsTopic, evt); err == nil {
+			return nil
+		}
+		if attempt < publishAttempts {
+			s.logger.WithError(err).Warn("Retrying asset lifecycle event", "event_id", evt.ID, "attempt", attempt)
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return err
+}
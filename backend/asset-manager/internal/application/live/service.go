@@ -0,0 +1,90 @@
+package live
+
+import (
+	"context"
+	"time"
+
+	appasset "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/asset"
+	assetCommands "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/asset/commands"
+	assetentity "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/entity"
+	assetvo "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/operations"
+)
+
+type Publisher interface {
+	Publish(ctx context.Context, topic string, ev *events.Event) error
+}
+
+// Service starts and stops live events. The transcoder reports back on the
+// live.stream.* topics, which the Kafka consumer applies to the asset.
+type Service struct {
+	assetCmd  *appasset.CommandService
+	publisher Publisher
+}
+
+func NewService(assetCmd *appasset.CommandService, publisher Publisher) *Service {
+	return &Service{assetCmd: assetCmd, publisher: publisher}
+}
+
+func (s *Service) CreateStreamKey(ctx context.Context, assetID, protocol string) (*assetentity.Asset, error) {
+	idVO, err := assetvo.NewAssetID(assetID)
+	if err != nil {
+		return nil, err
+	}
+	protocolVO, err := assetvo.NewLiveProtocol(protocol)
+	if err != nil {
+		return nil, err
+	}
+	return s.assetCmd.CreateStreamKey(ctx, assetCommands.CreateStreamKeyCommand{AssetID: *idVO, Protocol: protocolVO})
+}
+
+// StartLiveEvent opens a new session and asks a transcoder to listen for it.
+// If the request cannot be published the session is failed right away, so
+// the asset does not wait for a listener that will never come.
+func (s *Service) StartLiveEvent(ctx context.Context, assetID string) (*assetentity.Asset, error) {
+	idVO, err := assetvo.NewAssetID(assetID)
+	if err != nil {
+		return nil, err
+	}
+	sessionID := operations.GenerateID()
+	a, err := s.assetCmd.StartLiveEvent(ctx, assetCommands.StartLiveEventCommand{AssetID: *idVO, SessionID: sessionID})
+	if err != nil {
+		return nil, err
+	}
+
+	ls := a.LiveStream()
+	evt := events.NewLiveIngestRequestedEvent(assetID, sessionID, ls.StreamKey(), ls.Protocol().Value())
+	evt.SetSource("asset-manager").SetEventVersion("1").SetCorrelationID(sessionID)
+	if err := s.publisher.Publish(ctx, events.LiveIngestRequestedTopic, evt); err != nil {
+		_, _, _ = s.assetCmd.EndLiveEvent(ctx, assetCommands.EndLiveEventCommand{
+			AssetID:      *idVO,
+			SessionID:    sessionID,
+			ErrorMessage: "failed to request live ingest",
+			EndedAt:      time.Now().UTC(),
+		})
+		return nil, err
+	}
+	return a, nil
+}
+
+// StopLiveEvent asks the transcoder running the session to end it. The event
+// stays stopping until the transcoder reports it stopped, which is when the
+// archive becomes available.
+func (s *Service) StopLiveEvent(ctx context.Context, assetID string) (*assetentity.Asset, error) {
+	idVO, err := assetvo.NewAssetID(assetID)
+	if err != nil {
+		return nil, err
+	}
+	a, err := s.assetCmd.StopLiveEvent(ctx, assetCommands.StopLiveEventCommand{AssetID: *idVO})
+	if err != nil {
+		return nil, err
+	}
+	sessionID := a.LiveStream().SessionID()
+	evt := events.NewLiveIngestStopEvent(assetID, sessionID)
+	evt.SetSource("asset-manager").SetEventVersion("1").SetCorrelationID(sessionID)
+	if err := s.publisher.Publish(ctx, events.LiveIngestStopTopic, evt); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
@@ -0,0 +1,255 @@
+package person
+
+import (
+	"context"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/person/commands"
+	assetentity "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/entity"
+	assetvo "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/valueobjects"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
+)
+
+type CommandService struct {
+	saver   person.Saver
+	finder  person.Finder
+	credits person.Credits
+	logger  *logger.Logger
+}
+
+func NewCommandService(
+	saver person.Saver,
+	finder person.Finder,
+	credits person.Credits,
+	logger *logger.Logger,
+) *CommandService {
+	return &CommandService{
+		saver:   saver,
+		finder:  finder,
+		credits: credits,
+		logger:  logger,
+	}
+}
+
+// CreatePerson returns the existing person when the name and external ID
+// match one already stored, filling in the external ID and any missing
+// details, and creates a new person otherwise. A person with the same
+// external ID created concurrently is matched when the new one is stored.
+func (s *CommandService) CreatePerson(ctx context.Context, cmd commands.CreatePersonCommand) (*entity.Person, error) {
+	existing, err := s.findDuplicate(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return s.matchExisting(ctx, existing, cmd)
+	}
+
+	p, err := entity.NewPerson(cmd.Name, cmd.ExternalID)
+	if err != nil {
+		return nil, errors.NewValidationError("failed to create new person", err)
+	}
+	p.UpdateBiography(cmd.Biography)
+	p.UpdatePhotoURL(cmd.PhotoURL)
+
+	stored, err := s.saver.Create(ctx, p)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to save person", err)
+	}
+	if !stored.ID().Equals(p.ID()) {
+		return s.matchExisting(ctx, stored, cmd)
+	}
+	return stored, nil
+}
+
+// matchExisting fills in the external ID and any details the stored person
+// is missing from cmd.
+func (s *CommandService) matchExisting(ctx context.Context, existing *entity.Person, cmd commands.CreatePersonCommand) (*entity.Person, error) {
+	changed := false
+	if cmd.ExternalID != nil && existing.ExternalID() == nil {
+		if err := existing.SetExternalID(*cmd.ExternalID); err != nil {
+			return nil, errors.NewValidationError("failed to link external ID", err)
+		}
+		changed = true
+	}
+	if cmd.Biography != nil && existing.Biography() == nil {
+		existing.UpdateBiography(cmd.Biography)
+		changed = true
+	}
+	if cmd.PhotoURL != nil && existing.PhotoURL() == nil {
+		existing.UpdatePhotoURL(cmd.PhotoURL)
+		changed = true
+	}
+	if changed {
+		if err := s.saver.Update(ctx, existing); err != nil {
+			return nil, errors.NewInternalError("failed to update person", err)
+		}
+	}
+	s.logger.Info("Matched existing person", "person_id", existing.ID().Value(), "name", cmd.Name.Value())
+	return existing, nil
+}
+
+func (s *CommandService) findDuplicate(ctx context.Context, cmd commands.CreatePersonCommand) (*entity.Person, error) {
+	if cmd.ExternalID != nil {
+		p, err := s.finder.FindByExternalID(ctx, *cmd.ExternalID)
+		if err != nil {
+			return nil, errors.NewInternalError("failed to find person by external ID", err)
+		}
+		if p != nil {
+			return p, nil
+		}
+	}
+
+	candidates, err := s.finder.FindByNameKey(ctx, cmd.Name.Key())
+	if err != nil {
+		return nil, errors.NewInternalError("failed to find person by name", err)
+	}
+	return entity.FindDuplicate(candidates, cmd.Name, cmd.ExternalID), nil
+}
+
+func (s *CommandService) UpdatePerson(ctx context.Context, cmd commands.UpdatePersonCommand) (*entity.Person, error) {
+	p, err := s.getPerson(ctx, cmd.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.ExternalID != nil {
+		owner, err := s.finder.FindByExternalID(ctx, *cmd.ExternalID)
+		if err != nil {
+			return nil, errors.NewInternalError("failed to find person by external ID", err)
+		}
+		if owner != nil && !owner.ID().Equals(p.ID()) {
+			return nil, errors.NewValidationError("external ID already belongs to another person", nil)
+		}
+		if err := p.SetExternalID(*cmd.ExternalID); err != nil {
+			return nil, errors.NewValidationError("failed to set external ID", err)
+		}
+	}
+	if cmd.Name != nil {
+		p.UpdateName(*cmd.Name)
+	}
+	if cmd.Biography != nil {
+		p.UpdateBiography(cmd.Biography)
+	}
+	if cmd.PhotoURL != nil {
+		p.UpdatePhotoURL(cmd.PhotoURL)
+	}
+
+	if err := s.saver.Update(ctx, p); err != nil {
+		return nil, errors.NewInternalError("failed to update person", err)
+	}
+	return p, nil
+}
+
+// DeletePerson removes the person along with all of their credits.
+func (s *CommandService) DeletePerson(ctx context.Context, cmd commands.DeletePersonCommand) error {
+	return s.saver.Delete(ctx, cmd.ID)
+}
+
+// AddCredit credits the person on an asset, replacing any credit they
+// already have there in the same role.
+func (s *CommandService) AddCredit(ctx context.Context, cmd commands.AddCreditCommand) (*entity.Credit, error) {
+	if _, err := s.getPerson(ctx, cmd.PersonID); err != nil {
+		return nil, err
+	}
+
+	credit, err := entity.NewCredit(cmd.PersonID, cmd.AssetID, cmd.Role, cmd.Character, cmd.Order)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid credit", err)
+	}
+
+	if err := s.credits.AddCredit(ctx, credit); err != nil {
+		return nil, err
+	}
+	return credit, nil
+}
+
+func (s *CommandService) RemoveCredit(ctx context.Context, cmd commands.RemoveCreditCommand) error {
+	return s.credits.RemoveCredit(ctx, cmd.PersonID, cmd.AssetID, cmd.Role)
+}
+
+func (s *CommandService) getPerson(ctx context.Context, id valueobjects.PersonID) (*entity.Person, error) {
+	p, err := s.finder.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.NewInternalError("failed to find person", err)
+	}
+	if p == nil {
+		return nil, errors.NewNotFoundError("person not found", nil)
+	}
+	return p, nil
+}
+
+// AssetLister pages through assets for BackfillInlineCredits.
+type AssetLister interface {
+	List(ctx context.Context, limit *int, offset *int) ([]*assetentity.Asset, error)
+}
+
+// BackfillInlineCredits turns the credits stored inline on assets before
+// people existed into people and credit relationships, so person search and
+// filmographies cover them. Names are matched against existing people the
+// way CreatePerson matches them, and AddCredit replaces a credit in the same
+// role, so running it again changes nothing. Credits in a role people do not
+// have are skipped. It returns how many credits were linked.
+func (s *CommandService) BackfillInlineCredits(ctx context.Context, assets AssetLister, batchSize int) (int, error) {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	linked, skipped := 0, 0
+	for offset := 0; ; offset += batchSize {
+		limit, from := batchSize, offset
+		page, err := assets.List(ctx, &limit, &from)
+		if err != nil {
+			return linked, errors.NewInternalError("failed to list assets", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, a := range page {
+			for _, inline := range a.Credits() {
+				ok, err := s.backfillCredit(ctx, a.ID().Value(), inline)
+				if err != nil {
+					return linked, err
+				}
+				if ok {
+					linked++
+				} else {
+					skipped++
+				}
+			}
+		}
+	}
+	s.logger.Info("Backfilled inline credits", "linked", linked, "skipped", skipped)
+	return linked, nil
+}
+
+func (s *CommandService) backfillCredit(ctx context.Context, assetID string, inline assetvo.Credit) (bool, error) {
+	role, err := valueobjects.NewCreditRole(inline.Role())
+	if err != nil {
+		s.logger.Warn("Skipping inline credit", "asset_id", assetID, "role", inline.Role(), "name", inline.Name())
+		return false, nil
+	}
+	name, err := valueobjects.NewPersonName(inline.Name())
+	if err != nil {
+		s.logger.Warn("Skipping inline credit", "asset_id", assetID, "role", inline.Role(), "name", inline.Name())
+		return false, nil
+	}
+	p, err := s.CreatePerson(ctx, commands.CreatePersonCommand{
+		Name:      *name,
+		Biography: inline.Biography(),
+		PhotoURL:  inline.PhotoURL(),
+	})
+	if err != nil {
+		return false, err
+	}
+	if _, err := s.AddCredit(ctx, commands.AddCreditCommand{
+		PersonID: p.ID(),
+		AssetID:  assetID,
+		Role:     *role,
+		Order:    inline.Order(),
+	}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,176 @@
+package person
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/person/commands"
+	assetentity "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/entity"
+	assetvo "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/valueobjects"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
+)
+
+// fakePeople keeps people and credits in memory, credits keyed like the
+// graph keys them: one per person, asset and role.
+type fakePeople struct {
+	people  []*entity.Person
+	credits map[string]*entity.Credit
+}
+
+func newFakePeople() *fakePeople {
+	return &fakePeople{credits: map[string]*entity.Credit{}}
+}
+
+// Create matches on the external ID like the graph's MERGE does.
+func (f *fakePeople) Create(ctx context.Context, p *entity.Person) (*entity.Person, error) {
+	if p.ExternalID() != nil {
+		for _, existing := range f.people {
+			if existing.ExternalID() != nil && existing.ExternalID().Equals(*p.ExternalID()) {
+				return existing, nil
+			}
+		}
+	}
+	f.people = append(f.people, p)
+	return p, nil
+}
+
+func (f *fakePeople) Save(ctx context.Context, p *entity.Person) error {
+	f.people = append(f.people, p)
+	return nil
+}
+
+func (f *fakePeople) Update(ctx context.Context, p *entity.Person) error { return nil }
+
+func (f *fakePeople) Delete(ctx context.Context, id valueobjects.PersonID) error { return nil }
+
+func (f *fakePeople) FindByID(ctx context.Context, id valueobjects.PersonID) (*entity.Person, error) {
+	for _, p := range f.people {
+		if p.ID().Equals(id) {
+			return p, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *fakePeople) FindByExternalID(ctx context.Context, externalID valueobjects.ExternalID) (*entity.Person, error) {
+	return nil, nil
+}
+
+func (f *fakePeople) FindByNameKey(ctx context.Context, key string) ([]*entity.Person, error) {
+	var found []*entity.Person
+	for _, p := range f.people {
+		if p.Name().Key() == key {
+			found = append(found, p)
+		}
+	}
+	return found, nil
+}
+
+func (f *fakePeople) AddCredit(ctx context.Context, credit *entity.Credit) error {
+	f.credits[credit.PersonID().Value()+"/"+credit.AssetID()+"/"+credit.Role().Value()] = credit
+	return nil
+}
+
+func (f *fakePeople) RemoveCredit(ctx context.Context, personID valueobjects.PersonID, assetID string, role valueobjects.CreditRole) error {
+	return nil
+}
+
+func (f *fakePeople) FindCreditsByAsset(ctx context.Context, assetID string) ([]entity.CreditedPerson, error) {
+	return nil, nil
+}
+
+func (f *fakePeople) FindCreditsByPerson(ctx context.Context, personID valueobjects.PersonID, role *valueobjects.CreditRole) ([]*entity.Credit, error) {
+	return nil, nil
+}
+
+func (f *fakePeople) FindCollaborators(ctx context.Context, personID valueobjects.PersonID, limit int) ([]entity.Collaborator, error) {
+	return nil, nil
+}
+
+type fakeAssetLister []*assetentity.Asset
+
+func (f fakeAssetLister) List(ctx context.Context, limit *int, offset *int) ([]*assetentity.Asset, error) {
+	if *offset >= len(f) {
+		return nil, nil
+	}
+	end := *offset + *limit
+	if end > len(f) {
+		end = len(f)
+	}
+	return f[*offset:end], nil
+}
+
+func assetWithCredits(t *testing.T, slug string, credits ...[2]string) *assetentity.Asset {
+	t.Helper()
+	s, _ := assetvo.NewSlug(slug)
+	title, _ := assetvo.NewTitle(slug)
+	assetType, _ := assetvo.NewAssetType("movie")
+	a, err := assetentity.NewAsset(*s, title, assetType)
+	require.NoError(t, err)
+	for i, c := range credits {
+		credit, err := assetvo.NewCredit(c[0], c[1], i)
+		require.NoError(t, err)
+		a.AddCredit(*credit)
+	}
+	return a
+}
+
+func TestBackfillInlineCredits(t *testing.T) {
+	people := newFakePeople()
+	svc := NewCommandService(people, people, people, logger.WithService("test"))
+	assets := fakeAssetLister{
+		assetWithCredits(t, "first-movie", [2]string{"Director", "Ada Lovelace"}, [2]string{"actor", "Alan Turing"}),
+		assetWithCredits(t, "second-movie", [2]string{"actor", "alan  turing"}, [2]string{"gaffer", "Grace Hopper"}),
+		assetWithCredits(t, "third-movie"),
+	}
+
+	linked, err := svc.BackfillInlineCredits(context.Background(), assets, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, linked)
+	// The two spellings of Alan Turing are one person; the gaffer role has
+	// no relationship and is skipped.
+	require.Len(t, people.people, 2)
+	assert.Equal(t, "Ada Lovelace", people.people[0].Name().Value())
+	assert.Equal(t, "Alan Turing", people.people[1].Name().Value())
+	assert.Len(t, people.credits, 3)
+	director := people.credits[people.people[0].ID().Value()+"/"+assets[0].ID().Value()+"/director"]
+	require.NotNil(t, director)
+	assert.Equal(t, 0, director.Order())
+
+	// A second run finds the same people and replaces the same credits.
+	_, err = svc.BackfillInlineCredits(context.Background(), assets, 2)
+	require.NoError(t, err)
+	assert.Len(t, people.people, 2)
+	assert.Len(t, people.credits, 3)
+}
+
+func TestCreatePerson_MatchesConcurrentExternalID(t *testing.T) {
+	people := newFakePeople()
+	svc := NewCommandService(people, people, people, logger.WithService("test"))
+	externalID, err := valueobjects.NewExternalID("imdb", "nm0000158")
+	require.NoError(t, err)
+	name, err := valueobjects.NewPersonName("Tom Hanks")
+	require.NoError(t, err)
+	// Stored by another request after this one looked for it: the finder
+	// below never sees it, only the create does.
+	other, err := entity.NewPerson(*name, externalID)
+	require.NoError(t, err)
+	people.people = append(people.people, other)
+
+	alias, err := valueobjects.NewPersonName("Thomas J. Hanks")
+	require.NoError(t, err)
+	bio := "Actor"
+	got, err := svc.CreatePerson(context.Background(), commands.CreatePersonCommand{Name: *alias, ExternalID: externalID, Biography: &bio})
+	require.NoError(t, err)
+
+	assert.True(t, got.ID().Equals(other.ID()))
+	assert.Len(t, people.people, 1)
+	require.NotNil(t, got.Biography())
+	assert.Equal(t, bio, *got.Biography())
+}
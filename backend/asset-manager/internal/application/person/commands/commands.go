@@ -0,0 +1,36 @@
+package commands
+
+import "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/valueobjects"
+
+type CreatePersonCommand struct {
+	Name       valueobjects.PersonName
+	ExternalID *valueobjects.ExternalID
+	Biography  *string
+	PhotoURL   *string
+}
+
+type UpdatePersonCommand struct {
+	ID         valueobjects.PersonID
+	Name       *valueobjects.PersonName
+	ExternalID *valueobjects.ExternalID
+	Biography  *string
+	PhotoURL   *string
+}
+
+type DeletePersonCommand struct {
+	ID valueobjects.PersonID
+}
+
+type AddCreditCommand struct {
+	PersonID  valueobjects.PersonID
+	AssetID   string
+	Role      valueobjects.CreditRole
+	Character *string
+	Order     int
+}
+
+type RemoveCreditCommand struct {
+	PersonID valueobjects.PersonID
+	AssetID  string
+	Role     valueobjects.CreditRole
+}
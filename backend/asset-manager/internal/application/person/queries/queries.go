@@ -0,0 +1,32 @@
+package queries
+
+import "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/valueobjects"
+
+type GetPersonQuery struct {
+	ID valueobjects.PersonID
+}
+
+type ListPeopleQuery struct {
+	Limit  *int
+	Offset *int
+}
+
+type SearchPeopleQuery struct {
+	Query  string
+	Limit  *int
+	Offset *int
+}
+
+type GetAssetCreditsQuery struct {
+	AssetID string
+}
+
+type GetPersonCreditsQuery struct {
+	PersonID valueobjects.PersonID
+	Role     *valueobjects.CreditRole
+}
+
+type GetCollaboratorsQuery struct {
+	PersonID valueobjects.PersonID
+	Limit    *int
+}
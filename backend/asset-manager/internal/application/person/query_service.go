@@ -0,0 +1,75 @@
+package person
+
+import (
+	"context"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/person/queries"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
+)
+
+const (
+	defaultCollaboratorLimit = 20
+	maxCollaboratorLimit     = 100
+)
+
+type QueryService struct {
+	finder  person.Finder
+	pager   person.Pager
+	credits person.Credits
+	logger  *logger.Logger
+}
+
+func NewQueryService(
+	finder person.Finder,
+	pager person.Pager,
+	credits person.Credits,
+	logger *logger.Logger,
+) *QueryService {
+	return &QueryService{
+		finder:  finder,
+		pager:   pager,
+		credits: credits,
+		logger:  logger,
+	}
+}
+
+func (s *QueryService) GetPerson(ctx context.Context, query queries.GetPersonQuery) (*entity.Person, error) {
+	p, err := s.finder.FindByID(ctx, query.ID)
+	if err != nil {
+		return nil, err
+	}
+	if p == nil {
+		return nil, errors.NewNotFoundError("person not found", nil)
+	}
+	return p, nil
+}
+
+func (s *QueryService) ListPeople(ctx context.Context, query queries.ListPeopleQuery) ([]*entity.Person, error) {
+	return s.pager.List(ctx, query.Limit, query.Offset)
+}
+
+func (s *QueryService) SearchPeople(ctx context.Context, query queries.SearchPeopleQuery) ([]*entity.Person, error) {
+	return s.pager.Search(ctx, query.Query, query.Limit, query.Offset)
+}
+
+func (s *QueryService) GetAssetCredits(ctx context.Context, query queries.GetAssetCreditsQuery) ([]entity.CreditedPerson, error) {
+	return s.credits.FindCreditsByAsset(ctx, query.AssetID)
+}
+
+func (s *QueryService) GetPersonCredits(ctx context.Context, query queries.GetPersonCreditsQuery) ([]*entity.Credit, error) {
+	return s.credits.FindCreditsByPerson(ctx, query.PersonID, query.Role)
+}
+
+func (s *QueryService) GetCollaborators(ctx context.Context, query queries.GetCollaboratorsQuery) ([]entity.Collaborator, error) {
+	limit := defaultCollaboratorLimit
+	if query.Limit != nil && *query.Limit > 0 {
+		limit = *query.Limit
+	}
+	if limit > maxCollaboratorLimit {
+		limit = maxCollaboratorLimit
+	}
+	return s.credits.FindCollaborators(ctx, query.PersonID, limit)
+}
@@ -35,6 +35,17 @@ func (s *Service) MarkCompleted(ctx context.Context, assetID, videoID, step stri
 	return s.repo.Upsert(ctx, p)
 }
 
+func (s *Service) MarkProgress(ctx context.Context, assetID, videoID, step string, progress float64) error {
+	p, _ := s.repo.Get(ctx, assetID, videoID)
+	if p == nil {
+		p = domain.NewPipeline(assetID, videoID)
+	}
+	if !p.SetProgress(step, progress) {
+		return nil
+	}
+	return s.repo.Upsert(ctx, p)
+}
+
 func (s *Service) MarkFailed(ctx context.Context, assetID, videoID, step, errMsg string) error {
 	p, _ := s.repo.Get(ctx, assetID, videoID)
 	if p == nil {
@@ -44,6 +55,15 @@ func (s *Service) MarkFailed(ctx context.Context, assetID, videoID, step, errMsg
 	return s.repo.Upsert(ctx, p)
 }
 
+func (s *Service) MarkCancelled(ctx context.Context, assetID, videoID, step string) error {
+	p, _ := s.repo.Get(ctx, assetID, videoID)
+	if p == nil {
+		p = domain.NewPipeline(assetID, videoID)
+	}
+	p.SetCancelled(step)
+	return s.repo.Upsert(ctx, p)
+}
+
 func (s *Service) Get(ctx context.Context, assetID, videoID string) (*domain.Pipeline, error) {
 	return s.repo.Get(ctx, assetID, videoID)
 }
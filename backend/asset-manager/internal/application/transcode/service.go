@@ -4,15 +4,39 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"regexp"
+	"strings"
 
 	appasset "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/asset"
 	assetCommands "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/asset/commands"
 	assetQueries "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/asset/queries"
 	apppipeline "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/pipeline"
+	assetentity "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/entity"
 	assetvo "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/messages"
 )
 
+const (
+	thumbnailsStep = "thumbnails"
+	markersStep    = "markers"
+)
+
+// overlayLabelPattern matches what the transcoder accepts; the label becomes
+// a path segment of the variant's output key.
+var overlayLabelPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,39}$`)
+
+// Overlay asks for a watermarked or branded variant of a transcode. The
+// logo is one of the asset's images of type logo; Text is burned in as is,
+// for example a partner or viewer ID.
+type Overlay struct {
+	Label       string
+	LogoImageID string
+	Position    string
+	Opacity     float64
+	Text        string
+}
+
 type Publisher interface {
 	Publish(ctx context.Context, topic string, ev *events.Event) error
 }
@@ -28,16 +52,38 @@ func NewService(assetCmd *appasset.CommandService, assetQry *appasset.QueryServi
 	return &Service{assetCmd: assetCmd, assetQry: assetQry, publisher: publisher, pipeline: pipeline}
 }
 
-func (s *Service) RequestTranscode(ctx context.Context, assetID, videoID, format string) error {
+// RequestTranscode encodes the video into the main output of the format, or
+// into a separate variant when overlay is set.
+func (s *Service) RequestTranscode(ctx context.Context, assetID, videoID, format string, overlay *Overlay) error {
+	if overlay != nil && format != assetvo.VideoFormatHLS.Value() && format != assetvo.VideoFormatDASH.Value() {
+		return fmt.Errorf("overlays are only supported for hls and dash")
+	}
 	a, err := s.assetQry.GetAsset(ctx, assetQueries.GetAssetQuery{ID: assetID})
 	if err != nil || a == nil {
 		return fmt.Errorf("asset not found")
 	}
-	var inputURL, bucket string
+	quality := "main"
+	var overlayOpts *events.TranscodeOverlay
+	if overlay != nil {
+		if overlayOpts, err = overlayOptions(a, *overlay); err != nil {
+			return err
+		}
+		quality = overlayOpts.Label
+	}
+	var inputURL, bucket, videoType string
+	var sourceWidth, sourceHeight int
+	var sourceDuration, complexity float64
+	var audioTracks []messages.AudioTrackPayload
 	for _, v := range a.Videos() {
 		if v.ID().Value() == videoID {
+			audioTracks = audioTrackPayloads(v)
+			videoType = v.Type().Value()
 			inputURL = v.StorageLocation().URL()
 			bucket = v.StorageLocation().Bucket()
+			sourceWidth = v.Width()
+			sourceHeight = v.Height()
+			sourceDuration = v.Duration()
+			complexity = v.Complexity()
 			break
 		}
 	}
@@ -57,38 +103,227 @@ func (s *Service) RequestTranscode(ctx context.Context, assetID, videoID, format
 	case assetvo.VideoFormatHLS.Value():
 		fileName = "playlist.m3u8"
 		contentType = "application/x-mpegURL"
-	case assetvo.VideoFormatDASH.Value():
+	case assetvo.VideoFormatDASH.Value(), assetvo.VideoFormatCMAF.Value():
 		fileName = "manifest.mpd"
 		contentType = "application/dash+xml"
 	default:
 		return fmt.Errorf("unsupported format: %s", format)
 	}
-	outKey := path.Join(assetID, videoID, format, "main", fileName)
+	outKey := path.Join(assetID, videoID, format, quality, fileName)
 	s3Obj, _ := assetvo.NewS3Object(bucket, outKey, "")
 	statusTranscoding := assetvo.VideoStatusTranscoding
+	label := fileName
+	if overlayOpts != nil {
+		label = overlayOpts.Label
+	}
 	if _, _, err := s.assetCmd.UpsertVideo(ctx, assetCommands.UpsertVideoCommand{
 		AssetID:         *idVO,
-		Label:           fileName,
+		Label:           label,
 		Format:          fmtVO,
 		StorageLocation: *s3Obj,
 		ContentType:     contentType,
 		InitialStatus:   &statusTranscoding,
+		Variant:         overlayOpts != nil,
 	}); err != nil {
 		return err
 	}
 
-	corr := events.BuildJobCorrelationID(assetID, videoID, "transcode", format, "main")
-	evt := events.NewJobTranscodeRequestedEvent(assetID, videoID, inputURL, format, bucket, outKey)
+	corr := events.BuildJobCorrelationID(assetID, videoID, "transcode", format, quality)
+	evt := events.NewJobTranscodeRequestedEvent(assetID, videoID, inputURL, format, bucket, outKey, sourceWidth, sourceHeight, sourceDuration, overlayOpts)
 	evt.SetSource("asset-manager").SetEventVersion("1").SetCorrelationID(corr)
+	// The transcoder queues trailers and teasers ahead of main features.
+	evt.SetDataField("videoType", videoType)
+	if a.Type() != nil {
+		// Podcasts and music videos also get audio-only renditions.
+		evt.SetDataField("assetType", a.Type().Value())
+	}
+	if complexity > 0 {
+		evt.SetDataField("complexity", complexity)
+	}
+	if subtitles := subtitlePayloads(a); len(subtitles) > 0 {
+		evt.SetDataField("subtitles", subtitles)
+	}
+	if len(audioTracks) > 0 {
+		evt.SetDataField("audioTracks", audioTracks)
+	}
 	topic := map[string]string{
 		assetvo.VideoFormatHLS.Value():  events.HLSJobRequestedTopic,
 		assetvo.VideoFormatDASH.Value(): events.DASHJobRequestedTopic,
+		assetvo.VideoFormatCMAF.Value(): events.CMAFJobRequestedTopic,
 	}[format]
 	if err := s.publisher.Publish(ctx, topic, evt); err != nil {
 		return err
 	}
-	if s.pipeline != nil {
+	// The pipeline follows the main outputs; variants are extra deliveries.
+	if s.pipeline != nil && overlayOpts == nil {
 		_ = s.pipeline.MarkRequested(ctx, assetID, videoID, format, corr, corr)
 	}
 	return nil
 }
+
+// RequestThumbnails asks the transcoder for a poster frame, screenshots and
+// trickplay sprites cut from the given video.
+func (s *Service) RequestThumbnails(ctx context.Context, assetID, videoID string) error {
+	a, err := s.assetQry.GetAsset(ctx, assetQueries.GetAssetQuery{ID: assetID})
+	if err != nil || a == nil {
+		return fmt.Errorf("asset not found")
+	}
+	v, ok := a.Videos()[videoID]
+	if !ok || v.StorageLocation().URL() == "" || v.StorageLocation().Bucket() == "" {
+		return fmt.Errorf("video input not found")
+	}
+	bucket := v.StorageLocation().Bucket()
+	outKey := path.Join(assetID, videoID, thumbnailsStep, "main", "poster.jpg")
+
+	corr := events.BuildJobCorrelationID(assetID, videoID, "transcode", thumbnailsStep, "main")
+	evt := events.NewJobTranscodeRequestedEvent(assetID, videoID, v.StorageLocation().URL(), thumbnailsStep, bucket, outKey, v.Width(), v.Height(), v.Duration(), nil)
+	evt.SetSource("asset-manager").SetEventVersion("1").SetCorrelationID(corr)
+	if err := s.publisher.Publish(ctx, events.ThumbnailsJobRequestedTopic, evt); err != nil {
+		return err
+	}
+	if s.pipeline != nil {
+		_ = s.pipeline.MarkRequested(ctx, assetID, videoID, thumbnailsStep, corr, corr)
+	}
+	return nil
+}
+
+// RequestMarkers asks the transcoder to propose chapters, an intro range and
+// an end-credits start for the given video.
+func (s *Service) RequestMarkers(ctx context.Context, assetID, videoID string) error {
+	a, err := s.assetQry.GetAsset(ctx, assetQueries.GetAssetQuery{ID: assetID})
+	if err != nil || a == nil {
+		return fmt.Errorf("asset not found")
+	}
+	v, ok := a.Videos()[videoID]
+	if !ok || v.StorageLocation().URL() == "" || v.StorageLocation().Bucket() == "" {
+		return fmt.Errorf("video input not found")
+	}
+	bucket := v.StorageLocation().Bucket()
+	outKey := path.Join(assetID, videoID, markersStep, "main", "markers.json")
+
+	corr := events.BuildJobCorrelationID(assetID, videoID, "transcode", markersStep, "main")
+	evt := events.NewJobTranscodeRequestedEvent(assetID, videoID, v.StorageLocation().URL(), markersStep, bucket, outKey, v.Width(), v.Height(), v.Duration(), nil)
+	evt.SetSource("asset-manager").SetEventVersion("1").SetCorrelationID(corr)
+	if err := s.publisher.Publish(ctx, events.MarkersJobRequestedTopic, evt); err != nil {
+		return err
+	}
+	if s.pipeline != nil {
+		_ = s.pipeline.MarkRequested(ctx, assetID, videoID, markersStep, corr, corr)
+	}
+	return nil
+}
+
+func (s *Service) CancelTranscode(ctx context.Context, assetID, videoID, format string) error {
+	if _, err := assetvo.NewVideoFormat(format); err != nil {
+		return err
+	}
+	if s.pipeline != nil {
+		p, err := s.pipeline.Get(ctx, assetID, videoID)
+		if err != nil {
+			return err
+		}
+		step, ok := p.Step(format)
+		if !ok || step.IsTerminal() {
+			return fmt.Errorf("no %s transcode in progress", format)
+		}
+	}
+
+	corr := events.BuildJobCorrelationID(assetID, videoID, "transcode", format, "main")
+	evt := events.NewJobTranscodeCancelEvent(assetID, videoID, format)
+	evt.SetSource("asset-manager").SetEventVersion("1").SetCorrelationID(corr)
+	return s.publisher.Publish(ctx, events.TranscodeJobCancelTopic, evt)
+}
+
+// overlayOptions checks the overlay and resolves its logo to the image's
+// storage location.
+func overlayOptions(a *assetentity.Asset, o Overlay) (*events.TranscodeOverlay, error) {
+	label := strings.ToLower(strings.TrimSpace(o.Label))
+	if !overlayLabelPattern.MatchString(label) || label == "main" {
+		return nil, fmt.Errorf("overlay label must be 1-40 lowercase letters, digits, '-' or '_' and not main")
+	}
+	text := strings.TrimSpace(o.Text)
+	if o.LogoImageID == "" && text == "" {
+		return nil, fmt.Errorf("overlay needs a logo image or text")
+	}
+	if o.Opacity < 0 || o.Opacity > 1 {
+		return nil, fmt.Errorf("overlay opacity must be between 0 and 1")
+	}
+	opts := &events.TranscodeOverlay{Label: label, Position: o.Position, Opacity: o.Opacity, Text: text}
+	if o.LogoImageID == "" {
+		return opts, nil
+	}
+	for _, img := range a.Images() {
+		if img.ID().Value() != o.LogoImageID {
+			continue
+		}
+		if img.Type() != assetvo.ImageTypeLogo {
+			return nil, fmt.Errorf("overlay image must be a logo")
+		}
+		opts.Image = img.URL()
+		if loc := img.StorageLocation(); loc != nil && loc.URL() != "" {
+			opts.Image = loc.URL()
+		}
+		return opts, nil
+	}
+	return nil, fmt.Errorf("overlay image not found")
+}
+
+func subtitlePayloads(a *assetentity.Asset) []messages.SubtitlePayload {
+	payloads := make([]messages.SubtitlePayload, 0, len(a.Subtitles()))
+	for _, s := range a.Subtitles() {
+		payloads = append(payloads, messages.SubtitlePayload{
+			Language: s.Language().Value(),
+			Kind:     s.Kind().Value(),
+			Label:    s.Label(),
+			Default:  s.IsDefault(),
+			Input:    s.StorageLocation().URL(),
+		})
+	}
+	return payloads
+}
+
+// OutputSource reads back the asset, source video and format from the key
+// RequestTranscode stores an output under. The pipeline tracking that output
+// is keyed by the asset and source video, with the format as its step.
+func OutputSource(key string) (assetID, videoID, format string, ok bool) {
+	parts := strings.Split(key, "/")
+	if len(parts) < 5 {
+		return "", "", "", false
+	}
+	switch parts[2] {
+	case assetvo.VideoFormatHLS.Value(), assetvo.VideoFormatDASH.Value(), assetvo.VideoFormatCMAF.Value():
+		return parts[0], parts[1], parts[2], true
+	}
+	return "", "", "", false
+}
+
+func audioTrackPayloads(v *assetentity.Video) []messages.AudioTrackPayload {
+	payloads := make([]messages.AudioTrackPayload, 0, len(v.AudioTracks()))
+	for _, t := range v.AudioTracks() {
+		payloads = append(payloads, messages.AudioTrackPayload{
+			Index:         t.Index(),
+			Language:      t.Language(),
+			Codec:         t.Codec(),
+			Channels:      t.Channels(),
+			ChannelLayout: t.ChannelLayout(),
+			SampleRate:    t.SampleRate(),
+			Title:         t.Title(),
+			Default:       t.IsDefault(),
+			Loudness:      loudnessPayload(t.Loudness()),
+		})
+	}
+	return payloads
+}
+
+func loudnessPayload(l *assetvo.Loudness) *messages.LoudnessPayload {
+	if l == nil {
+		return nil
+	}
+	return &messages.LoudnessPayload{
+		Integrated: l.Integrated(),
+		TruePeak:   l.TruePeak(),
+		LRA:        l.LRA(),
+		Threshold:  l.Threshold(),
+		Offset:     l.Offset(),
+	}
+}
@@ -2,6 +2,7 @@ package asset
 
 import (
 	"context"
+	"math"
 	"testing"
 	"time"
 
@@ -216,6 +217,63 @@ func TestRichDomainModel(t *testing.T) {
 		assert.True(t, asset.CanBePublished())
 	})
 
+	t.Run("EditorialLifecycle", func(t *testing.T) {
+		slug, _ := valueobjects.NewSlug("test-asset")
+		title, _ := valueobjects.NewTitle("Test Asset")
+		assetType, _ := valueobjects.NewAssetType("movie")
+		asset, err := entity.NewAsset(*slug, title, assetType)
+		assert.NoError(t, err)
+		now := time.Now().UTC()
+
+		assert.Error(t, asset.TransitionTo(valueobjects.AssetStatusPublished, "", now))
+		assert.NoError(t, asset.TransitionTo(valueobjects.AssetStatusInReview, "", now))
+		assert.NoError(t, asset.TransitionTo(valueobjects.AssetStatusDraft, "needs a synopsis", now))
+		assert.NoError(t, asset.TransitionTo(valueobjects.AssetStatusInReview, "", now))
+		assert.NoError(t, asset.TransitionTo(valueobjects.AssetStatusApproved, "", now))
+
+		// Publishing needs a ready video and a poster.
+		assert.Error(t, asset.TransitionTo(valueobjects.AssetStatusPublished, "", now))
+		s3Object, _ := valueobjects.NewS3Object("test-bucket", "videos/main.m3u8", "")
+		hls := valueobjects.VideoFormat(constants.VideoStreamingFormatHLS)
+		ready := valueobjects.VideoStatusReady
+		_, err = asset.UpsertVideo("main", &hls, *s3Object, 1920, 1080, 60, 0, "", 0, "application/x-mpegURL", "", "", "", 0, 0, nil, &ready)
+		assert.NoError(t, err)
+		assert.ErrorContains(t, asset.TransitionTo(valueobjects.AssetStatusPublished, "", now), "poster")
+		poster, _ := valueobjects.NewImage("poster.jpg", "https://cdn.example.com/poster.jpg", valueobjects.ImageTypePoster, "image/jpeg")
+		asset.AddImage(*poster)
+
+		// Scheduling needs a publish date ahead.
+		assert.Error(t, asset.TransitionTo(valueobjects.AssetStatusScheduled, "", now))
+		publishAt := now.Add(time.Hour)
+		rule, _ := valueobjects.NewPublishRule(&publishAt, nil, []string{"tr"}, nil)
+		assert.NoError(t, asset.SetPublishRule(rule))
+		assert.NoError(t, asset.TransitionTo(valueobjects.AssetStatusScheduled, "", now))
+		assert.Empty(t, asset.PullEvents())
+
+		assert.NoError(t, asset.TransitionTo(valueobjects.AssetStatusPublished, "", now))
+		assert.Equal(t, constants.AssetStatusPublished, asset.Status())
+		published := asset.PullEvents()
+		assert.Len(t, published, 1)
+		assert.Equal(t, "asset.published", published[0].EventType())
+		assert.Empty(t, asset.PullEvents())
+
+		assert.Error(t, asset.TransitionTo(valueobjects.AssetStatusArchived, "", now))
+		assert.NoError(t, asset.TransitionTo(valueobjects.AssetStatusUnpublished, "rights expired", now))
+		unpublished := asset.PullEvents()
+		assert.Len(t, unpublished, 1)
+		assert.Equal(t, "asset.unpublished", unpublished[0].EventType())
+		assert.NoError(t, asset.TransitionTo(valueobjects.AssetStatusArchived, "", now))
+
+		history := asset.StatusHistory()
+		assert.Len(t, history, 8)
+		assert.Equal(t, valueobjects.AssetStatusInReview, history[1].From())
+		assert.Equal(t, "needs a synopsis", history[1].Note())
+		assert.Equal(t, valueobjects.AssetStatusArchived, history[7].To())
+
+		_, err = valueobjects.NewAssetStatus("expired")
+		assert.Error(t, err)
+	})
+
 	t.Run("AddVideoWithStreamInfo", func(t *testing.T) {
 		slug, _ := valueobjects.NewSlug("test-asset")
 		title, _ := valueobjects.NewTitle("Test Asset")
@@ -240,6 +298,309 @@ func TestRichDomainModel(t *testing.T) {
 		assert.Equal(t, streamURL, *video.StreamInfo().URL())
 	})
 
+	t.Run("VideoMarkers", func(t *testing.T) {
+		slug, _ := valueobjects.NewSlug("test-asset")
+		title, _ := valueobjects.NewTitle("Test Asset")
+		assetType, _ := valueobjects.NewAssetType("episode")
+
+		asset, err := entity.NewAsset(*slug, title, assetType)
+		assert.NoError(t, err)
+
+		s3Object, _ := valueobjects.NewS3Object("test-bucket", "videos/main.mp4", "https://test-bucket.s3.amazonaws.com/videos/main.mp4")
+		videoFormat := valueobjects.VideoFormat(constants.VideoStreamingFormatRaw)
+		video, err := asset.UpsertVideo("main", &videoFormat, *s3Object, 1920, 1080, 1800, 5000000, "h264", 1024000000, "video/mp4", "h264", "aac", "30fps", 2, 48000, nil, nil)
+		assert.NoError(t, err)
+
+		credits, err := valueobjects.NewMarker("credits", 1700, 0, "Credits")
+		assert.NoError(t, err)
+		intro, err := valueobjects.NewMarker("intro", 30, 95, "Intro")
+		assert.NoError(t, err)
+		chapter, err := valueobjects.NewMarker("chapter", 0, 600, "Chapter 1")
+		assert.NoError(t, err)
+
+		err = asset.SetVideoMarkers(video.ID().Value(), []valueobjects.Marker{*credits, *intro, *chapter})
+		assert.NoError(t, err)
+		assert.Len(t, video.Markers(), 3)
+		assert.Equal(t, valueobjects.MarkerKindChapter, video.Markers()[0].Kind())
+		assert.Equal(t, 30.0, video.Intro().Start())
+		assert.Equal(t, 1700.0, video.Credits().Start())
+
+		err = asset.SetVideoMarkers(video.ID().Value(), []valueobjects.Marker{*intro, *intro})
+		assert.Error(t, err)
+
+		_, err = valueobjects.NewMarker("intro", 95, 30, "")
+		assert.Error(t, err)
+		_, err = valueobjects.NewMarker("recap", 0, 30, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("VideoQualityScores", func(t *testing.T) {
+		slug, _ := valueobjects.NewSlug("test-asset")
+		title, _ := valueobjects.NewTitle("Test Asset")
+		assetType, _ := valueobjects.NewAssetType("movie")
+
+		asset, err := entity.NewAsset(*slug, title, assetType)
+		assert.NoError(t, err)
+
+		s3Object, _ := valueobjects.NewS3Object("test-bucket", "videos/main/hls/playlist.m3u8", "https://test-bucket.s3.amazonaws.com/videos/main/hls/playlist.m3u8")
+		videoFormat := valueobjects.VideoFormat(constants.VideoStreamingFormatHLS)
+		failedQC := valueobjects.VideoStatusFailedQC
+		video, err := asset.UpsertVideo("main", &videoFormat, *s3Object, 1920, 1080, 600, 5000000, "h264", 1024000000, "application/x-mpegURL", "h264", "aac", "30fps", 2, 48000, nil, &failedQC)
+		assert.NoError(t, err)
+		assert.True(t, video.IsFailedQC())
+		assert.False(t, video.IsReady())
+		assert.False(t, video.IsFailed())
+
+		vmaf := 74.5
+		low, err := valueobjects.NewRenditionQuality("720p", 33.1, 0.94, &vmaf)
+		assert.NoError(t, err)
+		high, err := valueobjects.NewRenditionQuality("1080p", 40.2, 0.98, nil)
+		assert.NoError(t, err)
+		video.SetQualityScores([]valueobjects.RenditionQuality{*low, *high})
+		assert.Len(t, video.QualityScores(), 2)
+		assert.Equal(t, "1080p", video.QualityScores()[0].Rendition())
+		assert.Nil(t, video.QualityScores()[0].VMAF())
+		assert.Equal(t, 74.5, *video.QualityScores()[1].VMAF())
+
+		_, err = valueobjects.NewRenditionQuality("720p", 33.1, 1.2, nil)
+		assert.Error(t, err)
+		_, err = valueobjects.NewRenditionQuality("", 33.1, 0.9, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("VideoPerTitleLadder", func(t *testing.T) {
+		slug, _ := valueobjects.NewSlug("test-asset")
+		title, _ := valueobjects.NewTitle("Test Asset")
+		assetType, _ := valueobjects.NewAssetType("movie")
+
+		asset, err := entity.NewAsset(*slug, title, assetType)
+		assert.NoError(t, err)
+
+		s3Object, _ := valueobjects.NewS3Object("test-bucket", "videos/main.mp4", "https://test-bucket.s3.amazonaws.com/videos/main.mp4")
+		videoFormat := valueobjects.VideoFormat(constants.VideoStreamingFormatRaw)
+		video, err := asset.UpsertVideo("main", &videoFormat, *s3Object, 1920, 1080, 600, 5000000, "h264", 1024000000, "video/mp4", "h264", "aac", "30fps", 2, 48000, nil, nil)
+		assert.NoError(t, err)
+
+		assert.NoError(t, asset.SetVideoComplexity(video.ID().Value(), 0.6))
+		assert.Equal(t, 0.6, video.Complexity())
+		assert.Error(t, asset.SetVideoComplexity(video.ID().Value(), -1))
+
+		assert.Zero(t, video.LadderSavings())
+		high, err := valueobjects.NewLadderRung("1080p", 1920, 1080, 3000, 128, 5000)
+		assert.NoError(t, err)
+		low, err := valueobjects.NewLadderRung("480p", 854, 480, 700, 96, 1200)
+		assert.NoError(t, err)
+		video.SetLadder([]valueobjects.LadderRung{*high, *low})
+		assert.Equal(t, "480p", video.Ladder()[0].Name())
+		assert.InDelta(t, 1-3700.0/6200.0, video.LadderSavings(), 1e-9)
+
+		_, err = valueobjects.NewLadderRung("720p", 1280, 720, 0, 128, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("VideoLoudness", func(t *testing.T) {
+		slug, _ := valueobjects.NewSlug("test-podcast")
+		title, _ := valueobjects.NewTitle("Test Podcast")
+		assetType, _ := valueobjects.NewAssetType("podcast")
+
+		asset, err := entity.NewAsset(*slug, title, assetType)
+		assert.NoError(t, err)
+
+		s3Object, _ := valueobjects.NewS3Object("test-bucket", "videos/main.m4a", "https://test-bucket.s3.amazonaws.com/videos/main.m4a")
+		videoFormat := valueobjects.VideoFormat(constants.VideoStreamingFormatRaw)
+		video, err := asset.UpsertVideo("main", &videoFormat, *s3Object, 0, 0, 1800, 128000, "aac", 28800000, "audio/mp4", "", "aac", "", 2, 48000, nil, nil)
+		assert.NoError(t, err)
+		assert.Nil(t, video.Loudness())
+
+		eng, _ := valueobjects.NewAudioTrack(0, "eng", "aac", 2, "stereo", 48000, "", false)
+		tur, _ := valueobjects.NewAudioTrack(1, "tur", "aac", 2, "stereo", 48000, "", false)
+		engLoudness, err := valueobjects.NewLoudness(-16.2, -0.4, 6.1, -26.5, 0.3)
+		assert.NoError(t, err)
+		turLoudness, err := valueobjects.NewLoudness(-27.8, -6.9, 9.4, -38.1, -0.1)
+		assert.NoError(t, err)
+		video.SetAudioTracks([]valueobjects.AudioTrack{eng.WithLoudness(engLoudness), tur.WithLoudness(turLoudness)})
+
+		assert.Equal(t, -16.2, video.Loudness().Integrated())
+		assert.NoError(t, video.SetDefaultAudioLanguage("tur"))
+		assert.Equal(t, -27.8, video.Loudness().Integrated())
+		assert.Equal(t, -6.9, video.AudioTracks()[1].Loudness().TruePeak())
+
+		_, err = valueobjects.NewLoudness(3, -1, 5, -30, 0)
+		assert.Error(t, err)
+		_, err = valueobjects.NewLoudness(math.Inf(-1), -1, 5, -30, 0)
+		assert.Error(t, err)
+	})
+
+	t.Run("VideoInputRejections", func(t *testing.T) {
+		slug, _ := valueobjects.NewSlug("test-asset")
+		title, _ := valueobjects.NewTitle("Test Asset")
+		assetType, _ := valueobjects.NewAssetType("movie")
+
+		asset, err := entity.NewAsset(*slug, title, assetType)
+		assert.NoError(t, err)
+
+		s3Object, _ := valueobjects.NewS3Object("test-bucket", "videos/main.mov", "https://test-bucket.s3.amazonaws.com/videos/main.mov")
+		videoFormat := valueobjects.VideoFormat(constants.VideoStreamingFormatRaw)
+		video, err := asset.UpsertVideo("main", &videoFormat, *s3Object, 1920, 1080, 600, 5000000, "h264", 1024000000, "video/quicktime", "h264", "aac", "29.97fps", 2, 48000, nil, nil)
+		assert.NoError(t, err)
+
+		interlaced, err := valueobjects.NewInputRejection("interlaced", "source is interlaced (field order \"tt\", 98% of frames)")
+		assert.NoError(t, err)
+		_, err = valueobjects.NewInputRejection(" ", "no code")
+		assert.Error(t, err)
+
+		assert.Error(t, asset.RejectVideoInput(video.ID().Value(), nil))
+		assert.Error(t, asset.RejectVideoInput("missing", []valueobjects.InputRejection{*interlaced}))
+
+		assert.NoError(t, asset.RejectVideoInput(video.ID().Value(), []valueobjects.InputRejection{*interlaced}))
+		assert.True(t, video.IsFailed())
+		assert.Equal(t, "interlaced", video.InputRejections()[0].Code())
+
+		// A later analysis that accepts the source clears the reasons.
+		contentType, _ := valueobjects.NewContentType("video/quicktime")
+		info := valueobjects.NewMediaInfo(1920, 1080, 600, 5000000, "h264", 1024000000, *contentType, "", "aac", "", 2, 48000)
+		assert.NoError(t, asset.UpdateVideoMediaInfo(video.ID().Value(), *info))
+		assert.Empty(t, video.InputRejections())
+	})
+
+	t.Run("StorageLocationSchemes", func(t *testing.T) {
+		for _, url := range []string{"s3://content-east/raw/main.mp4", "file://content-east/raw/main.mp4", "mem://content-east/raw/main.mp4"} {
+			s3Object, err := valueobjects.NewS3ObjectFromURL(url)
+			assert.NoError(t, err, url)
+			assert.Equal(t, "content-east", s3Object.Bucket())
+			assert.Equal(t, "raw/main.mp4", s3Object.Key())
+			assert.Equal(t, url, s3Object.BuildS3URL())
+		}
+		_, err := valueobjects.NewS3ObjectFromURL("ftp://content-east/raw/main.mp4")
+		assert.Error(t, err)
+		_, err = valueobjects.NewS3ObjectFromURL("file://content-east")
+		assert.Error(t, err)
+	})
+
+	t.Run("VideoVariant", func(t *testing.T) {
+		slug, _ := valueobjects.NewSlug("test-asset")
+		title, _ := valueobjects.NewTitle("Test Asset")
+		assetType, _ := valueobjects.NewAssetType("movie")
+		asset, err := entity.NewAsset(*slug, title, assetType)
+		assert.NoError(t, err)
+
+		hls := valueobjects.VideoFormat(constants.VideoStreamingFormatHLS)
+		mainObject, _ := valueobjects.NewS3Object("test-bucket", "a1/v1/hls/main/playlist.m3u8", "")
+		_, err = asset.UpsertVideo("main", &hls, *mainObject, 0, 0, 0, 0, "", 0, "application/x-mpegURL", "", "", "", 0, 0, nil, nil)
+		assert.NoError(t, err)
+
+		variantObject, _ := valueobjects.NewS3Object("test-bucket", "a1/v1/hls/screener/playlist.m3u8", "")
+		pending := valueobjects.VideoStatusPending
+		variant, err := asset.AddVideoVariant("screener", &hls, *variantObject, "application/x-mpegURL", &pending)
+		assert.NoError(t, err)
+		assert.True(t, variant.IsVariant())
+		assert.Len(t, asset.Videos(), 2)
+
+		again, err := asset.AddVideoVariant("screener", &hls, *variantObject, "application/x-mpegURL", &pending)
+		assert.NoError(t, err)
+		assert.Equal(t, variant.ID(), again.ID())
+		_, err = asset.AddVideoVariant("main", &hls, *mainObject, "application/x-mpegURL", &pending)
+		assert.Error(t, err)
+
+		// Variants do not count as the format's main video.
+		otherObject, _ := valueobjects.NewS3Object("test-bucket", "a1/v1/hls/other/playlist.m3u8", "")
+		_, err = asset.UpsertVideo("other", &hls, *otherObject, 0, 0, 0, 0, "", 0, "application/x-mpegURL", "", "", "", 0, 0, nil, nil)
+		assert.Error(t, err)
+
+		updated, err := asset.UpsertVideo("screener", &hls, *variantObject, 1920, 1080, 60, 0, "", 0, "application/x-mpegURL", "", "", "", 0, 0, nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, variant.ID(), updated.ID())
+		assert.True(t, updated.IsVariant())
+	})
+
+	t.Run("LiveStream", func(t *testing.T) {
+		slug, _ := valueobjects.NewSlug("live-event")
+		title, _ := valueobjects.NewTitle("Live Event")
+		movieType, _ := valueobjects.NewAssetType("movie")
+		movie, err := entity.NewAsset(*slug, title, movieType)
+		assert.NoError(t, err)
+		_, err = movie.CreateStreamKey(valueobjects.LiveProtocolSRT)
+		assert.Error(t, err)
+
+		liveType, _ := valueobjects.NewAssetType("live")
+		asset, err := entity.NewAsset(*slug, title, liveType)
+		assert.NoError(t, err)
+		assert.Error(t, asset.StartLiveEvent("session-1"))
+
+		ls, err := asset.CreateStreamKey(valueobjects.LiveProtocolSRT)
+		assert.NoError(t, err)
+		assert.Len(t, ls.StreamKey(), 32)
+		assert.Equal(t, valueobjects.LiveStatusIdle, ls.Status())
+
+		assert.NoError(t, asset.StartLiveEvent("session-1"))
+		assert.Error(t, asset.StartLiveEvent("session-2"))
+		_, err = asset.CreateStreamKey(valueobjects.LiveProtocolSRT)
+		assert.Error(t, err)
+
+		assert.ErrorIs(t, asset.MarkLiveReady("session-0", "srt://ingest:1935"), valueobjects.ErrLiveSessionMismatch)
+		assert.NoError(t, asset.MarkLiveReady("session-1", "srt://ingest:1935"))
+		assert.Equal(t, valueobjects.LiveStatusWaiting, asset.LiveStream().Status())
+
+		startedAt := time.Now().UTC()
+		assert.NoError(t, asset.MarkLiveStarted("session-1", "s3://content-east/a/live/session-1/hls/playlist.m3u8", startedAt))
+		assert.Equal(t, valueobjects.LiveStatusLive, asset.LiveStream().Status())
+
+		assert.NoError(t, asset.StopLiveEvent())
+		assert.NoError(t, asset.EndLiveEvent("session-1", true, "", "", startedAt.Add(time.Hour)))
+		ended := asset.LiveStream()
+		assert.Equal(t, valueobjects.LiveStatusEnded, ended.Status())
+		assert.Equal(t, "srt://ingest:1935", ended.IngestURL())
+		assert.Equal(t, ls.StreamKey(), ended.StreamKey())
+		// A redelivered stopped event is stale.
+		assert.ErrorIs(t, asset.EndLiveEvent("session-1", true, "", "", startedAt), valueobjects.ErrLiveSessionMismatch)
+		assert.Error(t, asset.SetLiveArchiveVideo("missing"))
+
+		assert.NoError(t, asset.StartLiveEvent("session-2"))
+		restarted := asset.LiveStream()
+		assert.Equal(t, valueobjects.LiveStatusStarting, restarted.Status())
+		assert.Empty(t, restarted.IngestURL())
+		assert.Nil(t, restarted.EndedAt())
+		assert.NoError(t, asset.EndLiveEvent("session-2", false, "no stream was received", "", time.Now()))
+		assert.Equal(t, valueobjects.LiveStatusFailed, asset.LiveStream().Status())
+		assert.Equal(t, "no stream was received", asset.LiveStream().ErrorMessage())
+
+		_, err = valueobjects.NewLiveProtocol("webrtc")
+		assert.Error(t, err)
+		_, err = valueobjects.NewLiveProtocol("RTMP")
+		assert.Error(t, err)
+
+		// A stream key stored before RTMP ingest was dropped cannot start an
+		// event.
+		asset.RestoreLiveStream(valueobjects.ReconstructLiveStream("0123456789abcdef", "rtmp", "ended", "session-2", "", "", nil, nil, "", ""))
+		assert.ErrorContains(t, asset.StartLiveEvent("session-3"), "not an srt key")
+	})
+
+	t.Run("LivePublishing", func(t *testing.T) {
+		slug, _ := valueobjects.NewSlug("live-event")
+		title, _ := valueobjects.NewTitle("Live Event")
+		liveType, _ := valueobjects.NewAssetType("live")
+		asset, err := entity.NewAsset(*slug, title, liveType)
+		assert.NoError(t, err)
+		poster, _ := valueobjects.NewImage("poster.jpg", "https://cdn.example.com/poster.jpg", valueobjects.ImageTypePoster, "image/jpeg")
+		asset.AddImage(*poster)
+		asset.RestoreStatus(valueobjects.AssetStatusApproved, nil)
+		now := time.Now().UTC()
+
+		// A stream key with no event running has nothing to play.
+		_, err = asset.CreateStreamKey(valueobjects.LiveProtocolSRT)
+		assert.NoError(t, err)
+		assert.ErrorContains(t, asset.TransitionTo(valueobjects.AssetStatusPublished, "", now), "no ready video or running live event")
+
+		assert.NoError(t, asset.StartLiveEvent("session-1"))
+		assert.NoError(t, asset.TransitionTo(valueobjects.AssetStatusPublished, "", now))
+		assert.Equal(t, constants.AssetStatusPublished, asset.Status())
+
+		// Once the event has ended the asset needs its archive to go out again.
+		assert.NoError(t, asset.TransitionTo(valueobjects.AssetStatusUnpublished, "", now))
+		assert.NoError(t, asset.EndLiveEvent("session-1", false, "no stream was received", "", now))
+		assert.Error(t, asset.TransitionTo(valueobjects.AssetStatusPublished, "", now))
+	})
+
 	t.Run("AssetHierarchy", func(t *testing.T) {
 		parentSlug, _ := valueobjects.NewSlug("parent-asset")
 		parentTitle, _ := valueobjects.NewTitle("Parent Asset")
@@ -259,6 +620,91 @@ func TestRichDomainModel(t *testing.T) {
 		child.SetParentID(&parentID)
 		assert.Equal(t, parent.ID().Value(), child.ParentID().Value())
 	})
+
+	t.Run("ReleaseYear", func(t *testing.T) {
+		slug, _ := valueobjects.NewSlug("release-year")
+		a, err := entity.NewAsset(*slug, nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, a.CreatedAt().Value().UTC().Year(), a.ReleaseYear())
+
+		publishAt := time.Date(2019, 12, 31, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*60*60))
+		rule, err := valueobjects.NewPublishRule(&publishAt, nil, nil, nil)
+		assert.NoError(t, err)
+		assert.NoError(t, a.SetPublishRule(rule))
+		assert.Equal(t, 2020, a.ReleaseYear())
+	})
+
+	t.Run("SeriesHierarchy", func(t *testing.T) {
+		newAsset := func(slug, assetType string) *entity.Asset {
+			s, _ := valueobjects.NewSlug(slug)
+			ti, _ := valueobjects.NewTitle(slug)
+			at, _ := valueobjects.NewAssetType(assetType)
+			a, err := entity.NewAsset(*s, ti, at)
+			assert.NoError(t, err)
+			return a
+		}
+		number := func(n int) *int { return &n }
+
+		series := newAsset("the-show", "series")
+		seasonOne := newAsset("the-show-s1", "season")
+		seasonTwo := newAsset("the-show-s2", "season")
+		trailer := newAsset("the-show-trailer", "trailer")
+
+		assert.Error(t, seasonOne.SetParent(nil))
+		assert.Error(t, seasonOne.SetParent(trailer))
+		assert.NoError(t, seasonOne.SetParent(series))
+		assert.Equal(t, series.ID().Value(), seasonOne.ParentID().Value())
+		assert.NoError(t, seasonTwo.SetParent(series))
+		assert.NoError(t, trailer.SetParent(series))
+		assert.Error(t, series.SetParent(trailer))
+
+		episode := newAsset("the-show-s1e1", "episode")
+		assert.Error(t, episode.SetParent(series))
+		assert.NoError(t, episode.SetParent(seasonOne))
+
+		assert.NoError(t, seasonOne.SetSeasonNumber(number(1)))
+		assert.NoError(t, seasonTwo.SetSeasonNumber(number(2)))
+		assert.Error(t, seasonOne.SetSeasonNumber(number(0)))
+		assert.Error(t, seasonOne.SetEpisodeNumber(number(1)))
+		assert.Error(t, episode.SetSeasonNumber(number(1)))
+		assert.Equal(t, 1, *seasonOne.SeasonNumber())
+
+		s1e2 := newAsset("the-show-s1e2", "episode")
+		s1e2.SetEpisodeNumber(number(2))
+		episode.SetEpisodeNumber(number(1))
+		s2e1 := newAsset("the-show-s2e1", "episode")
+		s2e1.SetEpisodeNumber(number(1))
+		unnumbered := newAsset("the-show-s2-special", "episode")
+
+		tree := entity.NewSeriesTree(
+			series,
+			[]*entity.Asset{trailer, seasonTwo, seasonOne},
+			map[string][]*entity.Asset{
+				seasonOne.ID().Value(): {s1e2, episode},
+				seasonTwo.ID().Value(): {unnumbered, s2e1},
+			},
+		)
+		assert.Len(t, tree.Seasons, 2)
+		assert.Equal(t, seasonOne.ID(), tree.Seasons[0].Season.ID())
+		assert.Equal(t, seasonTwo.ID(), tree.Seasons[1].Season.ID())
+
+		ordered := tree.Episodes()
+		assert.Len(t, ordered, 4)
+		assert.Equal(t, episode.ID(), ordered[0].ID())
+		assert.Equal(t, s1e2.ID(), ordered[1].ID())
+		assert.Equal(t, s2e1.ID(), ordered[2].ID())
+		assert.Equal(t, unnumbered.ID(), ordered[3].ID())
+
+		previous, next := tree.Adjacent(s1e2.ID())
+		assert.Equal(t, episode.ID(), previous.ID())
+		assert.Equal(t, s2e1.ID(), next.ID())
+		previous, next = tree.Adjacent(episode.ID())
+		assert.Nil(t, previous)
+		assert.Equal(t, s1e2.ID(), next.ID())
+		previous, next = tree.Adjacent(trailer.ID())
+		assert.Nil(t, previous)
+		assert.Nil(t, next)
+	})
 }
 
 func TestDomainServices(t *testing.T) {
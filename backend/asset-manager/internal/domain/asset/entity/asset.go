@@ -2,8 +2,10 @@ package entity
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/events"
 	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/constants"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/operations"
@@ -25,11 +27,21 @@ type Asset struct {
 	parentID    *valueobjects.AssetID
 	parent      *Asset
 	children    []Asset
+
+	seasonNumber  *int
+	episodeNumber *int
+
 	images      []valueobjects.Image
 	videos      map[string]*Video
+	subtitles   []*Subtitle
 	credits     []valueobjects.Credit
 	publishRule *valueobjects.PublishRule
+	liveStream  *valueobjects.LiveStream
 	metadata    map[string]interface{}
+
+	status        valueobjects.AssetStatus
+	statusHistory []valueobjects.AssetStatusChange
+	events        []events.AssetEvent
 }
 
 func NewAsset(slug valueobjects.Slug, title *valueobjects.Title, assetType *valueobjects.AssetType) (*Asset, error) {
@@ -63,6 +75,7 @@ func NewAsset(slug valueobjects.Slug, title *valueobjects.Title, assetType *valu
 		genres:    genres,
 		tags:      tags,
 		metadata:  make(map[string]interface{}),
+		status:    valueobjects.AssetStatusDraft,
 	}, nil
 }
 
@@ -104,6 +117,7 @@ func ReconstructAsset(
 		credits:     credits,
 		publishRule: publishRule,
 		metadata:    metadata,
+		status:      valueobjects.AssetStatusDraft,
 	}
 }
 
@@ -166,6 +180,16 @@ func (a *Asset) Children() []Asset {
 	return a.children
 }
 
+// SeasonNumber orders a season within its series.
+func (a *Asset) SeasonNumber() *int {
+	return a.seasonNumber
+}
+
+// EpisodeNumber orders an episode within its season.
+func (a *Asset) EpisodeNumber() *int {
+	return a.episodeNumber
+}
+
 func (a *Asset) Images() []valueobjects.Image {
 	return a.images
 }
@@ -174,6 +198,10 @@ func (a *Asset) Videos() map[string]*Video {
 	return a.videos
 }
 
+func (a *Asset) Subtitles() []*Subtitle {
+	return a.subtitles
+}
+
 func (a *Asset) Credits() []valueobjects.Credit {
 	return a.credits
 }
@@ -182,6 +210,15 @@ func (a *Asset) PublishRule() *valueobjects.PublishRule {
 	return a.publishRule
 }
 
+// ReleaseYear is the year of the publish date when one is set, and the year
+// the asset was created otherwise. Search facets group assets by it.
+func (a *Asset) ReleaseYear() int {
+	if a.publishRule != nil && a.publishRule.PublishAt() != nil {
+		return a.publishRule.PublishAt().UTC().Year()
+	}
+	return a.createdAt.Value().UTC().Year()
+}
+
 func (a *Asset) Metadata() map[string]interface{} {
 	return a.metadata
 }
@@ -212,7 +249,83 @@ func (a *Asset) UpdateTags(newTags *valueobjects.Tags) {
 }
 
 func (a *Asset) Status() string {
-	return "draft"
+	return a.status.Value()
+}
+
+func (a *Asset) StatusHistory() []valueobjects.AssetStatusChange {
+	return a.statusHistory
+}
+
+// RestoreStatus sets the editorial status and its history read back from
+// storage.
+func (a *Asset) RestoreStatus(status valueobjects.AssetStatus, history []valueobjects.AssetStatusChange) {
+	a.status = status
+	a.statusHistory = history
+}
+
+// TransitionTo moves the asset through the editorial lifecycle and records
+// the change. Scheduling and publishing require publishable content, and
+// scheduling also needs a publish date in the future.
+func (a *Asset) TransitionTo(next valueobjects.AssetStatus, note string, at time.Time) error {
+	change, err := valueobjects.NewAssetStatusChange(a.status, next, at, note)
+	if err != nil {
+		return err
+	}
+	switch next {
+	case valueobjects.AssetStatusScheduled:
+		if a.publishRule == nil || a.publishRule.PublishAt() == nil || !a.publishRule.PublishAt().After(at) {
+			return errors.New("scheduling requires a publish date in the future")
+		}
+		if err := a.checkPublishable(); err != nil {
+			return err
+		}
+	case valueobjects.AssetStatusPublished:
+		if err := a.checkPublishable(); err != nil {
+			return err
+		}
+	}
+
+	previous := a.status
+	a.status = next
+	a.statusHistory = append(a.statusHistory, *change)
+	if next == valueobjects.AssetStatusPublished {
+		var regions []string
+		if a.publishRule != nil {
+			regions = a.publishRule.Regions()
+		}
+		a.events = append(a.events, events.NewAssetPublishedEvent(a.id.Value(), change.At(), regions))
+	} else if previous == valueobjects.AssetStatusPublished {
+		a.events = append(a.events, events.NewAssetUnpublishedEvent(a.id.Value()))
+	}
+	a.touch()
+	return nil
+}
+
+// checkPublishable requires a poster to show in the catalogue and something
+// to play: a ready video, or for a live asset a running event. A stream key
+// alone is not enough, since nothing plays until the broadcaster connects.
+func (a *Asset) checkPublishable() error {
+	if !a.IsReadyForPublishing() && !a.isLiveForPublishing() {
+		return errors.New("asset has no ready video or running live event")
+	}
+	for _, image := range a.images {
+		if image.Type() == valueobjects.ImageTypePoster {
+			return nil
+		}
+	}
+	return errors.New("asset has no poster image")
+}
+
+func (a *Asset) isLiveForPublishing() bool {
+	return a.liveStream != nil && a.liveStream.IsActive()
+}
+
+// PullEvents returns the domain events recorded since the last call and
+// clears them.
+func (a *Asset) PullEvents() []events.AssetEvent {
+	pending := a.events
+	a.events = nil
+	return pending
 }
 
 func (a *Asset) CanUpdateTitle() bool {
@@ -246,7 +359,7 @@ func (a *Asset) findVideoByLabelAndFormat(label string, format valueobjects.Vide
 
 func (a *Asset) hasMainForFormat(format valueobjects.VideoFormat) bool {
 	for _, v := range a.videos {
-		if v.Type().IsMain() && v.Format().Equals(format) {
+		if v.Type().IsMain() && !v.IsVariant() && v.Format().Equals(format) {
 			return true
 		}
 	}
@@ -324,6 +437,32 @@ func (a *Asset) UpsertVideo(
 	return video, nil
 }
 
+// AddVideoVariant registers an overlay variant of a format under label, or
+// returns the one already there. Media details arrive later through
+// UpsertVideo, which finds the variant by the same label and format.
+func (a *Asset) AddVideoVariant(label string, format *valueobjects.VideoFormat, storageLocation valueobjects.S3Object, contentType string, initialStatus *valueobjects.VideoStatus) (*Video, error) {
+	if format == nil {
+		return nil, errors.New("format cannot be nil")
+	}
+	if _, existing := a.findVideoByLabelAndFormat(label, *format); existing != nil {
+		if !existing.IsVariant() {
+			return nil, errors.New("label is taken by a video that is not a variant")
+		}
+		return existing, nil
+	}
+	video, err := NewVideo(label, format, storageLocation, 0, 0, 0, 0, "", 0, contentType, "", "", "", 0, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	video.MarkVariant()
+	if initialStatus != nil {
+		video.UpdateStatus(*initialStatus)
+	}
+	a.videos[video.ID().Value()] = video
+	a.touch()
+	return video, nil
+}
+
 func (a *Asset) RemoveVideo(videoID string) error {
 	if _, exists := a.videos[videoID]; exists {
 		delete(a.videos, videoID)
@@ -346,17 +485,100 @@ func (a *Asset) UpdateVideoMediaInfo(videoID string, transcodingInfo valueobject
 	if video, exists := a.videos[videoID]; exists {
 		video.UpdateMediaInfo(transcodingInfo)
 		video.UpdateStatus(valueobjects.VideoStatus("ready"))
+		video.SetInputRejections(nil)
 		a.touch()
 		return nil
 	}
 	return errors.New("video not found")
 }
 
+func (a *Asset) SetVideoAudioTracks(videoID string, tracks []valueobjects.AudioTrack) error {
+	video, exists := a.videos[videoID]
+	if !exists {
+		return errors.New("video not found")
+	}
+	video.SetAudioTracks(tracks)
+	a.touch()
+	return nil
+}
+
+func (a *Asset) RejectVideoInput(videoID string, rejections []valueobjects.InputRejection) error {
+	video, exists := a.videos[videoID]
+	if !exists {
+		return errors.New("video not found")
+	}
+	if len(rejections) == 0 {
+		return errors.New("a rejected input needs at least one reason")
+	}
+	video.SetInputRejections(rejections)
+	video.UpdateStatus(valueobjects.VideoStatusFailed)
+	a.touch()
+	return nil
+}
+
+func (a *Asset) SetVideoComplexity(videoID string, score float64) error {
+	video, exists := a.videos[videoID]
+	if !exists {
+		return errors.New("video not found")
+	}
+	if score < 0 {
+		return errors.New("complexity score cannot be negative")
+	}
+	video.SetComplexity(score)
+	a.touch()
+	return nil
+}
+
+func (a *Asset) SetVideoMarkers(videoID string, markers []valueobjects.Marker) error {
+	video, exists := a.videos[videoID]
+	if !exists {
+		return errors.New("video not found")
+	}
+	if err := video.SetMarkers(markers); err != nil {
+		return err
+	}
+	a.touch()
+	return nil
+}
+
+func (a *Asset) SetVideoDefaultAudioLanguage(videoID, language string) error {
+	video, exists := a.videos[videoID]
+	if !exists {
+		return errors.New("video not found")
+	}
+	if err := video.SetDefaultAudioLanguage(language); err != nil {
+		return err
+	}
+	a.touch()
+	return nil
+}
+
 func (a *Asset) AddImage(image valueobjects.Image) {
 	a.images = append(a.images, image)
 	a.touch()
 }
 
+// AttachVideoImages stores generated stills on the video and replaces any
+// asset images previously generated from the same video.
+func (a *Asset) AttachVideoImages(videoID string, videoImages, assetImages []valueobjects.Image) error {
+	video, exists := a.videos[videoID]
+	if !exists {
+		return errors.New("video not found")
+	}
+	video.SetImages(videoImages)
+
+	kept := make([]valueobjects.Image, 0, len(a.images)+len(assetImages))
+	for _, image := range a.images {
+		if md := image.Metadata(); md != nil && md["videoId"] == videoID && md["source"] == "thumbnails" {
+			continue
+		}
+		kept = append(kept, image)
+	}
+	a.images = append(kept, assetImages...)
+	a.touch()
+	return nil
+}
+
 func (a *Asset) RemoveImage(imageID string) error {
 	for i, image := range a.images {
 		if image.ID().Value() == imageID {
@@ -368,6 +590,121 @@ func (a *Asset) RemoveImage(imageID string) error {
 	return errors.New("image not found")
 }
 
+// AddSubtitle rejects a second track with the same language and kind. A new
+// default track takes the default flag away from the others.
+func (a *Asset) AddSubtitle(subtitle *Subtitle) error {
+	for _, existing := range a.subtitles {
+		if existing.Language().Equals(subtitle.Language()) && existing.Kind() == subtitle.Kind() {
+			return errors.New("subtitle already exists for this language and kind")
+		}
+	}
+	if subtitle.IsDefault() {
+		for _, existing := range a.subtitles {
+			existing.setDefault(false)
+		}
+	}
+	a.subtitles = append(a.subtitles, subtitle)
+	a.touch()
+	return nil
+}
+
+func (a *Asset) RemoveSubtitle(subtitleID string) error {
+	for i, subtitle := range a.subtitles {
+		if subtitle.ID().Value() == subtitleID {
+			a.subtitles = append(a.subtitles[:i], a.subtitles[i+1:]...)
+			a.touch()
+			return nil
+		}
+	}
+	return errors.New("subtitle not found")
+}
+
+// RestoreSubtitles sets the subtitle tracks read back from storage.
+func (a *Asset) RestoreSubtitles(subtitles []*Subtitle) {
+	a.subtitles = subtitles
+}
+
+func (a *Asset) LiveStream() *valueobjects.LiveStream {
+	return a.liveStream
+}
+
+// RestoreLiveStream sets the live stream state read back from storage.
+func (a *Asset) RestoreLiveStream(liveStream *valueobjects.LiveStream) {
+	a.liveStream = liveStream
+}
+
+// CreateStreamKey issues a new stream key, replacing the old one. The key
+// cannot change while an event is running, since the transcoder is already
+// listening for it.
+func (a *Asset) CreateStreamKey(protocol valueobjects.LiveProtocol) (*valueobjects.LiveStream, error) {
+	if a.assetType == nil || a.assetType.Value() != constants.AssetTypeLive {
+		return nil, errors.New("stream keys are only available on live assets")
+	}
+	if a.liveStream != nil && a.liveStream.IsActive() {
+		return nil, errors.New("cannot change the stream key during a live event")
+	}
+	liveStream, err := valueobjects.NewLiveStream(protocol)
+	if err != nil {
+		return nil, err
+	}
+	a.liveStream = liveStream
+	a.touch()
+	return liveStream, nil
+}
+
+func (a *Asset) StartLiveEvent(sessionID string) error {
+	return a.updateLiveStream(func(l valueobjects.LiveStream) (*valueobjects.LiveStream, error) {
+		return l.Start(sessionID)
+	})
+}
+
+func (a *Asset) MarkLiveReady(sessionID, ingestURL string) error {
+	return a.updateLiveStream(func(l valueobjects.LiveStream) (*valueobjects.LiveStream, error) {
+		return l.Ready(sessionID, ingestURL)
+	})
+}
+
+func (a *Asset) MarkLiveStarted(sessionID, playbackURL string, at time.Time) error {
+	return a.updateLiveStream(func(l valueobjects.LiveStream) (*valueobjects.LiveStream, error) {
+		return l.Started(sessionID, playbackURL, at)
+	})
+}
+
+func (a *Asset) StopLiveEvent() error {
+	return a.updateLiveStream(func(l valueobjects.LiveStream) (*valueobjects.LiveStream, error) {
+		return l.Stop()
+	})
+}
+
+func (a *Asset) EndLiveEvent(sessionID string, success bool, errorMessage, playbackURL string, at time.Time) error {
+	return a.updateLiveStream(func(l valueobjects.LiveStream) (*valueobjects.LiveStream, error) {
+		return l.End(sessionID, success, errorMessage, playbackURL, at)
+	})
+}
+
+// SetLiveArchiveVideo links the video made from the last event's recording.
+func (a *Asset) SetLiveArchiveVideo(videoID string) error {
+	if _, exists := a.videos[videoID]; !exists {
+		return errors.New("video not found")
+	}
+	return a.updateLiveStream(func(l valueobjects.LiveStream) (*valueobjects.LiveStream, error) {
+		return l.WithArchiveVideo(videoID), nil
+	})
+}
+
+func (a *Asset) updateLiveStream(next func(valueobjects.LiveStream) (*valueobjects.LiveStream, error)) error {
+	if a.liveStream == nil {
+		return errors.New("asset has no stream key")
+	}
+	liveStream, err := next(*a.liveStream)
+	if err != nil {
+		return err
+	}
+	a.liveStream = liveStream
+	a.touch()
+	return nil
+}
+
 func (a *Asset) AddCredit(credit valueobjects.Credit) {
 	a.credits = append(a.credits, credit)
 	a.touch()
@@ -399,6 +736,81 @@ func (a *Asset) SetParentID(parentID *valueobjects.AssetID) {
 	a.touch()
 }
 
+// SetParent places the asset under parent, or at the top level when parent
+// is nil, provided the two asset types may nest.
+func (a *Asset) SetParent(parent *Asset) error {
+	if a.assetType != nil {
+		var parentType *valueobjects.AssetType
+		if parent != nil {
+			parentType = parent.assetType
+		}
+		if err := a.assetType.ValidateParent(parentType); err != nil {
+			return err
+		}
+	}
+	if parent == nil {
+		a.parentID = nil
+	} else {
+		if parent.id.Value() == a.id.Value() {
+			return errors.New("asset cannot be its own parent")
+		}
+		parentID := parent.id
+		a.parentID = &parentID
+	}
+	a.touch()
+	return nil
+}
+
+// SetSeasonNumber numbers a season within its series. Nil clears the
+// number.
+func (a *Asset) SetSeasonNumber(number *int) error {
+	if err := a.checkNumber(constants.AssetTypeSeason, number); err != nil {
+		return err
+	}
+	a.seasonNumber = copyNumber(number)
+	a.touch()
+	return nil
+}
+
+// SetEpisodeNumber numbers an episode within its season. Nil clears the
+// number.
+func (a *Asset) SetEpisodeNumber(number *int) error {
+	if err := a.checkNumber(constants.AssetTypeEpisode, number); err != nil {
+		return err
+	}
+	a.episodeNumber = copyNumber(number)
+	a.touch()
+	return nil
+}
+
+// RestoreNumbering sets the season and episode numbers read back from
+// storage.
+func (a *Asset) RestoreNumbering(seasonNumber, episodeNumber *int) {
+	a.seasonNumber = seasonNumber
+	a.episodeNumber = episodeNumber
+}
+
+func (a *Asset) checkNumber(assetType string, number *int) error {
+	if number == nil {
+		return nil
+	}
+	if a.assetType == nil || a.assetType.Value() != assetType {
+		return fmt.Errorf("only a %s can have a %s number", assetType, assetType)
+	}
+	if *number < 1 {
+		return fmt.Errorf("%s number must be at least 1", assetType)
+	}
+	return nil
+}
+
+func copyNumber(number *int) *int {
+	if number == nil {
+		return nil
+	}
+	n := *number
+	return &n
+}
+
 func (a *Asset) ValidateForPublishing() error {
 	if a.publishRule == nil {
 		return errors.New("asset is not ready for publishing")
@@ -0,0 +1,43 @@
+package entity
+
+// SearchCriteria is a full-text query over assets. Type, Genre, Year and
+// Status narrow the hits; facet counts are taken before they apply, so a
+// client can offer the other values to switch to.
+type SearchCriteria struct {
+	Query  string
+	Type   *string
+	Genre  *string
+	Year   *int
+	Status *string
+	Limit  int
+	Offset int
+}
+
+// SearchHit is an asset matching a search, with its relevance score.
+// Higher scores rank first.
+type SearchHit struct {
+	Asset *Asset
+	Score float64
+}
+
+type FacetCount struct {
+	Value string
+	Count int
+}
+
+// SearchFacets counts the assets matching the query text and status by
+// type, genre and release year, most common first. Each dimension is
+// counted with the other dimensions' filters applied but not its own.
+type SearchFacets struct {
+	Types  []FacetCount
+	Genres []FacetCount
+	Years  []FacetCount
+}
+
+// SearchResult is one page of ranked hits. Total counts every hit that
+// passes the filters, not just this page.
+type SearchResult struct {
+	Hits   []SearchHit
+	Total  int
+	Facets SearchFacets
+}
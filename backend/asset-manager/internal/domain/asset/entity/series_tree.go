@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"sort"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/constants"
+)
+
+// SeriesTree is a series with its seasons in season order, each holding its
+// episodes in episode order. Children of other types, such as trailers, are
+// left out.
+type SeriesTree struct {
+	Series  *Asset
+	Seasons []SeasonBranch
+}
+
+type SeasonBranch struct {
+	Season   *Asset
+	Episodes []*Asset
+}
+
+// NewSeriesTree builds the tree from the children of series and the
+// children of each season, keyed by season ID. Unnumbered seasons and
+// episodes come after numbered ones, and equal numbers fall back to
+// creation order.
+func NewSeriesTree(series *Asset, seasons []*Asset, episodesBySeason map[string][]*Asset) *SeriesTree {
+	ordered := ofType(seasons, constants.AssetTypeSeason)
+	sortByNumber(ordered, (*Asset).SeasonNumber)
+
+	tree := &SeriesTree{Series: series, Seasons: make([]SeasonBranch, 0, len(ordered))}
+	for _, season := range ordered {
+		episodes := ofType(episodesBySeason[season.ID().Value()], constants.AssetTypeEpisode)
+		sortByNumber(episodes, (*Asset).EpisodeNumber)
+		tree.Seasons = append(tree.Seasons, SeasonBranch{Season: season, Episodes: episodes})
+	}
+	return tree
+}
+
+// Episodes returns every episode of the series in viewing order.
+func (t *SeriesTree) Episodes() []*Asset {
+	var all []*Asset
+	for _, branch := range t.Seasons {
+		all = append(all, branch.Episodes...)
+	}
+	return all
+}
+
+// Adjacent returns the episodes before and after episodeID in viewing
+// order, crossing into the neighbouring season at either end of a season.
+// Both are nil when the episode is not in the tree.
+func (t *SeriesTree) Adjacent(episodeID valueobjects.AssetID) (previous, next *Asset) {
+	episodes := t.Episodes()
+	for i, episode := range episodes {
+		if episode.ID().Value() != episodeID.Value() {
+			continue
+		}
+		if i > 0 {
+			previous = episodes[i-1]
+		}
+		if i < len(episodes)-1 {
+			next = episodes[i+1]
+		}
+		break
+	}
+	return previous, next
+}
+
+func ofType(assets []*Asset, assetType string) []*Asset {
+	out := make([]*Asset, 0, len(assets))
+	for _, a := range assets {
+		if a.Type() != nil && a.Type().Value() == assetType {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+func sortByNumber(assets []*Asset, number func(*Asset) *int) {
+	sort.SliceStable(assets, func(i, j int) bool {
+		ni, nj := number(assets[i]), number(assets[j])
+		switch {
+		case ni != nil && nj != nil && *ni != *nj:
+			return *ni < *nj
+		case ni != nil && nj == nil:
+			return true
+		case ni == nil && nj != nil:
+			return false
+		}
+		return assets[i].CreatedAt().Value().Before(assets[j].CreatedAt().Value())
+	})
+}
@@ -0,0 +1,101 @@
+package entity
+
+import (
+	"errors"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
+)
+
+const subtitleLabelMaxLength = 100
+
+// Subtitle is a text track uploaded as SRT or WebVTT for one language.
+type Subtitle struct {
+	id              valueobjects.ID
+	language        valueobjects.LanguageCode
+	kind            valueobjects.SubtitleKind
+	label           string
+	isDefault       bool
+	storageLocation valueobjects.S3Object
+	contentType     string
+	createdAt       time.Time
+	updatedAt       time.Time
+}
+
+func NewSubtitle(language, kind, label string, isDefault bool, storageLocation valueobjects.S3Object, contentType string) (*Subtitle, error) {
+	languageVO, err := valueobjects.NewLanguageCode(language)
+	if err != nil {
+		return nil, err
+	}
+	kindVO, err := valueobjects.NewSubtitleKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	if len(label) > subtitleLabelMaxLength {
+		return nil, errors.New("subtitle label too long")
+	}
+	switch strings.ToLower(path.Ext(storageLocation.Key())) {
+	case ".srt", ".vtt":
+	default:
+		return nil, errors.New("subtitle file must be .srt or .vtt")
+	}
+	id, err := valueobjects.GenerateSubtitleID()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	return &Subtitle{
+		id:              *id,
+		language:        *languageVO,
+		kind:            *kindVO,
+		label:           label,
+		isDefault:       isDefault,
+		storageLocation: storageLocation,
+		contentType:     contentType,
+		createdAt:       now,
+		updatedAt:       now,
+	}, nil
+}
+
+func ReconstructSubtitle(
+	id valueobjects.ID,
+	language valueobjects.LanguageCode,
+	kind valueobjects.SubtitleKind,
+	label string,
+	isDefault bool,
+	storageLocation valueobjects.S3Object,
+	contentType string,
+	createdAt, updatedAt time.Time,
+) *Subtitle {
+	return &Subtitle{
+		id:              id,
+		language:        language,
+		kind:            kind,
+		label:           label,
+		isDefault:       isDefault,
+		storageLocation: storageLocation,
+		contentType:     contentType,
+		createdAt:       createdAt,
+		updatedAt:       updatedAt,
+	}
+}
+
+func (s *Subtitle) ID() valueobjects.ID                    { return s.id }
+func (s *Subtitle) Language() valueobjects.LanguageCode    { return s.language }
+func (s *Subtitle) Kind() valueobjects.SubtitleKind        { return s.kind }
+func (s *Subtitle) Label() string                          { return s.label }
+func (s *Subtitle) IsDefault() bool                        { return s.isDefault }
+func (s *Subtitle) StorageLocation() valueobjects.S3Object { return s.storageLocation }
+func (s *Subtitle) ContentType() string                    { return s.contentType }
+func (s *Subtitle) CreatedAt() time.Time                   { return s.createdAt }
+func (s *Subtitle) UpdatedAt() time.Time                   { return s.updatedAt }
+
+func (s *Subtitle) setDefault(isDefault bool) {
+	if s.isDefault == isDefault {
+		return
+	}
+	s.isDefault = isDefault
+	s.updatedAt = time.Now().UTC()
+}
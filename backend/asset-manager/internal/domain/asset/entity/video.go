@@ -1,6 +1,9 @@
 package entity
 
 import (
+	"errors"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
@@ -30,6 +33,14 @@ type Video struct {
 	audioChannels      int
 	audioSampleRate    int
 	streamInfo         *valueobjects.StreamInfo
+	images             []valueobjects.Image
+	audioTracks        []valueobjects.AudioTrack
+	markers            []valueobjects.Marker
+	qualityScores      []valueobjects.RenditionQuality
+	complexity         float64
+	ladder             []valueobjects.LadderRung
+	inputRejections    []valueobjects.InputRejection
+	variant            bool
 }
 
 func NewVideo(
@@ -159,6 +170,9 @@ func (v *Video) FrameRate() string                      { return v.frameRate }
 func (v *Video) AudioChannels() int                     { return v.audioChannels }
 func (v *Video) AudioSampleRate() int                   { return v.audioSampleRate }
 func (v *Video) StreamInfo() *valueobjects.StreamInfo   { return v.streamInfo }
+func (v *Video) Images() []valueobjects.Image           { return v.images }
+func (v *Video) AudioTracks() []valueobjects.AudioTrack { return v.audioTracks }
+func (v *Video) Markers() []valueobjects.Marker         { return v.markers }
 func (v *Video) CreatedAt() time.Time                   { return v.timestamps.CreatedAt() }
 func (v *Video) UpdatedAt() time.Time                   { return v.timestamps.UpdatedAt() }
 
@@ -167,6 +181,166 @@ func (v *Video) SetStreamInfo(streamInfo *valueobjects.StreamInfo) {
 	v.timestamps.Update()
 }
 
+func (v *Video) SetImages(images []valueobjects.Image) {
+	v.images = images
+	v.timestamps.Update()
+}
+
+func (v *Video) SetAudioTracks(tracks []valueobjects.AudioTrack) {
+	v.audioTracks = tracks
+	v.timestamps.Update()
+}
+
+// SetMarkers replaces the video's timeline markers, ordered by start time. A
+// video has at most one intro and one credits marker.
+func (v *Video) SetMarkers(markers []valueobjects.Marker) error {
+	seen := make(map[valueobjects.MarkerKind]bool)
+	for _, m := range markers {
+		if m.Kind() == valueobjects.MarkerKindChapter {
+			continue
+		}
+		if seen[m.Kind()] {
+			return errors.New("video can only have one " + m.Kind().Value() + " marker")
+		}
+		seen[m.Kind()] = true
+	}
+	sorted := append([]valueobjects.Marker(nil), markers...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start() < sorted[j].Start() })
+	v.markers = sorted
+	v.timestamps.Update()
+	return nil
+}
+
+// QualityScores returns the per-rendition scores from the last measured
+// transcode, ordered by rendition name.
+func (v *Video) QualityScores() []valueobjects.RenditionQuality { return v.qualityScores }
+
+func (v *Video) SetQualityScores(scores []valueobjects.RenditionQuality) {
+	sorted := append([]valueobjects.RenditionQuality(nil), scores...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Rendition() < sorted[j].Rendition() })
+	v.qualityScores = sorted
+	v.timestamps.Update()
+}
+
+// Complexity is the per-title score from analysis: 1 is average content,
+// higher needs more bits for the same quality. Zero when it was not probed.
+func (v *Video) Complexity() float64 { return v.complexity }
+
+func (v *Video) SetComplexity(score float64) {
+	v.complexity = score
+	v.timestamps.Update()
+}
+
+// Ladder returns the renditions the video was encoded with, lowest first.
+func (v *Video) Ladder() []valueobjects.LadderRung { return v.ladder }
+
+func (v *Video) SetLadder(ladder []valueobjects.LadderRung) {
+	sorted := append([]valueobjects.LadderRung(nil), ladder...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Height() < sorted[j].Height() })
+	v.ladder = sorted
+	v.timestamps.Update()
+}
+
+// LadderSavings is the share of video bitrate per-title encoding saved over
+// the configured ladder, summed across rungs. It is negative when complex
+// content was given more bits, and zero when no rung was scaled.
+func (v *Video) LadderSavings() float64 {
+	var chosen, base int
+	for _, r := range v.ladder {
+		if r.BaseVideoBitrate() == 0 {
+			continue
+		}
+		chosen += r.VideoBitrate()
+		base += r.BaseVideoBitrate()
+	}
+	if base == 0 {
+		return 0
+	}
+	return 1 - float64(chosen)/float64(base)
+}
+
+// IsVariant reports a watermarked or branded copy of a format, stored under
+// the overlay's label next to the format's main video.
+func (v *Video) IsVariant() bool { return v.variant }
+
+func (v *Video) MarkVariant() {
+	v.variant = true
+	v.timestamps.Update()
+}
+
+// InputRejections are the reasons the transcoder refused the source. They
+// are cleared once the source passes a later analysis.
+func (v *Video) InputRejections() []valueobjects.InputRejection { return v.inputRejections }
+
+func (v *Video) SetInputRejections(rejections []valueobjects.InputRejection) {
+	v.inputRejections = rejections
+	v.timestamps.Update()
+}
+
+// Intro returns the intro marker, if one has been set.
+func (v *Video) Intro() *valueobjects.Marker {
+	return v.markerOfKind(valueobjects.MarkerKindIntro)
+}
+
+// Credits returns the end-credits marker, if one has been set.
+func (v *Video) Credits() *valueobjects.Marker {
+	return v.markerOfKind(valueobjects.MarkerKindCredits)
+}
+
+func (v *Video) markerOfKind(kind valueobjects.MarkerKind) *valueobjects.Marker {
+	for i := range v.markers {
+		if v.markers[i].Kind() == kind {
+			return &v.markers[i]
+		}
+	}
+	return nil
+}
+
+// SetDefaultAudioLanguage flags the first track in the given language as the
+// default rendition and clears the flag on every other track.
+func (v *Video) SetDefaultAudioLanguage(language string) error {
+	language = strings.ToLower(strings.TrimSpace(language))
+	target := -1
+	for i, track := range v.audioTracks {
+		if track.Language() == language {
+			target = i
+			break
+		}
+	}
+	if target < 0 {
+		return errors.New("video has no audio track in language " + language)
+	}
+	for i := range v.audioTracks {
+		v.audioTracks[i] = v.audioTracks[i].WithDefault(i == target)
+	}
+	v.timestamps.Update()
+	return nil
+}
+
+// Loudness is the measurement of the track players start with: the one
+// flagged default, or the first. Nil until analyze has measured it.
+func (v *Video) Loudness() *valueobjects.Loudness {
+	if len(v.audioTracks) == 0 {
+		return nil
+	}
+	for _, track := range v.audioTracks {
+		if track.IsDefault() {
+			return track.Loudness()
+		}
+	}
+	return v.audioTracks[0].Loudness()
+}
+
+// Thumbnail returns the poster frame generated for this video, if any.
+func (v *Video) Thumbnail() *valueobjects.Image {
+	for i := range v.images {
+		if v.images[i].Type() == valueobjects.ImageTypeThumbnail {
+			return &v.images[i]
+		}
+	}
+	return nil
+}
+
 func (v *Video) UpdateStatus(status valueobjects.VideoStatus) {
 	v.status = status
 	v.timestamps.Update()
@@ -195,6 +369,7 @@ func (v *Video) UpdateMediaInfo(info valueobjects.TranscodingInfo) {
 
 func (v *Video) IsReady() bool      { return v.status.IsReady() }
 func (v *Video) IsFailed() bool     { return v.status.IsFailed() }
+func (v *Video) IsFailedQC() bool   { return v.status.IsFailedQC() }
 func (v *Video) IsProcessing() bool { return v.status.IsProcessing() }
 
 func (v *Video) UpdateStorageLocation(location valueobjects.S3Object) {
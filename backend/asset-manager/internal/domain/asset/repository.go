@@ -2,6 +2,7 @@ package asset
 
 import (
 	"context"
+	"time"
 
 	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/entity"
 	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
@@ -28,6 +29,25 @@ type Querier interface {
 	FindByTag(ctx context.Context, tag valueobjects.Tag, limit *int, offset *int) ([]*entity.Asset, error)
 }
 
+// Searcher runs ranked full-text searches with facet counts.
+type Searcher interface {
+	FullTextSearch(ctx context.Context, criteria entity.SearchCriteria) (*entity.SearchResult, error)
+}
+
+// StatusWriter saves a status change only if the stored status is still
+// expected. It reports false, and writes nothing, when another change got
+// there first.
+type StatusWriter interface {
+	UpdateStatus(ctx context.Context, asset *entity.Asset, expected valueobjects.AssetStatus) (bool, error)
+}
+
+// ScheduleFinder finds assets whose publish rule has come due for the
+// scheduler to act on, oldest date first, skipping the first offset.
+type ScheduleFinder interface {
+	FindDueForPublish(ctx context.Context, now time.Time, offset, limit int) ([]valueobjects.AssetID, error)
+	FindDueForUnpublish(ctx context.Context, now time.Time, offset, limit int) ([]valueobjects.AssetID, error)
+}
+
 type Repository interface {
 	Saver
 	Finder
@@ -0,0 +1,90 @@
+package valueobjects
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/constants"
+)
+
+type AssetStatus string
+
+const (
+	AssetStatusDraft       AssetStatus = AssetStatus(constants.AssetStatusDraft)
+	AssetStatusInReview    AssetStatus = AssetStatus(constants.AssetStatusInReview)
+	AssetStatusApproved    AssetStatus = AssetStatus(constants.AssetStatusApproved)
+	AssetStatusScheduled   AssetStatus = AssetStatus(constants.AssetStatusScheduled)
+	AssetStatusPublished   AssetStatus = AssetStatus(constants.AssetStatusPublished)
+	AssetStatusUnpublished AssetStatus = AssetStatus(constants.AssetStatusUnpublished)
+	AssetStatusArchived    AssetStatus = AssetStatus(constants.AssetStatusArchived)
+)
+
+// assetStatusTransitions lists where each editorial status may go next.
+// Content that has been live is unpublished before it can be archived, and
+// an archived asset comes back as a draft that goes through review again.
+var assetStatusTransitions = map[AssetStatus][]AssetStatus{
+	AssetStatusDraft:       {AssetStatusInReview, AssetStatusArchived},
+	AssetStatusInReview:    {AssetStatusApproved, AssetStatusDraft},
+	AssetStatusApproved:    {AssetStatusScheduled, AssetStatusPublished, AssetStatusDraft, AssetStatusArchived},
+	AssetStatusScheduled:   {AssetStatusPublished, AssetStatusApproved},
+	AssetStatusPublished:   {AssetStatusUnpublished},
+	AssetStatusUnpublished: {AssetStatusPublished, AssetStatusDraft, AssetStatusArchived},
+	AssetStatusArchived:    {AssetStatusDraft},
+}
+
+func NewAssetStatus(value string) (AssetStatus, error) {
+	s := AssetStatus(strings.ToLower(strings.TrimSpace(value)))
+	if _, ok := assetStatusTransitions[s]; !ok {
+		return "", errors.New("invalid asset status")
+	}
+	return s, nil
+}
+
+func (s AssetStatus) Value() string { return string(s) }
+
+func (s AssetStatus) CanTransitionTo(next AssetStatus) bool {
+	for _, allowed := range assetStatusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+func (s AssetStatus) checkTransition(next AssetStatus) error {
+	if !s.CanTransitionTo(next) {
+		return fmt.Errorf("asset cannot move from %s to %s", s, next)
+	}
+	return nil
+}
+
+// AssetStatusChange is one entry of an asset's status history.
+type AssetStatusChange struct {
+	from AssetStatus
+	to   AssetStatus
+	at   time.Time
+	note string
+}
+
+func NewAssetStatusChange(from, to AssetStatus, at time.Time, note string) (*AssetStatusChange, error) {
+	if err := from.checkTransition(to); err != nil {
+		return nil, err
+	}
+	if len(note) > 500 {
+		return nil, errors.New("status note too long")
+	}
+	return &AssetStatusChange{from: from, to: to, at: at.UTC(), note: strings.TrimSpace(note)}, nil
+}
+
+// ReconstructAssetStatusChange restores a history entry without checking the
+// transition, since the rules may have changed since it was recorded.
+func ReconstructAssetStatusChange(from, to string, at time.Time, note string) AssetStatusChange {
+	return AssetStatusChange{from: AssetStatus(from), to: AssetStatus(to), at: at, note: note}
+}
+
+func (c AssetStatusChange) From() AssetStatus { return c.from }
+func (c AssetStatusChange) To() AssetStatus   { return c.to }
+func (c AssetStatusChange) At() time.Time     { return c.at }
+func (c AssetStatusChange) Note() string      { return c.note }
@@ -2,6 +2,7 @@ package valueobjects
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/constants"
 )
@@ -10,6 +11,13 @@ type AssetType struct {
 	value string
 }
 
+// requiredParentTypes lists the types that only exist inside a parent of
+// one particular type.
+var requiredParentTypes = map[string]string{
+	constants.AssetTypeSeason:  constants.AssetTypeSeries,
+	constants.AssetTypeEpisode: constants.AssetTypeSeason,
+}
+
 func NewAssetType(value string) (*AssetType, error) {
 	if value == "" {
 		return nil, errors.New("asset type cannot be empty")
@@ -29,3 +37,20 @@ func (at AssetType) Value() string {
 func (at AssetType) Equals(other AssetType) bool {
 	return at.value == other.value
 }
+
+// ValidateParent checks that an asset of this type may sit under an asset
+// of type parent, where nil means top-level. Seasons belong to a series and
+// episodes to a season, and a series is always top-level. Other types, such
+// as trailers and extras, may sit anywhere.
+func (at AssetType) ValidateParent(parent *AssetType) error {
+	if required, ok := requiredParentTypes[at.value]; ok {
+		if parent == nil || parent.value != required {
+			return fmt.Errorf("%s must be placed under a %s", at.value, required)
+		}
+		return nil
+	}
+	if at.value == constants.AssetTypeSeries && parent != nil {
+		return errors.New("series cannot have a parent")
+	}
+	return nil
+}
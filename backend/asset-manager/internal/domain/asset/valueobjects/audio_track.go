@@ -0,0 +1,60 @@
+package valueobjects
+
+import (
+	"errors"
+	"strings"
+)
+
+// AudioTrack describes one audio stream found in a source video.
+type AudioTrack struct {
+	index         int
+	language      string
+	codec         string
+	channels      int
+	channelLayout string
+	sampleRate    int
+	title         string
+	isDefault     bool
+	loudness      *Loudness
+}
+
+func NewAudioTrack(index int, language, codec string, channels int, channelLayout string, sampleRate int, title string, isDefault bool) (*AudioTrack, error) {
+	if index < 0 {
+		return nil, errors.New("audio track index cannot be negative")
+	}
+	if channels < 0 || sampleRate < 0 {
+		return nil, errors.New("audio track channels and sample rate cannot be negative")
+	}
+	return &AudioTrack{
+		index:         index,
+		language:      strings.ToLower(strings.TrimSpace(language)),
+		codec:         codec,
+		channels:      channels,
+		channelLayout: channelLayout,
+		sampleRate:    sampleRate,
+		title:         title,
+		isDefault:     isDefault,
+	}, nil
+}
+
+func (t AudioTrack) Index() int            { return t.index }
+func (t AudioTrack) Language() string      { return t.language }
+func (t AudioTrack) Codec() string         { return t.codec }
+func (t AudioTrack) Channels() int         { return t.channels }
+func (t AudioTrack) ChannelLayout() string { return t.channelLayout }
+func (t AudioTrack) SampleRate() int       { return t.sampleRate }
+func (t AudioTrack) Title() string         { return t.title }
+func (t AudioTrack) IsDefault() bool       { return t.isDefault }
+
+// Loudness is nil until analyze has measured the track.
+func (t AudioTrack) Loudness() *Loudness { return t.loudness }
+
+func (t AudioTrack) WithDefault(isDefault bool) AudioTrack {
+	t.isDefault = isDefault
+	return t
+}
+
+func (t AudioTrack) WithLoudness(loudness *Loudness) AudioTrack {
+	t.loudness = loudness
+	return t
+}
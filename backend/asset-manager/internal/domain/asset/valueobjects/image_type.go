@@ -12,6 +12,7 @@ const (
 	ImageTypeThumbnail  ImageType = "thumbnail"
 	ImageTypeScreenshot ImageType = "screenshot"
 	ImageTypeLogo       ImageType = "logo"
+	ImageTypeSprite     ImageType = "sprite"
 )
 
 func NewImageType(value string) (*ImageType, error) {
@@ -25,6 +26,7 @@ func NewImageType(value string) (*ImageType, error) {
 		ImageTypeThumbnail,
 		ImageTypeScreenshot,
 		ImageTypeLogo,
+		ImageTypeSprite,
 	}
 
 	for _, imgType := range validTypes {
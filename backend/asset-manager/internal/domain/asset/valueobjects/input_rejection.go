@@ -0,0 +1,25 @@
+package valueobjects
+
+import (
+	"errors"
+	"strings"
+)
+
+// InputRejection is one reason the transcoder turned a source away before
+// encoding it, such as an unsupported codec or an interlaced picture. Code
+// is one of the transcoder's stable rejection codes.
+type InputRejection struct {
+	code    string
+	message string
+}
+
+func NewInputRejection(code, message string) (*InputRejection, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return nil, errors.New("input rejection code cannot be empty")
+	}
+	return &InputRejection{code: code, message: strings.TrimSpace(message)}, nil
+}
+
+func (r InputRejection) Code() string    { return r.code }
+func (r InputRejection) Message() string { return r.message }
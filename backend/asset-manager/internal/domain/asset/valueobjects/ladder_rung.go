@@ -0,0 +1,49 @@
+package valueobjects
+
+import (
+	"errors"
+	"strings"
+)
+
+// LadderRung is one rendition of the bitrate ladder a video was encoded with,
+// bitrates in kbps. baseVideoBitrate is the configured bitrate when per-title
+// encoding scaled the rung, and zero otherwise.
+type LadderRung struct {
+	name             string
+	width            int
+	height           int
+	videoBitrate     int
+	audioBitrate     int
+	baseVideoBitrate int
+}
+
+func NewLadderRung(name string, width, height, videoBitrate, audioBitrate, baseVideoBitrate int) (*LadderRung, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, errors.New("ladder rung name cannot be empty")
+	}
+	if height <= 0 || width < 0 {
+		return nil, errors.New("ladder rung dimensions must be positive")
+	}
+	if videoBitrate <= 0 {
+		return nil, errors.New("ladder rung video bitrate must be positive")
+	}
+	if audioBitrate < 0 || baseVideoBitrate < 0 {
+		return nil, errors.New("ladder rung bitrates cannot be negative")
+	}
+	return &LadderRung{
+		name:             name,
+		width:            width,
+		height:           height,
+		videoBitrate:     videoBitrate,
+		audioBitrate:     audioBitrate,
+		baseVideoBitrate: baseVideoBitrate,
+	}, nil
+}
+
+func (r LadderRung) Name() string          { return r.name }
+func (r LadderRung) Width() int            { return r.width }
+func (r LadderRung) Height() int           { return r.height }
+func (r LadderRung) VideoBitrate() int     { return r.videoBitrate }
+func (r LadderRung) AudioBitrate() int     { return r.audioBitrate }
+func (r LadderRung) BaseVideoBitrate() int { return r.baseVideoBitrate }
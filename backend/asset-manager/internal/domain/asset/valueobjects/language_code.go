@@ -0,0 +1,33 @@
+package valueobjects
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// languageCodePattern accepts BCP 47 tags such as "en", "pt-BR" or "zh-Hant".
+var languageCodePattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+type LanguageCode struct {
+	value string
+}
+
+func NewLanguageCode(value string) (*LanguageCode, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, errors.New("language code cannot be empty")
+	}
+	if !languageCodePattern.MatchString(value) {
+		return nil, errors.New("invalid language code")
+	}
+	return &LanguageCode{value: value}, nil
+}
+
+func (l LanguageCode) Value() string {
+	return l.value
+}
+
+func (l LanguageCode) Equals(other LanguageCode) bool {
+	return strings.EqualFold(l.value, other.value)
+}
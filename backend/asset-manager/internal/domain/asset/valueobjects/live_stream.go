@@ -0,0 +1,205 @@
+package valueobjects
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+type LiveProtocol string
+
+// LiveProtocolSRT is the only ingest protocol: its passphrase is checked by
+// the listener. RTMP stream keys may still be stored from before, but an
+// ffmpeg RTMP listener accepts any stream name, so they cannot start events.
+const (
+	LiveProtocolSRT  LiveProtocol = "srt"
+	liveProtocolRTMP LiveProtocol = "rtmp"
+)
+
+func NewLiveProtocol(value string) (LiveProtocol, error) {
+	switch p := LiveProtocol(strings.ToLower(strings.TrimSpace(value))); p {
+	case LiveProtocolSRT:
+		return p, nil
+	case liveProtocolRTMP:
+		return "", errors.New("rtmp ingest is not supported, use srt")
+	}
+	return "", errors.New("invalid live protocol")
+}
+
+func (p LiveProtocol) Value() string { return string(p) }
+
+type LiveStatus string
+
+const (
+	// LiveStatusIdle has a stream key and no event running.
+	LiveStatusIdle LiveStatus = "idle"
+	// LiveStatusStarting is waiting for a transcoder to pick the event up.
+	LiveStatusStarting LiveStatus = "starting"
+	// LiveStatusWaiting is listening for the broadcaster.
+	LiveStatusWaiting  LiveStatus = "waiting"
+	LiveStatusLive     LiveStatus = "live"
+	LiveStatusStopping LiveStatus = "stopping"
+	LiveStatusEnded    LiveStatus = "ended"
+	LiveStatusFailed   LiveStatus = "failed"
+)
+
+func (s LiveStatus) Value() string { return string(s) }
+
+// streamKeyBytes gives a 32 character key, inside the 10 to 79 characters
+// SRT accepts as a passphrase.
+const streamKeyBytes = 16
+
+var ErrLiveSessionMismatch = errors.New("live event belongs to another session")
+
+// LiveStream is a live asset's stream key and the state of its current or
+// last event. Transitions return a new value.
+type LiveStream struct {
+	streamKey      string
+	protocol       LiveProtocol
+	status         LiveStatus
+	sessionID      string
+	ingestURL      string
+	playbackURL    string
+	startedAt      *time.Time
+	endedAt        *time.Time
+	errorMessage   string
+	archiveVideoID string
+}
+
+func NewLiveStream(protocol LiveProtocol) (*LiveStream, error) {
+	if _, err := NewLiveProtocol(string(protocol)); err != nil {
+		return nil, err
+	}
+	key := make([]byte, streamKeyBytes)
+	if _, err := rand.Read(key); err != nil {
+		return nil, errors.New("failed to generate stream key")
+	}
+	return &LiveStream{streamKey: hex.EncodeToString(key), protocol: protocol, status: LiveStatusIdle}, nil
+}
+
+func ReconstructLiveStream(streamKey, protocol, status, sessionID, ingestURL, playbackURL string, startedAt, endedAt *time.Time, errorMessage, archiveVideoID string) *LiveStream {
+	if status == "" {
+		status = string(LiveStatusIdle)
+	}
+	return &LiveStream{
+		streamKey:      streamKey,
+		protocol:       LiveProtocol(protocol),
+		status:         LiveStatus(status),
+		sessionID:      sessionID,
+		ingestURL:      ingestURL,
+		playbackURL:    playbackURL,
+		startedAt:      startedAt,
+		endedAt:        endedAt,
+		errorMessage:   errorMessage,
+		archiveVideoID: archiveVideoID,
+	}
+}
+
+func (l LiveStream) StreamKey() string      { return l.streamKey }
+func (l LiveStream) Protocol() LiveProtocol { return l.protocol }
+func (l LiveStream) Status() LiveStatus     { return l.status }
+func (l LiveStream) SessionID() string      { return l.sessionID }
+func (l LiveStream) IngestURL() string      { return l.ingestURL }
+func (l LiveStream) PlaybackURL() string    { return l.playbackURL }
+func (l LiveStream) StartedAt() *time.Time  { return l.startedAt }
+func (l LiveStream) EndedAt() *time.Time    { return l.endedAt }
+func (l LiveStream) ErrorMessage() string   { return l.errorMessage }
+func (l LiveStream) ArchiveVideoID() string { return l.archiveVideoID }
+
+// IsActive reports an event between the start request and the transcoder's
+// stopped event.
+func (l LiveStream) IsActive() bool {
+	switch l.status {
+	case LiveStatusStarting, LiveStatusWaiting, LiveStatusLive, LiveStatusStopping:
+		return true
+	}
+	return false
+}
+
+// Start begins a new event and clears what the last one left behind.
+func (l LiveStream) Start(sessionID string) (*LiveStream, error) {
+	if l.IsActive() {
+		return nil, errors.New("live event already running")
+	}
+	if sessionID == "" {
+		return nil, errors.New("live session ID cannot be empty")
+	}
+	if l.protocol != LiveProtocolSRT {
+		return nil, errors.New("stream key is not an srt key, create a new one")
+	}
+	return &LiveStream{
+		streamKey: l.streamKey,
+		protocol:  l.protocol,
+		status:    LiveStatusStarting,
+		sessionID: sessionID,
+	}, nil
+}
+
+func (l LiveStream) Ready(sessionID, ingestURL string) (*LiveStream, error) {
+	if err := l.checkSession(sessionID); err != nil {
+		return nil, err
+	}
+	next := l
+	// A late ready event must not move a started or stopping event back.
+	if l.status == LiveStatusStarting {
+		next.status = LiveStatusWaiting
+	}
+	next.ingestURL = ingestURL
+	return &next, nil
+}
+
+func (l LiveStream) Started(sessionID, playbackURL string, at time.Time) (*LiveStream, error) {
+	if err := l.checkSession(sessionID); err != nil {
+		return nil, err
+	}
+	next := l
+	if l.status != LiveStatusStopping {
+		next.status = LiveStatusLive
+	}
+	next.playbackURL = playbackURL
+	next.startedAt = &at
+	return &next, nil
+}
+
+func (l LiveStream) Stop() (*LiveStream, error) {
+	if !l.IsActive() {
+		return nil, errors.New("no live event is running")
+	}
+	next := l
+	next.status = LiveStatusStopping
+	return &next, nil
+}
+
+// End records the transcoder's stopped event. A failed event keeps the
+// transcoder's error message.
+func (l LiveStream) End(sessionID string, success bool, errorMessage, playbackURL string, at time.Time) (*LiveStream, error) {
+	if err := l.checkSession(sessionID); err != nil {
+		return nil, err
+	}
+	next := l
+	next.status = LiveStatusEnded
+	if !success {
+		next.status = LiveStatusFailed
+		next.errorMessage = errorMessage
+	}
+	if playbackURL != "" {
+		next.playbackURL = playbackURL
+	}
+	next.endedAt = &at
+	return &next, nil
+}
+
+func (l LiveStream) WithArchiveVideo(videoID string) *LiveStream {
+	next := l
+	next.archiveVideoID = videoID
+	return &next
+}
+
+func (l LiveStream) checkSession(sessionID string) error {
+	if sessionID == "" || sessionID != l.sessionID || !l.IsActive() {
+		return ErrLiveSessionMismatch
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package valueobjects
+
+import (
+	"errors"
+	"math"
+)
+
+// Loudness is the EBU R128 measurement analyze took of an audio track:
+// integrated loudness and gating threshold in LUFS, true peak in dBTP,
+// loudness range in LU and the gain offset the second loudnorm pass uses.
+type Loudness struct {
+	integrated float64
+	truePeak   float64
+	lra        float64
+	threshold  float64
+	offset     float64
+}
+
+func NewLoudness(integrated, truePeak, lra, threshold, offset float64) (*Loudness, error) {
+	for _, v := range []float64{integrated, truePeak, lra, threshold, offset} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, errors.New("loudness values must be finite")
+		}
+	}
+	if integrated > 0 {
+		return nil, errors.New("integrated loudness cannot be above 0 LUFS")
+	}
+	if lra < 0 {
+		return nil, errors.New("loudness range cannot be negative")
+	}
+	return &Loudness{
+		integrated: integrated,
+		truePeak:   truePeak,
+		lra:        lra,
+		threshold:  threshold,
+		offset:     offset,
+	}, nil
+}
+
+func (l Loudness) Integrated() float64 { return l.integrated }
+func (l Loudness) TruePeak() float64   { return l.truePeak }
+func (l Loudness) LRA() float64        { return l.lra }
+func (l Loudness) Threshold() float64  { return l.threshold }
+func (l Loudness) Offset() float64     { return l.offset }
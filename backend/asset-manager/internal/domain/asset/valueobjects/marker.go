@@ -0,0 +1,71 @@
+package valueobjects
+
+import (
+	"errors"
+	"strings"
+)
+
+type MarkerKind string
+
+const (
+	MarkerKindChapter MarkerKind = "chapter"
+	MarkerKindIntro   MarkerKind = "intro"
+	MarkerKindCredits MarkerKind = "credits"
+)
+
+func NewMarkerKind(value string) (*MarkerKind, error) {
+	if value == "" {
+		return nil, errors.New("marker kind cannot be empty")
+	}
+
+	validKinds := []MarkerKind{
+		MarkerKindChapter,
+		MarkerKindIntro,
+		MarkerKindCredits,
+	}
+
+	for _, kind := range validKinds {
+		if MarkerKind(value) == kind {
+			k := MarkerKind(value)
+			return &k, nil
+		}
+	}
+
+	return nil, errors.New("invalid marker kind")
+}
+
+func (k MarkerKind) Value() string {
+	return string(k)
+}
+
+// Marker is a point or range on a video's timeline, in seconds. Credits
+// markers only carry a start; chapters and the intro are ranges.
+type Marker struct {
+	kind  MarkerKind
+	start float64
+	end   float64
+	title string
+}
+
+func NewMarker(kind string, start, end float64, title string) (*Marker, error) {
+	k, err := NewMarkerKind(kind)
+	if err != nil {
+		return nil, err
+	}
+	if start < 0 {
+		return nil, errors.New("marker start cannot be negative")
+	}
+	if *k == MarkerKindCredits {
+		if end != 0 && end <= start {
+			return nil, errors.New("marker end must be after start")
+		}
+	} else if end <= start {
+		return nil, errors.New("marker end must be after start")
+	}
+	return &Marker{kind: *k, start: start, end: end, title: strings.TrimSpace(title)}, nil
+}
+
+func (m Marker) Kind() MarkerKind { return m.kind }
+func (m Marker) Start() float64   { return m.start }
+func (m Marker) End() float64     { return m.end }
+func (m Marker) Title() string    { return m.title }
@@ -0,0 +1,42 @@
+package valueobjects
+
+import (
+	"errors"
+	"strings"
+)
+
+// RenditionQuality holds the objective scores the transcoder measured for one
+// rendition against the source. VMAF is nil when the transcoder's ffmpeg had
+// no libvmaf.
+type RenditionQuality struct {
+	rendition string
+	psnr      float64
+	ssim      float64
+	vmaf      *float64
+}
+
+func NewRenditionQuality(rendition string, psnr, ssim float64, vmaf *float64) (*RenditionQuality, error) {
+	rendition = strings.TrimSpace(rendition)
+	if rendition == "" {
+		return nil, errors.New("rendition name cannot be empty")
+	}
+	if psnr < 0 {
+		return nil, errors.New("PSNR cannot be negative")
+	}
+	if ssim < 0 || ssim > 1 {
+		return nil, errors.New("SSIM must be between 0 and 1")
+	}
+	if vmaf != nil {
+		if *vmaf < 0 || *vmaf > 100 {
+			return nil, errors.New("VMAF must be between 0 and 100")
+		}
+		v := *vmaf
+		vmaf = &v
+	}
+	return &RenditionQuality{rendition: rendition, psnr: psnr, ssim: ssim, vmaf: vmaf}, nil
+}
+
+func (q RenditionQuality) Rendition() string { return q.rendition }
+func (q RenditionQuality) PSNR() float64     { return q.psnr }
+func (q RenditionQuality) SSIM() float64     { return q.ssim }
+func (q RenditionQuality) VMAF() *float64    { return q.vmaf }
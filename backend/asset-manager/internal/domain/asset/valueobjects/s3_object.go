@@ -81,11 +81,19 @@ func (s3 S3Object) Equals(other S3Object) bool {
 	return s3.bucket == other.bucket && s3.key == other.key
 }
 
+// storageSchemes are the object URL schemes the transcoder's storage
+// backends write; file:// and mem:// address a bucket and key the same way
+// s3:// does.
+var storageSchemes = []string{"s3://", "file://", "mem://"}
+
 func parseS3URL(url string) (string, string, error) {
-	if strings.HasPrefix(url, "s3://") {
-		parts := strings.SplitN(url[5:], "/", 2)
+	for _, scheme := range storageSchemes {
+		if !strings.HasPrefix(url, scheme) {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimPrefix(url, scheme), "/", 2)
 		if len(parts) != 2 {
-			return "", "", errors.New("invalid S3 URL format")
+			return "", "", errors.New("invalid storage URL format")
 		}
 		return parts[0], parts[1], nil
 	}
@@ -8,6 +8,7 @@ type StreamInfo struct {
 	downloadURL *string
 	cdnPrefix   *string
 	url         *string
+	hlsURL      *string
 }
 
 func NewStreamInfo(downloadURL, cdnPrefix, url *string) (*StreamInfo, error) {
@@ -22,6 +23,13 @@ func NewStreamInfo(downloadURL, cdnPrefix, url *string) (*StreamInfo, error) {
 	}, nil
 }
 
+// WithHLSURL returns a copy that also carries the HLS playlist URL, for
+// CMAF outputs whose segments are shared by a DASH and an HLS manifest.
+func (si StreamInfo) WithHLSURL(hlsURL string) *StreamInfo {
+	si.hlsURL = &hlsURL
+	return &si
+}
+
 func (si StreamInfo) DownloadURL() *string {
 	return si.downloadURL
 }
@@ -34,6 +42,10 @@ func (si StreamInfo) URL() *string {
 	return si.url
 }
 
+func (si StreamInfo) HLSURL() *string {
+	return si.hlsURL
+}
+
 func (si StreamInfo) HasDownloadURL() bool {
 	return si.downloadURL != nil && *si.downloadURL != ""
 }
@@ -0,0 +1,14 @@
+package valueobjects
+
+const (
+	subtitleIDType   = "subtitle"
+	subtitleIDLength = 32
+)
+
+func GenerateSubtitleID() (*ID, error) {
+	id, err := GenerateID(subtitleIDType, subtitleIDLength/2)
+	if err != nil {
+		return nil, err
+	}
+	return NewID(id, subtitleIDType, subtitleIDLength)
+}
@@ -0,0 +1,42 @@
+package valueobjects
+
+import (
+	"errors"
+)
+
+type SubtitleKind string
+
+const (
+	SubtitleKindSubtitles SubtitleKind = "subtitles"
+	SubtitleKindCaptions  SubtitleKind = "captions"
+	SubtitleKindForced    SubtitleKind = "forced"
+)
+
+func NewSubtitleKind(value string) (*SubtitleKind, error) {
+	if value == "" {
+		return nil, errors.New("subtitle kind cannot be empty")
+	}
+
+	validKinds := []SubtitleKind{
+		SubtitleKindSubtitles,
+		SubtitleKindCaptions,
+		SubtitleKindForced,
+	}
+
+	for _, kind := range validKinds {
+		if SubtitleKind(value) == kind {
+			k := SubtitleKind(value)
+			return &k, nil
+		}
+	}
+
+	return nil, errors.New("invalid subtitle kind")
+}
+
+func (k SubtitleKind) Value() string {
+	return string(k)
+}
+
+func (k SubtitleKind) Equals(other SubtitleKind) bool {
+	return k == other
+}
@@ -10,6 +10,7 @@ const (
 	VideoFormatRaw  VideoFormat = "raw"
 	VideoFormatHLS  VideoFormat = "hls"
 	VideoFormatDASH VideoFormat = "dash"
+	VideoFormatCMAF VideoFormat = "cmaf"
 	VideoFormatMP4  VideoFormat = "mp4"
 	VideoFormatWebM VideoFormat = "webm"
 )
@@ -23,6 +24,7 @@ func NewVideoFormat(value string) (*VideoFormat, error) {
 		VideoFormatRaw,
 		VideoFormatHLS,
 		VideoFormatDASH,
+		VideoFormatCMAF,
 		VideoFormatMP4,
 		VideoFormatWebM,
 	}
@@ -46,7 +48,7 @@ func (vf VideoFormat) Equals(other VideoFormat) bool {
 }
 
 func (vf VideoFormat) IsStreaming() bool {
-	return vf == VideoFormatHLS || vf == VideoFormatDASH
+	return vf == VideoFormatHLS || vf == VideoFormatDASH || vf == VideoFormatCMAF
 }
 
 func (vf VideoFormat) IsDownloadable() bool {
@@ -8,6 +8,16 @@ import (
 
 type VideoStatus string
 
+const (
+	VideoStatusPending     VideoStatus = VideoStatus(constants.VideoStatusPending)
+	VideoStatusAnalyzing   VideoStatus = VideoStatus(constants.VideoStatusAnalyzing)
+	VideoStatusTranscoding VideoStatus = VideoStatus(constants.VideoStatusTranscoding)
+	VideoStatusReady       VideoStatus = VideoStatus(constants.VideoStatusReady)
+	VideoStatusFailed      VideoStatus = VideoStatus(constants.VideoStatusFailed)
+	VideoStatusCancelled   VideoStatus = VideoStatus(constants.VideoStatusCancelled)
+	VideoStatusFailedQC    VideoStatus = VideoStatus(constants.VideoStatusFailedQC)
+)
+
 func NewVideoStatus(value string) (*VideoStatus, error) {
 	if value == "" {
 		return nil, errors.New("video status cannot be empty")
@@ -37,6 +47,16 @@ func (vs VideoStatus) IsFailed() bool {
 	return vs == VideoStatus(constants.VideoStatusFailed)
 }
 
+// IsFailedQC reports a video whose output was produced but scored below the
+// transcoder's quality thresholds.
+func (vs VideoStatus) IsFailedQC() bool {
+	return vs == VideoStatusFailedQC
+}
+
+func (vs VideoStatus) IsCancelled() bool {
+	return vs == VideoStatusCancelled
+}
+
 func (vs VideoStatus) IsProcessing() bool {
 	return vs == VideoStatus(constants.VideoStatusPending) ||
 		vs == VideoStatus(constants.VideoStatusAnalyzing) ||
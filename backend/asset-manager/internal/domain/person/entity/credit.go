@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/valueobjects"
+)
+
+// Credit is one person's work on one asset. It is stored as a relationship
+// from the Person node to the Asset node, typed by the role.
+type Credit struct {
+	personID  valueobjects.PersonID
+	assetID   string
+	role      valueobjects.CreditRole
+	character *string
+	order     int
+}
+
+// NewCredit validates a credit. Only actors play a character, and order
+// sorts credits of the same role on an asset, lowest first.
+func NewCredit(personID valueobjects.PersonID, assetID string, role valueobjects.CreditRole, character *string, order int) (*Credit, error) {
+	if assetID == "" {
+		return nil, errors.New("asset ID cannot be empty")
+	}
+	if order < 0 {
+		return nil, errors.New("credit order cannot be negative")
+	}
+	if character != nil {
+		trimmed := strings.TrimSpace(*character)
+		if trimmed == "" {
+			character = nil
+		} else {
+			if role.Value() != valueobjects.CreditRoleActor {
+				return nil, errors.New("only actors can have a character")
+			}
+			if len(trimmed) > 200 {
+				return nil, errors.New("character name too long")
+			}
+			character = &trimmed
+		}
+	}
+	return &Credit{personID: personID, assetID: assetID, role: role, character: character, order: order}, nil
+}
+
+func (c *Credit) PersonID() valueobjects.PersonID {
+	return c.personID
+}
+
+func (c *Credit) AssetID() string {
+	return c.assetID
+}
+
+func (c *Credit) Role() valueobjects.CreditRole {
+	return c.role
+}
+
+func (c *Credit) Character() *string {
+	return c.character
+}
+
+func (c *Credit) Order() int {
+	return c.order
+}
+
+// CreditedPerson is a credit on an asset together with the person it names.
+type CreditedPerson struct {
+	Person *Person
+	Credit *Credit
+}
+
+// Collaborator is a person who shares credits with another, with the number
+// of assets they both worked on.
+type Collaborator struct {
+	Person       *Person
+	SharedAssets int
+}
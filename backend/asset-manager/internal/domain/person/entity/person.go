@@ -0,0 +1,154 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/valueobjects"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/operations"
+)
+
+// Person is someone credited on assets. People are shared between assets,
+// so the same actor is one node however many titles they appear in.
+type Person struct {
+	id         valueobjects.PersonID
+	version    int
+	name       valueobjects.PersonName
+	externalID *valueobjects.ExternalID
+	biography  *string
+	photoURL   *string
+	createdAt  valueobjects.CreatedAt
+	updatedAt  valueobjects.UpdatedAt
+}
+
+func NewPerson(name valueobjects.PersonName, externalID *valueobjects.ExternalID) (*Person, error) {
+	personID, err := valueobjects.NewPersonID(operations.GenerateID())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	return &Person{
+		id:         *personID,
+		name:       name,
+		externalID: externalID,
+		createdAt:  valueobjects.NewCreatedAt(now),
+		updatedAt:  valueobjects.NewUpdatedAt(now),
+	}, nil
+}
+
+func ReconstructPerson(
+	id valueobjects.PersonID,
+	name valueobjects.PersonName,
+	externalID *valueobjects.ExternalID,
+	biography *string,
+	photoURL *string,
+	createdAt valueobjects.CreatedAt,
+	updatedAt valueobjects.UpdatedAt,
+) *Person {
+	return &Person{
+		id:         id,
+		name:       name,
+		externalID: externalID,
+		biography:  biography,
+		photoURL:   photoURL,
+		createdAt:  createdAt,
+		updatedAt:  updatedAt,
+	}
+}
+
+func (p *Person) ID() valueobjects.PersonID {
+	return p.id
+}
+
+func (p *Person) Version() int     { return p.version }
+func (p *Person) SetVersion(v int) { p.version = v }
+
+func (p *Person) Name() valueobjects.PersonName {
+	return p.name
+}
+
+func (p *Person) ExternalID() *valueobjects.ExternalID {
+	return p.externalID
+}
+
+func (p *Person) Biography() *string {
+	return p.biography
+}
+
+func (p *Person) PhotoURL() *string {
+	return p.photoURL
+}
+
+func (p *Person) CreatedAt() valueobjects.CreatedAt {
+	return p.createdAt
+}
+
+func (p *Person) UpdatedAt() valueobjects.UpdatedAt {
+	return p.updatedAt
+}
+
+func (p *Person) UpdateName(name valueobjects.PersonName) {
+	p.name = name
+	p.touch()
+}
+
+// SetExternalID links the person to an outside catalogue entry. Once set it
+// can only be replaced by the same ID, since a different one means a
+// different person.
+func (p *Person) SetExternalID(externalID valueobjects.ExternalID) error {
+	if p.externalID != nil {
+		if p.externalID.Equals(externalID) {
+			return nil
+		}
+		return errors.New("person already has a different external ID")
+	}
+	p.externalID = &externalID
+	p.touch()
+	return nil
+}
+
+func (p *Person) UpdateBiography(biography *string) {
+	p.biography = biography
+	p.touch()
+}
+
+func (p *Person) UpdatePhotoURL(photoURL *string) {
+	p.photoURL = photoURL
+	p.touch()
+}
+
+// Matches reports whether a person named name with externalID is this
+// person. Two external IDs decide on their own; otherwise the folded names
+// must agree.
+func (p *Person) Matches(name valueobjects.PersonName, externalID *valueobjects.ExternalID) bool {
+	if p.externalID != nil && externalID != nil {
+		return p.externalID.Equals(*externalID)
+	}
+	return p.name.Key() == name.Key()
+}
+
+func (p *Person) touch() {
+	p.updatedAt = valueobjects.NewUpdatedAt(time.Now().UTC())
+}
+
+// FindDuplicate picks the existing person a new entry for name and
+// externalID should be merged into, or nil if it is someone new. Candidates
+// are expected oldest first. A candidate with the same external ID wins over
+// a bare name match, and a candidate without an external ID wins over one
+// that has a different ID.
+func FindDuplicate(candidates []*Person, name valueobjects.PersonName, externalID *valueobjects.ExternalID) *Person {
+	var byName *Person
+	for _, c := range candidates {
+		if !c.Matches(name, externalID) {
+			continue
+		}
+		if externalID != nil && c.externalID != nil {
+			return c
+		}
+		if byName == nil {
+			byName = c
+		}
+	}
+	return byName
+}
@@ -0,0 +1,143 @@
+package person
+
+import (
+	"testing"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/valueobjects"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustName(t *testing.T, value string) valueobjects.PersonName {
+	name, err := valueobjects.NewPersonName(value)
+	require.NoError(t, err)
+	return *name
+}
+
+func mustExternalID(t *testing.T, source, value string) *valueobjects.ExternalID {
+	id, err := valueobjects.NewExternalID(source, value)
+	require.NoError(t, err)
+	return id
+}
+
+func mustPerson(t *testing.T, name string, externalID *valueobjects.ExternalID) *entity.Person {
+	p, err := entity.NewPerson(mustName(t, name), externalID)
+	require.NoError(t, err)
+	return p
+}
+
+func TestPersonNameKey(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		same bool
+	}{
+		{name: "case and punctuation", a: "Robert Downey Jr.", b: "robert downey jr", same: true},
+		{name: "whitespace", a: "  Tilda   Swinton ", b: "Tilda Swinton", same: true},
+		{name: "accented letters kept", a: "Penélope Cruz", b: "Penelope Cruz", same: false},
+		{name: "different people", a: "Chris Evans", b: "Chris Pratt", same: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.same, mustName(t, tt.a).Key() == mustName(t, tt.b).Key())
+		})
+	}
+
+	_, err := valueobjects.NewPersonName("   ")
+	assert.Error(t, err)
+}
+
+func TestExternalID(t *testing.T) {
+	id := mustExternalID(t, " IMDb ", "nm0000158")
+	assert.Equal(t, "imdb", id.Source())
+	assert.True(t, id.Equals(*mustExternalID(t, "imdb", "nm0000158")))
+	assert.False(t, id.Equals(*mustExternalID(t, "tmdb", "nm0000158")))
+
+	_, err := valueobjects.NewExternalID("", "nm0000158")
+	assert.Error(t, err)
+	_, err = valueobjects.NewExternalID("imdb", "")
+	assert.Error(t, err)
+}
+
+func TestCreditRole(t *testing.T) {
+	role, err := valueobjects.NewCreditRole("Director")
+	require.NoError(t, err)
+	assert.Equal(t, "DIRECTED", role.RelationshipType())
+
+	back, err := valueobjects.CreditRoleFromRelationship("ACTED_IN")
+	require.NoError(t, err)
+	assert.Equal(t, valueobjects.CreditRoleActor, back.Value())
+
+	_, err = valueobjects.NewCreditRole("gaffer")
+	assert.Error(t, err)
+}
+
+func TestNewCredit(t *testing.T) {
+	p := mustPerson(t, "Tom Hanks", nil)
+	actor, _ := valueobjects.NewCreditRole(valueobjects.CreditRoleActor)
+	director, _ := valueobjects.NewCreditRole(valueobjects.CreditRoleDirector)
+	character := " Forrest Gump "
+	blank := "  "
+
+	credit, err := entity.NewCredit(p.ID(), "asset-1", *actor, &character, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "Forrest Gump", *credit.Character())
+
+	credit, err = entity.NewCredit(p.ID(), "asset-1", *director, &blank, 0)
+	require.NoError(t, err)
+	assert.Nil(t, credit.Character())
+
+	_, err = entity.NewCredit(p.ID(), "asset-1", *director, &character, 0)
+	assert.Error(t, err)
+	_, err = entity.NewCredit(p.ID(), "", *actor, nil, 0)
+	assert.Error(t, err)
+	_, err = entity.NewCredit(p.ID(), "asset-1", *actor, nil, -1)
+	assert.Error(t, err)
+}
+
+func TestPersonExternalID(t *testing.T) {
+	p := mustPerson(t, "Tom Hanks", nil)
+	imdb := mustExternalID(t, "imdb", "nm0000158")
+
+	assert.NoError(t, p.SetExternalID(*imdb))
+	assert.NoError(t, p.SetExternalID(*imdb))
+	assert.Error(t, p.SetExternalID(*mustExternalID(t, "imdb", "nm0000001")))
+	assert.True(t, p.ExternalID().Equals(*imdb))
+}
+
+func TestFindDuplicate(t *testing.T) {
+	imdb := mustExternalID(t, "imdb", "nm0000158")
+	other := mustExternalID(t, "imdb", "nm0000001")
+
+	bare := mustPerson(t, "Tom Hanks", nil)
+	linked := mustPerson(t, "Tom Hanks", imdb)
+	namesake := mustPerson(t, "Tom Hanks", other)
+
+	t.Run("external ID beats name", func(t *testing.T) {
+		found := entity.FindDuplicate([]*entity.Person{bare, namesake, linked}, mustName(t, "tom hanks"), imdb)
+		assert.Same(t, linked, found)
+	})
+
+	t.Run("name match without external ID", func(t *testing.T) {
+		found := entity.FindDuplicate([]*entity.Person{namesake, bare}, mustName(t, "Tom Hanks"), imdb)
+		assert.Same(t, bare, found)
+	})
+
+	t.Run("different external IDs are different people", func(t *testing.T) {
+		found := entity.FindDuplicate([]*entity.Person{namesake}, mustName(t, "Tom Hanks"), imdb)
+		assert.Nil(t, found)
+	})
+
+	t.Run("no external ID takes the oldest namesake", func(t *testing.T) {
+		found := entity.FindDuplicate([]*entity.Person{linked, bare}, mustName(t, "Tom Hanks"), nil)
+		assert.Same(t, linked, found)
+	})
+
+	t.Run("different name", func(t *testing.T) {
+		found := entity.FindDuplicate([]*entity.Person{bare}, mustName(t, "Tom Hardy"), nil)
+		assert.Nil(t, found)
+	})
+}
@@ -0,0 +1,51 @@
+package person
+
+import (
+	"context"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/valueobjects"
+)
+
+type Saver interface {
+	// Create stores a new person. When another person already holds its
+	// external ID nothing is written and that person is returned instead.
+	Create(ctx context.Context, person *entity.Person) (*entity.Person, error)
+	Save(ctx context.Context, person *entity.Person) error
+	Update(ctx context.Context, person *entity.Person) error
+	Delete(ctx context.Context, id valueobjects.PersonID) error
+}
+
+type Finder interface {
+	FindByID(ctx context.Context, id valueobjects.PersonID) (*entity.Person, error)
+	FindByExternalID(ctx context.Context, externalID valueobjects.ExternalID) (*entity.Person, error)
+	// FindByNameKey returns people whose folded name equals key, oldest
+	// first.
+	FindByNameKey(ctx context.Context, key string) ([]*entity.Person, error)
+}
+
+type Pager interface {
+	List(ctx context.Context, limit *int, offset *int) ([]*entity.Person, error)
+	Search(ctx context.Context, query string, limit *int, offset *int) ([]*entity.Person, error)
+}
+
+type Credits interface {
+	AddCredit(ctx context.Context, credit *entity.Credit) error
+	RemoveCredit(ctx context.Context, personID valueobjects.PersonID, assetID string, role valueobjects.CreditRole) error
+	// FindCreditsByAsset returns the credits on an asset by role, then
+	// order.
+	FindCreditsByAsset(ctx context.Context, assetID string) ([]entity.CreditedPerson, error)
+	// FindCreditsByPerson returns the person's credits, limited to role when
+	// it is set.
+	FindCreditsByPerson(ctx context.Context, personID valueobjects.PersonID, role *valueobjects.CreditRole) ([]*entity.Credit, error)
+	// FindCollaborators returns people credited on the same assets as
+	// personID, most shared assets first.
+	FindCollaborators(ctx context.Context, personID valueobjects.PersonID, limit int) ([]entity.Collaborator, error)
+}
+
+type Repository interface {
+	Saver
+	Finder
+	Pager
+	Credits
+}
@@ -0,0 +1,67 @@
+package valueobjects
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	CreditRoleActor    = "actor"
+	CreditRoleDirector = "director"
+	CreditRoleWriter   = "writer"
+	CreditRoleProducer = "producer"
+	CreditRoleComposer = "composer"
+)
+
+// relationshipTypes maps each role to the relationship linking a Person node
+// to an Asset node.
+var relationshipTypes = map[string]string{
+	CreditRoleActor:    "ACTED_IN",
+	CreditRoleDirector: "DIRECTED",
+	CreditRoleWriter:   "WROTE",
+	CreditRoleProducer: "PRODUCED",
+	CreditRoleComposer: "COMPOSED",
+}
+
+type CreditRole struct {
+	value string
+}
+
+func NewCreditRole(value string) (*CreditRole, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return nil, errors.New("credit role cannot be empty")
+	}
+	if _, ok := relationshipTypes[value]; !ok {
+		return nil, errors.New("invalid credit role")
+	}
+	return &CreditRole{value: value}, nil
+}
+
+// CreditRoleFromRelationship returns the role stored as relationship type t.
+func CreditRoleFromRelationship(t string) (*CreditRole, error) {
+	for role, rel := range relationshipTypes {
+		if rel == t {
+			return &CreditRole{value: role}, nil
+		}
+	}
+	return nil, errors.New("unknown credit relationship type: " + t)
+}
+
+// CreditRelationshipTypes lists every relationship type a credit can be
+// stored as, in a fixed order.
+func CreditRelationshipTypes() []string {
+	return []string{"ACTED_IN", "DIRECTED", "WROTE", "PRODUCED", "COMPOSED"}
+}
+
+func (r CreditRole) Value() string {
+	return r.value
+}
+
+func (r CreditRole) RelationshipType() string {
+	return relationshipTypes[r.value]
+}
+
+func (r CreditRole) Equals(other CreditRole) bool {
+	return r.value == other.value
+}
@@ -0,0 +1,48 @@
+package valueobjects
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+var externalSourcePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// ExternalID identifies a person in an outside catalogue, such as
+// imdb/nm0000158.
+type ExternalID struct {
+	source string
+	value  string
+}
+
+func NewExternalID(source, value string) (*ExternalID, error) {
+	source = strings.ToLower(strings.TrimSpace(source))
+	value = strings.TrimSpace(value)
+
+	if source == "" {
+		return nil, errors.New("external ID source cannot be empty")
+	}
+	if len(source) > 30 || !externalSourcePattern.MatchString(source) {
+		return nil, errors.New("invalid external ID source")
+	}
+	if value == "" {
+		return nil, errors.New("external ID cannot be empty")
+	}
+	if len(value) > 100 {
+		return nil, errors.New("external ID too long")
+	}
+
+	return &ExternalID{source: source, value: value}, nil
+}
+
+func (e ExternalID) Source() string {
+	return e.source
+}
+
+func (e ExternalID) Value() string {
+	return e.value
+}
+
+func (e ExternalID) Equals(other ExternalID) bool {
+	return e.source == other.source && e.value == other.value
+}
@@ -0,0 +1,38 @@
+package valueobjects
+
+import (
+	"errors"
+	"regexp"
+)
+
+type PersonID struct {
+	value string
+}
+
+func NewPersonID(value string) (*PersonID, error) {
+	if value == "" {
+		return nil, errors.New("person ID cannot be empty")
+	}
+
+	if len(value) > 50 {
+		return nil, errors.New("person ID too long")
+	}
+
+	matched, err := regexp.MatchString(`^[a-zA-Z0-9_-]+$`, value)
+	if err != nil {
+		return nil, err
+	}
+	if !matched {
+		return nil, errors.New("person ID contains invalid characters")
+	}
+
+	return &PersonID{value: value}, nil
+}
+
+func (id PersonID) Value() string {
+	return id.value
+}
+
+func (id PersonID) Equals(other PersonID) bool {
+	return id.value == other.value
+}
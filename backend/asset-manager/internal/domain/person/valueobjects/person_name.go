@@ -0,0 +1,42 @@
+package valueobjects
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+)
+
+type PersonName struct {
+	value string
+}
+
+func NewPersonName(value string) (*PersonName, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return nil, errors.New("person name cannot be empty")
+	}
+
+	if len(trimmed) > 200 {
+		return nil, errors.New("person name too long")
+	}
+
+	return &PersonName{value: trimmed}, nil
+}
+
+func (n PersonName) Value() string {
+	return n.value
+}
+
+// Key is the name folded for duplicate detection: lower case, with
+// punctuation dropped and runs of whitespace collapsed, so "Robert Downey Jr."
+// and "robert  downey jr" share a key.
+func (n PersonName) Key() string {
+	words := strings.FieldsFunc(strings.ToLower(n.value), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
+
+func (n PersonName) Equals(other PersonName) bool {
+	return n.value == other.value
+}
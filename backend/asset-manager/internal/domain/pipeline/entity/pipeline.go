@@ -11,6 +11,11 @@ type StepState struct {
 	ErrorMessage  string     `json:"errorMessage,omitempty"`
 	JobID         string     `json:"jobId,omitempty"`
 	CorrelationID string     `json:"correlationId,omitempty"`
+	Progress      float64    `json:"progress,omitempty"`
+}
+
+func (s StepState) IsTerminal() bool {
+	return s.Status == "completed" || s.Status == "failed" || s.Status == "cancelled"
 }
 
 type Pipeline struct {
@@ -26,15 +31,42 @@ func NewPipeline(assetID, videoID string) *Pipeline {
 	return &Pipeline{AssetID: assetID, VideoID: videoID, Steps: map[string]StepState{}, UpdatedAt: now, CreatedAt: now}
 }
 
+func (p *Pipeline) Step(name string) (StepState, bool) {
+	if p == nil {
+		return StepState{}, false
+	}
+	s, ok := p.Steps[name]
+	return s, ok
+}
+
 func (p *Pipeline) SetRequested(step, jobID, correlationID string) {
 	p.Steps[step] = StepState{Status: "requested", StartedAt: time.Now().UTC(), JobID: jobID, CorrelationID: correlationID}
 	p.UpdatedAt = time.Now().UTC()
 }
 
+// SetProgress ignores late or out-of-order reports so a finished step never
+// moves backwards.
+func (p *Pipeline) SetProgress(step string, progress float64) bool {
+	s, ok := p.Steps[step]
+	if ok && (s.IsTerminal() || progress <= s.Progress) {
+		return false
+	}
+	now := time.Now().UTC()
+	if !ok {
+		s.StartedAt = now
+	}
+	s.Status = "running"
+	s.Progress = progress
+	p.Steps[step] = s
+	p.UpdatedAt = now
+	return true
+}
+
 func (p *Pipeline) SetCompleted(step string) {
 	now := time.Now().UTC()
 	s := p.Steps[step]
 	s.Status = "completed"
+	s.Progress = 100
 	s.CompletedAt = &now
 	p.Steps[step] = s
 	p.UpdatedAt = now
@@ -49,3 +81,12 @@ func (p *Pipeline) SetFailed(step, errMsg string) {
 	p.Steps[step] = s
 	p.UpdatedAt = now
 }
+
+func (p *Pipeline) SetCancelled(step string) {
+	now := time.Now().UTC()
+	s := p.Steps[step]
+	s.Status = "cancelled"
+	s.CompletedAt = &now
+	p.Steps[step] = s
+	p.UpdatedAt = now
+}
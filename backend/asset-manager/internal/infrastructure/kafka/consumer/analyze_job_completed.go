@@ -32,11 +32,34 @@ func (h *EventHandlers) HandleAnalyzeJobCompleted(ctx context.Context, ev *event
 			Codec:       payload.Codec,
 			Size:        payload.Size,
 			ContentType: payload.ContentType,
+			AudioTracks: audioTracksFromPayload(payload.AudioTracks),
+			Complexity:  payload.Complexity,
 		}
 		if err := h.appService.UpdateVideoMetadata(ctx, cmd); err != nil {
 			return err
 		}
 		// Do not auto-trigger HLS/DASH; user initiates via GraphQL mutation
+		return nil
+	}
+	if h.rejectInput(ctx, payload) && h.pipeline != nil {
+		h.pipeline.MarkFailed(ctx, payload.AssetID, payload.VideoID, "analyze", rejectionSummary(payload))
 	}
 	return nil
 }
+
+func audioTracksFromPayload(payloads []messages.AudioTrackPayload) []valueobjects.AudioTrack {
+	tracks := make([]valueobjects.AudioTrack, 0, len(payloads))
+	for _, p := range payloads {
+		track, err := valueobjects.NewAudioTrack(p.Index, p.Language, p.Codec, p.Channels, p.ChannelLayout, p.SampleRate, p.Title, p.Default)
+		if err != nil {
+			continue
+		}
+		if l := p.Loudness; l != nil {
+			if loudness, err := valueobjects.NewLoudness(l.Integrated, l.TruePeak, l.LRA, l.Threshold, l.Offset); err == nil {
+				*track = track.WithLoudness(loudness)
+			}
+		}
+		tracks = append(tracks, *track)
+	}
+	return tracks
+}
@@ -33,6 +33,30 @@ func (a *AssetAppServiceAdapter) UpdateVideoMetadata(ctx context.Context, cmd co
 	return a.commandService.UpdateVideoMetadata(ctx, cmd)
 }
 
+func (a *AssetAppServiceAdapter) AttachVideoImages(ctx context.Context, cmd commands.AttachVideoImagesCommand) error {
+	return a.commandService.AttachVideoImages(ctx, cmd)
+}
+
+func (a *AssetAppServiceAdapter) SetVideoMarkers(ctx context.Context, cmd commands.SetVideoMarkersCommand) error {
+	return a.commandService.SetVideoMarkers(ctx, cmd)
+}
+
+func (a *AssetAppServiceAdapter) RejectVideoInput(ctx context.Context, cmd commands.RejectVideoInputCommand) error {
+	return a.commandService.RejectVideoInput(ctx, cmd)
+}
+
 func (a *AssetAppServiceAdapter) UpsertVideo(ctx context.Context, cmd commands.UpsertVideoCommand) (*domainentity.Asset, *domainentity.Video, error) {
 	return a.commandService.UpsertVideo(ctx, cmd)
 }
+
+func (a *AssetAppServiceAdapter) MarkLiveReady(ctx context.Context, cmd commands.MarkLiveReadyCommand) error {
+	return a.commandService.MarkLiveReady(ctx, cmd)
+}
+
+func (a *AssetAppServiceAdapter) MarkLiveStarted(ctx context.Context, cmd commands.MarkLiveStartedCommand) error {
+	return a.commandService.MarkLiveStarted(ctx, cmd)
+}
+
+func (a *AssetAppServiceAdapter) EndLiveEvent(ctx context.Context, cmd commands.EndLiveEventCommand) (*domainentity.Asset, *domainentity.Video, error) {
+	return a.commandService.EndLiveEvent(ctx, cmd)
+}
@@ -42,6 +42,13 @@ func (c *AssetEventConsumer) Start(ctx context.Context, bootstrapServers string)
 		events.AnalyzeJobCompletedTopic,
 		events.HLSJobCompletedTopic,
 		events.DASHJobCompletedTopic,
+		events.CMAFJobCompletedTopic,
+		events.ThumbnailsJobCompletedTopic,
+		events.MarkersJobCompletedTopic,
+		events.TranscodeJobProgressTopic,
+		events.LiveStreamReadyTopic,
+		events.LiveStreamStartedTopic,
+		events.LiveStreamStoppedTopic,
 	}
 
 	cons, err := events.NewConsumer(ctx, cfg)
@@ -53,6 +60,13 @@ func (c *AssetEventConsumer) Start(ctx context.Context, bootstrapServers string)
 	cons.Subscribe(events.AnalyzeJobCompletedTopic, c.handlers.HandleAnalyzeJobCompleted)
 	cons.Subscribe(events.HLSJobCompletedTopic, c.handlers.HandleTranscodeHlsJobCompleted)
 	cons.Subscribe(events.DASHJobCompletedTopic, c.handlers.HandleTranscodeDashJobCompleted)
+	cons.Subscribe(events.CMAFJobCompletedTopic, c.handlers.HandleTranscodeCmafJobCompleted)
+	cons.Subscribe(events.ThumbnailsJobCompletedTopic, c.handlers.HandleThumbnailsJobCompleted)
+	cons.Subscribe(events.MarkersJobCompletedTopic, c.handlers.HandleMarkersJobCompleted)
+	cons.Subscribe(events.TranscodeJobProgressTopic, c.handlers.HandleTranscodeJobProgress)
+	cons.Subscribe(events.LiveStreamReadyTopic, c.handlers.HandleLiveStreamReady)
+	cons.Subscribe(events.LiveStreamStartedTopic, c.handlers.HandleLiveStreamStarted)
+	cons.Subscribe(events.LiveStreamStoppedTopic, c.handlers.HandleLiveStreamStopped)
 
 	c.consumer = cons
 	go func() { _ = cons.Start(ctx) }()
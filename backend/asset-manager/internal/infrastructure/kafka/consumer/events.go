@@ -35,3 +35,27 @@ type AnalyzeJobCompletedEvent struct {
 	ErrorMessage string                 `json:"errorMessage,omitempty"`
 	CompletedAt  string                 `json:"completedAt"`
 }
+
+type LiveStreamReadyEvent struct {
+	AssetID   string `json:"assetId"`
+	SessionID string `json:"sessionId"`
+	IngestURL string `json:"ingestUrl"`
+}
+
+type LiveStreamStartedEvent struct {
+	AssetID     string `json:"assetId"`
+	SessionID   string `json:"sessionId"`
+	PlaybackURL string `json:"playbackUrl"`
+	StartedAt   string `json:"startedAt"`
+}
+
+type LiveStreamStoppedEvent struct {
+	AssetID      string  `json:"assetId"`
+	SessionID    string  `json:"sessionId"`
+	Success      bool    `json:"success"`
+	ErrorMessage string  `json:"errorMessage,omitempty"`
+	PlaybackURL  string  `json:"playbackUrl,omitempty"`
+	ArchiveURL   string  `json:"archiveUrl,omitempty"`
+	Duration     float64 `json:"duration,omitempty"`
+	EndedAt      string  `json:"endedAt"`
+}
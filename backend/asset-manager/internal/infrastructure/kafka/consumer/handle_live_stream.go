@@ -0,0 +1,102 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/asset/commands"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+)
+
+func (h *EventHandlers) HandleLiveStreamReady(ctx context.Context, ev *events.Event) error {
+	var payload LiveStreamReadyEvent
+	if err := unmarshalEventData(h.logger, ev, &payload); err != nil {
+		return err
+	}
+	assetID, err := valueobjects.NewAssetID(payload.AssetID)
+	if err != nil {
+		return err
+	}
+	err = h.appService.MarkLiveReady(ctx, commands.MarkLiveReadyCommand{
+		AssetID:   *assetID,
+		SessionID: payload.SessionID,
+		IngestURL: payload.IngestURL,
+	})
+	return h.ignoreStaleLiveEvent(err, payload.AssetID, payload.SessionID)
+}
+
+func (h *EventHandlers) HandleLiveStreamStarted(ctx context.Context, ev *events.Event) error {
+	var payload LiveStreamStartedEvent
+	if err := unmarshalEventData(h.logger, ev, &payload); err != nil {
+		return err
+	}
+	assetID, err := valueobjects.NewAssetID(payload.AssetID)
+	if err != nil {
+		return err
+	}
+	err = h.appService.MarkLiveStarted(ctx, commands.MarkLiveStartedCommand{
+		AssetID:     *assetID,
+		SessionID:   payload.SessionID,
+		PlaybackURL: payload.PlaybackURL,
+		StartedAt:   eventTime(payload.StartedAt, ev),
+	})
+	return h.ignoreStaleLiveEvent(err, payload.AssetID, payload.SessionID)
+}
+
+// HandleLiveStreamStopped ends the event and turns the recording into a raw
+// video, which then goes through analyze and the VOD pipeline like an upload.
+func (h *EventHandlers) HandleLiveStreamStopped(ctx context.Context, ev *events.Event) error {
+	var payload LiveStreamStoppedEvent
+	if err := unmarshalEventData(h.logger, ev, &payload); err != nil {
+		return err
+	}
+	assetID, err := valueobjects.NewAssetID(payload.AssetID)
+	if err != nil {
+		return err
+	}
+	cmd := commands.EndLiveEventCommand{
+		AssetID:      *assetID,
+		SessionID:    payload.SessionID,
+		Success:      payload.Success,
+		ErrorMessage: payload.ErrorMessage,
+		PlaybackURL:  payload.PlaybackURL,
+		EndedAt:      eventTime(payload.EndedAt, ev),
+		Duration:     payload.Duration,
+	}
+	if payload.ArchiveURL != "" {
+		archive, err := valueobjects.NewS3ObjectFromURL(payload.ArchiveURL)
+		if err != nil {
+			h.logger.WithError(err).Warn("Invalid live archive location", "asset_id", payload.AssetID, "archive_url", payload.ArchiveURL)
+		} else {
+			cmd.Archive = archive
+		}
+	}
+
+	asset, video, err := h.appService.EndLiveEvent(ctx, cmd)
+	if err != nil {
+		return h.ignoreStaleLiveEvent(err, payload.AssetID, payload.SessionID)
+	}
+	if video == nil {
+		return nil
+	}
+	return h.requestAnalyze(ctx, ev, asset, video.ID().Value(), payload.ArchiveURL)
+}
+
+// ignoreStaleLiveEvent drops events for a session the asset has moved past,
+// such as a redelivered stop or a late event from a replaced session.
+func (h *EventHandlers) ignoreStaleLiveEvent(err error, assetID, sessionID string) error {
+	if errors.Is(err, valueobjects.ErrLiveSessionMismatch) {
+		h.logger.Info("Ignoring live event for an inactive session", "asset_id", assetID, "session_id", sessionID)
+		return nil
+	}
+	return err
+}
+
+func eventTime(value string, ev *events.Event) time.Time {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC()
+	}
+	return ev.Time.UTC()
+}
@@ -0,0 +1,55 @@
+package consumer
+
+import (
+	"context"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/asset/commands"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/messages"
+)
+
+const markersStep = "markers"
+
+// HandleMarkersJobCompleted replaces the video's markers with the detected
+// ones; editors can adjust them afterwards through GraphQL.
+func (h *EventHandlers) HandleMarkersJobCompleted(ctx context.Context, ev *events.Event) error {
+	var payload messages.JobCompletionPayload
+	if err := unmarshalEventData(h.logger, ev, &payload); err != nil {
+		return err
+	}
+	if !payload.Success {
+		if h.pipeline != nil && payload.Cancelled {
+			h.pipeline.MarkCancelled(ctx, payload.AssetID, payload.VideoID, markersStep)
+		} else if h.pipeline != nil {
+			h.pipeline.MarkFailed(ctx, payload.AssetID, payload.VideoID, markersStep, payload.Error)
+		}
+		return nil
+	}
+	assetIDVO, err := valueobjects.NewAssetID(payload.AssetID)
+	if err != nil {
+		return err
+	}
+
+	markers := make([]valueobjects.Marker, 0, len(payload.Markers))
+	for _, p := range payload.Markers {
+		marker, err := valueobjects.NewMarker(p.Kind, p.Start, p.End, p.Title)
+		if err != nil {
+			h.logger.WithError(err).Warn("Skipping detected marker", "asset_id", payload.AssetID, "video_id", payload.VideoID, "kind", p.Kind)
+			continue
+		}
+		markers = append(markers, *marker)
+	}
+
+	if err := h.appService.SetVideoMarkers(ctx, commands.SetVideoMarkersCommand{
+		AssetID: *assetIDVO,
+		VideoID: payload.VideoID,
+		Markers: markers,
+	}); err != nil {
+		return err
+	}
+	if h.pipeline != nil {
+		_ = h.pipeline.MarkCompleted(ctx, payload.AssetID, payload.VideoID, markersStep)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/asset/commands"
+	domainentity "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/entity"
 	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
 )
@@ -26,7 +27,7 @@ func (h *EventHandlers) HandleRawVideoUploaded(ctx context.Context, ev *events.E
 		return err
 	}
 	initial := valueobjects.VideoStatusReady
-	_, _, err = h.appService.UpsertVideo(ctx, commands.UpsertVideoCommand{
+	asset, _, err := h.appService.UpsertVideo(ctx, commands.UpsertVideoCommand{
 		AssetID:         *assetIDVO,
 		Label:           payload.Filename,
 		Format:          formatVO,
@@ -43,11 +44,20 @@ func (h *EventHandlers) HandleRawVideoUploaded(ctx context.Context, ev *events.E
 	if err != nil {
 		return err
 	}
-	evt := events.NewJobAnalyzeRequestedEvent(payload.AssetID, payload.VideoID, payload.StorageLocation)
-	corr := events.BuildJobCorrelationID(payload.AssetID, payload.VideoID, "analyze", "", "main")
-	evt.SetSource("asset-manager").SetEventVersion("1").SetCorrelationID(corr).SetCausationID(ev.ID)
+	return h.requestAnalyze(ctx, ev, asset, payload.VideoID, payload.StorageLocation)
+}
+
+func (h *EventHandlers) requestAnalyze(ctx context.Context, cause *events.Event, asset *domainentity.Asset, videoID, input string) error {
+	assetID := asset.ID().Value()
+	evt := events.NewJobAnalyzeRequestedEvent(assetID, videoID, input)
+	corr := events.BuildJobCorrelationID(assetID, videoID, "analyze", "", "main")
+	evt.SetSource("asset-manager").SetEventVersion("1").SetCorrelationID(corr).SetCausationID(cause.ID)
+	if asset.Type() != nil {
+		// Audio-only asset types may have sources without a video stream.
+		evt.SetDataField("assetType", asset.Type().Value())
+	}
 	if h.pipeline != nil {
-		_ = h.pipeline.MarkRequested(ctx, payload.AssetID, payload.VideoID, "analyze", corr, corr)
+		_ = h.pipeline.MarkRequested(ctx, assetID, videoID, "analyze", corr, corr)
 	}
 	return h.publisher.Publish(ctx, events.AnalyzeJobRequestedTopic, evt)
 }
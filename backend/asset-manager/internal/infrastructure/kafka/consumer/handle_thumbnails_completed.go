@@ -0,0 +1,117 @@
+package consumer
+
+import (
+	"context"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/asset/commands"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/messages"
+)
+
+const thumbnailsStep = "thumbnails"
+
+var thumbnailImageTypes = map[string]valueobjects.ImageType{
+	"poster":     valueobjects.ImageTypeThumbnail,
+	"screenshot": valueobjects.ImageTypeScreenshot,
+	"sprite":     valueobjects.ImageTypeSprite,
+}
+
+func (h *EventHandlers) HandleThumbnailsJobCompleted(ctx context.Context, ev *events.Event) error {
+	var payload messages.JobCompletionPayload
+	if err := unmarshalEventData(h.logger, ev, &payload); err != nil {
+		return err
+	}
+	if !payload.Success {
+		if h.pipeline != nil && payload.Cancelled {
+			h.pipeline.MarkCancelled(ctx, payload.AssetID, payload.VideoID, thumbnailsStep)
+		} else if h.pipeline != nil {
+			h.pipeline.MarkFailed(ctx, payload.AssetID, payload.VideoID, thumbnailsStep, payload.Error)
+		}
+		return nil
+	}
+	assetIDVO, err := valueobjects.NewAssetID(payload.AssetID)
+	if err != nil {
+		return err
+	}
+
+	var trackURL string
+	if payload.TrackKey != "" {
+		_, trackURL = h.cdn.BuildPlayURL(payload.TrackKey)
+	}
+
+	var videoImages, assetImages []valueobjects.Image
+	for _, p := range payload.Images {
+		img, err := h.thumbnailImage(payload.VideoID, p, trackURL)
+		if err != nil {
+			h.logger.WithError(err).Warn("Skipping generated image", "asset_id", payload.AssetID, "video_id", payload.VideoID, "key", p.Key)
+			continue
+		}
+		videoImages = append(videoImages, *img)
+		if img.Type() != valueobjects.ImageTypeSprite {
+			assetImages = append(assetImages, *img)
+		}
+	}
+
+	if err := h.appService.AttachVideoImages(ctx, commands.AttachVideoImagesCommand{
+		AssetID:     *assetIDVO,
+		VideoID:     payload.VideoID,
+		VideoImages: videoImages,
+		AssetImages: assetImages,
+	}); err != nil {
+		return err
+	}
+	if h.pipeline != nil {
+		_ = h.pipeline.MarkCompleted(ctx, payload.AssetID, payload.VideoID, thumbnailsStep)
+	}
+	return nil
+}
+
+func (h *EventHandlers) thumbnailImage(videoID string, p messages.ImagePayload, trackURL string) (*valueobjects.Image, error) {
+	imageType, ok := thumbnailImageTypes[p.Kind]
+	if !ok {
+		imageType = valueobjects.ImageTypeScreenshot
+	}
+	id, err := valueobjects.GenerateImageID()
+	if err != nil {
+		return nil, err
+	}
+	s3Obj, err := valueobjects.NewS3Object(p.Bucket, p.Key, p.URL)
+	if err != nil {
+		return nil, err
+	}
+	cdnPrefix, playURL := h.cdn.BuildPlayURL(p.Key)
+	si, _ := valueobjects.NewStreamInfo(nil, &cdnPrefix, &playURL)
+
+	metadata := map[string]string{
+		"source":  thumbnailsStep,
+		"videoId": videoID,
+		"kind":    p.Kind,
+	}
+	if p.Index > 0 {
+		metadata["index"] = strconv.Itoa(p.Index)
+	}
+	if p.Offset > 0 {
+		metadata["offset"] = strconv.FormatFloat(p.Offset, 'f', 3, 64)
+	}
+	if imageType == valueobjects.ImageTypeSprite && trackURL != "" {
+		metadata["track"] = trackURL
+	}
+
+	var width, height *int
+	if p.Width > 0 {
+		width = &p.Width
+	}
+	if p.Height > 0 {
+		height = &p.Height
+	}
+	var size *int64
+	if p.Size > 0 {
+		size = &p.Size
+	}
+	now := time.Now().UTC()
+	return valueobjects.NewImageWithDetails(*id, path.Base(p.Key), playURL, imageType, s3Obj, width, height, size, p.ContentType, si, metadata, now, now)
+}
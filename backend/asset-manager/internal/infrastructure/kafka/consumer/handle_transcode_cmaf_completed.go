@@ -0,0 +1,12 @@
+package consumer
+
+import (
+	"context"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+)
+
+func (h *EventHandlers) HandleTranscodeCmafJobCompleted(ctx context.Context, ev *events.Event) error {
+	return h.handleTranscodeJobCompleted(ctx, ev, valueobjects.VideoFormatCMAF)
+}
@@ -0,0 +1,155 @@
+package consumer
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/asset/commands"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/messages"
+)
+
+func (h *EventHandlers) handleTranscodeJobCompleted(ctx context.Context, ev *events.Event, format valueobjects.VideoFormat) error {
+	var payload messages.JobCompletionPayload
+	if err := unmarshalEventData(h.logger, ev, &payload); err != nil {
+		return err
+	}
+	// Overlay variants are stored under their label and kept out of the
+	// pipeline, which tracks the main outputs.
+	label := path.Base(payload.Key)
+	variant := payload.Variant != ""
+	if variant {
+		label = payload.Variant
+	}
+	if !payload.Success {
+		assetIDVO, err := valueobjects.NewAssetID(payload.AssetID)
+		if err != nil {
+			return err
+		}
+		status := valueobjects.VideoStatusFailed
+		if payload.Cancelled {
+			status = valueobjects.VideoStatusCancelled
+		}
+		formatVO, _ := valueobjects.NewVideoFormat(format.Value())
+		h.appService.UpsertVideo(ctx, commands.UpsertVideoCommand{
+			AssetID:       *assetIDVO,
+			Label:         label,
+			Format:        formatVO,
+			ContentType:   payload.ContentType,
+			InitialStatus: &status,
+			Variant:       variant,
+		})
+		reason := payload.Error
+		if h.rejectInput(ctx, payload) {
+			reason = rejectionSummary(payload)
+		}
+		if h.pipeline != nil && !variant && payload.Cancelled {
+			h.pipeline.MarkCancelled(ctx, payload.AssetID, payload.VideoID, format.Value())
+		} else if h.pipeline != nil && !variant {
+			h.pipeline.MarkFailed(ctx, payload.AssetID, payload.VideoID, format.Value(), reason)
+		}
+
+		ev2 := events.NewVideoStatusUpdatedEvent(payload.AssetID, payload.VideoID, status.Value())
+		ev2.SetSource("asset-manager").SetEventVersion("1").SetCorrelationID(ev.CorrelationID).SetCausationID(ev.ID)
+		h.publisher.Publish(ctx, events.AssetEventsTopic, ev2)
+		return nil
+	}
+	assetIDVO, err := valueobjects.NewAssetID(payload.AssetID)
+	if err != nil {
+		return err
+	}
+	s3Obj, err := valueobjects.NewS3ObjectFromURL(payload.URL)
+	if err != nil {
+		return err
+	}
+	formatVO, err := valueobjects.NewVideoFormat(format.Value())
+	if err != nil {
+		return err
+	}
+	cdnPrefix, playURL := h.cdn.BuildPlayURL(payload.Key)
+	si, _ := valueobjects.NewStreamInfo(nil, &cdnPrefix, &playURL)
+	if payload.PlaylistKey != "" {
+		_, hlsURL := h.cdn.BuildPlayURL(payload.PlaylistKey)
+		si = si.WithHLSURL(hlsURL)
+	}
+
+	// A rendition below the transcoder's quality thresholds keeps its output
+	// for inspection but never becomes playable.
+	status := valueobjects.VideoStatusReady
+	if payload.QualityCheck != nil && !payload.QualityCheck.Passed {
+		status = valueobjects.VideoStatusFailedQC
+	}
+	_, _, err = h.appService.UpsertVideo(ctx, commands.UpsertVideoCommand{
+		AssetID:            *assetIDVO,
+		Label:              label,
+		Format:             formatVO,
+		StorageLocation:    *s3Obj,
+		ContentType:        payload.ContentType,
+		Codec:              payload.VideoCodec,
+		VideoCodec:         payload.VideoCodec,
+		AudioCodec:         payload.AudioCodec,
+		FrameRate:          payload.FrameRate,
+		AudioChannels:      payload.AudioChannels,
+		AudioSampleRate:    payload.AudioSampleRate,
+		Duration:           payload.Duration,
+		Bitrate:            payload.Bitrate,
+		Width:              payload.Width,
+		Height:             payload.Height,
+		Size:               payload.Size,
+		StreamInfo:         si,
+		SegmentCount:       payload.SegmentCount,
+		AvgSegmentDuration: payload.AvgSegmentDuration,
+		Segments:           payload.Segments,
+		InitialStatus:      &status,
+		QualityScores:      h.renditionQuality(payload),
+		Complexity:         payload.Complexity,
+		Ladder:             h.ladderRungs(payload),
+		Variant:            variant,
+	})
+	if err != nil {
+		return err
+	}
+	if h.pipeline != nil && !variant {
+		if status.IsFailedQC() {
+			h.pipeline.MarkFailed(ctx, payload.AssetID, payload.VideoID, format.Value(), "quality check failed: "+strings.Join(payload.QualityCheck.Reasons, "; "))
+		} else {
+			_ = h.pipeline.MarkCompleted(ctx, payload.AssetID, payload.VideoID, format.Value())
+		}
+	}
+
+	ev2 := events.NewVideoStatusUpdatedEvent(payload.AssetID, payload.VideoID, status.Value())
+	ev2.SetSource("asset-manager").SetEventVersion("1").SetCorrelationID(ev.CorrelationID).SetCausationID(ev.ID)
+	h.publisher.Publish(ctx, events.AssetEventsTopic, ev2)
+	return nil
+}
+
+func (h *EventHandlers) renditionQuality(payload messages.JobCompletionPayload) []valueobjects.RenditionQuality {
+	var scores []valueobjects.RenditionQuality
+	for _, r := range payload.Renditions {
+		if r.Quality == nil {
+			continue
+		}
+		q, err := valueobjects.NewRenditionQuality(r.Name, r.Quality.PSNR, r.Quality.SSIM, r.Quality.VMAF)
+		if err != nil {
+			h.logger.WithError(err).Warn("Skipping rendition quality scores", "asset_id", payload.AssetID, "video_id", payload.VideoID, "rendition", r.Name)
+			continue
+		}
+		scores = append(scores, *q)
+	}
+	return scores
+}
+
+func (h *EventHandlers) ladderRungs(payload messages.JobCompletionPayload) []valueobjects.LadderRung {
+	rungs := make([]valueobjects.LadderRung, 0, len(payload.Ladder))
+	for _, r := range payload.Ladder {
+		rung, err := valueobjects.NewLadderRung(r.Name, r.Width, r.Height, r.VideoBitrate, r.AudioBitrate, r.BaseVideoBitrate)
+		if err != nil {
+			h.logger.WithError(err).Warn("Skipping ladder rung", "asset_id", payload.AssetID, "video_id", payload.VideoID, "rung", r.Name)
+			continue
+		}
+		rungs = append(rungs, *rung)
+	}
+	return rungs
+}
@@ -0,0 +1,22 @@
+package consumer
+
+import (
+	"context"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/messages"
+)
+
+func (h *EventHandlers) HandleTranscodeJobProgress(ctx context.Context, ev *events.Event) error {
+	var payload messages.JobProgressPayload
+	if err := unmarshalEventData(h.logger, ev, &payload); err != nil {
+		return err
+	}
+	if h.pipeline == nil || payload.Format == "" {
+		return nil
+	}
+	if err := h.pipeline.MarkProgress(ctx, payload.AssetID, payload.VideoID, payload.Format, payload.Progress); err != nil {
+		h.logger.WithError(err).Warn("Failed to store transcode progress", "asset_id", payload.AssetID, "video_id", payload.VideoID, "format", payload.Format)
+	}
+	return nil
+}
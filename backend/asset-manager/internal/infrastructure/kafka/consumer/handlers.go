@@ -15,6 +15,12 @@ import (
 type AssetAppService interface {
 	UpdateVideoMetadata(ctx context.Context, cmd commands.UpdateVideoMetadataCommand) error
 	UpsertVideo(ctx context.Context, cmd commands.UpsertVideoCommand) (*domainentity.Asset, *domainentity.Video, error)
+	AttachVideoImages(ctx context.Context, cmd commands.AttachVideoImagesCommand) error
+	SetVideoMarkers(ctx context.Context, cmd commands.SetVideoMarkersCommand) error
+	RejectVideoInput(ctx context.Context, cmd commands.RejectVideoInputCommand) error
+	MarkLiveReady(ctx context.Context, cmd commands.MarkLiveReadyCommand) error
+	MarkLiveStarted(ctx context.Context, cmd commands.MarkLiveStartedCommand) error
+	EndLiveEvent(ctx context.Context, cmd commands.EndLiveEventCommand) (*domainentity.Asset, *domainentity.Video, error)
 }
 
 type Publisher interface {
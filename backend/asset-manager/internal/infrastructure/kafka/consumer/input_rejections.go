@@ -0,0 +1,47 @@
+package consumer
+
+import (
+	"context"
+	"strings"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/asset/commands"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/messages"
+)
+
+// rejectInput stores the transcoder's rejection reasons on the source video
+// so editors can see what to fix. It reports whether there were any.
+func (h *EventHandlers) rejectInput(ctx context.Context, payload messages.JobCompletionPayload) bool {
+	rejections := make([]valueobjects.InputRejection, 0, len(payload.Rejections))
+	for _, p := range payload.Rejections {
+		r, err := valueobjects.NewInputRejection(p.Code, p.Message)
+		if err != nil {
+			h.logger.WithError(err).Warn("Skipping input rejection", "asset_id", payload.AssetID, "video_id", payload.VideoID, "code", p.Code)
+			continue
+		}
+		rejections = append(rejections, *r)
+	}
+	if len(rejections) == 0 {
+		return false
+	}
+	assetIDVO, err := valueobjects.NewAssetID(payload.AssetID)
+	if err != nil {
+		return false
+	}
+	if err := h.appService.RejectVideoInput(ctx, commands.RejectVideoInputCommand{
+		AssetID:    *assetIDVO,
+		VideoID:    payload.VideoID,
+		Rejections: rejections,
+	}); err != nil {
+		h.logger.WithError(err).Error("Failed to store input rejections", "asset_id", payload.AssetID, "video_id", payload.VideoID)
+	}
+	return true
+}
+
+func rejectionSummary(payload messages.JobCompletionPayload) string {
+	reasons := make([]string, len(payload.Rejections))
+	for i, r := range payload.Rejections {
+		reasons[i] = r.Message
+	}
+	return "input rejected: " + strings.Join(reasons, "; ")
+}
@@ -2,6 +2,7 @@ package asset
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
@@ -54,9 +55,15 @@ func (c *AssetConverter) AssetToParams(a *entity.Asset) map[string]interface{} {
 		"tags":            c.tagsToStringSlice(a.Tags()),
 		"ownerId":         ownerID,
 		"parentId":        parentID,
+		"seasonNumber":    numberParam(a.SeasonNumber()),
+		"episodeNumber":   numberParam(a.EpisodeNumber()),
 		"createdAt":       a.CreatedAt().Value().Format(time.RFC3339),
 		"updatedAt":       a.UpdatedAt().Value().Format(time.RFC3339),
+		"keywords":        c.keywords(a),
+		"releaseYear":     a.ReleaseYear(),
 		"publishRule":     nil,
+		"publishAt":       nil,
+		"unpublishAt":     nil,
 	}
 
 	if a.PublishRule() != nil {
@@ -68,6 +75,14 @@ func (c *AssetConverter) AssetToParams(a *entity.Asset) map[string]interface{} {
 		}
 		publishRuleJSON, _ := json.Marshal(publishRuleData)
 		params["publishRule"] = string(publishRuleJSON)
+		// The dates are also stored as properties so the scheduler can
+		// query for rules that have come due.
+		if t := a.PublishRule().PublishAt(); t != nil {
+			params["publishAt"] = t.UTC().Format(time.RFC3339)
+		}
+		if t := a.PublishRule().UnpublishAt(); t != nil {
+			params["unpublishAt"] = t.UTC().Format(time.RFC3339)
+		}
 	}
 
 	var videosData []map[string]interface{}
@@ -106,64 +121,215 @@ func (c *AssetConverter) AssetToParams(a *entity.Asset) map[string]interface{} {
 				"downloadURL": si.DownloadURL(),
 				"cdnPrefix":   si.CDNPrefix(),
 				"url":         si.URL(),
+				"hlsURL":      si.HLSURL(),
 			}
 		}
-		videosData = append(videosData, videoData)
-	}
-	videosJSON, _ := json.Marshal(videosData)
-	params["videos"] = string(videosJSON)
-
-	var imagesData []map[string]interface{}
-	for _, image := range a.Images() {
-		imageData := map[string]interface{}{
-			"id":          image.ID().Value(),
-			"fileName":    image.FileName().Value(),
-			"url":         image.URL(),
-			"type":        string(image.Type()),
-			"contentType": image.ContentType().Value(),
-			"createdAt":   image.CreatedAt().Format(time.RFC3339),
-			"updatedAt":   image.UpdatedAt().Format(time.RFC3339),
+		if images := video.Images(); len(images) > 0 {
+			imagesData := make([]map[string]interface{}, 0, len(images))
+			for _, image := range images {
+				imagesData = append(imagesData, c.imageToData(image))
+			}
+			videoData["images"] = imagesData
 		}
-		if image.StorageLocation() != nil {
-			imageData["storageLocation"] = map[string]interface{}{
-				"bucket": image.StorageLocation().Bucket(),
-				"key":    image.StorageLocation().Key(),
-				"url":    image.StorageLocation().URL(),
+		if tracks := video.AudioTracks(); len(tracks) > 0 {
+			tracksData := make([]map[string]interface{}, 0, len(tracks))
+			for _, track := range tracks {
+				trackData := map[string]interface{}{
+					"index":         track.Index(),
+					"language":      track.Language(),
+					"codec":         track.Codec(),
+					"channels":      track.Channels(),
+					"channelLayout": track.ChannelLayout(),
+					"sampleRate":    track.SampleRate(),
+					"title":         track.Title(),
+					"default":       track.IsDefault(),
+				}
+				if l := track.Loudness(); l != nil {
+					trackData["loudness"] = map[string]interface{}{
+						"integrated": l.Integrated(),
+						"truePeak":   l.TruePeak(),
+						"lra":        l.LRA(),
+						"threshold":  l.Threshold(),
+						"offset":     l.Offset(),
+					}
+				}
+				tracksData = append(tracksData, trackData)
 			}
+			videoData["audioTracks"] = tracksData
 		}
-		if image.Width() != nil {
-			imageData["width"] = *image.Width()
+		if markers := video.Markers(); len(markers) > 0 {
+			markersData := make([]map[string]interface{}, 0, len(markers))
+			for _, marker := range markers {
+				markersData = append(markersData, map[string]interface{}{
+					"kind":  marker.Kind().Value(),
+					"start": marker.Start(),
+					"end":   marker.End(),
+					"title": marker.Title(),
+				})
+			}
+			videoData["markers"] = markersData
+		}
+		if scores := video.QualityScores(); len(scores) > 0 {
+			scoresData := make([]map[string]interface{}, 0, len(scores))
+			for _, q := range scores {
+				scoreData := map[string]interface{}{
+					"rendition": q.Rendition(),
+					"psnr":      q.PSNR(),
+					"ssim":      q.SSIM(),
+				}
+				if q.VMAF() != nil {
+					scoreData["vmaf"] = *q.VMAF()
+				}
+				scoresData = append(scoresData, scoreData)
+			}
+			videoData["qualityScores"] = scoresData
 		}
-		if image.Height() != nil {
-			imageData["height"] = *image.Height()
+		if complexity := video.Complexity(); complexity > 0 {
+			videoData["complexity"] = complexity
 		}
-		if image.Size() != nil {
-			imageData["size"] = *image.Size()
+		if video.IsVariant() {
+			videoData["variant"] = true
 		}
-		if image.StreamInfo() != nil {
-			imageData["streamInfo"] = map[string]interface{}{
-				"downloadURL": image.StreamInfo().DownloadURL(),
-				"cdnPrefix":   image.StreamInfo().CDNPrefix(),
-				"url":         image.StreamInfo().URL(),
+		if ladder := video.Ladder(); len(ladder) > 0 {
+			ladderData := make([]map[string]interface{}, 0, len(ladder))
+			for _, rung := range ladder {
+				ladderData = append(ladderData, map[string]interface{}{
+					"name":             rung.Name(),
+					"width":            rung.Width(),
+					"height":           rung.Height(),
+					"videoBitrate":     rung.VideoBitrate(),
+					"audioBitrate":     rung.AudioBitrate(),
+					"baseVideoBitrate": rung.BaseVideoBitrate(),
+				})
 			}
+			videoData["ladder"] = ladderData
 		}
-		if image.Metadata() != nil {
-			imageData["metadata"] = image.Metadata()
+		if rejections := video.InputRejections(); len(rejections) > 0 {
+			rejectionsData := make([]map[string]interface{}, 0, len(rejections))
+			for _, r := range rejections {
+				rejectionsData = append(rejectionsData, map[string]interface{}{
+					"code":    r.Code(),
+					"message": r.Message(),
+				})
+			}
+			videoData["inputRejections"] = rejectionsData
 		}
-		imagesData = append(imagesData, imageData)
+		videosData = append(videosData, videoData)
+	}
+	videosJSON, _ := json.Marshal(videosData)
+	params["videos"] = string(videosJSON)
+
+	var imagesData []map[string]interface{}
+	for _, image := range a.Images() {
+		imagesData = append(imagesData, c.imageToData(image))
 	}
 	imagesJSON, _ := json.Marshal(imagesData)
 	params["images"] = string(imagesJSON)
 
+	var subtitlesData []map[string]interface{}
+	for _, subtitle := range a.Subtitles() {
+		subtitlesData = append(subtitlesData, map[string]interface{}{
+			"id":        subtitle.ID().Value(),
+			"language":  subtitle.Language().Value(),
+			"kind":      subtitle.Kind().Value(),
+			"label":     subtitle.Label(),
+			"isDefault": subtitle.IsDefault(),
+			"storageLocation": map[string]interface{}{
+				"bucket": subtitle.StorageLocation().Bucket(),
+				"key":    subtitle.StorageLocation().Key(),
+				"url":    subtitle.StorageLocation().URL(),
+			},
+			"contentType": subtitle.ContentType(),
+			"createdAt":   subtitle.CreatedAt().Format(time.RFC3339),
+			"updatedAt":   subtitle.UpdatedAt().Format(time.RFC3339),
+		})
+	}
+	subtitlesJSON, _ := json.Marshal(subtitlesData)
+	params["subtitles"] = string(subtitlesJSON)
+
+	params["liveStream"] = ""
+	if ls := a.LiveStream(); ls != nil {
+		liveData := map[string]interface{}{
+			"streamKey":      ls.StreamKey(),
+			"protocol":       ls.Protocol().Value(),
+			"status":         ls.Status().Value(),
+			"sessionId":      ls.SessionID(),
+			"ingestUrl":      ls.IngestURL(),
+			"playbackUrl":    ls.PlaybackURL(),
+			"errorMessage":   ls.ErrorMessage(),
+			"archiveVideoId": ls.ArchiveVideoID(),
+		}
+		if t := ls.StartedAt(); t != nil {
+			liveData["startedAt"] = t.Format(time.RFC3339)
+		}
+		if t := ls.EndedAt(); t != nil {
+			liveData["endedAt"] = t.Format(time.RFC3339)
+		}
+		liveJSON, _ := json.Marshal(liveData)
+		params["liveStream"] = string(liveJSON)
+	}
+
 	creditsJSON, _ := json.Marshal(a.Credits())
 	params["credits"] = string(creditsJSON)
 
+	params["status"] = a.Status()
+	historyData := make([]map[string]interface{}, 0, len(a.StatusHistory()))
+	for _, change := range a.StatusHistory() {
+		historyData = append(historyData, map[string]interface{}{
+			"from": change.From().Value(),
+			"to":   change.To().Value(),
+			"at":   change.At().Format(time.RFC3339),
+			"note": change.Note(),
+		})
+	}
+	historyJSON, _ := json.Marshal(historyData)
+	params["statusHistory"] = string(historyJSON)
+
 	metadataJSON, _ := json.Marshal(a.Metadata())
 	params["metadata"] = string(metadataJSON)
 
 	return params
 }
 
+func (c *AssetConverter) imageToData(image valueobjects.Image) map[string]interface{} {
+	imageData := map[string]interface{}{
+		"id":          image.ID().Value(),
+		"fileName":    image.FileName().Value(),
+		"url":         image.URL(),
+		"type":        string(image.Type()),
+		"contentType": image.ContentType().Value(),
+		"createdAt":   image.CreatedAt().Format(time.RFC3339),
+		"updatedAt":   image.UpdatedAt().Format(time.RFC3339),
+	}
+	if image.StorageLocation() != nil {
+		imageData["storageLocation"] = map[string]interface{}{
+			"bucket": image.StorageLocation().Bucket(),
+			"key":    image.StorageLocation().Key(),
+			"url":    image.StorageLocation().URL(),
+		}
+	}
+	if image.Width() != nil {
+		imageData["width"] = *image.Width()
+	}
+	if image.Height() != nil {
+		imageData["height"] = *image.Height()
+	}
+	if image.Size() != nil {
+		imageData["size"] = *image.Size()
+	}
+	if image.StreamInfo() != nil {
+		imageData["streamInfo"] = map[string]interface{}{
+			"downloadURL": image.StreamInfo().DownloadURL(),
+			"cdnPrefix":   image.StreamInfo().CDNPrefix(),
+			"url":         image.StreamInfo().URL(),
+		}
+	}
+	if image.Metadata() != nil {
+		imageData["metadata"] = image.Metadata()
+	}
+	return imageData
+}
+
 func (c *AssetConverter) RecordToAsset(record *neo4j.Record) (*entity.Asset, error) {
 	log := c.logger
 
@@ -187,6 +353,7 @@ func (c *AssetConverter) RecordToAsset(record *neo4j.Record) (*entity.Asset, err
 	assetType, _ := props["type"].(string)
 	genre, _ := props["genre"].(string)
 	ownerID, _ := props["ownerId"].(string)
+	parentID, _ := props["parentId"].(string)
 	createdAtStr, _ := props["createdAt"].(string)
 	updatedAtStr, _ := props["updatedAt"].(string)
 
@@ -380,6 +547,14 @@ func (c *AssetConverter) RecordToAsset(record *neo4j.Record) (*entity.Asset, err
 		}
 	}
 
+	var parentIDVO *valueobjects.AssetID
+	if parentID != "" {
+		parentIDVO, err = valueobjects.NewAssetID(parentID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	createdAtTime, err := time.Parse(time.RFC3339, createdAtStr)
 	if err != nil {
 		return nil, pkgerrors.NewInternalError("failed to parse createdAt", err)
@@ -404,7 +579,7 @@ func (c *AssetConverter) RecordToAsset(record *neo4j.Record) (*entity.Asset, err
 		*createdAtVO,
 		*updatedAtVO,
 		ownerIDVO,
-		nil, //TODO: parentID will be set separately if needed
+		parentIDVO,
 		images,
 		videosMap,
 		credits,
@@ -414,9 +589,141 @@ func (c *AssetConverter) RecordToAsset(record *neo4j.Record) (*entity.Asset, err
 
 	a.SetVersion(version)
 
+	if subtitlesJSON, ok := props["subtitles"].(string); ok && subtitlesJSON != "" {
+		var subtitlesData []map[string]interface{}
+		if err := json.Unmarshal([]byte(subtitlesJSON), &subtitlesData); err != nil {
+			log.WithError(err).Error("Failed to unmarshal subtitles JSON")
+		} else {
+			subtitles := make([]*entity.Subtitle, 0, len(subtitlesData))
+			for _, data := range subtitlesData {
+				subtitle, err := c.reconstructSubtitleFromData(data)
+				if err != nil {
+					log.WithError(err).Error("Failed to reconstruct subtitle from data")
+					continue
+				}
+				subtitles = append(subtitles, subtitle)
+			}
+			a.RestoreSubtitles(subtitles)
+		}
+	}
+
+	if liveJSON, ok := props["liveStream"].(string); ok && liveJSON != "" {
+		var liveData map[string]string
+		if err := json.Unmarshal([]byte(liveJSON), &liveData); err != nil {
+			log.WithError(err).Error("Failed to unmarshal live stream JSON")
+		} else {
+			a.RestoreLiveStream(valueobjects.ReconstructLiveStream(
+				liveData["streamKey"],
+				liveData["protocol"],
+				liveData["status"],
+				liveData["sessionId"],
+				liveData["ingestUrl"],
+				liveData["playbackUrl"],
+				parseOptionalTime(liveData["startedAt"]),
+				parseOptionalTime(liveData["endedAt"]),
+				liveData["errorMessage"],
+				liveData["archiveVideoId"],
+			))
+		}
+	}
+
+	a.RestoreNumbering(optionalInt(props["seasonNumber"]), optionalInt(props["episodeNumber"]))
+
+	// Assets saved before the editorial lifecycle existed have no status and
+	// start out as drafts.
+	if statusStr, ok := props["status"].(string); ok && statusStr != "" {
+		status, err := valueobjects.NewAssetStatus(statusStr)
+		if err != nil {
+			log.WithError(err).Error("Failed to restore asset status", "status", statusStr)
+			status = valueobjects.AssetStatusDraft
+		}
+		var history []valueobjects.AssetStatusChange
+		if historyJSON, ok := props["statusHistory"].(string); ok && historyJSON != "" {
+			var historyData []map[string]string
+			if err := json.Unmarshal([]byte(historyJSON), &historyData); err != nil {
+				log.WithError(err).Error("Failed to unmarshal status history JSON")
+			}
+			for _, data := range historyData {
+				at := parseOptionalTime(data["at"])
+				if at == nil {
+					continue
+				}
+				history = append(history, valueobjects.ReconstructAssetStatusChange(data["from"], data["to"], *at, data["note"]))
+			}
+		}
+		a.RestoreStatus(status, history)
+	}
+
 	return a, nil
 }
 
+// numberParam stores an unset number as null rather than zero.
+func numberParam(number *int) interface{} {
+	if number == nil {
+		return nil
+	}
+	return *number
+}
+
+func optionalInt(value interface{}) *int {
+	n, ok := value.(int64)
+	if !ok {
+		return nil
+	}
+	i := int(n)
+	return &i
+}
+
+func parseOptionalTime(value string) *time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+func (c *AssetConverter) reconstructSubtitleFromData(data map[string]interface{}) (*entity.Subtitle, error) {
+	idStr, _ := data["id"].(string)
+	idVO, err := valueobjects.NewID(idStr, "subtitle id", 36)
+	if err != nil {
+		return nil, err
+	}
+	languageStr, _ := data["language"].(string)
+	language, err := valueobjects.NewLanguageCode(languageStr)
+	if err != nil {
+		return nil, err
+	}
+	kindStr, _ := data["kind"].(string)
+	kind, err := valueobjects.NewSubtitleKind(kindStr)
+	if err != nil {
+		return nil, err
+	}
+	storageLocationMap, _ := data["storageLocation"].(map[string]interface{})
+	bucket, _ := storageLocationMap["bucket"].(string)
+	key, _ := storageLocationMap["key"].(string)
+	url, _ := storageLocationMap["url"].(string)
+	storageLocation, err := valueobjects.NewS3Object(bucket, key, url)
+	if err != nil {
+		return nil, err
+	}
+	label, _ := data["label"].(string)
+	isDefault, _ := data["isDefault"].(bool)
+	contentType, _ := data["contentType"].(string)
+
+	createdAtStr, _ := data["createdAt"].(string)
+	updatedAtStr, _ := data["updatedAt"].(string)
+	createdAt, err := time.Parse(time.RFC3339, createdAtStr)
+	if err != nil {
+		createdAt = time.Now().UTC()
+	}
+	updatedAt, err := time.Parse(time.RFC3339, updatedAtStr)
+	if err != nil {
+		updatedAt = createdAt
+	}
+
+	return entity.ReconstructSubtitle(*idVO, *language, *kind, label, isDefault, *storageLocation, contentType, createdAt, updatedAt), nil
+}
+
 func (c *AssetConverter) reconstructImageFromData(imgData map[string]interface{}) (*valueobjects.Image, error) {
 	idVO, err := valueobjects.NewID(imgData["id"].(string), "image id", 36)
 	if err != nil {
@@ -649,13 +956,169 @@ func (c *AssetConverter) reconstructVideoFromData(videoData map[string]interface
 		}
 		if downloadURL != nil || cdnPrefix != nil || urlStr != nil {
 			if si, err := valueobjects.NewStreamInfo(downloadURL, cdnPrefix, urlStr); err == nil {
+				if v, ok := streamInfoMap["hlsURL"].(string); ok && v != "" {
+					si = si.WithHLSURL(v)
+				}
 				video.SetStreamInfo(si)
 			}
 		}
 	}
+	if imagesData, ok := videoData["images"].([]interface{}); ok {
+		images := make([]valueobjects.Image, 0, len(imagesData))
+		for _, raw := range imagesData {
+			imgData, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			img, err := c.reconstructImageFromData(imgData)
+			if err != nil {
+				log.WithError(err).Error("Failed to reconstruct video image from data")
+				continue
+			}
+			images = append(images, *img)
+		}
+		video.SetImages(images)
+	}
+	if tracksData, ok := videoData["audioTracks"].([]interface{}); ok {
+		tracks := make([]valueobjects.AudioTrack, 0, len(tracksData))
+		for _, raw := range tracksData {
+			trackData, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			index, _ := trackData["index"].(float64)
+			language, _ := trackData["language"].(string)
+			codec, _ := trackData["codec"].(string)
+			channels, _ := trackData["channels"].(float64)
+			channelLayout, _ := trackData["channelLayout"].(string)
+			sampleRate, _ := trackData["sampleRate"].(float64)
+			title, _ := trackData["title"].(string)
+			isDefault, _ := trackData["default"].(bool)
+			track, err := valueobjects.NewAudioTrack(int(index), language, codec, int(channels), channelLayout, int(sampleRate), title, isDefault)
+			if err != nil {
+				log.WithError(err).Error("Failed to reconstruct audio track from data")
+				continue
+			}
+			if l, ok := trackData["loudness"].(map[string]interface{}); ok {
+				integrated, _ := l["integrated"].(float64)
+				truePeak, _ := l["truePeak"].(float64)
+				lra, _ := l["lra"].(float64)
+				threshold, _ := l["threshold"].(float64)
+				offset, _ := l["offset"].(float64)
+				if loudness, err := valueobjects.NewLoudness(integrated, truePeak, lra, threshold, offset); err == nil {
+					*track = track.WithLoudness(loudness)
+				}
+			}
+			tracks = append(tracks, *track)
+		}
+		video.SetAudioTracks(tracks)
+	}
+	if markersData, ok := videoData["markers"].([]interface{}); ok {
+		markers := make([]valueobjects.Marker, 0, len(markersData))
+		for _, raw := range markersData {
+			markerData, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			kind, _ := markerData["kind"].(string)
+			start, _ := markerData["start"].(float64)
+			end, _ := markerData["end"].(float64)
+			title, _ := markerData["title"].(string)
+			marker, err := valueobjects.NewMarker(kind, start, end, title)
+			if err != nil {
+				log.WithError(err).Error("Failed to reconstruct marker from data")
+				continue
+			}
+			markers = append(markers, *marker)
+		}
+		if err := video.SetMarkers(markers); err != nil {
+			log.WithError(err).Error("Failed to restore video markers")
+		}
+	}
+	if scoresData, ok := videoData["qualityScores"].([]interface{}); ok {
+		scores := make([]valueobjects.RenditionQuality, 0, len(scoresData))
+		for _, raw := range scoresData {
+			scoreData, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			rendition, _ := scoreData["rendition"].(string)
+			psnr, _ := scoreData["psnr"].(float64)
+			ssim, _ := scoreData["ssim"].(float64)
+			var vmaf *float64
+			if v, ok := scoreData["vmaf"].(float64); ok {
+				vmaf = &v
+			}
+			q, err := valueobjects.NewRenditionQuality(rendition, psnr, ssim, vmaf)
+			if err != nil {
+				log.WithError(err).Error("Failed to reconstruct rendition quality from data")
+				continue
+			}
+			scores = append(scores, *q)
+		}
+		video.SetQualityScores(scores)
+	}
+	if complexity, ok := videoData["complexity"].(float64); ok {
+		video.SetComplexity(complexity)
+	}
+	if variant, _ := videoData["variant"].(bool); variant {
+		video.MarkVariant()
+	}
+	if ladderData, ok := videoData["ladder"].([]interface{}); ok {
+		ladder := make([]valueobjects.LadderRung, 0, len(ladderData))
+		for _, raw := range ladderData {
+			rungData, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := rungData["name"].(string)
+			width, _ := rungData["width"].(float64)
+			height, _ := rungData["height"].(float64)
+			videoBitrate, _ := rungData["videoBitrate"].(float64)
+			audioBitrate, _ := rungData["audioBitrate"].(float64)
+			baseVideoBitrate, _ := rungData["baseVideoBitrate"].(float64)
+			rung, err := valueobjects.NewLadderRung(name, int(width), int(height), int(videoBitrate), int(audioBitrate), int(baseVideoBitrate))
+			if err != nil {
+				log.WithError(err).Error("Failed to reconstruct ladder rung from data")
+				continue
+			}
+			ladder = append(ladder, *rung)
+		}
+		video.SetLadder(ladder)
+	}
+	if rejectionsData, ok := videoData["inputRejections"].([]interface{}); ok {
+		rejections := make([]valueobjects.InputRejection, 0, len(rejectionsData))
+		for _, raw := range rejectionsData {
+			rejectionData, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			code, _ := rejectionData["code"].(string)
+			message, _ := rejectionData["message"].(string)
+			rejection, err := valueobjects.NewInputRejection(code, message)
+			if err != nil {
+				log.WithError(err).Error("Failed to reconstruct input rejection from data")
+				continue
+			}
+			rejections = append(rejections, *rejection)
+		}
+		video.SetInputRejections(rejections)
+	}
 	return video, nil
 }
 
+// keywords joins the genre, genres and tags into the text the full-text
+// index reads, since it only indexes string properties.
+func (c *AssetConverter) keywords(a *entity.Asset) string {
+	var words []string
+	if a.Genre() != nil {
+		words = append(words, a.Genre().Value())
+	}
+	words = append(words, c.genresToStringSlice(a.Genres())...)
+	words = append(words, c.tagsToStringSlice(a.Tags())...)
+	return strings.Join(words, " ")
+}
+
 func (c *AssetConverter) genresToStringSlice(genres *valueobjects.Genres) []string {
 	if genres == nil {
 		return []string{}
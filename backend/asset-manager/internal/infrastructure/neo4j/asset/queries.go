@@ -12,14 +12,24 @@ func buildAssetSaveQuery() string {
 		a.genre = $genre,
 		a.genres = $genres,
 		a.tags = $tags,
+		a.keywords = $keywords,
+		a.releaseYear = $releaseYear,
 		a.createdAt = $createdAt,
 		a.updatedAt = $updatedAt,
 		a.ownerId = $ownerId,
 		a.parentId = $parentId,
+		a.seasonNumber = $seasonNumber,
+		a.episodeNumber = $episodeNumber,
 		a.videos = $videos,
 		a.images = $images,
+		a.subtitles = $subtitles,
+		a.liveStream = $liveStream,
 		a.credits = $credits,
 		a.publishRule = $publishRule,
+		a.publishAt = CASE WHEN $publishAt IS NULL THEN null ELSE datetime($publishAt) END,
+		a.unpublishAt = CASE WHEN $unpublishAt IS NULL THEN null ELSE datetime($unpublishAt) END,
+		a.status = $status,
+		a.statusHistory = $statusHistory,
 		a.metadata = $metadata
     ON MATCH SET
         a.version = CASE WHEN $expectedVersion IS NULL THEN coalesce(a.version, 0) + 1 ELSE CASE WHEN a.version = $expectedVersion THEN a.version + 1 ELSE a.version END END,
@@ -30,13 +40,23 @@ func buildAssetSaveQuery() string {
 		a.genre = $genre,
 		a.genres = $genres,
 		a.tags = $tags,
+		a.keywords = $keywords,
+		a.releaseYear = $releaseYear,
 		a.updatedAt = $updatedAt,
 		a.ownerId = $ownerId,
 		a.parentId = $parentId,
+		a.seasonNumber = $seasonNumber,
+		a.episodeNumber = $episodeNumber,
 		a.videos = $videos,
 		a.images = $images,
+		a.subtitles = $subtitles,
+		a.liveStream = $liveStream,
 		a.credits = $credits,
 		a.publishRule = $publishRule,
+		a.publishAt = CASE WHEN $publishAt IS NULL THEN null ELSE datetime($publishAt) END,
+		a.unpublishAt = CASE WHEN $unpublishAt IS NULL THEN null ELSE datetime($unpublishAt) END,
+		a.status = $status,
+		a.statusHistory = $statusHistory,
 		a.metadata = $metadata
 	`
 }
@@ -72,19 +92,37 @@ func buildAssetListQuery() string {
 	`
 }
 
-// TODO: Makes this more efficient by using a full-text search index on the title and slug properties.
-func buildAssetSearchQuery() string {
+// buildAssetDueQuery finds assets in $status whose publish rule date in
+// $field has passed. The oldest come first so a backlog drains in order; the
+// ID breaks ties so pages do not overlap.
+func buildAssetDueQuery(field string) string {
 	return `
-	MATCH (a:Asset)
-	WHERE toLower(a.title) CONTAINS toLower($query)
-	   OR toLower(a.slug) CONTAINS toLower($query)
-	RETURN a
-	ORDER BY a.createdAt DESC
+	MATCH (a:Asset {status: $status})
+	WHERE a.` + field + ` <= datetime($now)
+	RETURN a.id AS id
+	ORDER BY a.` + field + ` ASC, a.id ASC
 	SKIP $offset
 	LIMIT $limit
 	`
 }
 
+// buildAssetStatusUpdateQuery writes a status change only while the stored
+// status is still $expected. Touching _lock takes the node's write lock
+// before the status is read, so a concurrent change is seen rather than
+// overwritten. Assets saved before the lifecycle existed have no status
+// and count as drafts.
+func buildAssetStatusUpdateQuery() string {
+	return `
+	MATCH (a:Asset {id: $id})
+	SET a._lock = true
+	REMOVE a._lock
+	WITH a
+	WHERE coalesce(a.status, 'draft') = $expected
+	SET a.status = $status, a.statusHistory = $statusHistory, a.updatedAt = $updatedAt
+	RETURN a.id AS id
+	`
+}
+
 func buildParentRelationshipQuery() string {
 	return `
 	MATCH (child:Asset {id: $childID})
@@ -2,6 +2,7 @@ package asset
 
 import (
 	"context"
+	"time"
 
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/entity"
@@ -103,6 +104,73 @@ func (r *Repository) Update(ctx context.Context, a *entity.Asset) error {
 	return nil
 }
 
+// UpdateStatus saves the status and status history of a only if the stored
+// status is still expected. Concurrent transitions of one asset each read
+// the same status, and only the first write matches it.
+func (r *Repository) UpdateStatus(ctx context.Context, a *entity.Asset, expected valueobjects.AssetStatus) (bool, error) {
+	log := r.logger.WithContext(ctx)
+
+	session := r.driver.NewSession(neo4j.SessionConfig{})
+	defer session.Close()
+
+	params := r.converter.AssetToParams(a)
+	result, err := session.Run(buildAssetStatusUpdateQuery(), map[string]interface{}{
+		"id":            a.ID().Value(),
+		"expected":      expected.Value(),
+		"status":        params["status"],
+		"statusHistory": params["statusHistory"],
+		"updatedAt":     params["updatedAt"],
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to update asset status", "asset_id", a.ID().Value())
+		return false, pkgerrors.NewInternalError("database operation failed: unable to update asset status", err)
+	}
+	updated := result.Next()
+	return updated, result.Err()
+}
+
+// FindDueForPublish returns scheduled assets whose publish date has passed.
+func (r *Repository) FindDueForPublish(ctx context.Context, now time.Time, offset, limit int) ([]valueobjects.AssetID, error) {
+	return r.findDue(ctx, "publishAt", valueobjects.AssetStatusScheduled, now, offset, limit)
+}
+
+// FindDueForUnpublish returns published assets whose unpublish date has
+// passed.
+func (r *Repository) FindDueForUnpublish(ctx context.Context, now time.Time, offset, limit int) ([]valueobjects.AssetID, error) {
+	return r.findDue(ctx, "unpublishAt", valueobjects.AssetStatusPublished, now, offset, limit)
+}
+
+func (r *Repository) findDue(ctx context.Context, field string, status valueobjects.AssetStatus, now time.Time, offset, limit int) ([]valueobjects.AssetID, error) {
+	log := r.logger.WithContext(ctx)
+
+	session := r.driver.NewSession(neo4j.SessionConfig{})
+	defer session.Close()
+
+	result, err := session.Run(buildAssetDueQuery(field), map[string]interface{}{
+		"status": status.Value(),
+		"now":    now.UTC().Format(time.RFC3339),
+		"offset": offset,
+		"limit":  limit,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to find due assets", "status", status.Value(), "field", field)
+		return nil, pkgerrors.NewInternalError("database operation failed: unable to find due assets", err)
+	}
+
+	var ids []valueobjects.AssetID
+	for result.Next() {
+		value, _ := result.Record().Get("id")
+		idStr, _ := value.(string)
+		id, err := valueobjects.NewAssetID(idStr)
+		if err != nil {
+			log.WithError(err).Error("Skipping asset with invalid ID", "asset_id", idStr)
+			continue
+		}
+		ids = append(ids, *id)
+	}
+	return ids, result.Err()
+}
+
 func (r *Repository) Delete(ctx context.Context, id valueobjects.AssetID) error {
 	log := r.logger.WithContext(ctx)
 
@@ -159,42 +227,26 @@ func (r *Repository) List(ctx context.Context, limit int, lastKey map[string]int
 	}, nil
 }
 
+// Search returns the best matches for query from the full-text index.
 func (r *Repository) Search(ctx context.Context, query string, limit int, lastKey map[string]interface{}) (*entity.AssetPage, error) {
-	log := r.logger.WithContext(ctx)
-
-	session := r.driver.NewSession(neo4j.SessionConfig{})
-	defer session.Close()
-
-	queryStr := buildAssetSearchQuery()
-	params := map[string]interface{}{
-		"query":  query,
-		"limit":  limit,
-		"offset": 0,
-	}
+	criteria := entity.SearchCriteria{Query: query, Limit: limit}
 	if off, ok := lastKey["offset"].(int); ok {
-		params["offset"] = off
+		criteria.Offset = off
 	}
 
-	result, err := session.Run(queryStr, params)
+	result, err := r.FullTextSearch(ctx, criteria)
 	if err != nil {
-		log.WithError(err).Error("Failed to search assets in Neo4j", "query", query)
-		return nil, pkgerrors.NewInternalError("database operation failed: unable to search assets", err)
+		return nil, err
 	}
 
-	var assets []*entity.Asset
-	for result.Next() {
-		record := result.Record()
-		asset, err := r.converter.RecordToAsset(record)
-		if err != nil {
-			log.WithError(err).Error("Failed to convert Neo4j record to asset")
-			continue
-		}
-		assets = append(assets, asset)
+	assets := make([]*entity.Asset, len(result.Hits))
+	for i, hit := range result.Hits {
+		assets[i] = hit.Asset
 	}
 
 	return &entity.AssetPage{
 		Items:   assets,
-		HasMore: len(assets) == limit,
+		HasMore: criteria.Offset+len(assets) < result.Total,
 		LastKey: lastKey,
 	}, nil
 }
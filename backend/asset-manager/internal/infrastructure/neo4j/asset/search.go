@@ -0,0 +1,282 @@
+package asset
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/entity"
+	personvo "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/valueobjects"
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+)
+
+const (
+	assetSearchIndex  = "assetSearch"
+	personSearchIndex = "personSearch"
+
+	// maxSearchWords bounds the Lucene query built from user input.
+	maxSearchWords = 10
+
+	// creditWeight scales the score an asset gets from a person credited on
+	// it, so a title match outranks a cast match of the same strength.
+	creditWeight = 0.5
+)
+
+var searchIndexQueries = []string{
+	`CREATE FULLTEXT INDEX ` + assetSearchIndex + ` IF NOT EXISTS
+	FOR (a:Asset) ON EACH [a.title, a.description, a.keywords]`,
+	`CREATE FULLTEXT INDEX ` + personSearchIndex + ` IF NOT EXISTS
+	FOR (p:Person) ON EACH [p.name]`,
+}
+
+// backfillSearchFieldsQuery fills the derived search properties on assets
+// saved before they existed.
+const backfillSearchFieldsQuery = `
+MATCH (a:Asset)
+WHERE a.keywords IS NULL OR a.releaseYear IS NULL
+SET a.keywords = reduce(s = coalesce(a.genre, ''), w IN coalesce(a.genres, []) + coalesce(a.tags, []) | s + ' ' + w),
+    a.releaseYear = coalesce(a.publishAt.year, datetime(a.createdAt).year)
+`
+
+// EnsureSearchIndexes creates the full-text indexes used by FullTextSearch
+// and backfills the properties they read. It is safe to run on every start.
+func (r *Repository) EnsureSearchIndexes(ctx context.Context) error {
+	log := r.logger.WithContext(ctx)
+
+	session := r.driver.NewSession(neo4j.SessionConfig{})
+	defer session.Close()
+
+	for _, query := range searchIndexQueries {
+		if _, err := session.Run(query, nil); err != nil {
+			log.WithError(err).Error("Failed to create search index")
+			return pkgerrors.NewInternalError("failed to create search index", err)
+		}
+	}
+	if _, err := session.Run(backfillSearchFieldsQuery, nil); err != nil {
+		log.WithError(err).Error("Failed to backfill search fields")
+		return pkgerrors.NewInternalError("failed to backfill search fields", err)
+	}
+	return nil
+}
+
+// FullTextSearch ranks assets by how well their title, description,
+// genres, tags and credited people match the query.
+func (r *Repository) FullTextSearch(ctx context.Context, criteria entity.SearchCriteria) (*entity.SearchResult, error) {
+	log := r.logger.WithContext(ctx)
+
+	lucene := buildLuceneQuery(criteria.Query)
+	if lucene == "" {
+		return &entity.SearchResult{}, nil
+	}
+
+	session := r.driver.NewSession(neo4j.SessionConfig{})
+	defer session.Close()
+
+	params := map[string]interface{}{
+		"assetQuery":   "title:(" + lucene + ")^2 " + lucene,
+		"personQuery":  lucene,
+		"creditWeight": creditWeight,
+		"type":         criteria.Type,
+		"genre":        criteria.Genre,
+		"year":         criteria.Year,
+		"status":       criteria.Status,
+		"limit":        criteria.Limit,
+		"offset":       criteria.Offset,
+	}
+
+	result, err := session.Run(buildSearchHitsQuery(), params)
+	if err != nil {
+		log.WithError(err).Error("Failed to search assets in Neo4j", "query", criteria.Query)
+		return nil, pkgerrors.NewInternalError("database operation failed: unable to search assets", err)
+	}
+
+	out := &entity.SearchResult{}
+	for result.Next() {
+		record := result.Record()
+		a, err := r.converter.RecordToAsset(record)
+		if err != nil {
+			log.WithError(err).Error("Failed to convert Neo4j record to asset")
+			continue
+		}
+		score, _ := record.Get("score")
+		scoreFloat, _ := score.(float64)
+		out.Hits = append(out.Hits, entity.SearchHit{Asset: a, Score: scoreFloat})
+	}
+	if err := result.Err(); err != nil {
+		return nil, pkgerrors.NewInternalError("database operation failed: unable to search assets", err)
+	}
+
+	result, err = session.Run(buildSearchFacetsQuery(), params)
+	if err != nil {
+		log.WithError(err).Error("Failed to count search facets in Neo4j", "query", criteria.Query)
+		return nil, pkgerrors.NewInternalError("database operation failed: unable to count search facets", err)
+	}
+	record, err := result.Single()
+	if err != nil {
+		return nil, pkgerrors.NewInternalError("database operation failed: unable to count search facets", err)
+	}
+	total, _ := record.Get("total")
+	totalInt, _ := total.(int64)
+	out.Total = int(totalInt)
+	out.Facets = entity.SearchFacets{
+		Types:  facetCounts(record, "types"),
+		Genres: facetCounts(record, "genres"),
+		Years:  facetCounts(record, "years"),
+	}
+
+	return out, nil
+}
+
+// buildLuceneQuery turns free text into a query for the full-text indexes.
+// Each word matches exactly with a boost, as a prefix so partly typed words
+// find results, and from four letters on within one edit to forgive typos.
+// Words are lower-cased because prefix and fuzzy terms skip the analyzer,
+// and punctuation is dropped, which keeps Lucene syntax out of user input.
+func buildLuceneQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > maxSearchWords {
+		words = words[:maxSearchWords]
+	}
+
+	clauses := make([]string, 0, len(words))
+	for _, w := range words {
+		clause := w + "^3 OR " + w + "*"
+		if utf8.RuneCountInString(w) >= 4 {
+			clause += " OR " + w + "~1"
+		}
+		clauses = append(clauses, "("+clause+")")
+	}
+	return strings.Join(clauses, " ")
+}
+
+// searchMatches yields each matching asset once with its summed score. An
+// asset matching both on its own text and through a credited person ranks
+// above one matching on either alone.
+func searchMatches() string {
+	return `
+	CALL {
+		CALL db.index.fulltext.queryNodes('` + assetSearchIndex + `', $assetQuery) YIELD node, score
+		RETURN node AS a, score
+		UNION ALL
+		CALL db.index.fulltext.queryNodes('` + personSearchIndex + `', $personQuery) YIELD node, score
+		MATCH (node)-[:` + strings.Join(personvo.CreditRelationshipTypes(), "|") + `]->(a:Asset)
+		RETURN a, score * $creditWeight AS score
+	}
+	WITH a, sum(score) AS score
+	`
+}
+
+// Search filter dimensions. Facets for one dimension are counted with the
+// filters of the others applied, so picking a genre still shows every genre.
+const (
+	filterType   = "type"
+	filterGenre  = "genre"
+	filterYear   = "year"
+	filterStatus = "status"
+)
+
+// searchFilter matches v against every search filter except the skipped
+// dimensions.
+func searchFilter(v string, skip ...string) string {
+	clauses := []struct{ dimension, clause string }{
+		{filterType, `($type IS NULL OR ` + v + `.type = $type)`},
+		{filterGenre, `($genre IS NULL OR ` + v + `.genre = $genre OR $genre IN coalesce(` + v + `.genres, []))`},
+		{filterYear, `($year IS NULL OR ` + v + `.releaseYear = $year)`},
+		{filterStatus, `($status IS NULL OR ` + v + `.status = $status)`},
+	}
+	parts := make([]string, 0, len(clauses))
+	for _, c := range clauses {
+		if !slices.Contains(skip, c.dimension) {
+			parts = append(parts, c.clause)
+		}
+	}
+	return strings.Join(parts, "\n\t  AND ")
+}
+
+func buildSearchHitsQuery() string {
+	return searchMatches() + `
+	WHERE ` + searchFilter("a") + `
+	RETURN a, score
+	ORDER BY score DESC, a.createdAt DESC
+	SKIP $offset
+	LIMIT $limit
+	`
+}
+
+// buildSearchFacetsQuery counts the filtered total and the facets. Every
+// count applies the status filter; each facet also applies the filters of
+// the other dimensions but not its own.
+func buildSearchFacetsQuery() string {
+	return searchMatches() + `
+	WHERE ($status IS NULL OR a.status = $status)
+	WITH collect(a) AS hits
+	CALL {
+		WITH hits
+		UNWIND hits AS h
+		WITH h
+		WHERE ` + searchFilter("h", filterType) + `
+		WITH h.type AS value, count(*) AS n
+		WHERE coalesce(value, '') <> ''
+		RETURN collect({value: value, count: n}) AS types
+	}
+	CALL {
+		WITH hits
+		UNWIND hits AS h
+		WITH h
+		WHERE ` + searchFilter("h", filterGenre) + `
+		UNWIND coalesce(h.genres, []) + CASE WHEN coalesce(h.genre, '') = '' THEN [] ELSE [h.genre] END AS value
+		WITH value, count(DISTINCT h) AS n
+		RETURN collect({value: value, count: n}) AS genres
+	}
+	CALL {
+		WITH hits
+		UNWIND hits AS h
+		WITH h
+		WHERE ` + searchFilter("h", filterYear) + `
+		WITH h.releaseYear AS value, count(*) AS n
+		WHERE value IS NOT NULL
+		RETURN collect({value: toString(value), count: n}) AS years
+	}
+	RETURN size([h IN hits WHERE ` + searchFilter("h") + `]) AS total, types, genres, years
+	`
+}
+
+// facetCounts reads a list of {value, count} maps, most common first and
+// then by value.
+func facetCounts(record *neo4j.Record, key string) []entity.FacetCount {
+	raw, _ := record.Get(key)
+	items, _ := raw.([]interface{})
+	counts := make([]entity.FacetCount, 0, len(items))
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var value string
+		switch v := m["value"].(type) {
+		case string:
+			value = v
+		case int64:
+			value = strconv.FormatInt(v, 10)
+		}
+		n, _ := m["count"].(int64)
+		if value == "" {
+			continue
+		}
+		counts = append(counts, entity.FacetCount{Value: value, Count: int(n)})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Value < counts[j].Value
+	})
+	return counts
+}
@@ -2,6 +2,7 @@ package neo4j
 
 import (
 	"context"
+	"time"
 
 	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/entity"
 	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
@@ -32,6 +33,22 @@ func (a *AssetRepositoryAdapter) Update(ctx context.Context, asset *entity.Asset
 	return a.repo.Update(ctx, asset)
 }
 
+func (a *AssetRepositoryAdapter) UpdateStatus(ctx context.Context, asset *entity.Asset, expected valueobjects.AssetStatus) (bool, error) {
+	return a.repo.UpdateStatus(ctx, asset, expected)
+}
+
+func (a *AssetRepositoryAdapter) FullTextSearch(ctx context.Context, criteria entity.SearchCriteria) (*entity.SearchResult, error) {
+	return a.repo.FullTextSearch(ctx, criteria)
+}
+
+func (a *AssetRepositoryAdapter) FindDueForPublish(ctx context.Context, now time.Time, offset, limit int) ([]valueobjects.AssetID, error) {
+	return a.repo.FindDueForPublish(ctx, now, offset, limit)
+}
+
+func (a *AssetRepositoryAdapter) FindDueForUnpublish(ctx context.Context, now time.Time, offset, limit int) ([]valueobjects.AssetID, error) {
+	return a.repo.FindDueForUnpublish(ctx, now, offset, limit)
+}
+
 func (a *AssetRepositoryAdapter) Delete(ctx context.Context, id valueobjects.AssetID) error {
 	return a.repo.Delete(ctx, id)
 }
@@ -0,0 +1,84 @@
+package lease
+
+import (
+	"context"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+)
+
+// constraintQuery makes MERGE on a lease name lock the name, so two
+// instances racing to create the same lease end up sharing one node.
+const constraintQuery = `CREATE CONSTRAINT lease_name IF NOT EXISTS FOR (l:Lease) REQUIRE l.name IS UNIQUE`
+
+// acquireQuery takes or renews the lease in one statement. Setting
+// touchedAt first takes the node's write lock, so two instances cannot both
+// read an expired lease and claim it.
+const acquireQuery = `
+MERGE (l:Lease {name: $name})
+ON CREATE SET l.owner = $owner, l.expiresAt = 0
+SET l.touchedAt = $now
+WITH l, (l.owner = $owner OR l.expiresAt < $now) AS acquired
+SET l.owner = CASE WHEN acquired THEN $owner ELSE l.owner END,
+    l.expiresAt = CASE WHEN acquired THEN $expiresAt ELSE l.expiresAt END
+RETURN acquired
+`
+
+const releaseQuery = `
+MATCH (l:Lease {name: $name, owner: $owner})
+SET l.expiresAt = 0
+`
+
+// Lease is a named, time-limited lock held in Neo4j. The holder renews it by
+// acquiring again before it expires; if the holder dies, another instance
+// takes over once the TTL has passed.
+type Lease struct {
+	driver neo4j.Driver
+	name   string
+	owner  string
+	ttl    time.Duration
+}
+
+// EnsureConstraint has to run before any lease is acquired; without it two
+// instances can each create a node on a fresh database and both hold the
+// lease.
+func EnsureConstraint(ctx context.Context, driver neo4j.Driver) error {
+	session := driver.NewSession(neo4j.SessionConfig{})
+	defer session.Close()
+	_, err := session.Run(constraintQuery, nil)
+	return err
+}
+
+func New(driver neo4j.Driver, name, owner string, ttl time.Duration) *Lease {
+	return &Lease{driver: driver, name: name, owner: owner, ttl: ttl}
+}
+
+// Acquire reports whether this instance holds the lease for the next TTL.
+func (l *Lease) Acquire(ctx context.Context) (bool, error) {
+	session := l.driver.NewSession(neo4j.SessionConfig{})
+	defer session.Close()
+	now := time.Now().UTC()
+	res, err := session.Run(acquireQuery, map[string]interface{}{
+		"name":      l.name,
+		"owner":     l.owner,
+		"now":       now.UnixMilli(),
+		"expiresAt": now.Add(l.ttl).UnixMilli(),
+	})
+	if err != nil {
+		return false, err
+	}
+	if !res.Next() {
+		return false, res.Err()
+	}
+	acquired, _ := res.Record().Values[0].(bool)
+	return acquired, nil
+}
+
+// Release lets another instance take the lease without waiting for it to
+// expire.
+func (l *Lease) Release(ctx context.Context) error {
+	session := l.driver.NewSession(neo4j.SessionConfig{})
+	defer session.Close()
+	_, err := session.Run(releaseQuery, map[string]interface{}{"name": l.name, "owner": l.owner})
+	return err
+}
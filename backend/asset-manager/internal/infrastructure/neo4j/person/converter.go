@@ -0,0 +1,131 @@
+package person
+
+import (
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/valueobjects"
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+)
+
+func PersonToParams(p *entity.Person) map[string]interface{} {
+	var externalSource, externalID interface{}
+	if p.ExternalID() != nil {
+		externalSource = p.ExternalID().Source()
+		externalID = p.ExternalID().Value()
+	}
+	return map[string]interface{}{
+		"id":             p.ID().Value(),
+		"name":           p.Name().Value(),
+		"nameKey":        p.Name().Key(),
+		"externalSource": externalSource,
+		"externalId":     externalID,
+		"biography":      stringParam(p.Biography()),
+		"photoUrl":       stringParam(p.PhotoURL()),
+		"createdAt":      p.CreatedAt().Value().Format(time.RFC3339),
+		"updatedAt":      p.UpdatedAt().Value().Format(time.RFC3339),
+	}
+}
+
+func RecordToPerson(record *neo4j.Record) (*entity.Person, error) {
+	node, ok := record.Get("p")
+	if !ok {
+		return nil, pkgerrors.NewInternalError("person node not found in record", nil)
+	}
+	personNode, ok := node.(neo4j.Node)
+	if !ok {
+		return nil, pkgerrors.NewInternalError("person is not a node", nil)
+	}
+	props := personNode.Props
+
+	idStr, _ := props["id"].(string)
+	id, err := valueobjects.NewPersonID(idStr)
+	if err != nil {
+		return nil, pkgerrors.NewInternalError("invalid person id", err)
+	}
+
+	nameStr, _ := props["name"].(string)
+	name, err := valueobjects.NewPersonName(nameStr)
+	if err != nil {
+		return nil, pkgerrors.NewInternalError("invalid person name", err)
+	}
+
+	var externalID *valueobjects.ExternalID
+	source, _ := props["externalSource"].(string)
+	value, _ := props["externalId"].(string)
+	if source != "" && value != "" {
+		externalID, err = valueobjects.NewExternalID(source, value)
+		if err != nil {
+			return nil, pkgerrors.NewInternalError("invalid person external id", err)
+		}
+	}
+
+	createdAt, err := parseTime(props, "createdAt")
+	if err != nil {
+		return nil, err
+	}
+	updatedAt, err := parseTime(props, "updatedAt")
+	if err != nil {
+		return nil, err
+	}
+
+	p := entity.ReconstructPerson(
+		*id,
+		*name,
+		externalID,
+		optionalString(props["biography"]),
+		optionalString(props["photoUrl"]),
+		valueobjects.NewCreatedAt(createdAt),
+		valueobjects.NewUpdatedAt(updatedAt),
+	)
+	if v, ok := props["version"].(int64); ok {
+		p.SetVersion(int(v))
+	}
+	return p, nil
+}
+
+// recordToCredit reads a credit returned as role, character and order
+// columns.
+func recordToCredit(record *neo4j.Record, personID valueobjects.PersonID, assetID string) (*entity.Credit, error) {
+	relType, _ := record.Get("role")
+	relTypeStr, _ := relType.(string)
+	role, err := valueobjects.CreditRoleFromRelationship(relTypeStr)
+	if err != nil {
+		return nil, pkgerrors.NewInternalError("invalid credit role", err)
+	}
+
+	character, _ := record.Get("character")
+	order, _ := record.Get("order")
+	orderInt, _ := order.(int64)
+
+	credit, err := entity.NewCredit(personID, assetID, *role, optionalString(character), int(orderInt))
+	if err != nil {
+		return nil, pkgerrors.NewInternalError("invalid credit", err)
+	}
+	return credit, nil
+}
+
+func parseTime(props map[string]interface{}, key string) (time.Time, error) {
+	value, _ := props[key].(string)
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, pkgerrors.NewInternalError("failed to parse person "+key+": "+value, err)
+	}
+	return t, nil
+}
+
+func stringParam(value *string) interface{} {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
+
+func optionalString(value interface{}) *string {
+	s, ok := value.(string)
+	if !ok || s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,141 @@
+package person
+
+import (
+	"strings"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/valueobjects"
+)
+
+const (
+	// externalIDConstraintQuery keeps two people from sharing an external
+	// ID. People without one are not constrained.
+	externalIDConstraintQuery = `
+	CREATE CONSTRAINT person_external_id IF NOT EXISTS
+	FOR (p:Person) REQUIRE (p.externalSource, p.externalId) IS UNIQUE
+	`
+
+	// createByExternalIDQuery writes a new person unless one with the same
+	// external ID exists, in which case that one is returned untouched.
+	createByExternalIDQuery = `
+	MERGE (p:Person {externalSource: $externalSource, externalId: $externalId})
+	ON CREATE SET p.id = $id,
+	    p.version = 0,
+	    p.name = $name,
+	    p.nameKey = $nameKey,
+	    p.biography = $biography,
+	    p.photoUrl = $photoUrl,
+	    p.createdAt = $createdAt,
+	    p.updatedAt = $updatedAt
+	RETURN p
+	`
+
+	saveQuery = `
+	MERGE (p:Person {id: $id})
+	SET p.version = coalesce(p.version, -1) + 1,
+	    p.name = $name,
+	    p.nameKey = $nameKey,
+	    p.externalSource = $externalSource,
+	    p.externalId = $externalId,
+	    p.biography = $biography,
+	    p.photoUrl = $photoUrl,
+	    p.createdAt = $createdAt,
+	    p.updatedAt = $updatedAt
+	RETURN p
+	`
+
+	findByIDQuery = `
+	MATCH (p:Person {id: $id})
+	RETURN p
+	`
+
+	findByExternalIDQuery = `
+	MATCH (p:Person {externalSource: $source, externalId: $value})
+	RETURN p
+	ORDER BY p.createdAt
+	LIMIT 1
+	`
+
+	findByNameKeyQuery = `
+	MATCH (p:Person {nameKey: $nameKey})
+	RETURN p
+	ORDER BY p.createdAt
+	`
+
+	// deleteQuery drops the person's credits with the node.
+	deleteQuery = `
+	MATCH (p:Person {id: $id})
+	DETACH DELETE p
+	`
+
+	listQuery = `
+	MATCH (p:Person)
+	RETURN p
+	ORDER BY p.name
+	SKIP $offset
+	LIMIT $limit
+	`
+
+	searchQuery = `
+	MATCH (p:Person)
+	WHERE p.nameKey CONTAINS $query
+	RETURN p
+	ORDER BY p.name
+	SKIP $offset
+	LIMIT $limit
+	`
+)
+
+// creditTypes is the relationship pattern matching a credit in any role.
+func creditTypes() string {
+	return strings.Join(valueobjects.CreditRelationshipTypes(), "|")
+}
+
+// Relationship types cannot be query parameters, so the role's type is
+// written into the query. It always comes from the fixed set in
+// valueobjects.CreditRole.
+func buildAddCreditQuery(role valueobjects.CreditRole) string {
+	return `
+	MATCH (p:Person {id: $personId}), (a:Asset {id: $assetId})
+	MERGE (p)-[r:` + role.RelationshipType() + `]->(a)
+	SET r.character = $character, r.order = $order
+	RETURN type(r) AS role
+	`
+}
+
+func buildRemoveCreditQuery(role valueobjects.CreditRole) string {
+	return `
+	MATCH (:Person {id: $personId})-[r:` + role.RelationshipType() + `]->(:Asset {id: $assetId})
+	DELETE r
+	`
+}
+
+func buildCreditsByAssetQuery() string {
+	return `
+	MATCH (p:Person)-[r:` + creditTypes() + `]->(:Asset {id: $assetId})
+	RETURN p, type(r) AS role, r.character AS character, r.order AS order
+	ORDER BY r.order, p.name
+	`
+}
+
+func buildCreditsByPersonQuery(role *valueobjects.CreditRole) string {
+	types := creditTypes()
+	if role != nil {
+		types = role.RelationshipType()
+	}
+	return `
+	MATCH (:Person {id: $personId})-[r:` + types + `]->(a:Asset)
+	RETURN a.id AS assetId, type(r) AS role, r.character AS character, r.order AS order
+	ORDER BY a.title, a.id
+	`
+}
+
+func buildCollaboratorsQuery() string {
+	types := creditTypes()
+	return `
+	MATCH (p:Person {id: $personId})-[:` + types + `]->(a:Asset)<-[:` + types + `]-(other:Person)
+	WHERE other.id <> p.id
+	RETURN other AS p, count(DISTINCT a) AS shared
+	ORDER BY shared DESC, other.name
+	LIMIT $limit
+	`
+}
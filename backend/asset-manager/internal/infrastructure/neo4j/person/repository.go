@@ -0,0 +1,318 @@
+package person
+
+import (
+	"context"
+	"sort"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/valueobjects"
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
+)
+
+// Repository stores people as Person nodes and their credits as
+// relationships to Asset nodes. The find methods return nil without an
+// error when nothing matches.
+type Repository struct {
+	driver neo4j.Driver
+	logger *logger.Logger
+}
+
+func NewRepository(driver neo4j.Driver) *Repository {
+	return &Repository{
+		driver: driver,
+		logger: logger.WithService("neo4j-person-repository"),
+	}
+}
+
+// EnsureConstraint makes external IDs unique among people. Run it at start,
+// before any person is created; it is safe to run on every start.
+func (r *Repository) EnsureConstraint(ctx context.Context) error {
+	log := r.logger.WithContext(ctx)
+
+	session := r.driver.NewSession(neo4j.SessionConfig{})
+	defer session.Close()
+
+	if _, err := session.Run(externalIDConstraintQuery, nil); err != nil {
+		log.WithError(err).Error("Failed to create person external ID constraint")
+		return pkgerrors.NewInternalError("failed to create person external ID constraint", err)
+	}
+	return nil
+}
+
+// Create merges on the external ID when the person has one, so two
+// concurrent imports of the same person end up with a single node.
+func (r *Repository) Create(ctx context.Context, p *entity.Person) (*entity.Person, error) {
+	if p.ExternalID() == nil {
+		if err := r.Save(ctx, p); err != nil {
+			return nil, err
+		}
+		return p, nil
+	}
+
+	people, err := r.findPeople(ctx, createByExternalIDQuery, PersonToParams(p))
+	if err != nil {
+		return nil, err
+	}
+	if len(people) == 0 {
+		return nil, pkgerrors.NewInternalError("database operation failed: unable to save person", nil)
+	}
+	return people[0], nil
+}
+
+func (r *Repository) Save(ctx context.Context, p *entity.Person) error {
+	log := r.logger.WithContext(ctx)
+
+	session := r.driver.NewSession(neo4j.SessionConfig{})
+	defer session.Close()
+
+	if _, err := session.Run(saveQuery, PersonToParams(p)); err != nil {
+		log.WithError(err).Error("Failed to save person to Neo4j", "person_id", p.ID().Value())
+		return pkgerrors.NewInternalError("database operation failed: unable to save person", err)
+	}
+	return nil
+}
+
+func (r *Repository) Update(ctx context.Context, p *entity.Person) error {
+	return r.Save(ctx, p)
+}
+
+func (r *Repository) Delete(ctx context.Context, id valueobjects.PersonID) error {
+	log := r.logger.WithContext(ctx)
+
+	session := r.driver.NewSession(neo4j.SessionConfig{})
+	defer session.Close()
+
+	if _, err := session.Run(deleteQuery, map[string]interface{}{"id": id.Value()}); err != nil {
+		log.WithError(err).Error("Failed to delete person from Neo4j", "person_id", id.Value())
+		return pkgerrors.NewInternalError("failed to delete person", err)
+	}
+	return nil
+}
+
+func (r *Repository) FindByID(ctx context.Context, id valueobjects.PersonID) (*entity.Person, error) {
+	people, err := r.findPeople(ctx, findByIDQuery, map[string]interface{}{"id": id.Value()})
+	if err != nil || len(people) == 0 {
+		return nil, err
+	}
+	return people[0], nil
+}
+
+func (r *Repository) FindByExternalID(ctx context.Context, externalID valueobjects.ExternalID) (*entity.Person, error) {
+	people, err := r.findPeople(ctx, findByExternalIDQuery, map[string]interface{}{
+		"source": externalID.Source(),
+		"value":  externalID.Value(),
+	})
+	if err != nil || len(people) == 0 {
+		return nil, err
+	}
+	return people[0], nil
+}
+
+func (r *Repository) FindByNameKey(ctx context.Context, key string) ([]*entity.Person, error) {
+	return r.findPeople(ctx, findByNameKeyQuery, map[string]interface{}{"nameKey": key})
+}
+
+func (r *Repository) List(ctx context.Context, limit *int, offset *int) ([]*entity.Person, error) {
+	l, o := pageParams(limit, offset)
+	return r.findPeople(ctx, listQuery, map[string]interface{}{"limit": l, "offset": o})
+}
+
+// Search matches query against folded names, so it ignores case and
+// punctuation the same way duplicate detection does.
+func (r *Repository) Search(ctx context.Context, query string, limit *int, offset *int) ([]*entity.Person, error) {
+	name, err := valueobjects.NewPersonName(query)
+	if err != nil {
+		return nil, nil
+	}
+	l, o := pageParams(limit, offset)
+	return r.findPeople(ctx, searchQuery, map[string]interface{}{"query": name.Key(), "limit": l, "offset": o})
+}
+
+func (r *Repository) AddCredit(ctx context.Context, credit *entity.Credit) error {
+	log := r.logger.WithContext(ctx)
+
+	session := r.driver.NewSession(neo4j.SessionConfig{})
+	defer session.Close()
+
+	result, err := session.Run(buildAddCreditQuery(credit.Role()), map[string]interface{}{
+		"personId":  credit.PersonID().Value(),
+		"assetId":   credit.AssetID(),
+		"character": stringParam(credit.Character()),
+		"order":     credit.Order(),
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to add credit", "person_id", credit.PersonID().Value(), "asset_id", credit.AssetID())
+		return pkgerrors.NewInternalError("database operation failed: unable to add credit", err)
+	}
+	if !result.Next() {
+		if result.Err() != nil {
+			return pkgerrors.NewInternalError("database operation failed: unable to add credit", result.Err())
+		}
+		return pkgerrors.NewNotFoundError("person or asset not found", nil)
+	}
+	return nil
+}
+
+func (r *Repository) RemoveCredit(ctx context.Context, personID valueobjects.PersonID, assetID string, role valueobjects.CreditRole) error {
+	log := r.logger.WithContext(ctx)
+
+	session := r.driver.NewSession(neo4j.SessionConfig{})
+	defer session.Close()
+
+	_, err := session.Run(buildRemoveCreditQuery(role), map[string]interface{}{
+		"personId": personID.Value(),
+		"assetId":  assetID,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to remove credit", "person_id", personID.Value(), "asset_id", assetID)
+		return pkgerrors.NewInternalError("database operation failed: unable to remove credit", err)
+	}
+	return nil
+}
+
+func (r *Repository) FindCreditsByAsset(ctx context.Context, assetID string) ([]entity.CreditedPerson, error) {
+	log := r.logger.WithContext(ctx)
+
+	session := r.driver.NewSession(neo4j.SessionConfig{})
+	defer session.Close()
+
+	result, err := session.Run(buildCreditsByAssetQuery(), map[string]interface{}{"assetId": assetID})
+	if err != nil {
+		log.WithError(err).Error("Failed to find credits for asset", "asset_id", assetID)
+		return nil, pkgerrors.NewInternalError("database operation failed: unable to retrieve credits", err)
+	}
+
+	var credits []entity.CreditedPerson
+	for result.Next() {
+		record := result.Record()
+		p, err := RecordToPerson(record)
+		if err != nil {
+			log.WithError(err).Error("Failed to convert record to person")
+			continue
+		}
+		credit, err := recordToCredit(record, p.ID(), assetID)
+		if err != nil {
+			log.WithError(err).Error("Failed to convert record to credit")
+			continue
+		}
+		credits = append(credits, entity.CreditedPerson{Person: p, Credit: credit})
+	}
+	if err := result.Err(); err != nil {
+		return nil, pkgerrors.NewInternalError("database operation failed: unable to retrieve credits", err)
+	}
+
+	// The query orders by billing; grouping by role keeps that order within
+	// each role.
+	rank := make(map[string]int)
+	for i, t := range valueobjects.CreditRelationshipTypes() {
+		rank[t] = i
+	}
+	sort.SliceStable(credits, func(i, j int) bool {
+		return rank[credits[i].Credit.Role().RelationshipType()] < rank[credits[j].Credit.Role().RelationshipType()]
+	})
+	return credits, nil
+}
+
+func (r *Repository) FindCreditsByPerson(ctx context.Context, personID valueobjects.PersonID, role *valueobjects.CreditRole) ([]*entity.Credit, error) {
+	log := r.logger.WithContext(ctx)
+
+	session := r.driver.NewSession(neo4j.SessionConfig{})
+	defer session.Close()
+
+	result, err := session.Run(buildCreditsByPersonQuery(role), map[string]interface{}{"personId": personID.Value()})
+	if err != nil {
+		log.WithError(err).Error("Failed to find credits for person", "person_id", personID.Value())
+		return nil, pkgerrors.NewInternalError("database operation failed: unable to retrieve credits", err)
+	}
+
+	var credits []*entity.Credit
+	for result.Next() {
+		record := result.Record()
+		assetID, _ := record.Get("assetId")
+		assetIDStr, _ := assetID.(string)
+		credit, err := recordToCredit(record, personID, assetIDStr)
+		if err != nil {
+			log.WithError(err).Error("Failed to convert record to credit")
+			continue
+		}
+		credits = append(credits, credit)
+	}
+	if err := result.Err(); err != nil {
+		return nil, pkgerrors.NewInternalError("database operation failed: unable to retrieve credits", err)
+	}
+	return credits, nil
+}
+
+func (r *Repository) FindCollaborators(ctx context.Context, personID valueobjects.PersonID, limit int) ([]entity.Collaborator, error) {
+	log := r.logger.WithContext(ctx)
+
+	session := r.driver.NewSession(neo4j.SessionConfig{})
+	defer session.Close()
+
+	result, err := session.Run(buildCollaboratorsQuery(), map[string]interface{}{
+		"personId": personID.Value(),
+		"limit":    limit,
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to find collaborators", "person_id", personID.Value())
+		return nil, pkgerrors.NewInternalError("database operation failed: unable to retrieve collaborators", err)
+	}
+
+	var collaborators []entity.Collaborator
+	for result.Next() {
+		record := result.Record()
+		p, err := RecordToPerson(record)
+		if err != nil {
+			log.WithError(err).Error("Failed to convert record to person")
+			continue
+		}
+		shared, _ := record.Get("shared")
+		sharedInt, _ := shared.(int64)
+		collaborators = append(collaborators, entity.Collaborator{Person: p, SharedAssets: int(sharedInt)})
+	}
+	if err := result.Err(); err != nil {
+		return nil, pkgerrors.NewInternalError("database operation failed: unable to retrieve collaborators", err)
+	}
+	return collaborators, nil
+}
+
+func (r *Repository) findPeople(ctx context.Context, query string, params map[string]interface{}) ([]*entity.Person, error) {
+	log := r.logger.WithContext(ctx)
+
+	session := r.driver.NewSession(neo4j.SessionConfig{})
+	defer session.Close()
+
+	result, err := session.Run(query, params)
+	if err != nil {
+		log.WithError(err).Error("Failed to find people")
+		return nil, pkgerrors.NewInternalError("database operation failed: unable to retrieve people", err)
+	}
+
+	var people []*entity.Person
+	for result.Next() {
+		p, err := RecordToPerson(result.Record())
+		if err != nil {
+			log.WithError(err).Error("Failed to convert record to person")
+			continue
+		}
+		people = append(people, p)
+	}
+	if err := result.Err(); err != nil {
+		return nil, pkgerrors.NewInternalError("database operation failed: unable to retrieve people", err)
+	}
+	return people, nil
+}
+
+func pageParams(limit *int, offset *int) (int, int) {
+	l := 10
+	if limit != nil {
+		l = *limit
+	}
+	o := 0
+	if offset != nil {
+		o = *offset
+	}
+	return l, o
+}
@@ -7,6 +7,8 @@ import (
 
 	assetCommands "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/asset/commands"
 	assetAppQueries "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/asset/queries"
+	applifecycle "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/lifecycle"
+	applive "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/live"
 	transcode "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/transcode"
 	assetvo "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
 )
@@ -128,6 +130,100 @@ func (r *mutationResolver) DeleteImage(ctx context.Context, assetId string, imag
 	return domainAssetToGraphQL(a), nil
 }
 
+func (r *mutationResolver) AddSubtitle(ctx context.Context, input AddSubtitleInput) (*Asset, error) {
+	idVO, err := assetvo.NewAssetID(input.AssetID)
+	if err != nil {
+		return nil, err
+	}
+	s3Obj, err := assetvo.NewS3Object(input.Bucket, input.Key, input.URL)
+	if err != nil {
+		return nil, err
+	}
+	cmd := assetCommands.AddSubtitleCommand{
+		AssetID:         *idVO,
+		Language:        input.Language,
+		Kind:            string(input.Kind),
+		StorageLocation: *s3Obj,
+		ContentType:     input.ContentType,
+	}
+	if input.Label != nil {
+		cmd.Label = *input.Label
+	}
+	if input.IsDefault != nil {
+		cmd.IsDefault = *input.IsDefault
+	}
+	if err := r.assetCommandService.AddSubtitle(ctx, cmd); err != nil {
+		return nil, err
+	}
+	a, err := r.assetQueryService.GetAsset(ctx, assetAppQueries.GetAssetQuery{ID: input.AssetID})
+	if err != nil {
+		return nil, err
+	}
+	return domainAssetToGraphQL(a), nil
+}
+
+func (r *mutationResolver) DeleteSubtitle(ctx context.Context, assetId string, subtitleId string) (*Asset, error) {
+	idVO, err := assetvo.NewAssetID(assetId)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.assetCommandService.RemoveSubtitle(ctx, assetCommands.RemoveSubtitleCommand{AssetID: *idVO, SubtitleID: subtitleId}); err != nil {
+		return nil, err
+	}
+	a, err := r.assetQueryService.GetAsset(ctx, assetAppQueries.GetAssetQuery{ID: assetId})
+	if err != nil {
+		return nil, err
+	}
+	return domainAssetToGraphQL(a), nil
+}
+
+func (r *mutationResolver) SetDefaultAudioLanguage(ctx context.Context, assetId string, videoId string, language string) (*Video, error) {
+	idVO, err := assetvo.NewAssetID(assetId)
+	if err != nil {
+		return nil, err
+	}
+	cmd := assetCommands.SetDefaultAudioLanguageCommand{AssetID: *idVO, VideoID: videoId, Language: language}
+	if err := r.assetCommandService.SetDefaultAudioLanguage(ctx, cmd); err != nil {
+		return nil, err
+	}
+	a, err := r.assetQueryService.GetAsset(ctx, assetAppQueries.GetAssetQuery{ID: assetId})
+	if err != nil {
+		return nil, err
+	}
+	return domainVideoToGraphQL(a.Videos()[videoId]), nil
+}
+
+func (r *mutationResolver) SetVideoMarkers(ctx context.Context, assetId string, videoId string, markers []*MarkerInput) (*Video, error) {
+	idVO, err := assetvo.NewAssetID(assetId)
+	if err != nil {
+		return nil, err
+	}
+	cmd := assetCommands.SetVideoMarkersCommand{AssetID: *idVO, VideoID: videoId}
+	for _, in := range markers {
+		var end float64
+		if in.End != nil {
+			end = *in.End
+		}
+		var title string
+		if in.Title != nil {
+			title = *in.Title
+		}
+		marker, err := assetvo.NewMarker(string(in.Kind), in.Start, end, title)
+		if err != nil {
+			return nil, err
+		}
+		cmd.Markers = append(cmd.Markers, *marker)
+	}
+	if err := r.assetCommandService.SetVideoMarkers(ctx, cmd); err != nil {
+		return nil, err
+	}
+	a, err := r.assetQueryService.GetAsset(ctx, assetAppQueries.GetAssetQuery{ID: assetId})
+	if err != nil {
+		return nil, err
+	}
+	return domainVideoToGraphQL(a.Videos()[videoId]), nil
+}
+
 func (r *mutationResolver) UpdateAssetTitle(ctx context.Context, id string, title string) (*Asset, error) {
 	idVO, err := assetvo.NewAssetID(id)
 	if err != nil {
@@ -221,14 +317,121 @@ func (r *mutationResolver) ClearAssetPublishRule(ctx context.Context, id string)
 	return domainAssetToGraphQL(a), nil
 }
 
-func (r *mutationResolver) RequestTranscode(ctx context.Context, assetId string, videoId string, format VideoFormat) (bool, error) {
+func (r *mutationResolver) SubmitAssetForReview(ctx context.Context, id string, note *string) (*Asset, error) {
+	return r.changeAssetStatus(ctx, (*applifecycle.Service).SubmitForReview, id, note)
+}
+
+func (r *mutationResolver) ApproveAsset(ctx context.Context, id string, note *string) (*Asset, error) {
+	return r.changeAssetStatus(ctx, (*applifecycle.Service).Approve, id, note)
+}
+
+func (r *mutationResolver) RejectAsset(ctx context.Context, id string, note *string) (*Asset, error) {
+	return r.changeAssetStatus(ctx, (*applifecycle.Service).Reject, id, note)
+}
+
+func (r *mutationResolver) ScheduleAsset(ctx context.Context, id string, note *string) (*Asset, error) {
+	return r.changeAssetStatus(ctx, (*applifecycle.Service).Schedule, id, note)
+}
+
+func (r *mutationResolver) UnscheduleAsset(ctx context.Context, id string, note *string) (*Asset, error) {
+	return r.changeAssetStatus(ctx, (*applifecycle.Service).Unschedule, id, note)
+}
+
+func (r *mutationResolver) PublishAsset(ctx context.Context, id string, note *string) (*Asset, error) {
+	return r.changeAssetStatus(ctx, (*applifecycle.Service).Publish, id, note)
+}
+
+func (r *mutationResolver) UnpublishAsset(ctx context.Context, id string, note *string) (*Asset, error) {
+	return r.changeAssetStatus(ctx, (*applifecycle.Service).Unpublish, id, note)
+}
+
+func (r *mutationResolver) ReturnAssetToDraft(ctx context.Context, id string, note *string) (*Asset, error) {
+	return r.changeAssetStatus(ctx, (*applifecycle.Service).ReturnToDraft, id, note)
+}
+
+func (r *mutationResolver) ArchiveAsset(ctx context.Context, id string, note *string) (*Asset, error) {
+	return r.changeAssetStatus(ctx, (*applifecycle.Service).Archive, id, note)
+}
+
+func (r *mutationResolver) RestoreAsset(ctx context.Context, id string, note *string) (*Asset, error) {
+	return r.changeAssetStatus(ctx, (*applifecycle.Service).Restore, id, note)
+}
+
+func (r *mutationResolver) SetAssetNumbering(ctx context.Context, id string, seasonNumber *int, episodeNumber *int) (*Asset, error) {
+	idVO, err := assetvo.NewAssetID(id)
+	if err != nil {
+		return nil, err
+	}
+	a, err := r.assetCommandService.SetAssetNumbering(ctx, assetCommands.SetAssetNumberingCommand{
+		AssetID:       *idVO,
+		SeasonNumber:  seasonNumber,
+		EpisodeNumber: episodeNumber,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return domainAssetToGraphQL(a), nil
+}
+
+func (r *mutationResolver) RequestTranscode(ctx context.Context, assetId string, videoId string, format VideoFormat, overlay *OverlayInput) (bool, error) {
+	svc := transcode.NewService(r.assetCommandService, r.assetQueryService, r.publisher, r.pipelineService)
+	if err := svc.RequestTranscode(ctx, assetId, videoId, string(format), MapOverlayInput(overlay)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *mutationResolver) CancelTranscode(ctx context.Context, assetId string, videoId string, format VideoFormat) (bool, error) {
+	svc := transcode.NewService(r.assetCommandService, r.assetQueryService, r.publisher, r.pipelineService)
+	if err := svc.CancelTranscode(ctx, assetId, videoId, string(format)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *mutationResolver) RequestThumbnails(ctx context.Context, assetId string, videoId string) (bool, error) {
 	svc := transcode.NewService(r.assetCommandService, r.assetQueryService, r.publisher, r.pipelineService)
-	if err := svc.RequestTranscode(ctx, assetId, videoId, string(format)); err != nil {
+	if err := svc.RequestThumbnails(ctx, assetId, videoId); err != nil {
 		return false, err
 	}
 	return true, nil
 }
 
+func (r *mutationResolver) RequestMarkers(ctx context.Context, assetId string, videoId string) (bool, error) {
+	svc := transcode.NewService(r.assetCommandService, r.assetQueryService, r.publisher, r.pipelineService)
+	if err := svc.RequestMarkers(ctx, assetId, videoId); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *mutationResolver) CreateStreamKey(ctx context.Context, assetId string, protocol LiveProtocol) (*Asset, error) {
+	svc := applive.NewService(r.assetCommandService, r.publisher)
+	a, err := svc.CreateStreamKey(ctx, assetId, protocol.String())
+	if err != nil {
+		return nil, err
+	}
+	return domainAssetToGraphQL(a), nil
+}
+
+func (r *mutationResolver) StartLiveEvent(ctx context.Context, assetId string) (*Asset, error) {
+	svc := applive.NewService(r.assetCommandService, r.publisher)
+	a, err := svc.StartLiveEvent(ctx, assetId)
+	if err != nil {
+		return nil, err
+	}
+	return domainAssetToGraphQL(a), nil
+}
+
+func (r *mutationResolver) StopLiveEvent(ctx context.Context, assetId string) (*Asset, error) {
+	svc := applive.NewService(r.assetCommandService, r.publisher)
+	a, err := svc.StopLiveEvent(ctx, assetId)
+	if err != nil {
+		return nil, err
+	}
+	return domainAssetToGraphQL(a), nil
+}
+
 func (r *queryResolver) Assets(ctx context.Context, limit *int, offset *int) ([]*Asset, error) {
 	q := assetAppQueries.ListAssetsQuery{Limit: limit, Offset: offset}
 	items, err := r.assetQueryService.ListAssets(ctx, q)
@@ -253,16 +456,28 @@ func (r *queryResolver) Asset(ctx context.Context, id *string) (*Asset, error) {
 	return domainAssetToGraphQL(a), nil
 }
 
-func (r *queryResolver) SearchAssets(ctx context.Context, query string, limit *int, offset *int) ([]*Asset, error) {
-	page, err := r.assetQueryService.SearchAssetsPage(ctx, assetAppQueries.SearchAssetsQuery{Query: query, Limit: limit, Offset: offset})
+func (r *queryResolver) SearchAssets(ctx context.Context, query string, limit *int, offset *int, filter *AssetSearchFilter) (*AssetSearchResult, error) {
+	q := assetAppQueries.FullTextSearchQuery{Query: query, Limit: limit, Offset: offset}
+	if filter != nil {
+		q.Type, q.Genre, q.Year, q.Status = filter.Type, filter.Genre, filter.Year, filter.Status
+	}
+	result, err := r.assetQueryService.FullTextSearch(ctx, q)
 	if err != nil {
 		return nil, err
 	}
-	out := make([]*Asset, len(page.Items))
-	for i, a := range page.Items {
-		out[i] = domainAssetToGraphQL(a)
+	start := 0
+	if offset != nil && *offset > 0 {
+		start = *offset
 	}
-	return out, nil
+	return domainSearchResultToGraphQL(result, start), nil
+}
+
+func (r *queryResolver) SeriesTree(ctx context.Context, id string) (*SeriesTree, error) {
+	tree, err := r.assetQueryService.GetSeriesTree(ctx, assetAppQueries.GetSeriesTreeQuery{ID: id})
+	if err != nil {
+		return nil, err
+	}
+	return domainSeriesTreeToGraphQL(tree), nil
 }
 
 func (r *queryResolver) ProcessingStatus(ctx context.Context, assetId string, videoId string) (*ProcessingStatus, error) {
@@ -285,16 +500,50 @@ func (r *queryResolver) ProcessingStatus(ctx context.Context, assetId string, vi
 			ErrorMessage:  &s.ErrorMessage,
 			JobID:         &s.JobID,
 			CorrelationID: &s.CorrelationID,
+			Progress:      &s.Progress,
 		}
 		return step
 	}
 	return &ProcessingStatus{
-		AssetID:   assetId,
-		VideoID:   videoId,
-		Analyze:   toStep("analyze"),
-		Hls:       toStep("hls"),
-		Dash:      toStep("dash"),
-		UpdatedAt: p.UpdatedAt,
-		CreatedAt: p.CreatedAt,
+		AssetID:    assetId,
+		VideoID:    videoId,
+		Analyze:    toStep("analyze"),
+		Hls:        toStep("hls"),
+		Dash:       toStep("dash"),
+		Cmaf:       toStep("cmaf"),
+		Thumbnails: toStep("thumbnails"),
+		Markers:    toStep("markers"),
+		UpdatedAt:  p.UpdatedAt,
+		CreatedAt:  p.CreatedAt,
 	}, nil
 }
+
+func (r *videoResolver) TranscodingInfo(ctx context.Context, obj *Video) (*TranscodingInfo, error) {
+	if r.pipelineService == nil || obj.Variant || obj.StorageLocation == nil {
+		return nil, nil
+	}
+	assetID, videoID, format, ok := transcode.OutputSource(obj.StorageLocation.Key)
+	if !ok {
+		return nil, nil
+	}
+	p, err := r.pipelineService.Get(ctx, assetID, videoID)
+	if err != nil {
+		return nil, err
+	}
+	step, ok := p.Step(format)
+	if !ok {
+		return nil, nil
+	}
+	info := &TranscodingInfo{
+		JobID:       &step.JobID,
+		Progress:    &step.Progress,
+		CompletedAt: step.CompletedAt,
+	}
+	if step.ErrorMessage != "" {
+		info.Error = &step.ErrorMessage
+	}
+	if obj.StreamInfo != nil {
+		info.OutputURL = obj.StreamInfo.URL
+	}
+	return info, nil
+}
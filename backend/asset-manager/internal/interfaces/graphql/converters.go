@@ -1,12 +1,12 @@
 package graphql
 
 import (
-	"time"
+	"strings"
 
 	assetentity "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/entity"
 	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
 	bucketentity "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/bucket/entity"
-	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/constants"
+	personentity "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/entity"
 )
 
 func convertVideos(videos map[string]*assetentity.Video) []*Video {
@@ -25,6 +25,54 @@ func convertImages(imgs []valueobjects.Image) []*Image {
 	return res
 }
 
+func convertSubtitles(subtitles []*assetentity.Subtitle) []*Subtitle {
+	res := make([]*Subtitle, len(subtitles))
+	for i, s := range subtitles {
+		label := s.Label()
+		contentType := s.ContentType()
+		res[i] = &Subtitle{
+			ID:        s.ID().Value(),
+			Language:  s.Language().Value(),
+			Kind:      SubtitleKind(s.Kind().Value()),
+			Label:     &label,
+			IsDefault: s.IsDefault(),
+			StorageLocation: &S3Object{
+				Bucket: s.StorageLocation().Bucket(),
+				Key:    s.StorageLocation().Key(),
+				URL:    s.StorageLocation().URL(),
+			},
+			ContentType: &contentType,
+			CreatedAt:   s.CreatedAt(),
+			UpdatedAt:   s.UpdatedAt(),
+		}
+	}
+	return res
+}
+
+func convertLiveStream(l *valueobjects.LiveStream) *LiveStream {
+	if l == nil {
+		return nil
+	}
+	optional := func(s string) *string {
+		if s == "" {
+			return nil
+		}
+		return &s
+	}
+	return &LiveStream{
+		StreamKey:      l.StreamKey(),
+		Protocol:       LiveProtocol(strings.ToUpper(l.Protocol().Value())),
+		Status:         LiveStatus(strings.ToUpper(l.Status().Value())),
+		SessionID:      optional(l.SessionID()),
+		IngestURL:      optional(l.IngestURL()),
+		PlaybackURL:    optional(l.PlaybackURL()),
+		StartedAt:      l.StartedAt(),
+		EndedAt:        l.EndedAt(),
+		ErrorMessage:   optional(l.ErrorMessage()),
+		ArchiveVideoID: optional(l.ArchiveVideoID()),
+	}
+}
+
 func domainAssetToGraphQL(asset *assetentity.Asset) *Asset {
 	if asset == nil {
 		return nil
@@ -84,7 +132,6 @@ func domainAssetToGraphQL(asset *assetentity.Asset) *Asset {
 	}
 
 	var publishRule *PublishRule
-	var computedStatus string = constants.AssetStatusDraft
 	if asset.PublishRule() != nil {
 		domainRule := asset.PublishRule()
 		publishRule = &PublishRule{
@@ -93,15 +140,6 @@ func domainAssetToGraphQL(asset *assetentity.Asset) *Asset {
 			Regions:     domainRule.Regions(),
 			AgeRating:   domainRule.AgeRating(),
 		}
-
-		now := time.Now().UTC()
-		if domainRule.PublishAt() != nil && now.Before(*domainRule.PublishAt()) {
-			computedStatus = constants.AssetStatusScheduled
-		} else if domainRule.UnpublishAt() != nil && now.After(*domainRule.UnpublishAt()) {
-			computedStatus = constants.AssetStatusExpired
-		} else {
-			computedStatus = constants.AssetStatusPublished
-		}
 	}
 
 	videos := convertVideos(asset.Videos())
@@ -109,25 +147,81 @@ func domainAssetToGraphQL(asset *assetentity.Asset) *Asset {
 	images := convertImages(asset.Images())
 
 	return &Asset{
-		ID:          asset.ID().Value(),
-		Slug:        asset.Slug().Value(),
-		Title:       &title,
-		Description: &description,
-		Type:        &assetType,
-		Genre:       &genre,
-		OwnerID:     &ownerID,
-		ParentID:    &parentID,
-		Genres:      genres,
-		Tags:        tags,
-		Metadata:    &metadata,
-		PublishRule: publishRule,
-		Videos:      videos,
-		Images:      images,
-		CreatedAt:   asset.CreatedAt().Value(),
-		UpdatedAt:   asset.UpdatedAt().Value(),
-		Status:      computedStatus,
+		ID:            asset.ID().Value(),
+		Slug:          asset.Slug().Value(),
+		Title:         &title,
+		Description:   &description,
+		Type:          &assetType,
+		Genre:         &genre,
+		OwnerID:       &ownerID,
+		ParentID:      &parentID,
+		SeasonNumber:  asset.SeasonNumber(),
+		EpisodeNumber: asset.EpisodeNumber(),
+		Genres:        genres,
+		Tags:          tags,
+		Metadata:      &metadata,
+		PublishRule:   publishRule,
+		Videos:        videos,
+		Images:        images,
+		Subtitles:     convertSubtitles(asset.Subtitles()),
+		LiveStream:    convertLiveStream(asset.LiveStream()),
+		CreatedAt:     asset.CreatedAt().Value(),
+		UpdatedAt:     asset.UpdatedAt().Value(),
+		Status:        asset.Status(),
+		StatusHistory: convertStatusHistory(asset.StatusHistory()),
 	}
 }
+
+// domainSearchResultToGraphQL converts a page of hits starting at offset.
+func domainSearchResultToGraphQL(result *assetentity.SearchResult, offset int) *AssetSearchResult {
+	hits := make([]*AssetSearchHit, len(result.Hits))
+	for i, hit := range result.Hits {
+		hits[i] = &AssetSearchHit{Asset: domainAssetToGraphQL(hit.Asset), Score: hit.Score}
+	}
+	return &AssetSearchResult{
+		Hits:    hits,
+		Total:   result.Total,
+		HasMore: offset+len(hits) < result.Total,
+		Facets: &SearchFacets{
+			Types:  convertFacetCounts(result.Facets.Types),
+			Genres: convertFacetCounts(result.Facets.Genres),
+			Years:  convertFacetCounts(result.Facets.Years),
+		},
+	}
+}
+
+func convertFacetCounts(counts []assetentity.FacetCount) []*FacetCount {
+	out := make([]*FacetCount, len(counts))
+	for i, c := range counts {
+		out[i] = &FacetCount{Value: c.Value, Count: c.Count}
+	}
+	return out
+}
+
+func domainSeriesTreeToGraphQL(tree *assetentity.SeriesTree) *SeriesTree {
+	seasons := make([]*SeasonBranch, 0, len(tree.Seasons))
+	for _, branch := range tree.Seasons {
+		episodes := make([]*Asset, 0, len(branch.Episodes))
+		for _, episode := range branch.Episodes {
+			episodes = append(episodes, domainAssetToGraphQL(episode))
+		}
+		seasons = append(seasons, &SeasonBranch{Season: domainAssetToGraphQL(branch.Season), Episodes: episodes})
+	}
+	return &SeriesTree{Series: domainAssetToGraphQL(tree.Series), Seasons: seasons}
+}
+
+func convertStatusHistory(history []valueobjects.AssetStatusChange) []*AssetStatusChange {
+	out := make([]*AssetStatusChange, 0, len(history))
+	for _, change := range history {
+		var note *string
+		if n := change.Note(); n != "" {
+			note = &n
+		}
+		out = append(out, &AssetStatusChange{From: change.From().Value(), To: change.To().Value(), At: change.At(), Note: note})
+	}
+	return out
+}
+
 func domainVideoToGraphQL(video *assetentity.Video) *Video {
 	if video == nil {
 		return nil
@@ -150,6 +244,7 @@ func domainVideoToGraphQL(video *assetentity.Video) *Video {
 			DownloadURL: domainStreamInfo.DownloadURL(),
 			CdnPrefix:   domainStreamInfo.CDNPrefix(),
 			URL:         domainStreamInfo.URL(),
+			HlsURL:      domainStreamInfo.HLSURL(),
 		}
 	}
 
@@ -171,6 +266,25 @@ func domainVideoToGraphQL(video *assetentity.Video) *Video {
 	audioChannels := video.AudioChannels()
 	audioSampleRate := video.AudioSampleRate()
 
+	var complexity, ladderSavings *float64
+	if c := video.Complexity(); c > 0 {
+		complexity = &c
+	}
+	if len(video.Ladder()) > 0 {
+		savings := video.LadderSavings()
+		ladderSavings = &savings
+	}
+
+	images := make([]*Image, 0, len(video.Images()))
+	var thumbnailTrack *string
+	for i := range video.Images() {
+		img := &video.Images()[i]
+		images = append(images, domainImageToGraphQL(img))
+		if track, ok := img.Metadata()["track"]; ok && thumbnailTrack == nil {
+			thumbnailTrack = &track
+		}
+	}
+
 	return &Video{
 		ID:                 video.ID().Value(),
 		Label:              video.Label().Value(),
@@ -186,6 +300,9 @@ func domainVideoToGraphQL(video *assetentity.Video) *Video {
 		ContentType:        &contentType,
 		StreamInfo:         streamInfo,
 		Status:             status,
+		Thumbnail:          domainImageToGraphQL(video.Thumbnail()),
+		Images:             images,
+		ThumbnailTrack:     thumbnailTrack,
 		CreatedAt:          video.CreatedAt(),
 		UpdatedAt:          video.UpdatedAt(),
 		IsReady:            video.IsReady(),
@@ -199,6 +316,102 @@ func domainVideoToGraphQL(video *assetentity.Video) *Video {
 		FrameRate:          &frameRate,
 		AudioChannels:      &audioChannels,
 		AudioSampleRate:    &audioSampleRate,
+		AudioTracks:        convertAudioTracks(video.AudioTracks()),
+		Markers:            convertMarkers(video.Markers()),
+		QualityScores:      convertQualityScores(video.QualityScores()),
+		Complexity:         complexity,
+		Ladder:             convertLadder(video.Ladder()),
+		LadderSavings:      ladderSavings,
+		Loudness:           convertLoudness(video.Loudness()),
+		InputRejections:    convertInputRejections(video.InputRejections()),
+		Variant:            video.IsVariant(),
+	}
+}
+
+func convertInputRejections(rejections []valueobjects.InputRejection) []*InputRejection {
+	res := make([]*InputRejection, len(rejections))
+	for i, r := range rejections {
+		res[i] = &InputRejection{Code: r.Code(), Message: r.Message()}
+	}
+	return res
+}
+
+func convertLadder(ladder []valueobjects.LadderRung) []*LadderRung {
+	res := make([]*LadderRung, len(ladder))
+	for i, r := range ladder {
+		res[i] = &LadderRung{
+			Name:         r.Name(),
+			Width:        r.Width(),
+			Height:       r.Height(),
+			VideoBitrate: r.VideoBitrate(),
+			AudioBitrate: r.AudioBitrate(),
+		}
+		if base := r.BaseVideoBitrate(); base > 0 {
+			res[i].BaseVideoBitrate = &base
+		}
+	}
+	return res
+}
+
+func convertQualityScores(scores []valueobjects.RenditionQuality) []*RenditionQuality {
+	res := make([]*RenditionQuality, len(scores))
+	for i, q := range scores {
+		res[i] = &RenditionQuality{
+			Rendition: q.Rendition(),
+			Psnr:      q.PSNR(),
+			Ssim:      q.SSIM(),
+			Vmaf:      q.VMAF(),
+		}
+	}
+	return res
+}
+
+func convertMarkers(markers []valueobjects.Marker) []*Marker {
+	res := make([]*Marker, len(markers))
+	for i, m := range markers {
+		title := m.Title()
+		res[i] = &Marker{
+			Kind:  MarkerKind(m.Kind().Value()),
+			Start: m.Start(),
+			Title: &title,
+		}
+		if m.End() > 0 {
+			end := m.End()
+			res[i].End = &end
+		}
+	}
+	return res
+}
+
+func convertAudioTracks(tracks []valueobjects.AudioTrack) []*AudioTrack {
+	res := make([]*AudioTrack, len(tracks))
+	for i, t := range tracks {
+		language, codec, channelLayout, title := t.Language(), t.Codec(), t.ChannelLayout(), t.Title()
+		channels, sampleRate := t.Channels(), t.SampleRate()
+		res[i] = &AudioTrack{
+			Index:         t.Index(),
+			Language:      &language,
+			Codec:         &codec,
+			Channels:      &channels,
+			ChannelLayout: &channelLayout,
+			SampleRate:    &sampleRate,
+			Title:         &title,
+			IsDefault:     t.IsDefault(),
+			Loudness:      convertLoudness(t.Loudness()),
+		}
+	}
+	return res
+}
+
+func convertLoudness(l *valueobjects.Loudness) *Loudness {
+	if l == nil {
+		return nil
+	}
+	return &Loudness{
+		Integrated: l.Integrated(),
+		TruePeak:   l.TruePeak(),
+		Lra:        l.LRA(),
+		Threshold:  l.Threshold(),
 	}
 }
 func domainImageToGraphQL(img *valueobjects.Image) *Image {
@@ -345,3 +558,67 @@ func domainBucketPageToGraphQL(page *bucketentity.BucketPage) *BucketPage {
 		HasMore: page.HasMore,
 	}
 }
+
+func domainPersonToGraphQL(person *personentity.Person) *Person {
+	if person == nil {
+		return nil
+	}
+
+	out := &Person{
+		ID:        person.ID().Value(),
+		Name:      person.Name().Value(),
+		Biography: person.Biography(),
+		PhotoURL:  person.PhotoURL(),
+		CreatedAt: person.CreatedAt().Value(),
+		UpdatedAt: person.UpdatedAt().Value(),
+	}
+	if ext := person.ExternalID(); ext != nil {
+		source, id := ext.Source(), ext.Value()
+		out.ExternalSource = &source
+		out.ExternalID = &id
+	}
+	return out
+}
+
+func domainPeopleToGraphQL(people []*personentity.Person) []*Person {
+	out := make([]*Person, len(people))
+	for i, p := range people {
+		out[i] = domainPersonToGraphQL(p)
+	}
+	return out
+}
+
+func domainCreditsToGraphQL(credits []personentity.CreditedPerson) []*Credit {
+	out := make([]*Credit, len(credits))
+	for i, c := range credits {
+		out[i] = &Credit{
+			Role:      c.Credit.Role().Value(),
+			Name:      c.Person.Name().Value(),
+			PersonID:  c.Person.ID().Value(),
+			Character: c.Credit.Character(),
+			Order:     c.Credit.Order(),
+		}
+	}
+	return out
+}
+
+func domainPersonCreditsToGraphQL(credits []*personentity.Credit) []*PersonCredit {
+	out := make([]*PersonCredit, len(credits))
+	for i, c := range credits {
+		out[i] = &PersonCredit{
+			AssetID:   c.AssetID(),
+			Role:      c.Role().Value(),
+			Character: c.Character(),
+			Order:     c.Order(),
+		}
+	}
+	return out
+}
+
+func domainCollaboratorsToGraphQL(collaborators []personentity.Collaborator) []*Collaborator {
+	out := make([]*Collaborator, len(collaborators))
+	for i, c := range collaborators {
+		out[i] = &Collaborator{Person: domainPersonToGraphQL(c.Person), SharedAssets: c.SharedAssets}
+	}
+	return out
+}
@@ -1,11 +1,16 @@
 package graphql
 
 import (
+	"errors"
+
 	assetCommands "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/asset/commands"
 	bucketCommands "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/bucket/commands"
+	personCommands "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/person/commands"
+	"github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/transcode"
 
 	assetvo "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/valueobjects"
 	bucketvo "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/bucket/valueobjects"
+	personvo "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/valueobjects"
 )
 
 func MapCreateAssetInput(input CreateAssetInput) (assetCommands.CreateAssetCommand, error) {
@@ -78,6 +83,9 @@ func MapCreateAssetInput(input CreateAssetInput) (assetCommands.CreateAssetComma
 		Tags:      tags,
 		OwnerID:   owner,
 		ParentID:  parent,
+
+		SeasonNumber:  input.SeasonNumber,
+		EpisodeNumber: input.EpisodeNumber,
 	}, nil
 }
 
@@ -192,3 +200,120 @@ func MapRemoveAssetFromBucketInput(input RemoveAssetFromBucketInput) (bucketComm
 	}
 	return bucketCommands.RemoveAssetFromBucketCommand{BucketID: *idVO, AssetID: input.AssetID}, nil
 }
+
+func MapCreatePersonInput(input PersonInput) (personCommands.CreatePersonCommand, error) {
+	if input.Name == nil {
+		return personCommands.CreatePersonCommand{}, errors.New("person name is required")
+	}
+	name, err := personvo.NewPersonName(*input.Name)
+	if err != nil {
+		return personCommands.CreatePersonCommand{}, err
+	}
+	externalID, err := mapExternalID(input.ExternalSource, input.ExternalID)
+	if err != nil {
+		return personCommands.CreatePersonCommand{}, err
+	}
+	return personCommands.CreatePersonCommand{Name: *name, ExternalID: externalID, Biography: input.Biography, PhotoURL: input.PhotoURL}, nil
+}
+
+func MapUpdatePersonInput(id string, input PersonInput) (personCommands.UpdatePersonCommand, error) {
+	idVO, err := personvo.NewPersonID(id)
+	if err != nil {
+		return personCommands.UpdatePersonCommand{}, err
+	}
+	var name *personvo.PersonName
+	if input.Name != nil {
+		name, err = personvo.NewPersonName(*input.Name)
+		if err != nil {
+			return personCommands.UpdatePersonCommand{}, err
+		}
+	}
+	externalID, err := mapExternalID(input.ExternalSource, input.ExternalID)
+	if err != nil {
+		return personCommands.UpdatePersonCommand{}, err
+	}
+	return personCommands.UpdatePersonCommand{ID: *idVO, Name: name, ExternalID: externalID, Biography: input.Biography, PhotoURL: input.PhotoURL}, nil
+}
+
+func MapDeletePersonInput(id string) (personCommands.DeletePersonCommand, error) {
+	idVO, err := personvo.NewPersonID(id)
+	if err != nil {
+		return personCommands.DeletePersonCommand{}, err
+	}
+	return personCommands.DeletePersonCommand{ID: *idVO}, nil
+}
+
+func MapCreditInput(input CreditInput) (personCommands.AddCreditCommand, error) {
+	idVO, err := personvo.NewPersonID(input.PersonID)
+	if err != nil {
+		return personCommands.AddCreditCommand{}, err
+	}
+	role, err := personvo.NewCreditRole(input.Role.String())
+	if err != nil {
+		return personCommands.AddCreditCommand{}, err
+	}
+	order := 0
+	if input.Order != nil {
+		order = *input.Order
+	}
+	return personCommands.AddCreditCommand{PersonID: *idVO, AssetID: input.AssetID, Role: *role, Character: input.Character, Order: order}, nil
+}
+
+func MapRemoveCreditInput(personID, assetID string, role CreditRole) (personCommands.RemoveCreditCommand, error) {
+	idVO, err := personvo.NewPersonID(personID)
+	if err != nil {
+		return personCommands.RemoveCreditCommand{}, err
+	}
+	roleVO, err := personvo.NewCreditRole(role.String())
+	if err != nil {
+		return personCommands.RemoveCreditCommand{}, err
+	}
+	return personCommands.RemoveCreditCommand{PersonID: *idVO, AssetID: assetID, Role: *roleVO}, nil
+}
+
+// MapCreditRole returns nil when no role filter is given.
+func MapCreditRole(role *CreditRole) (*personvo.CreditRole, error) {
+	if role == nil {
+		return nil, nil
+	}
+	return personvo.NewCreditRole(role.String())
+}
+
+// mapExternalID needs both parts or neither.
+func mapExternalID(source, id *string) (*personvo.ExternalID, error) {
+	if source == nil && id == nil {
+		return nil, nil
+	}
+	if source == nil || id == nil {
+		return nil, errors.New("externalSource and externalId must be given together")
+	}
+	return personvo.NewExternalID(*source, *id)
+}
+
+// MapOverlayInput returns nil for a clean transcode.
+func MapOverlayInput(input *OverlayInput) *transcode.Overlay {
+	if input == nil {
+		return nil
+	}
+	overlay := &transcode.Overlay{Label: input.Label}
+	if input.LogoImageID != nil {
+		overlay.LogoImageID = *input.LogoImageID
+	}
+	if input.Position != nil {
+		overlay.Position = string(*input.Position)
+	}
+	if input.Opacity != nil {
+		overlay.Opacity = *input.Opacity
+	}
+	if input.Text != nil {
+		overlay.Text = *input.Text
+	}
+	return overlay
+}
+
+func MapStatusNote(note *string) string {
+	if note == nil {
+		return ""
+	}
+	return *note
+}
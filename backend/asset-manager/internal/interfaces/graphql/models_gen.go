@@ -27,6 +27,18 @@ type AddImageInput struct {
 	Size        int       `json:"size"`
 }
 
+type AddSubtitleInput struct {
+	AssetID     string       `json:"assetId"`
+	Language    string       `json:"language"`
+	Kind        SubtitleKind `json:"kind"`
+	Label       *string      `json:"label,omitempty"`
+	IsDefault   *bool        `json:"isDefault,omitempty"`
+	Bucket      string       `json:"bucket"`
+	Key         string       `json:"key"`
+	URL         string       `json:"url"`
+	ContentType string       `json:"contentType"`
+}
+
 type AddVideoInput struct {
 	AssetID     string      `json:"assetId"`
 	Label       string      `json:"label"`
@@ -39,26 +51,31 @@ type AddVideoInput struct {
 }
 
 type Asset struct {
-	ID          string       `json:"id"`
-	Slug        string       `json:"slug"`
-	Title       *string      `json:"title,omitempty"`
-	Description *string      `json:"description,omitempty"`
-	Type        *string      `json:"type,omitempty"`
-	Genre       *string      `json:"genre,omitempty"`
-	Genres      []string     `json:"genres"`
-	Tags        []string     `json:"tags"`
-	CreatedAt   time.Time    `json:"createdAt"`
-	UpdatedAt   time.Time    `json:"updatedAt"`
-	OwnerID     *string      `json:"ownerId,omitempty"`
-	ParentID    *string      `json:"parentId,omitempty"`
-	Parent      *Asset       `json:"parent,omitempty"`
-	Children    []*Asset     `json:"children"`
-	Images      []*Image     `json:"images"`
-	Videos      []*Video     `json:"videos"`
-	Credits     []*Credit    `json:"credits"`
-	PublishRule *PublishRule `json:"publishRule,omitempty"`
-	Metadata    *string      `json:"metadata,omitempty"`
-	Status      string       `json:"status"`
+	ID            string               `json:"id"`
+	Slug          string               `json:"slug"`
+	Title         *string              `json:"title,omitempty"`
+	Description   *string              `json:"description,omitempty"`
+	Type          *string              `json:"type,omitempty"`
+	Genre         *string              `json:"genre,omitempty"`
+	Genres        []string             `json:"genres"`
+	Tags          []string             `json:"tags"`
+	CreatedAt     time.Time            `json:"createdAt"`
+	UpdatedAt     time.Time            `json:"updatedAt"`
+	OwnerID       *string              `json:"ownerId,omitempty"`
+	ParentID      *string              `json:"parentId,omitempty"`
+	Parent        *Asset               `json:"parent,omitempty"`
+	Children      []*Asset             `json:"children"`
+	SeasonNumber  *int                 `json:"seasonNumber,omitempty"`
+	EpisodeNumber *int                 `json:"episodeNumber,omitempty"`
+	Images        []*Image             `json:"images"`
+	Videos        []*Video             `json:"videos"`
+	Subtitles     []*Subtitle          `json:"subtitles"`
+	Credits       []*Credit            `json:"credits"`
+	PublishRule   *PublishRule         `json:"publishRule,omitempty"`
+	LiveStream    *LiveStream          `json:"liveStream,omitempty"`
+	Metadata      *string              `json:"metadata,omitempty"`
+	Status        string               `json:"status"`
+	StatusHistory []*AssetStatusChange `json:"statusHistory"`
 }
 
 type AssetPage struct {
@@ -67,6 +84,44 @@ type AssetPage struct {
 	HasMore bool     `json:"hasMore"`
 }
 
+type AssetSearchFilter struct {
+	Type   *string `json:"type,omitempty"`
+	Genre  *string `json:"genre,omitempty"`
+	Year   *int    `json:"year,omitempty"`
+	Status *string `json:"status,omitempty"`
+}
+
+type AssetSearchHit struct {
+	Asset *Asset  `json:"asset"`
+	Score float64 `json:"score"`
+}
+
+type AssetSearchResult struct {
+	Hits    []*AssetSearchHit `json:"hits"`
+	Total   int               `json:"total"`
+	HasMore bool              `json:"hasMore"`
+	Facets  *SearchFacets     `json:"facets"`
+}
+
+type AssetStatusChange struct {
+	From string    `json:"from"`
+	To   string    `json:"to"`
+	At   time.Time `json:"at"`
+	Note *string   `json:"note,omitempty"`
+}
+
+type AudioTrack struct {
+	Index         int       `json:"index"`
+	Language      *string   `json:"language,omitempty"`
+	Codec         *string   `json:"codec,omitempty"`
+	Channels      *int      `json:"channels,omitempty"`
+	ChannelLayout *string   `json:"channelLayout,omitempty"`
+	SampleRate    *int      `json:"sampleRate,omitempty"`
+	Title         *string   `json:"title,omitempty"`
+	IsDefault     bool      `json:"isDefault"`
+	Loudness      *Loudness `json:"loudness,omitempty"`
+}
+
 type Bucket struct {
 	ID          string    `json:"id"`
 	Key         string    `json:"key"`
@@ -97,23 +152,45 @@ type BucketPage struct {
 	HasMore bool      `json:"hasMore"`
 }
 
+type Collaborator struct {
+	Person       *Person `json:"person"`
+	SharedAssets int     `json:"sharedAssets"`
+}
+
 type CreateAssetInput struct {
-	Slug        string   `json:"slug"`
-	Title       *string  `json:"title,omitempty"`
-	Description *string  `json:"description,omitempty"`
-	Type        *string  `json:"type,omitempty"`
-	Genre       *string  `json:"genre,omitempty"`
-	Genres      []string `json:"genres,omitempty"`
-	Tags        []string `json:"tags,omitempty"`
-	OwnerID     *string  `json:"ownerId,omitempty"`
-	ParentID    *string  `json:"parentId,omitempty"`
-	Metadata    *string  `json:"metadata,omitempty"`
+	Slug          string   `json:"slug"`
+	Title         *string  `json:"title,omitempty"`
+	Description   *string  `json:"description,omitempty"`
+	Type          *string  `json:"type,omitempty"`
+	Genre         *string  `json:"genre,omitempty"`
+	Genres        []string `json:"genres,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	OwnerID       *string  `json:"ownerId,omitempty"`
+	ParentID      *string  `json:"parentId,omitempty"`
+	SeasonNumber  *int     `json:"seasonNumber,omitempty"`
+	EpisodeNumber *int     `json:"episodeNumber,omitempty"`
+	Metadata      *string  `json:"metadata,omitempty"`
 }
 
 type Credit struct {
-	Role     string `json:"role"`
-	Name     string `json:"name"`
-	PersonID string `json:"personId"`
+	Role      string  `json:"role"`
+	Name      string  `json:"name"`
+	PersonID  string  `json:"personId"`
+	Character *string `json:"character,omitempty"`
+	Order     int     `json:"order"`
+}
+
+type CreditInput struct {
+	PersonID  string     `json:"personId"`
+	AssetID   string     `json:"assetId"`
+	Role      CreditRole `json:"role"`
+	Character *string    `json:"character,omitempty"`
+	Order     *int       `json:"order,omitempty"`
+}
+
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
 }
 
 type Image struct {
@@ -132,9 +209,92 @@ type Image struct {
 	UpdatedAt       time.Time   `json:"updatedAt"`
 }
 
+type InputRejection struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type LadderRung struct {
+	Name             string `json:"name"`
+	Width            int    `json:"width"`
+	Height           int    `json:"height"`
+	VideoBitrate     int    `json:"videoBitrate"`
+	AudioBitrate     int    `json:"audioBitrate"`
+	BaseVideoBitrate *int   `json:"baseVideoBitrate,omitempty"`
+}
+
+type LiveStream struct {
+	StreamKey      string       `json:"streamKey"`
+	Protocol       LiveProtocol `json:"protocol"`
+	Status         LiveStatus   `json:"status"`
+	SessionID      *string      `json:"sessionId,omitempty"`
+	IngestURL      *string      `json:"ingestUrl,omitempty"`
+	PlaybackURL    *string      `json:"playbackUrl,omitempty"`
+	StartedAt      *time.Time   `json:"startedAt,omitempty"`
+	EndedAt        *time.Time   `json:"endedAt,omitempty"`
+	ErrorMessage   *string      `json:"errorMessage,omitempty"`
+	ArchiveVideoID *string      `json:"archiveVideoId,omitempty"`
+}
+
+type Loudness struct {
+	Integrated float64 `json:"integrated"`
+	TruePeak   float64 `json:"truePeak"`
+	Lra        float64 `json:"lra"`
+	Threshold  float64 `json:"threshold"`
+}
+
+type Marker struct {
+	Kind  MarkerKind `json:"kind"`
+	Start float64    `json:"start"`
+	End   *float64   `json:"end,omitempty"`
+	Title *string    `json:"title,omitempty"`
+}
+
+type MarkerInput struct {
+	Kind  MarkerKind `json:"kind"`
+	Start float64    `json:"start"`
+	End   *float64   `json:"end,omitempty"`
+	Title *string    `json:"title,omitempty"`
+}
+
 type Mutation struct {
 }
 
+type OverlayInput struct {
+	Label       string           `json:"label"`
+	LogoImageID *string          `json:"logoImageId,omitempty"`
+	Position    *OverlayPosition `json:"position,omitempty"`
+	Opacity     *float64         `json:"opacity,omitempty"`
+	Text        *string          `json:"text,omitempty"`
+}
+
+type Person struct {
+	ID             string          `json:"id"`
+	Name           string          `json:"name"`
+	ExternalSource *string         `json:"externalSource,omitempty"`
+	ExternalID     *string         `json:"externalId,omitempty"`
+	Biography      *string         `json:"biography,omitempty"`
+	PhotoURL       *string         `json:"photoUrl,omitempty"`
+	Credits        []*PersonCredit `json:"credits"`
+	CreatedAt      time.Time       `json:"createdAt"`
+	UpdatedAt      time.Time       `json:"updatedAt"`
+}
+
+type PersonCredit struct {
+	AssetID   string  `json:"assetId"`
+	Role      string  `json:"role"`
+	Character *string `json:"character,omitempty"`
+	Order     int     `json:"order"`
+}
+
+type PersonInput struct {
+	Name           *string `json:"name,omitempty"`
+	ExternalSource *string `json:"externalSource,omitempty"`
+	ExternalID     *string `json:"externalId,omitempty"`
+	Biography      *string `json:"biography,omitempty"`
+	PhotoURL       *string `json:"photoUrl,omitempty"`
+}
+
 type PipelineStep struct {
 	Status        string     `json:"status"`
 	StartedAt     time.Time  `json:"startedAt"`
@@ -142,16 +302,20 @@ type PipelineStep struct {
 	ErrorMessage  *string    `json:"errorMessage,omitempty"`
 	JobID         *string    `json:"jobId,omitempty"`
 	CorrelationID *string    `json:"correlationId,omitempty"`
+	Progress      *float64   `json:"progress,omitempty"`
 }
 
 type ProcessingStatus struct {
-	AssetID   string        `json:"assetId"`
-	VideoID   string        `json:"videoId"`
-	Analyze   *PipelineStep `json:"analyze,omitempty"`
-	Hls       *PipelineStep `json:"hls,omitempty"`
-	Dash      *PipelineStep `json:"dash,omitempty"`
-	UpdatedAt time.Time     `json:"updatedAt"`
-	CreatedAt time.Time     `json:"createdAt"`
+	AssetID    string        `json:"assetId"`
+	VideoID    string        `json:"videoId"`
+	Analyze    *PipelineStep `json:"analyze,omitempty"`
+	Hls        *PipelineStep `json:"hls,omitempty"`
+	Dash       *PipelineStep `json:"dash,omitempty"`
+	Cmaf       *PipelineStep `json:"cmaf,omitempty"`
+	Thumbnails *PipelineStep `json:"thumbnails,omitempty"`
+	Markers    *PipelineStep `json:"markers,omitempty"`
+	UpdatedAt  time.Time     `json:"updatedAt"`
+	CreatedAt  time.Time     `json:"createdAt"`
 }
 
 type PublishRule struct {
@@ -177,18 +341,58 @@ type RemoveAssetFromBucketInput struct {
 	OwnerID  string `json:"ownerId"`
 }
 
+type RenditionQuality struct {
+	Rendition string   `json:"rendition"`
+	Psnr      float64  `json:"psnr"`
+	Ssim      float64  `json:"ssim"`
+	Vmaf      *float64 `json:"vmaf,omitempty"`
+}
+
 type S3Object struct {
 	Bucket string `json:"bucket"`
 	Key    string `json:"key"`
 	URL    string `json:"url"`
 }
 
+type SearchFacets struct {
+	Types  []*FacetCount `json:"types"`
+	Genres []*FacetCount `json:"genres"`
+	Years  []*FacetCount `json:"years"`
+}
+
+type SeasonBranch struct {
+	Season   *Asset   `json:"season"`
+	Episodes []*Asset `json:"episodes"`
+}
+
+type SeriesTree struct {
+	Series  *Asset          `json:"series"`
+	Seasons []*SeasonBranch `json:"seasons"`
+}
+
 type StreamInfo struct {
 	DownloadURL *string `json:"downloadUrl,omitempty"`
 	CdnPrefix   *string `json:"cdnPrefix,omitempty"`
 	URL         *string `json:"url,omitempty"`
+	// HLS playlist of a CMAF video. url is its DASH manifest; both read the
+	// same segments.
+	HlsURL *string `json:"hlsUrl,omitempty"`
+}
+
+type Subtitle struct {
+	ID              string       `json:"id"`
+	Language        string       `json:"language"`
+	Kind            SubtitleKind `json:"kind"`
+	Label           *string      `json:"label,omitempty"`
+	IsDefault       bool         `json:"isDefault"`
+	StorageLocation *S3Object    `json:"storageLocation"`
+	ContentType     *string      `json:"contentType,omitempty"`
+	CreatedAt       time.Time    `json:"createdAt"`
+	UpdatedAt       time.Time    `json:"updatedAt"`
 }
 
+// Transcoding state of an HLS, DASH or CMAF output, read from the processing
+// pipeline of its source video. progress runs from 0 to 100.
 type TranscodingInfo struct {
 	JobID       *string    `json:"jobId,omitempty"`
 	Progress    *float64   `json:"progress,omitempty"`
@@ -198,37 +402,109 @@ type TranscodingInfo struct {
 }
 
 type Video struct {
-	ID                 string           `json:"id"`
-	Label              string           `json:"label"`
-	Type               VideoType        `json:"type"`
-	Format             *VideoFormat     `json:"format,omitempty"`
-	StorageLocation    *S3Object        `json:"storageLocation"`
-	Width              *int             `json:"width,omitempty"`
-	Height             *int             `json:"height,omitempty"`
-	Duration           *float64         `json:"duration,omitempty"`
-	Bitrate            *int             `json:"bitrate,omitempty"`
-	Codec              *string          `json:"codec,omitempty"`
-	Size               *int             `json:"size,omitempty"`
-	ContentType        *string          `json:"contentType,omitempty"`
-	StreamInfo         *StreamInfo      `json:"streamInfo,omitempty"`
-	Metadata           []string         `json:"metadata"`
-	Status             VideoStatus      `json:"status"`
-	Thumbnail          *Image           `json:"thumbnail,omitempty"`
-	TranscodingInfo    *TranscodingInfo `json:"transcodingInfo,omitempty"`
-	CreatedAt          time.Time        `json:"createdAt"`
-	UpdatedAt          time.Time        `json:"updatedAt"`
-	Quality            VideoQuality     `json:"quality"`
-	IsReady            bool             `json:"isReady"`
-	IsProcessing       bool             `json:"isProcessing"`
-	IsFailed           bool             `json:"isFailed"`
-	SegmentCount       *int             `json:"segmentCount,omitempty"`
-	VideoCodec         *string          `json:"videoCodec,omitempty"`
-	AudioCodec         *string          `json:"audioCodec,omitempty"`
-	AvgSegmentDuration *float64         `json:"avgSegmentDuration,omitempty"`
-	Segments           []string         `json:"segments,omitempty"`
-	FrameRate          *string          `json:"frameRate,omitempty"`
-	AudioChannels      *int             `json:"audioChannels,omitempty"`
-	AudioSampleRate    *int             `json:"audioSampleRate,omitempty"`
+	ID                 string              `json:"id"`
+	Label              string              `json:"label"`
+	Type               VideoType           `json:"type"`
+	Format             *VideoFormat        `json:"format,omitempty"`
+	StorageLocation    *S3Object           `json:"storageLocation"`
+	Width              *int                `json:"width,omitempty"`
+	Height             *int                `json:"height,omitempty"`
+	Duration           *float64            `json:"duration,omitempty"`
+	Bitrate            *int                `json:"bitrate,omitempty"`
+	Codec              *string             `json:"codec,omitempty"`
+	Size               *int                `json:"size,omitempty"`
+	ContentType        *string             `json:"contentType,omitempty"`
+	StreamInfo         *StreamInfo         `json:"streamInfo,omitempty"`
+	Metadata           []string            `json:"metadata"`
+	Status             VideoStatus         `json:"status"`
+	Thumbnail          *Image              `json:"thumbnail,omitempty"`
+	Images             []*Image            `json:"images"`
+	ThumbnailTrack     *string             `json:"thumbnailTrack,omitempty"`
+	TranscodingInfo    *TranscodingInfo    `json:"transcodingInfo,omitempty"`
+	CreatedAt          time.Time           `json:"createdAt"`
+	UpdatedAt          time.Time           `json:"updatedAt"`
+	Quality            VideoQuality        `json:"quality"`
+	IsReady            bool                `json:"isReady"`
+	IsProcessing       bool                `json:"isProcessing"`
+	IsFailed           bool                `json:"isFailed"`
+	SegmentCount       *int                `json:"segmentCount,omitempty"`
+	VideoCodec         *string             `json:"videoCodec,omitempty"`
+	AudioCodec         *string             `json:"audioCodec,omitempty"`
+	AvgSegmentDuration *float64            `json:"avgSegmentDuration,omitempty"`
+	Segments           []string            `json:"segments,omitempty"`
+	FrameRate          *string             `json:"frameRate,omitempty"`
+	AudioChannels      *int                `json:"audioChannels,omitempty"`
+	AudioSampleRate    *int                `json:"audioSampleRate,omitempty"`
+	AudioTracks        []*AudioTrack       `json:"audioTracks"`
+	Markers            []*Marker           `json:"markers"`
+	QualityScores      []*RenditionQuality `json:"qualityScores"`
+	Complexity         *float64            `json:"complexity,omitempty"`
+	Ladder             []*LadderRung       `json:"ladder"`
+	LadderSavings      *float64            `json:"ladderSavings,omitempty"`
+	Loudness           *Loudness           `json:"loudness,omitempty"`
+	InputRejections    []*InputRejection   `json:"inputRejections"`
+	Variant            bool                `json:"variant"`
+}
+
+type CreditRole string
+
+const (
+	CreditRoleActor    CreditRole = "actor"
+	CreditRoleDirector CreditRole = "director"
+	CreditRoleWriter   CreditRole = "writer"
+	CreditRoleProducer CreditRole = "producer"
+	CreditRoleComposer CreditRole = "composer"
+)
+
+var AllCreditRole = []CreditRole{
+	CreditRoleActor,
+	CreditRoleDirector,
+	CreditRoleWriter,
+	CreditRoleProducer,
+	CreditRoleComposer,
+}
+
+func (e CreditRole) IsValid() bool {
+	switch e {
+	case CreditRoleActor, CreditRoleDirector, CreditRoleWriter, CreditRoleProducer, CreditRoleComposer:
+		return true
+	}
+	return false
+}
+
+func (e CreditRole) String() string {
+	return string(e)
+}
+
+func (e *CreditRole) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = CreditRole(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid CreditRole", str)
+	}
+	return nil
+}
+
+func (e CreditRole) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *CreditRole) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e CreditRole) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
 }
 
 type ImageType string
@@ -243,6 +519,7 @@ const (
 	ImageTypeScreenshot   ImageType = "screenshot"
 	ImageTypeBehindScenes ImageType = "behind_scenes"
 	ImageTypeInterview    ImageType = "interview"
+	ImageTypeSprite       ImageType = "sprite"
 )
 
 var AllImageType = []ImageType{
@@ -255,11 +532,12 @@ var AllImageType = []ImageType{
 	ImageTypeScreenshot,
 	ImageTypeBehindScenes,
 	ImageTypeInterview,
+	ImageTypeSprite,
 }
 
 func (e ImageType) IsValid() bool {
 	switch e {
-	case ImageTypePoster, ImageTypeBackdrop, ImageTypeThumbnail, ImageTypeLogo, ImageTypeBanner, ImageTypeHero, ImageTypeScreenshot, ImageTypeBehindScenes, ImageTypeInterview:
+	case ImageTypePoster, ImageTypeBackdrop, ImageTypeThumbnail, ImageTypeLogo, ImageTypeBanner, ImageTypeHero, ImageTypeScreenshot, ImageTypeBehindScenes, ImageTypeInterview, ImageTypeSprite:
 		return true
 	}
 	return false
@@ -300,23 +578,320 @@ func (e ImageType) MarshalJSON() ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+type LiveProtocol string
+
+const (
+	LiveProtocolRtmp LiveProtocol = "RTMP"
+	LiveProtocolSrt  LiveProtocol = "SRT"
+)
+
+var AllLiveProtocol = []LiveProtocol{
+	LiveProtocolRtmp,
+	LiveProtocolSrt,
+}
+
+func (e LiveProtocol) IsValid() bool {
+	switch e {
+	case LiveProtocolRtmp, LiveProtocolSrt:
+		return true
+	}
+	return false
+}
+
+func (e LiveProtocol) String() string {
+	return string(e)
+}
+
+func (e *LiveProtocol) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = LiveProtocol(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid LiveProtocol", str)
+	}
+	return nil
+}
+
+func (e LiveProtocol) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *LiveProtocol) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e LiveProtocol) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type LiveStatus string
+
+const (
+	LiveStatusIdle     LiveStatus = "IDLE"
+	LiveStatusStarting LiveStatus = "STARTING"
+	LiveStatusWaiting  LiveStatus = "WAITING"
+	LiveStatusLive     LiveStatus = "LIVE"
+	LiveStatusStopping LiveStatus = "STOPPING"
+	LiveStatusEnded    LiveStatus = "ENDED"
+	LiveStatusFailed   LiveStatus = "FAILED"
+)
+
+var AllLiveStatus = []LiveStatus{
+	LiveStatusIdle,
+	LiveStatusStarting,
+	LiveStatusWaiting,
+	LiveStatusLive,
+	LiveStatusStopping,
+	LiveStatusEnded,
+	LiveStatusFailed,
+}
+
+func (e LiveStatus) IsValid() bool {
+	switch e {
+	case LiveStatusIdle, LiveStatusStarting, LiveStatusWaiting, LiveStatusLive, LiveStatusStopping, LiveStatusEnded, LiveStatusFailed:
+		return true
+	}
+	return false
+}
+
+func (e LiveStatus) String() string {
+	return string(e)
+}
+
+func (e *LiveStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = LiveStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid LiveStatus", str)
+	}
+	return nil
+}
+
+func (e LiveStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *LiveStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e LiveStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type MarkerKind string
+
+const (
+	MarkerKindChapter MarkerKind = "chapter"
+	MarkerKindIntro   MarkerKind = "intro"
+	MarkerKindCredits MarkerKind = "credits"
+)
+
+var AllMarkerKind = []MarkerKind{
+	MarkerKindChapter,
+	MarkerKindIntro,
+	MarkerKindCredits,
+}
+
+func (e MarkerKind) IsValid() bool {
+	switch e {
+	case MarkerKindChapter, MarkerKindIntro, MarkerKindCredits:
+		return true
+	}
+	return false
+}
+
+func (e MarkerKind) String() string {
+	return string(e)
+}
+
+func (e *MarkerKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = MarkerKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid MarkerKind", str)
+	}
+	return nil
+}
+
+func (e MarkerKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *MarkerKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e MarkerKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type OverlayPosition string
+
+const (
+	OverlayPositionTopLeft     OverlayPosition = "top_left"
+	OverlayPositionTopRight    OverlayPosition = "top_right"
+	OverlayPositionBottomLeft  OverlayPosition = "bottom_left"
+	OverlayPositionBottomRight OverlayPosition = "bottom_right"
+	OverlayPositionCenter      OverlayPosition = "center"
+)
+
+var AllOverlayPosition = []OverlayPosition{
+	OverlayPositionTopLeft,
+	OverlayPositionTopRight,
+	OverlayPositionBottomLeft,
+	OverlayPositionBottomRight,
+	OverlayPositionCenter,
+}
+
+func (e OverlayPosition) IsValid() bool {
+	switch e {
+	case OverlayPositionTopLeft, OverlayPositionTopRight, OverlayPositionBottomLeft, OverlayPositionBottomRight, OverlayPositionCenter:
+		return true
+	}
+	return false
+}
+
+func (e OverlayPosition) String() string {
+	return string(e)
+}
+
+func (e *OverlayPosition) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OverlayPosition(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OverlayPosition", str)
+	}
+	return nil
+}
+
+func (e OverlayPosition) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *OverlayPosition) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e OverlayPosition) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type SubtitleKind string
+
+const (
+	SubtitleKindSubtitles SubtitleKind = "subtitles"
+	SubtitleKindCaptions  SubtitleKind = "captions"
+	SubtitleKindForced    SubtitleKind = "forced"
+)
+
+var AllSubtitleKind = []SubtitleKind{
+	SubtitleKindSubtitles,
+	SubtitleKindCaptions,
+	SubtitleKindForced,
+}
+
+func (e SubtitleKind) IsValid() bool {
+	switch e {
+	case SubtitleKindSubtitles, SubtitleKindCaptions, SubtitleKindForced:
+		return true
+	}
+	return false
+}
+
+func (e SubtitleKind) String() string {
+	return string(e)
+}
+
+func (e *SubtitleKind) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = SubtitleKind(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid SubtitleKind", str)
+	}
+	return nil
+}
+
+func (e SubtitleKind) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *SubtitleKind) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e SubtitleKind) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
 type VideoFormat string
 
 const (
 	VideoFormatRaw  VideoFormat = "raw"
 	VideoFormatHls  VideoFormat = "hls"
 	VideoFormatDash VideoFormat = "dash"
+	VideoFormatCmaf VideoFormat = "cmaf"
 )
 
 var AllVideoFormat = []VideoFormat{
 	VideoFormatRaw,
 	VideoFormatHls,
 	VideoFormatDash,
+	VideoFormatCmaf,
 }
 
 func (e VideoFormat) IsValid() bool {
 	switch e {
-	case VideoFormatRaw, VideoFormatHls, VideoFormatDash:
+	case VideoFormatRaw, VideoFormatHls, VideoFormatDash, VideoFormatCmaf:
 		return true
 	}
 	return false
@@ -424,6 +999,8 @@ const (
 	VideoStatusTranscoding VideoStatus = "transcoding"
 	VideoStatusReady       VideoStatus = "ready"
 	VideoStatusFailed      VideoStatus = "failed"
+	VideoStatusCancelled   VideoStatus = "cancelled"
+	VideoStatusFailedQc    VideoStatus = "failed_qc"
 )
 
 var AllVideoStatus = []VideoStatus{
@@ -432,11 +1009,13 @@ var AllVideoStatus = []VideoStatus{
 	VideoStatusTranscoding,
 	VideoStatusReady,
 	VideoStatusFailed,
+	VideoStatusCancelled,
+	VideoStatusFailedQc,
 }
 
 func (e VideoStatus) IsValid() bool {
 	switch e {
-	case VideoStatusPending, VideoStatusAnalyzing, VideoStatusTranscoding, VideoStatusReady, VideoStatusFailed:
+	case VideoStatusPending, VideoStatusAnalyzing, VideoStatusTranscoding, VideoStatusReady, VideoStatusFailed, VideoStatusCancelled, VideoStatusFailedQc:
 		return true
 	}
 	return false
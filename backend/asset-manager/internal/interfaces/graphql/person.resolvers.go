@@ -0,0 +1,158 @@
+package graphql
+
+import (
+	"context"
+
+	assetAppQueries "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/asset/queries"
+	personAppQueries "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/person/queries"
+	personentity "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/entity"
+	personvo "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/person/valueobjects"
+)
+
+func (r *assetResolver) Credits(ctx context.Context, obj *Asset) ([]*Credit, error) {
+	credits, err := r.personQueryService.GetAssetCredits(ctx, personAppQueries.GetAssetCreditsQuery{AssetID: obj.ID})
+	if err != nil {
+		return nil, err
+	}
+	return domainCreditsToGraphQL(credits), nil
+}
+
+func (r *personResolver) Credits(ctx context.Context, obj *Person, role *CreditRole) ([]*PersonCredit, error) {
+	credits, err := r.personCredits(ctx, obj.ID, role)
+	if err != nil {
+		return nil, err
+	}
+	return domainPersonCreditsToGraphQL(credits), nil
+}
+
+func (r *mutationResolver) CreatePerson(ctx context.Context, input PersonInput) (*Person, error) {
+	cmd, err := MapCreatePersonInput(input)
+	if err != nil {
+		return nil, err
+	}
+	p, err := r.personCommandService.CreatePerson(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return domainPersonToGraphQL(p), nil
+}
+
+func (r *mutationResolver) UpdatePerson(ctx context.Context, id string, input PersonInput) (*Person, error) {
+	cmd, err := MapUpdatePersonInput(id, input)
+	if err != nil {
+		return nil, err
+	}
+	p, err := r.personCommandService.UpdatePerson(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+	return domainPersonToGraphQL(p), nil
+}
+
+func (r *mutationResolver) DeletePerson(ctx context.Context, id string) (bool, error) {
+	cmd, err := MapDeletePersonInput(id)
+	if err != nil {
+		return false, err
+	}
+	if err := r.personCommandService.DeletePerson(ctx, cmd); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *mutationResolver) AddCredit(ctx context.Context, input CreditInput) (bool, error) {
+	cmd, err := MapCreditInput(input)
+	if err != nil {
+		return false, err
+	}
+	if _, err := r.personCommandService.AddCredit(ctx, cmd); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *mutationResolver) RemoveCredit(ctx context.Context, personID string, assetID string, role CreditRole) (bool, error) {
+	cmd, err := MapRemoveCreditInput(personID, assetID, role)
+	if err != nil {
+		return false, err
+	}
+	if err := r.personCommandService.RemoveCredit(ctx, cmd); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *queryResolver) Person(ctx context.Context, id string) (*Person, error) {
+	idVO, err := personvo.NewPersonID(id)
+	if err != nil {
+		return nil, err
+	}
+	p, err := r.personQueryService.GetPerson(ctx, personAppQueries.GetPersonQuery{ID: *idVO})
+	if err != nil {
+		return nil, err
+	}
+	return domainPersonToGraphQL(p), nil
+}
+
+func (r *queryResolver) People(ctx context.Context, limit *int, offset *int) ([]*Person, error) {
+	people, err := r.personQueryService.ListPeople(ctx, personAppQueries.ListPeopleQuery{Limit: limit, Offset: offset})
+	if err != nil {
+		return nil, err
+	}
+	return domainPeopleToGraphQL(people), nil
+}
+
+func (r *queryResolver) SearchPeople(ctx context.Context, query string, limit *int, offset *int) ([]*Person, error) {
+	people, err := r.personQueryService.SearchPeople(ctx, personAppQueries.SearchPeopleQuery{Query: query, Limit: limit, Offset: offset})
+	if err != nil {
+		return nil, err
+	}
+	return domainPeopleToGraphQL(people), nil
+}
+
+// AssetsByPerson lists each asset once, even when the person holds several
+// roles on it.
+func (r *queryResolver) AssetsByPerson(ctx context.Context, personID string, role *CreditRole) ([]*Asset, error) {
+	credits, err := r.personCredits(ctx, personID, role)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(credits))
+	out := make([]*Asset, 0, len(credits))
+	for _, c := range credits {
+		if seen[c.AssetID()] {
+			continue
+		}
+		seen[c.AssetID()] = true
+		a, err := r.assetQueryService.GetAsset(ctx, assetAppQueries.GetAssetQuery{ID: c.AssetID()})
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, domainAssetToGraphQL(a))
+	}
+	return out, nil
+}
+
+func (r *queryResolver) Collaborators(ctx context.Context, personID string, limit *int) ([]*Collaborator, error) {
+	idVO, err := personvo.NewPersonID(personID)
+	if err != nil {
+		return nil, err
+	}
+	collaborators, err := r.personQueryService.GetCollaborators(ctx, personAppQueries.GetCollaboratorsQuery{PersonID: *idVO, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	return domainCollaboratorsToGraphQL(collaborators), nil
+}
+
+func (r *Resolver) personCredits(ctx context.Context, personID string, role *CreditRole) ([]*personentity.Credit, error) {
+	idVO, err := personvo.NewPersonID(personID)
+	if err != nil {
+		return nil, err
+	}
+	roleVO, err := MapCreditRole(role)
+	if err != nil {
+		return nil, err
+	}
+	return r.personQueryService.GetPersonCredits(ctx, personAppQueries.GetPersonCreditsQuery{PersonID: *idVO, Role: roleVO})
+}
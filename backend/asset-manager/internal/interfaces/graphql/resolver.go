@@ -6,7 +6,10 @@ import (
 	appasset "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/asset"
 	appbucket "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/bucket"
 	cdn "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/cdn"
+	applifecycle "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/lifecycle"
+	appperson "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/person"
 	apppipeline "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/application/pipeline"
+	assetentity "github.com/serdarburakguneri/hobby-streamer/backend/asset-manager/internal/domain/asset/entity"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
 )
 
@@ -15,6 +18,8 @@ type Resolver struct {
 	assetQueryService    *appasset.QueryService
 	bucketCommandService *appbucket.CommandService
 	bucketQueryService   *appbucket.QueryService
+	personCommandService *appperson.CommandService
+	personQueryService   *appperson.QueryService
 	cdnService           cdn.Service
 	pipelineService      *apppipeline.Service
 	publisher            interface {
@@ -27,6 +32,8 @@ func NewResolver(
 	assetQueryService *appasset.QueryService,
 	bucketCommandService *appbucket.CommandService,
 	bucketQueryService *appbucket.QueryService,
+	personCommandService *appperson.CommandService,
+	personQueryService *appperson.QueryService,
 	cdnService cdn.Service,
 	pipelineService *apppipeline.Service,
 	publisher interface {
@@ -38,6 +45,8 @@ func NewResolver(
 		assetQueryService:    assetQueryService,
 		bucketCommandService: bucketCommandService,
 		bucketQueryService:   bucketQueryService,
+		personCommandService: personCommandService,
+		personQueryService:   personQueryService,
 		cdnService:           cdnService,
 		pipelineService:      pipelineService,
 		publisher:            publisher,
@@ -47,7 +56,27 @@ func NewResolver(
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 func (r *Resolver) Query() QueryResolver       { return &queryResolver{r} }
 func (r *Resolver) Bucket() BucketResolver     { return &bucketResolver{r} }
+func (r *Resolver) Asset() AssetResolver       { return &assetResolver{r} }
+func (r *Resolver) Person() PersonResolver     { return &personResolver{r} }
+func (r *Resolver) Video() VideoResolver       { return &videoResolver{r} }
 
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
 type bucketResolver struct{ *Resolver }
+type assetResolver struct{ *Resolver }
+type personResolver struct{ *Resolver }
+type videoResolver struct{ *Resolver }
+
+// assetTransition is one of the lifecycle service's status changes, taken as
+// a method expression such as (*applifecycle.Service).Publish.
+type assetTransition func(s *applifecycle.Service, ctx context.Context, assetID, note string) (*assetentity.Asset, error)
+
+// changeAssetStatus backs the lifecycle mutations, which differ only in the
+// transition they apply.
+func (r *Resolver) changeAssetStatus(ctx context.Context, transition assetTransition, id string, note *string) (*Asset, error) {
+	a, err := transition(applifecycle.NewService(r.assetCommandService, r.publisher), ctx, id, MapStatusNote(note))
+	if err != nil {
+		return nil, err
+	}
+	return domainAssetToGraphQL(a), nil
+}
@@ -1,12 +1,32 @@
 package auth
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type User struct {
 	ID       string   `json:"id"`
 	Username string   `json:"username"`
 	Email    string   `json:"email"`
 	Roles    []string `json:"roles"`
+	// BirthDate is the OIDC birthdate claim, YYYY-MM-DD when the realm maps
+	// it.
+	BirthDate string `json:"birthdate,omitempty"`
+}
+
+// Age is the user's age in whole years at now, or nil when the token has no
+// full birth date.
+func (u *User) Age(now time.Time) *int {
+	born, err := time.Parse("2006-01-02", u.BirthDate)
+	if err != nil || born.Year() == 0 {
+		return nil
+	}
+	age := now.Year() - born.Year()
+	if now.Month() < born.Month() || now.Month() == born.Month() && now.Day() < born.Day() {
+		age--
+	}
+	return &age
 }
 
 type TokenValidator interface {
@@ -91,9 +91,10 @@ func (k *KeycloakValidator) ValidateToken(ctx context.Context, token string) (*U
 	}
 
 	user := &User{
-		ID:       getStringClaim(claims, "sub"),
-		Username: getStringClaim(claims, "preferred_username"),
-		Email:    getStringClaim(claims, "email"),
+		ID:        getStringClaim(claims, "sub"),
+		Username:  getStringClaim(claims, "preferred_username"),
+		Email:     getStringClaim(claims, "email"),
+		BirthDate: getStringClaim(claims, "birthdate"),
 	}
 
 	if realmAccess, ok := claims["realm_access"].(map[string]interface{}); ok {
@@ -15,6 +15,13 @@ const (
 	serviceUserContextKey contextKey = "service_user"
 )
 
+// UserFromContext returns the user a RequireUserAuth middleware put on the
+// request context.
+func UserFromContext(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(userContextKey).(*User)
+	return user, ok
+}
+
 type AuthMiddleware struct {
 	validator   TokenValidator
 	userAuth    bool
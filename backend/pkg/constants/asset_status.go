@@ -1,17 +1,25 @@
 package constants
 
 const (
-	AssetStatusDraft     = "draft"
-	AssetStatusPublished = "published"
-	AssetStatusScheduled = "scheduled"
-	AssetStatusExpired   = "expired"
+	AssetStatusDraft       = "draft"
+	AssetStatusInReview    = "in_review"
+	AssetStatusApproved    = "approved"
+	AssetStatusScheduled   = "scheduled"
+	AssetStatusPublished   = "published"
+	AssetStatusUnpublished = "unpublished"
+	AssetStatusArchived    = "archived"
+	AssetStatusExpired     = "expired"
 )
 
 var AllowedAssetStatuses = map[string]struct{}{
-	AssetStatusDraft:     {},
-	AssetStatusPublished: {},
-	AssetStatusScheduled: {},
-	AssetStatusExpired:   {},
+	AssetStatusDraft:       {},
+	AssetStatusInReview:    {},
+	AssetStatusApproved:    {},
+	AssetStatusScheduled:   {},
+	AssetStatusPublished:   {},
+	AssetStatusUnpublished: {},
+	AssetStatusArchived:    {},
+	AssetStatusExpired:     {},
 }
 
 func IsValidAssetStatus(s string) bool {
@@ -3,6 +3,7 @@ package constants
 const (
 	VideoFormatHLS  = "hls"
 	VideoFormatDASH = "dash"
+	VideoFormatCMAF = "cmaf"
 	VideoFormatRAW  = "raw"
 	VideoFormatMP4  = "mp4"
 	VideoFormatWEBM = "webm"
@@ -14,6 +15,7 @@ const (
 var AllowedVideoFormats = map[string]struct{}{
 	VideoFormatHLS:  {},
 	VideoFormatDASH: {},
+	VideoFormatCMAF: {},
 	VideoFormatRAW:  {},
 	VideoFormatMP4:  {},
 	VideoFormatWEBM: {},
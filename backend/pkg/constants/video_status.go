@@ -6,6 +6,8 @@ const (
 	VideoStatusTranscoding = "transcoding"
 	VideoStatusReady       = "ready"
 	VideoStatusFailed      = "failed"
+	VideoStatusCancelled   = "cancelled"
+	VideoStatusFailedQC    = "failed_qc"
 )
 
 var AllowedVideoStatuses = map[string]struct{}{
@@ -14,6 +16,8 @@ var AllowedVideoStatuses = map[string]struct{}{
 	VideoStatusTranscoding: {},
 	VideoStatusReady:       {},
 	VideoStatusFailed:      {},
+	VideoStatusCancelled:   {},
+	VideoStatusFailedQC:    {},
 }
 
 func IsValidVideoStatus(s string) bool {
@@ -4,12 +4,14 @@ const (
 	VideoStreamingFormatRaw  = "raw"
 	VideoStreamingFormatHLS  = "hls"
 	VideoStreamingFormatDASH = "dash"
+	VideoStreamingFormatCMAF = "cmaf"
 )
 
 var AllowedVideoStreamingFormats = map[string]struct{}{
 	VideoStreamingFormatRaw:  {},
 	VideoStreamingFormatHLS:  {},
 	VideoStreamingFormatDASH: {},
+	VideoStreamingFormatCMAF: {},
 }
 
 func IsValidVideoStreamingFormat(f string) bool {
@@ -0,0 +1,153 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
+)
+
+// BroadcastConsumer reads every partition of its topics without a consumer
+// group, so each running instance sees every message. It starts at the
+// newest offset and commits nothing, which suits signals that only matter to
+// the processes running when they are sent, such as cancels. Partitions
+// added after Start are not picked up until the next start.
+type BroadcastConsumer struct {
+	consumer   sarama.Consumer
+	logger     *logger.Logger
+	handlers   handlerRegistry
+	topics     []string
+	mu         sync.Mutex
+	partitions []sarama.PartitionConsumer
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+// NewBroadcastConsumer uses the config's BootstrapServers and Topics; the
+// group settings do not apply.
+func NewBroadcastConsumer(ctx context.Context, config *ConsumerConfig) (*BroadcastConsumer, error) {
+	if config == nil || len(config.Topics) == 0 {
+		return nil, fmt.Errorf("invalid consumer configuration: Topics must be set")
+	}
+
+	saramaConfig := sarama.NewConfig()
+	saramaConfig.Consumer.Offsets.Initial = sarama.OffsetNewest
+	saramaConfig.Version = sarama.V2_8_1_0
+
+	consumer, err := sarama.NewConsumer(config.BootstrapServers, saramaConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kafka consumer: %w", err)
+	}
+
+	return newBroadcastConsumer(ctx, consumer, config.Topics), nil
+}
+
+func newBroadcastConsumer(ctx context.Context, consumer sarama.Consumer, topics []string) *BroadcastConsumer {
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &BroadcastConsumer{
+		consumer: consumer,
+		logger:   logger.WithService("kafka-broadcast-consumer"),
+		topics:   topics,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+func (c *BroadcastConsumer) Subscribe(topic string, handler EventHandler) {
+	c.handlers.set(topic, handler)
+	c.logger.Info("Subscribed to topic", "topic", topic)
+}
+
+// Start consumes until ctx is done or Stop is called. Topics that do not
+// exist yet are retried every second.
+func (c *BroadcastConsumer) Start(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-c.ctx.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	c.logger.Info("Starting Kafka broadcast consumer", "topics", c.topics)
+
+	var wg sync.WaitGroup
+	for _, topic := range c.topics {
+		partitions, err := c.partitionsOf(ctx, topic)
+		if err != nil {
+			wg.Wait()
+			return err
+		}
+		for _, partition := range partitions {
+			pc, err := c.consumer.ConsumePartition(topic, partition, sarama.OffsetNewest)
+			if err != nil {
+				c.logger.WithError(err).Error("Failed to consume partition", "topic", topic, "partition", partition)
+				continue
+			}
+			c.mu.Lock()
+			c.partitions = append(c.partitions, pc)
+			c.mu.Unlock()
+
+			wg.Add(1)
+			go func(pc sarama.PartitionConsumer) {
+				defer wg.Done()
+				c.consume(ctx, pc)
+			}(pc)
+		}
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+func (c *BroadcastConsumer) partitionsOf(ctx context.Context, topic string) ([]int32, error) {
+	for {
+		partitions, err := c.consumer.Partitions(topic)
+		if err == nil {
+			return partitions, nil
+		}
+		c.logger.WithError(err).Error("Failed to list partitions", "topic", topic)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func (c *BroadcastConsumer) consume(ctx context.Context, pc sarama.PartitionConsumer) {
+	for {
+		select {
+		case message, ok := <-pc.Messages():
+			if !ok {
+				return
+			}
+			if err := c.handlers.process(ctx, c.logger, message); err != nil {
+				c.logger.WithError(err).Error("Failed to process message",
+					"topic", message.Topic,
+					"partition", message.Partition,
+					"offset", message.Offset,
+				)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *BroadcastConsumer) Stop() error {
+	c.logger.Info("Stopping Kafka broadcast consumer", "topics", c.topics)
+	c.cancel()
+	c.mu.Lock()
+	for _, pc := range c.partitions {
+		_ = pc.Close()
+	}
+	c.partitions = nil
+	c.mu.Unlock()
+	return c.consumer.Close()
+}
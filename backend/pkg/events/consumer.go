@@ -16,10 +16,9 @@ type EventHandler func(ctx context.Context, event *Event) error
 type Consumer struct {
 	consumer sarama.ConsumerGroup
 	logger   *logger.Logger
-	handlers map[string]EventHandler
+	handlers handlerRegistry
 	topics   []string
 	groupID  string
-	mu       sync.RWMutex
 	ctx      context.Context
 	cancel   context.CancelFunc
 }
@@ -67,7 +66,6 @@ func NewConsumer(ctx context.Context, config *ConsumerConfig) (*Consumer, error)
 	return &Consumer{
 		consumer: consumer,
 		logger:   logger.WithService("kafka-consumer"),
-		handlers: make(map[string]EventHandler),
 		topics:   config.Topics,
 		groupID:  config.GroupID,
 		ctx:      ctx,
@@ -76,9 +74,7 @@ func NewConsumer(ctx context.Context, config *ConsumerConfig) (*Consumer, error)
 }
 
 func (c *Consumer) Subscribe(topic string, handler EventHandler) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	c.handlers[topic] = handler
+	c.handlers.set(topic, handler)
 	c.logger.Info("Subscribed to topic", "topic", topic, "group_id", c.groupID)
 }
 
@@ -154,6 +150,26 @@ func (c *Consumer) ConsumeClaim(session sarama.ConsumerGroupSession, claim saram
 }
 
 func (c *Consumer) processMessage(ctx context.Context, message *sarama.ConsumerMessage) error {
+	return c.handlers.process(ctx, c.logger, message)
+}
+
+// handlerRegistry maps topics to handlers for the consumers in this package.
+type handlerRegistry struct {
+	mu       sync.RWMutex
+	handlers map[string]EventHandler
+}
+
+func (r *handlerRegistry) set(topic string, handler EventHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.handlers == nil {
+		r.handlers = make(map[string]EventHandler)
+	}
+	r.handlers[topic] = handler
+}
+
+// process decodes message and runs the handler subscribed to its topic.
+func (r *handlerRegistry) process(ctx context.Context, log *logger.Logger, message *sarama.ConsumerMessage) error {
 	var event Event
 	if err := json.Unmarshal(message.Value, &event); err != nil {
 		return fmt.Errorf("failed to unmarshal event: %w", err)
@@ -163,16 +179,16 @@ func (c *Consumer) processMessage(ctx context.Context, message *sarama.ConsumerM
 		return fmt.Errorf("invalid event: %w", err)
 	}
 
-	c.mu.RLock()
-	handler, exists := c.handlers[message.Topic]
-	c.mu.RUnlock()
+	r.mu.RLock()
+	handler, exists := r.handlers[message.Topic]
+	r.mu.RUnlock()
 
 	if !exists {
-		c.logger.Warn("No handler registered for topic", "topic", message.Topic, "event_type", event.Type)
+		log.Warn("No handler registered for topic", "topic", message.Topic, "event_type", event.Type)
 		return nil
 	}
 
-	c.logger.Debug("Processing event",
+	log.Debug("Processing event",
 		"topic", message.Topic,
 		"event_id", event.ID,
 		"event_type", event.Type,
@@ -59,6 +59,15 @@ func (e *Event) SetEventVersion(version string) *Event {
 	return e
 }
 
+// SetDataField adds a field to an event whose data is a map, as built by the
+// New*Event constructors.
+func (e *Event) SetDataField(key string, value interface{}) *Event {
+	if data, ok := e.Data.(map[string]interface{}); ok {
+		data[key] = value
+	}
+	return e
+}
+
 func (e *Event) AddExtension(key string, value interface{}) *Event {
 	if e.Extensions == nil {
 		e.Extensions = make(map[string]interface{})
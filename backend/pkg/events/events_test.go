@@ -1,10 +1,14 @@
 package events
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -115,3 +119,162 @@ func TestEventString(t *testing.T) {
 	assert.Contains(t, str, event.ID)
 	assert.Contains(t, str, event.Type)
 }
+
+func eventMessage(t *testing.T, eventType string) *sarama.ConsumerMessage {
+	t.Helper()
+	event := NewEvent(eventType, nil)
+	event.SetSource("test-service")
+	value, err := json.Marshal(event)
+	require.NoError(t, err)
+	return &sarama.ConsumerMessage{Value: value}
+}
+
+// startBroadcast runs Start in the background and returns its result.
+func startBroadcast(ctx context.Context, c *BroadcastConsumer) <-chan error {
+	done := make(chan error, 1)
+	go func() { done <- c.Start(ctx) }()
+	return done
+}
+
+func waitStopped(t *testing.T, done <-chan error) error {
+	t.Helper()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start did not return")
+		return nil
+	}
+}
+
+func TestBroadcastConsumer_ReadsEveryPartition(t *testing.T) {
+	mock := mocks.NewConsumer(t, nil)
+	mock.SetTopicMetadata(map[string][]int32{"jobs.cancel": {0, 1, 2}})
+	var partitions []*mocks.PartitionConsumer
+	for p := int32(0); p < 3; p++ {
+		partitions = append(partitions, mock.ExpectConsumePartition("jobs.cancel", p, sarama.OffsetNewest))
+	}
+
+	c := newBroadcastConsumer(context.Background(), mock, []string{"jobs.cancel"})
+	seen := make(chan string, 3)
+	c.Subscribe("jobs.cancel", func(ctx context.Context, event *Event) error {
+		seen <- event.Type
+		return nil
+	})
+	done := startBroadcast(context.Background(), c)
+
+	for i, pc := range partitions {
+		pc.YieldMessage(eventMessage(t, fmt.Sprintf("job.cancel.%d", i)))
+	}
+	var got []string
+	for range partitions {
+		select {
+		case eventType := <-seen:
+			got = append(got, eventType)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("handled %v, want one event per partition", got)
+		}
+	}
+	assert.ElementsMatch(t, []string{"job.cancel.0", "job.cancel.1", "job.cancel.2"}, got)
+
+	require.NoError(t, c.Stop())
+	assert.ErrorIs(t, waitStopped(t, done), context.Canceled)
+}
+
+func TestBroadcastConsumer_DispatchesByTopic(t *testing.T) {
+	mock := mocks.NewConsumer(t, nil)
+	mock.SetTopicMetadata(map[string][]int32{"jobs.cancel": {0}, "live.stop": {0}, "unhandled": {0}})
+	cancels := mock.ExpectConsumePartition("jobs.cancel", 0, sarama.OffsetNewest)
+	stops := mock.ExpectConsumePartition("live.stop", 0, sarama.OffsetNewest)
+	unhandled := mock.ExpectConsumePartition("unhandled", 0, sarama.OffsetNewest)
+
+	c := newBroadcastConsumer(context.Background(), mock, []string{"jobs.cancel", "live.stop", "unhandled"})
+	type handled struct{ topic, eventType string }
+	seen := make(chan handled, 4)
+	c.Subscribe("jobs.cancel", func(ctx context.Context, event *Event) error {
+		seen <- handled{"jobs.cancel", event.Type}
+		return fmt.Errorf("job not running")
+	})
+	c.Subscribe("live.stop", func(ctx context.Context, event *Event) error {
+		seen <- handled{"live.stop", event.Type}
+		return nil
+	})
+	done := startBroadcast(context.Background(), c)
+
+	// Neither an unhandled topic, a malformed message nor a failing handler
+	// stops the partition.
+	unhandled.YieldMessage(eventMessage(t, "ignored"))
+	cancels.YieldMessage(&sarama.ConsumerMessage{Value: []byte("not json")})
+	cancels.YieldMessage(eventMessage(t, "job.cancel.first"))
+	cancels.YieldMessage(eventMessage(t, "job.cancel.second"))
+	stops.YieldMessage(eventMessage(t, "live.stop"))
+
+	var got []handled
+	for len(got) < 3 {
+		select {
+		case h := <-seen:
+			got = append(got, h)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("handled %v, want 3 events", got)
+		}
+	}
+	assert.ElementsMatch(t, []handled{
+		{"jobs.cancel", "job.cancel.first"},
+		{"jobs.cancel", "job.cancel.second"},
+		{"live.stop", "live.stop"},
+	}, got)
+
+	require.NoError(t, c.Stop())
+	waitStopped(t, done)
+	assert.Empty(t, seen)
+}
+
+func TestBroadcastConsumer_Stop(t *testing.T) {
+	t.Run("StopsStart", func(t *testing.T) {
+		mock := mocks.NewConsumer(t, nil)
+		mock.SetTopicMetadata(map[string][]int32{"jobs.cancel": {0}})
+		mock.ExpectConsumePartition("jobs.cancel", 0, sarama.OffsetNewest)
+
+		c := newBroadcastConsumer(context.Background(), mock, []string{"jobs.cancel"})
+		done := startBroadcast(context.Background(), c)
+		require.Eventually(t, func() bool {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			return len(c.partitions) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+
+		require.NoError(t, c.Stop())
+		assert.ErrorIs(t, waitStopped(t, done), context.Canceled)
+		assert.Empty(t, c.partitions)
+	})
+
+	t.Run("WhileWaitingForTopic", func(t *testing.T) {
+		mock := mocks.NewConsumer(t, nil)
+		mock.SetTopicMetadata(map[string][]int32{})
+
+		c := newBroadcastConsumer(context.Background(), mock, []string{"not.created"})
+		done := startBroadcast(context.Background(), c)
+
+		require.NoError(t, c.Stop())
+		assert.ErrorIs(t, waitStopped(t, done), context.Canceled)
+	})
+
+	t.Run("WhenStartContextEnds", func(t *testing.T) {
+		mock := mocks.NewConsumer(t, nil)
+		mock.SetTopicMetadata(map[string][]int32{"jobs.cancel": {0}})
+		mock.ExpectConsumePartition("jobs.cancel", 0, sarama.OffsetNewest)
+
+		c := newBroadcastConsumer(context.Background(), mock, []string{"jobs.cancel"})
+		ctx, cancel := context.WithCancel(context.Background())
+		done := startBroadcast(ctx, c)
+		require.Eventually(t, func() bool {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			return len(c.partitions) == 1
+		}, 5*time.Second, 10*time.Millisecond)
+
+		cancel()
+		assert.ErrorIs(t, waitStopped(t, done), context.Canceled)
+		require.NoError(t, c.Stop())
+	})
+}
@@ -3,10 +3,11 @@ package events
 const (
 	EventNamespace = "com.hobbystreamer"
 
-	AssetCreatedEventType   = EventNamespace + ".asset.created"
-	AssetUpdatedEventType   = EventNamespace + ".asset.updated"
-	AssetDeletedEventType   = EventNamespace + ".asset.deleted"
-	AssetPublishedEventType = EventNamespace + ".asset.published"
+	AssetCreatedEventType     = EventNamespace + ".asset.created"
+	AssetUpdatedEventType     = EventNamespace + ".asset.updated"
+	AssetDeletedEventType     = EventNamespace + ".asset.deleted"
+	AssetPublishedEventType   = EventNamespace + ".asset.published"
+	AssetUnpublishedEventType = EventNamespace + ".asset.unpublished"
 
 	VideoAddedEventType         = EventNamespace + ".video.added"
 	VideoRemovedEventType       = EventNamespace + ".video.removed"
@@ -22,6 +23,14 @@ const (
 	JobTranscodeRequestedEventType = EventNamespace + ".job.transcode.requested"
 	JobAnalyzeCompletedEventType   = EventNamespace + ".job.analyze.completed"
 	JobTranscodeCompletedEventType = EventNamespace + ".job.transcode.completed"
+	JobTranscodeProgressEventType  = EventNamespace + ".job.transcode.progress"
+	JobTranscodeCancelEventType    = EventNamespace + ".job.transcode.cancel"
+
+	LiveIngestRequestedEventType = EventNamespace + ".live.ingest.requested"
+	LiveIngestStopEventType      = EventNamespace + ".live.ingest.stop"
+	LiveStreamReadyEventType     = EventNamespace + ".live.stream.ready"
+	LiveStreamStartedEventType   = EventNamespace + ".live.stream.started"
+	LiveStreamStoppedEventType   = EventNamespace + ".live.stream.stopped"
 
 	ContentAnalysisRequestedEventType = EventNamespace + ".content.analysis.requested"
 	ContentAnalysisCompletedEventType = EventNamespace + ".content.analysis.completed"
@@ -44,10 +53,26 @@ const (
 	AnalyzeJobRequestedTopic = "analyze.job.requested"
 	HLSJobRequestedTopic     = "hls.job.requested"
 	DASHJobRequestedTopic    = "dash.job.requested"
+	CMAFJobRequestedTopic    = "cmaf.job.requested"
 
 	AnalyzeJobCompletedTopic = "analyze.job.completed"
 	HLSJobCompletedTopic     = "hls.job.completed"
 	DASHJobCompletedTopic    = "dash.job.completed"
+	CMAFJobCompletedTopic    = "cmaf.job.completed"
+
+	ThumbnailsJobRequestedTopic = "thumbnails.job.requested"
+	ThumbnailsJobCompletedTopic = "thumbnails.job.completed"
+	MarkersJobRequestedTopic    = "markers.job.requested"
+	MarkersJobCompletedTopic    = "markers.job.completed"
+
+	TranscodeJobProgressTopic = "transcode.job.progress"
+	TranscodeJobCancelTopic   = "transcode.job.cancel"
+
+	LiveIngestRequestedTopic = "live.ingest.requested"
+	LiveIngestStopTopic      = "live.ingest.stop"
+	LiveStreamReadyTopic     = "live.stream.ready"
+	LiveStreamStartedTopic   = "live.stream.started"
+	LiveStreamStoppedTopic   = "live.stream.stopped"
 
 	CDNInvalidationRequestedTopic = "cdn.invalidate.requested"
 )
@@ -90,6 +115,13 @@ func NewAssetPublishedEvent(assetID, slug string) *Event {
 	})
 }
 
+func NewAssetUnpublishedEvent(assetID, slug string) *Event {
+	return NewEvent(AssetUnpublishedEventType, map[string]interface{}{
+		"assetId": assetID,
+		"slug":    slug,
+	})
+}
+
 func NewVideoAddedEvent(assetID, videoID, label, format string) *Event {
 	return NewEvent(VideoAddedEventType, map[string]interface{}{
 		"assetId": assetID,
@@ -159,15 +191,59 @@ func NewJobAnalyzeRequestedEvent(assetID, videoID, input string) *Event {
 	})
 }
 
-func NewJobTranscodeRequestedEvent(assetID, videoID, input, format, outputBucket, outputKey string) *Event {
-	return NewEvent(JobTranscodeRequestedEventType, map[string]interface{}{
-		"assetId":      assetID,
-		"videoId":      videoID,
-		"input":        input,
-		"format":       format,
-		"outputBucket": outputBucket,
-		"outputKey":    outputKey,
-		"jobType":      "transcode",
+// TranscodeOverlay burns a logo and/or a line of text into a transcode and
+// stores the result as a separate variant under Label. Image is the storage
+// URL of the logo.
+type TranscodeOverlay struct {
+	Label    string  `json:"label"`
+	Image    string  `json:"image,omitempty"`
+	Position string  `json:"position,omitempty"`
+	Opacity  float64 `json:"opacity,omitempty"`
+	Text     string  `json:"text,omitempty"`
+}
+
+// NewJobTranscodeRequestedEvent requests a clean transcode when overlay is nil.
+func NewJobTranscodeRequestedEvent(assetID, videoID, input, format, outputBucket, outputKey string, sourceWidth, sourceHeight int, sourceDuration float64, overlay *TranscodeOverlay) *Event {
+	data := map[string]interface{}{
+		"assetId":        assetID,
+		"videoId":        videoID,
+		"input":          input,
+		"format":         format,
+		"outputBucket":   outputBucket,
+		"outputKey":      outputKey,
+		"sourceWidth":    sourceWidth,
+		"sourceHeight":   sourceHeight,
+		"sourceDuration": sourceDuration,
+		"jobType":        "transcode",
+	}
+	if overlay != nil {
+		data["overlay"] = overlay
+	}
+	return NewEvent(JobTranscodeRequestedEventType, data)
+}
+
+func NewJobTranscodeCancelEvent(assetID, videoID, format string) *Event {
+	return NewEvent(JobTranscodeCancelEventType, map[string]interface{}{
+		"assetId": assetID,
+		"videoId": videoID,
+		"format":  format,
+		"jobType": "transcode",
+	})
+}
+
+func NewLiveIngestRequestedEvent(assetID, sessionID, streamKey, protocol string) *Event {
+	return NewEvent(LiveIngestRequestedEventType, map[string]interface{}{
+		"assetId":   assetID,
+		"sessionId": sessionID,
+		"streamKey": streamKey,
+		"protocol":  protocol,
+	})
+}
+
+func NewLiveIngestStopEvent(assetID, sessionID string) *Event {
+	return NewEvent(LiveIngestStopEventType, map[string]interface{}{
+		"assetId":   assetID,
+		"sessionId": sessionID,
 	})
 }
 
@@ -0,0 +1,83 @@
+package keystore
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+)
+
+// FileStore keeps one JSON file per key. It is meant for local development
+// where transcoder and streaming-api share a volume.
+type FileStore struct {
+	dir string
+}
+
+func NewFileStore(dir string) (*FileStore, error) {
+	if dir == "" {
+		return nil, pkgerrors.NewValidationError("key store directory is required", nil)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, pkgerrors.NewInternalError("failed to create key store directory", err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) Put(ctx context.Context, key *ContentKey) error {
+	path, err := s.path(key.ID)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(key)
+	if err != nil {
+		return pkgerrors.NewInternalError("failed to marshal content key", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return pkgerrors.NewInternalError("failed to write content key", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return pkgerrors.NewInternalError("failed to write content key", err)
+	}
+	return nil
+}
+
+func (s *FileStore) Get(ctx context.Context, id string) (*ContentKey, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, pkgerrors.NewInternalError("failed to read content key", err)
+	}
+	var key ContentKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, pkgerrors.NewInternalError("failed to unmarshal content key", err)
+	}
+	return &key, nil
+}
+
+func (s *FileStore) Delete(ctx context.Context, id string) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return pkgerrors.NewInternalError("failed to delete content key", err)
+	}
+	return nil
+}
+
+func (s *FileStore) path(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.Contains(id, "..") {
+		return "", pkgerrors.NewValidationError("invalid content key ID", nil)
+	}
+	return filepath.Join(s.dir, strings.ReplaceAll(id, ":", "_")+".json"), nil
+}
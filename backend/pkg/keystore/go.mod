@@ -0,0 +1,15 @@
+module github.com/serdarburakguneri/hobby-streamer/backend/pkg/keystore
+
+go 1.23.0
+
+require (
+	github.com/redis/go-redis/v9 v9.11.0
+	github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors v0.0.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
+)
+
+replace github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors => ../errors
@@ -0,0 +1,89 @@
+package keystore
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+)
+
+const (
+	BackendFile  = "file"
+	BackendRedis = "redis"
+
+	keySize = 16
+)
+
+var ErrKeyNotFound = pkgerrors.NewNotFoundError("content key not found", nil)
+
+// ContentKey is the AES-128 key and IV one HLS output of a video is
+// encrypted with.
+type ContentKey struct {
+	ID        string    `json:"id"`
+	AssetID   string    `json:"assetId"`
+	VideoID   string    `json:"videoId"`
+	Name      string    `json:"name"`
+	Method    string    `json:"method"`
+	Key       []byte    `json:"key"`
+	IV        []byte    `json:"iv"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type Store interface {
+	Put(ctx context.Context, key *ContentKey) error
+	Get(ctx context.Context, id string) (*ContentKey, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type Config struct {
+	Backend       string
+	Dir           string
+	RedisAddr     string
+	RedisPassword string
+	RedisDB       int
+}
+
+func New(cfg Config) (Store, error) {
+	switch cfg.Backend {
+	case BackendFile:
+		return NewFileStore(cfg.Dir)
+	case BackendRedis:
+		return NewRedisStore(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB), nil
+	default:
+		return nil, pkgerrors.NewValidationError("unknown key store backend: "+cfg.Backend, nil)
+	}
+}
+
+// KeyID is the store key of a content key. name tells apart the outputs of
+// one video, so a re-encode or a variant gets its own key and never replaces
+// the one already-published segments are encrypted with.
+func KeyID(assetID, videoID, name string) string {
+	return assetID + ":" + videoID + ":" + name
+}
+
+func NewContentKey(assetID, videoID, name, method string) (*ContentKey, error) {
+	key := make([]byte, keySize)
+	iv := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, pkgerrors.NewInternalError("failed to generate content key", err)
+	}
+	if _, err := rand.Read(iv); err != nil {
+		return nil, pkgerrors.NewInternalError("failed to generate content key IV", err)
+	}
+	return &ContentKey{
+		ID:        KeyID(assetID, videoID, name),
+		AssetID:   assetID,
+		VideoID:   videoID,
+		Name:      name,
+		Method:    method,
+		Key:       key,
+		IV:        iv,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+func (k ContentKey) IVHex() string {
+	return "0x" + hex.EncodeToString(k.IV)
+}
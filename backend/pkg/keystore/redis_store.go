@@ -0,0 +1,61 @@
+package keystore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/redis/go-redis/v9"
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+)
+
+const redisKeyPrefix = "content-key:"
+
+type RedisStore struct {
+	client *redis.Client
+}
+
+func NewRedisStore(addr, password string, db int) *RedisStore {
+	return &RedisStore{client: redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       db,
+	})}
+}
+
+func (s *RedisStore) Put(ctx context.Context, key *ContentKey) error {
+	data, err := json.Marshal(key)
+	if err != nil {
+		return pkgerrors.NewInternalError("failed to marshal content key", err)
+	}
+	if err := s.client.Set(ctx, redisKeyPrefix+key.ID, data, 0).Err(); err != nil {
+		return pkgerrors.NewExternalError("failed to store content key", err)
+	}
+	return nil
+}
+
+func (s *RedisStore) Get(ctx context.Context, id string) (*ContentKey, error) {
+	data, err := s.client.Get(ctx, redisKeyPrefix+id).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, pkgerrors.NewExternalError("failed to load content key", err)
+	}
+	var key ContentKey
+	if err := json.Unmarshal(data, &key); err != nil {
+		return nil, pkgerrors.NewInternalError("failed to unmarshal content key", err)
+	}
+	return &key, nil
+}
+
+func (s *RedisStore) Delete(ctx context.Context, id string) error {
+	if err := s.client.Del(ctx, redisKeyPrefix+id).Err(); err != nil {
+		return pkgerrors.NewExternalError("failed to delete content key", err)
+	}
+	return nil
+}
+
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
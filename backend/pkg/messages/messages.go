@@ -1,43 +1,181 @@
 package messages
 
+import "time"
+
 type JobPayload struct {
-	JobID        string `json:"jobId,omitempty"`
-	JobType      string `json:"jobType"`
-	Input        string `json:"input"`
-	AssetID      string `json:"assetId"`
-	VideoID      string `json:"videoId"`
-	Format       string `json:"format,omitempty"`
-	Quality      string `json:"quality,omitempty"`
-	OutputBucket string `json:"outputBucket,omitempty"`
-	OutputKey    string `json:"outputKey,omitempty"`
+	JobID          string              `json:"jobId,omitempty"`
+	JobType        string              `json:"jobType"`
+	Input          string              `json:"input"`
+	AssetID        string              `json:"assetId"`
+	VideoID        string              `json:"videoId"`
+	AssetType      string              `json:"assetType,omitempty"`
+	VideoType      string              `json:"videoType,omitempty"`
+	Format         string              `json:"format,omitempty"`
+	Quality        string              `json:"quality,omitempty"`
+	OutputBucket   string              `json:"outputBucket,omitempty"`
+	OutputKey      string              `json:"outputKey,omitempty"`
+	SourceWidth    int                 `json:"sourceWidth,omitempty"`
+	SourceHeight   int                 `json:"sourceHeight,omitempty"`
+	SourceDuration float64             `json:"sourceDuration,omitempty"`
+	Complexity     float64             `json:"complexity,omitempty"`
+	CorrelationID  string              `json:"correlationId,omitempty"`
+	RequestedAt    time.Time           `json:"requestedAt,omitempty"`
+	Subtitles      []SubtitlePayload   `json:"subtitles,omitempty"`
+	AudioTracks    []AudioTrackPayload `json:"audioTracks,omitempty"`
+	Overlay        *OverlayPayload     `json:"overlay,omitempty"`
+}
+
+// OverlayPayload asks for a watermarked or branded variant stored under
+// Label. Image is the location of the logo to burn in.
+type OverlayPayload struct {
+	Label    string  `json:"label"`
+	Image    string  `json:"image,omitempty"`
+	Position string  `json:"position,omitempty"`
+	Opacity  float64 `json:"opacity,omitempty"`
+	Text     string  `json:"text,omitempty"`
+}
+
+type AudioTrackPayload struct {
+	Index         int    `json:"index"`
+	Language      string `json:"language,omitempty"`
+	Codec         string `json:"codec,omitempty"`
+	Channels      int    `json:"channels,omitempty"`
+	ChannelLayout string `json:"channelLayout,omitempty"`
+	SampleRate    int    `json:"sampleRate,omitempty"`
+	Title         string `json:"title,omitempty"`
+	Default       bool   `json:"default,omitempty"`
+	// Loudness is the track's EBU R128 measurement from analyze.
+	Loudness *LoudnessPayload `json:"loudness,omitempty"`
+}
+
+type LoudnessPayload struct {
+	Integrated float64 `json:"integrated"`
+	TruePeak   float64 `json:"truePeak"`
+	LRA        float64 `json:"lra"`
+	Threshold  float64 `json:"threshold"`
+	Offset     float64 `json:"offset"`
+}
+
+type SubtitlePayload struct {
+	Language string `json:"language"`
+	Kind     string `json:"kind"`
+	Label    string `json:"label,omitempty"`
+	Default  bool   `json:"default,omitempty"`
+	Input    string `json:"input"`
 }
 
 type JobCompletionPayload struct {
-	JobID              string   `json:"jobId,omitempty"`
-	JobType            string   `json:"jobType"`
-	AssetID            string   `json:"assetId"`
-	VideoID            string   `json:"videoId"`
-	Format             string   `json:"format,omitempty"`
-	Success            bool     `json:"success"`
-	Error              string   `json:"error,omitempty"`
-	Width              int      `json:"width,omitempty"`
-	Height             int      `json:"height,omitempty"`
-	Duration           float64  `json:"duration,omitempty"`
-	Bitrate            int      `json:"bitrate,omitempty"`
-	Codec              string   `json:"codec,omitempty"`
-	Size               int64    `json:"size,omitempty"`
-	ContentType        string   `json:"contentType,omitempty"`
-	Bucket             string   `json:"bucket,omitempty"`
-	Key                string   `json:"key,omitempty"`
-	URL                string   `json:"url,omitempty"`
-	SegmentCount       int      `json:"segmentCount,omitempty"`
-	VideoCodec         string   `json:"videoCodec,omitempty"`
-	AudioCodec         string   `json:"audioCodec,omitempty"`
-	AvgSegmentDuration float64  `json:"avgSegmentDuration,omitempty"`
-	Segments           []string `json:"segments,omitempty"`
-	FrameRate          string   `json:"frameRate,omitempty"`
-	AudioChannels      int      `json:"audioChannels,omitempty"`
-	AudioSampleRate    int      `json:"audioSampleRate,omitempty"`
+	JobID              string                  `json:"jobId,omitempty"`
+	JobType            string                  `json:"jobType"`
+	AssetID            string                  `json:"assetId"`
+	VideoID            string                  `json:"videoId"`
+	Format             string                  `json:"format,omitempty"`
+	Success            bool                    `json:"success"`
+	Cancelled          bool                    `json:"cancelled,omitempty"`
+	Error              string                  `json:"error,omitempty"`
+	Width              int                     `json:"width,omitempty"`
+	Height             int                     `json:"height,omitempty"`
+	Duration           float64                 `json:"duration,omitempty"`
+	Bitrate            int                     `json:"bitrate,omitempty"`
+	Codec              string                  `json:"codec,omitempty"`
+	Size               int64                   `json:"size,omitempty"`
+	ContentType        string                  `json:"contentType,omitempty"`
+	Bucket             string                  `json:"bucket,omitempty"`
+	Key                string                  `json:"key,omitempty"`
+	URL                string                  `json:"url,omitempty"`
+	PlaylistKey        string                  `json:"playlistKey,omitempty"`
+	PlaylistURL        string                  `json:"playlistUrl,omitempty"`
+	SegmentCount       int                     `json:"segmentCount,omitempty"`
+	VideoCodec         string                  `json:"videoCodec,omitempty"`
+	AudioCodec         string                  `json:"audioCodec,omitempty"`
+	AvgSegmentDuration float64                 `json:"avgSegmentDuration,omitempty"`
+	Segments           []string                `json:"segments,omitempty"`
+	FrameRate          string                  `json:"frameRate,omitempty"`
+	AudioChannels      int                     `json:"audioChannels,omitempty"`
+	AudioSampleRate    int                     `json:"audioSampleRate,omitempty"`
+	Renditions         []RenditionPayload      `json:"renditions,omitempty"`
+	Images             []ImagePayload          `json:"images,omitempty"`
+	TrackURL           string                  `json:"trackUrl,omitempty"`
+	TrackKey           string                  `json:"trackKey,omitempty"`
+	AudioTracks        []AudioTrackPayload     `json:"audioTracks,omitempty"`
+	Markers            []MarkerPayload         `json:"markers,omitempty"`
+	QualityCheck       *QualityCheckPayload    `json:"qualityCheck,omitempty"`
+	Complexity         float64                 `json:"complexity,omitempty"`
+	Ladder             []LadderRungPayload     `json:"ladder,omitempty"`
+	Rejections         []InputRejectionPayload `json:"rejections,omitempty"`
+	Variant            string                  `json:"variant,omitempty"`
+}
+
+// InputRejectionPayload is one reason the transcoder refused a source.
+type InputRejectionPayload struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// LadderRungPayload is one rung of the ladder a job was encoded with.
+// BaseVideoBitrate is set when per-title encoding scaled the rung.
+type LadderRungPayload struct {
+	Name             string `json:"name"`
+	Width            int    `json:"width"`
+	Height           int    `json:"height"`
+	VideoBitrate     int    `json:"videoBitrate"`
+	AudioBitrate     int    `json:"audioBitrate"`
+	BaseVideoBitrate int    `json:"baseVideoBitrate,omitempty"`
+}
+
+type QualityCheckPayload struct {
+	Passed  bool     `json:"passed"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+type QualityScoresPayload struct {
+	PSNR float64  `json:"psnr"`
+	SSIM float64  `json:"ssim"`
+	VMAF *float64 `json:"vmaf,omitempty"`
+}
+
+type MarkerPayload struct {
+	Kind  string  `json:"kind"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end,omitempty"`
+	Title string  `json:"title,omitempty"`
+}
+
+type RenditionPayload struct {
+	Name         string                `json:"name"`
+	URI          string                `json:"uri,omitempty"`
+	ContentType  string                `json:"contentType,omitempty"`
+	Width        int                   `json:"width,omitempty"`
+	Height       int                   `json:"height,omitempty"`
+	Bandwidth    int                   `json:"bandwidth,omitempty"`
+	Codecs       string                `json:"codecs,omitempty"`
+	SegmentCount int                   `json:"segmentCount,omitempty"`
+	Duration     float64               `json:"duration,omitempty"`
+	Quality      *QualityScoresPayload `json:"quality,omitempty"`
+}
+
+type ImagePayload struct {
+	Kind        string  `json:"kind"`
+	Index       int     `json:"index,omitempty"`
+	URL         string  `json:"url"`
+	Bucket      string  `json:"bucket"`
+	Key         string  `json:"key"`
+	Width       int     `json:"width,omitempty"`
+	Height      int     `json:"height,omitempty"`
+	Size        int64   `json:"size,omitempty"`
+	ContentType string  `json:"contentType"`
+	Offset      float64 `json:"offset,omitempty"`
+}
+
+type JobProgressPayload struct {
+	JobID          string  `json:"jobId,omitempty"`
+	JobType        string  `json:"jobType"`
+	AssetID        string  `json:"assetId"`
+	VideoID        string  `json:"videoId"`
+	Format         string  `json:"format,omitempty"`
+	Progress       float64 `json:"progress"`
+	OutTimeSeconds float64 `json:"outTimeSeconds,omitempty"`
+	ReportedAt     string  `json:"reportedAt"`
 }
 
 const (
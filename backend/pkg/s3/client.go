@@ -158,3 +158,38 @@ func (c *Client) UploadDirectory(ctx context.Context, localDir, bucket, keyPrefi
 
 	return nil
 }
+
+func (c *Client) DeletePrefix(ctx context.Context, bucket, prefix string) error {
+	log := c.logger.WithContext(ctx)
+
+	var objects []*s3.ObjectIdentifier
+	err := c.client.ListObjectsV2PagesWithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, obj := range page.Contents {
+			objects = append(objects, &s3.ObjectIdentifier{Key: obj.Key})
+		}
+		return true
+	})
+	if err != nil {
+		return errors.NewInternalError("failed to list objects in S3", err)
+	}
+
+	for start := 0; start < len(objects); start += 1000 {
+		end := start + 1000
+		if end > len(objects) {
+			end = len(objects)
+		}
+		_, err := c.client.DeleteObjectsWithContext(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(bucket),
+			Delete: &s3.Delete{Objects: objects[start:end], Quiet: aws.Bool(true)},
+		})
+		if err != nil {
+			return errors.NewInternalError("failed to delete objects from S3", err)
+		}
+	}
+
+	log.Info("Deleted objects from S3", "bucket", bucket, "prefix", prefix, "count", len(objects))
+	return nil
+}
@@ -0,0 +1,165 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+)
+
+// FileBackend keeps objects as plain files under root/<bucket>/<key>. It is
+// meant for laptops and tests that run the pipeline without LocalStack.
+type FileBackend struct {
+	root string
+}
+
+func NewFileBackend(root string) (*FileBackend, error) {
+	if root == "" {
+		return nil, pkgerrors.NewValidationError("file storage root is required", nil)
+	}
+	if err := os.MkdirAll(root, 0750); err != nil {
+		return nil, pkgerrors.NewInternalError("failed to create file storage root", err)
+	}
+	return &FileBackend{root: root}, nil
+}
+
+// Download hard-links the object into the temp directory, falling back to a
+// copy across filesystems, so removing the local copy never touches the
+// stored object.
+func (b *FileBackend) Download(ctx context.Context, loc Location) (string, error) {
+	src, err := b.path(loc)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(src); err != nil {
+		if os.IsNotExist(err) {
+			return "", pkgerrors.NewNotFoundError("object not found: "+loc.String(), err)
+		}
+		return "", pkgerrors.NewInternalError("failed to stat object", err)
+	}
+	dst, err := tempPath(loc.Key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.Link(src, dst); err == nil {
+		return dst, nil
+	}
+	if err := copyFile(ctx, src, dst); err != nil {
+		return "", err
+	}
+	return dst, nil
+}
+
+// Upload writes to a temporary name next to the target and renames it, so
+// readers never see a half-written object.
+func (b *FileBackend) Upload(ctx context.Context, localPath string, loc Location) error {
+	dst, err := b.path(loc)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0750); err != nil {
+		return pkgerrors.NewInternalError("failed to create object directory", err)
+	}
+	tmp := dst + ".tmp"
+	if err := copyFile(ctx, localPath, tmp); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, dst); err != nil {
+		os.Remove(tmp)
+		return pkgerrors.NewInternalError("failed to write object", err)
+	}
+	return nil
+}
+
+func (b *FileBackend) DeletePrefix(ctx context.Context, loc Location) error {
+	prefix, err := b.path(loc)
+	if err != nil {
+		return err
+	}
+	if strings.HasSuffix(loc.Key, "/") {
+		if err := os.RemoveAll(prefix); err != nil {
+			return pkgerrors.NewInternalError("failed to delete objects", err)
+		}
+		return nil
+	}
+	dir := filepath.Dir(prefix)
+	err = filepath.Walk(dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !info.IsDir() && strings.HasPrefix(p, prefix) {
+			return os.Remove(p)
+		}
+		return nil
+	})
+	if err != nil {
+		return pkgerrors.NewInternalError("failed to delete objects", err)
+	}
+	return nil
+}
+
+func (b *FileBackend) path(loc Location) (string, error) {
+	if err := loc.validate(); err != nil {
+		return "", err
+	}
+	p := filepath.Join(b.root, loc.Bucket, filepath.FromSlash(path.Clean("/"+loc.Key)))
+	if strings.HasSuffix(loc.Key, "/") {
+		p += string(filepath.Separator)
+	}
+	return p, nil
+}
+
+// tempPath reserves a temp file name that keeps the key's extension, which
+// ffprobe and ffmpeg use to guess the format.
+func tempPath(key string) (string, error) {
+	f, err := os.CreateTemp("", "*-"+path.Base(key))
+	if err != nil {
+		return "", pkgerrors.NewInternalError("failed to create local file", err)
+	}
+	name := f.Name()
+	f.Close()
+	os.Remove(name)
+	return name, nil
+}
+
+func copyFile(ctx context.Context, src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return pkgerrors.NewInternalError("failed to open source file", err)
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return pkgerrors.NewInternalError("failed to create local file", err)
+	}
+	if _, err := io.Copy(out, &contextReader{ctx: ctx, r: in}); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return pkgerrors.NewInternalError("failed to copy file", err)
+	}
+	if err := out.Close(); err != nil {
+		os.Remove(dst)
+		return pkgerrors.NewInternalError("failed to copy file", err)
+	}
+	return nil
+}
+
+// contextReader stops a long copy once the job is cancelled.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c *contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
@@ -0,0 +1,24 @@
+module github.com/serdarburakguneri/hobby-streamer/backend/pkg/storage
+
+go 1.23.0
+
+require (
+	github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors v0.0.0
+	github.com/serdarburakguneri/hobby-streamer/backend/pkg/s3 v0.0.0
+	github.com/stretchr/testify v1.10.0
+)
+
+require (
+	github.com/aws/aws-sdk-go v1.53.0 // indirect
+	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
+	github.com/jmespath/go-jmespath v0.4.0 // indirect
+	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
+	github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger v0.0.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace (
+	github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors => ../errors
+	github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger => ../logger
+	github.com/serdarburakguneri/hobby-streamer/backend/pkg/s3 => ../s3
+)
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+)
+
+// MemoryBackend keeps objects in the process. It is for tests; nothing
+// survives a restart and other services cannot read it.
+type MemoryBackend struct {
+	mu      sync.RWMutex
+	objects map[string][]byte
+}
+
+func NewMemoryBackend() *MemoryBackend {
+	return &MemoryBackend{objects: map[string][]byte{}}
+}
+
+// Put stores data directly, for seeding sources in tests.
+func (b *MemoryBackend) Put(loc Location, data []byte) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.objects[loc.String()] = append([]byte(nil), data...)
+}
+
+func (b *MemoryBackend) Get(loc Location) ([]byte, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	data, ok := b.objects[loc.String()]
+	return data, ok
+}
+
+// Keys lists the keys stored in a bucket under a prefix.
+func (b *MemoryBackend) Keys(loc Location) []string {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	var keys []string
+	for url := range b.objects {
+		if strings.HasPrefix(url, loc.String()) {
+			keys = append(keys, strings.TrimPrefix(url, loc.WithKey("").String()))
+		}
+	}
+	return keys
+}
+
+func (b *MemoryBackend) Download(ctx context.Context, loc Location) (string, error) {
+	data, ok := b.Get(loc)
+	if !ok {
+		return "", pkgerrors.NewNotFoundError("object not found: "+loc.String(), nil)
+	}
+	dst, err := tempPath(loc.Key)
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(dst, data, 0600); err != nil {
+		return "", pkgerrors.NewInternalError("failed to write local file", err)
+	}
+	return dst, nil
+}
+
+func (b *MemoryBackend) Upload(ctx context.Context, localPath string, loc Location) error {
+	data, err := os.ReadFile(localPath)
+	if err != nil {
+		return pkgerrors.NewInternalError("failed to open local file", err)
+	}
+	b.Put(loc, data)
+	return nil
+}
+
+func (b *MemoryBackend) DeletePrefix(ctx context.Context, loc Location) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for url := range b.objects {
+		if strings.HasPrefix(url, loc.String()) {
+			delete(b.objects, url)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+)
+
+// Router sends each location to the backend registered for its scheme.
+type Router struct {
+	scheme   string
+	backends map[string]Backend
+}
+
+func NewRouter(scheme string, backends map[string]Backend) *Router {
+	return &Router{scheme: scheme, backends: backends}
+}
+
+// Scheme is the scheme new objects are written under.
+func (r *Router) Scheme() string {
+	return r.scheme
+}
+
+// Location addresses bucket/key under the default scheme.
+func (r *Router) Location(bucket, key string) Location {
+	return Location{Scheme: r.scheme, Bucket: bucket, Key: key}
+}
+
+func (r *Router) Supports(loc Location) bool {
+	_, ok := r.backends[loc.Scheme]
+	return ok
+}
+
+func (r *Router) Download(ctx context.Context, loc Location) (string, error) {
+	b, err := r.backend(loc)
+	if err != nil {
+		return "", err
+	}
+	return b.Download(ctx, loc)
+}
+
+func (r *Router) Upload(ctx context.Context, localPath string, loc Location) error {
+	b, err := r.backend(loc)
+	if err != nil {
+		return err
+	}
+	return b.Upload(ctx, localPath, loc)
+}
+
+func (r *Router) DeletePrefix(ctx context.Context, loc Location) error {
+	b, err := r.backend(loc)
+	if err != nil {
+		return err
+	}
+	return b.DeletePrefix(ctx, loc)
+}
+
+func (r *Router) backend(loc Location) (Backend, error) {
+	b, ok := r.backends[loc.Scheme]
+	if !ok {
+		return nil, pkgerrors.NewValidationError("no storage backend for scheme "+loc.Scheme, nil)
+	}
+	return b, nil
+}
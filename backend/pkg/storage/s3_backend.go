@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"context"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/s3"
+)
+
+type S3Backend struct {
+	client *s3.Client
+}
+
+func NewS3Backend(client *s3.Client) *S3Backend {
+	return &S3Backend{client: client}
+}
+
+func (b *S3Backend) Download(ctx context.Context, loc Location) (string, error) {
+	return b.client.Download(ctx, loc.String())
+}
+
+func (b *S3Backend) Upload(ctx context.Context, localPath string, loc Location) error {
+	return b.client.Upload(ctx, localPath, loc.Bucket, loc.Key)
+}
+
+func (b *S3Backend) DeletePrefix(ctx context.Context, loc Location) error {
+	return b.client.DeletePrefix(ctx, loc.Bucket, loc.Key)
+}
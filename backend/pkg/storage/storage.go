@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"context"
+	"strings"
+
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/s3"
+)
+
+const (
+	BackendS3     = "s3"
+	BackendFile   = "file"
+	BackendMemory = "memory"
+
+	SchemeS3     = "s3"
+	SchemeFile   = "file"
+	SchemeMemory = "mem"
+)
+
+// Location is an object addressed the same way under every backend:
+// <scheme>://<bucket>/<key>. For the file backend the bucket is a directory
+// under the configured root.
+type Location struct {
+	Scheme string
+	Bucket string
+	Key    string
+}
+
+// Backend stores objects under one URL scheme. Download always leaves a
+// local copy the caller owns and removes.
+type Backend interface {
+	Download(ctx context.Context, loc Location) (string, error)
+	Upload(ctx context.Context, localPath string, loc Location) error
+	DeletePrefix(ctx context.Context, loc Location) error
+}
+
+type Config struct {
+	Backend string
+	Root    string
+}
+
+// Scheme is the URL scheme of the configured backend, the one new outputs
+// are written under. It defaults to S3.
+func (c Config) Scheme() string {
+	switch c.Backend {
+	case BackendFile:
+		return SchemeFile
+	case BackendMemory:
+		return SchemeMemory
+	default:
+		return SchemeS3
+	}
+}
+
+// New builds a Router whose default is the configured backend. With a root
+// directory set, file:// URLs are readable and writable alongside it.
+func New(ctx context.Context, cfg Config) (*Router, error) {
+	backends := map[string]Backend{}
+	switch cfg.Backend {
+	case "", BackendS3:
+		client, err := s3.NewClient(ctx)
+		if err != nil {
+			return nil, err
+		}
+		backends[SchemeS3] = NewS3Backend(client)
+	case BackendFile:
+		if cfg.Root == "" {
+			return nil, pkgerrors.NewValidationError("file storage root is required", nil)
+		}
+	case BackendMemory:
+		backends[SchemeMemory] = NewMemoryBackend()
+	default:
+		return nil, pkgerrors.NewValidationError("unknown storage backend: "+cfg.Backend, nil)
+	}
+	if cfg.Root != "" {
+		file, err := NewFileBackend(cfg.Root)
+		if err != nil {
+			return nil, err
+		}
+		backends[SchemeFile] = file
+	}
+	return NewRouter(cfg.Scheme(), backends), nil
+}
+
+func ParseURL(raw string) (Location, error) {
+	scheme, rest, ok := strings.Cut(raw, "://")
+	if !ok || scheme == "" {
+		return Location{}, pkgerrors.NewValidationError("not a storage URL: "+raw, nil)
+	}
+	bucket, key, _ := strings.Cut(rest, "/")
+	loc := Location{Scheme: strings.ToLower(scheme), Bucket: bucket, Key: key}
+	if err := loc.validate(); err != nil {
+		return Location{}, err
+	}
+	return loc, nil
+}
+
+// IsURL reports whether raw is a URL of one of the known schemes rather than
+// a plain local path.
+func IsURL(raw string) bool {
+	scheme, _, ok := strings.Cut(raw, "://")
+	if !ok {
+		return false
+	}
+	switch strings.ToLower(scheme) {
+	case SchemeS3, SchemeFile, SchemeMemory:
+		return true
+	}
+	return false
+}
+
+func (l Location) String() string {
+	return l.Scheme + "://" + l.Bucket + "/" + l.Key
+}
+
+// WithKey returns the location of another key in the same bucket.
+func (l Location) WithKey(key string) Location {
+	l.Key = key
+	return l
+}
+
+// validate rejects empty parts and ".." segments, which would let a key
+// escape the file backend's root.
+func (l Location) validate() error {
+	if l.Bucket == "" || l.Key == "" {
+		return pkgerrors.NewValidationError("storage URL needs a bucket and a key: "+l.String(), nil)
+	}
+	for _, part := range append([]string{l.Bucket}, strings.Split(l.Key, "/")...) {
+		if part == ".." {
+			return pkgerrors.NewValidationError("invalid storage URL: "+l.String(), nil)
+		}
+	}
+	return nil
+}
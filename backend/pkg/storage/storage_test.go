@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseURL(t *testing.T) {
+	loc, err := ParseURL("file://content/asset-1/hls/main/playlist.m3u8")
+	require.NoError(t, err)
+	assert.Equal(t, Location{Scheme: SchemeFile, Bucket: "content", Key: "asset-1/hls/main/playlist.m3u8"}, loc)
+	assert.Equal(t, "file://content/asset-1/hls/main/playlist.m3u8", loc.String())
+
+	for _, raw := range []string{"/tmp/video.mp4", "s3://bucket", "s3://bucket/", "mem://bucket/../etc/passwd", "://bucket/key"} {
+		_, err := ParseURL(raw)
+		assert.Error(t, err, raw)
+	}
+
+	assert.True(t, IsURL("mem://bucket/key"))
+	assert.True(t, IsURL("S3://bucket/key"))
+	assert.False(t, IsURL("/tmp/video.mp4"))
+	assert.False(t, IsURL("https://bucket.s3.amazonaws.com/key"))
+}
+
+func TestConfigScheme(t *testing.T) {
+	assert.Equal(t, SchemeS3, Config{}.Scheme())
+	assert.Equal(t, SchemeFile, Config{Backend: BackendFile}.Scheme())
+	assert.Equal(t, SchemeMemory, Config{Backend: BackendMemory}.Scheme())
+}
+
+func TestBackends(t *testing.T) {
+	file, err := NewFileBackend(t.TempDir())
+	require.NoError(t, err)
+
+	for name, backend := range map[string]Backend{SchemeFile: file, SchemeMemory: NewMemoryBackend()} {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			src := filepath.Join(t.TempDir(), "segment.ts")
+			require.NoError(t, os.WriteFile(src, []byte("segment"), 0600))
+
+			loc := Location{Scheme: name, Bucket: "content", Key: "asset-1/hls/main/segment.ts"}
+			require.NoError(t, backend.Upload(ctx, src, loc))
+			require.NoError(t, backend.Upload(ctx, src, loc.WithKey("asset-1/hls/other/segment.ts")))
+
+			local, err := backend.Download(ctx, loc)
+			require.NoError(t, err)
+			assert.Equal(t, ".ts", filepath.Ext(local))
+			data, err := os.ReadFile(local)
+			require.NoError(t, err)
+			assert.Equal(t, "segment", string(data))
+			// The local copy is the caller's to remove.
+			require.NoError(t, os.Remove(local))
+			_, err = backend.Download(ctx, loc)
+			require.NoError(t, err)
+
+			require.NoError(t, backend.DeletePrefix(ctx, loc.WithKey("asset-1/hls/main/")))
+			_, err = backend.Download(ctx, loc)
+			assert.Error(t, err)
+			_, err = backend.Download(ctx, loc.WithKey("asset-1/hls/other/segment.ts"))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestRouter(t *testing.T) {
+	ctx := context.Background()
+	mem := NewMemoryBackend()
+	router := NewRouter(SchemeMemory, map[string]Backend{SchemeMemory: mem})
+
+	loc := router.Location("content", "raw/video.mp4")
+	assert.Equal(t, "mem://content/raw/video.mp4", loc.String())
+	assert.True(t, router.Supports(loc))
+
+	mem.Put(loc, []byte("video"))
+	local, err := router.Download(ctx, loc)
+	require.NoError(t, err)
+	defer os.Remove(local)
+
+	_, err = router.Download(ctx, Location{Scheme: SchemeS3, Bucket: "content", Key: "raw/video.mp4"})
+	assert.Error(t, err)
+}
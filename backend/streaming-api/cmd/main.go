@@ -8,7 +8,10 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/auth"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/keystore"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
+	appkey "github.com/serdarburakguneri/hobby-streamer/backend/streaming-api/internal/application/key"
 	sbootstrap "github.com/serdarburakguneri/hobby-streamer/backend/streaming-api/internal/bootstrap"
 	streamevents "github.com/serdarburakguneri/hobby-streamer/backend/streaming-api/internal/infrastructure/events"
 	httphandler "github.com/serdarburakguneri/hobby-streamer/backend/streaming-api/internal/infrastructure/http"
@@ -28,6 +31,26 @@ func main() {
 	assetService, bucketService := sbootstrap.InitServices(cfg, dynamicCfg, secretsManager)
 
 	handler := httphandler.NewHandler(assetService, bucketService, cfg)
+	if backend := dynamicCfg.GetStringFromComponent("keystore", "backend"); backend != "" {
+		keyStore, err := keystore.New(keystore.Config{
+			Backend:       backend,
+			Dir:           dynamicCfg.GetStringFromComponent("keystore", "dir"),
+			RedisAddr:     dynamicCfg.GetStringFromComponent("keystore", "redis_addr"),
+			RedisPassword: secretsManager.Get("keystore_redis_password"),
+			RedisDB:       dynamicCfg.GetIntFromComponent("keystore", "redis_db"),
+		})
+		if err != nil {
+			log.WithError(err).Error("Failed to create key store")
+			os.Exit(1)
+		}
+		validator := auth.NewKeycloakValidator(
+			dynamicCfg.GetStringFromComponent("keycloak", "url"),
+			dynamicCfg.GetStringFromComponent("keycloak", "realm"),
+			dynamicCfg.GetStringFromComponent("keycloak", "client_id"),
+		)
+		requireUser := auth.NewAuthMiddleware(validator).RequireUserAuth().Build()
+		handler.WithKeyDelivery(appkey.NewService(assetService, keyStore), requireUser)
+	}
 	router := handler.SetupRoutes()
 	wrapped := sbootstrap.InitRouter(router, cfg)
 	server := sbootstrap.InitServer(wrapped, cfg)
@@ -10,6 +10,7 @@ require (
 	github.com/serdarburakguneri/hobby-streamer/backend/pkg/constants v0.0.0
 	github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors v0.0.0
 	github.com/serdarburakguneri/hobby-streamer/backend/pkg/events v0.0.0-20250809102723-5210023bd7ed
+	github.com/serdarburakguneri/hobby-streamer/backend/pkg/keystore v0.0.0
 	github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger v0.0.0
 	github.com/serdarburakguneri/hobby-streamer/backend/pkg/resilience v0.0.0
 	github.com/serdarburakguneri/hobby-streamer/backend/pkg/security v0.0.0
@@ -66,8 +67,12 @@ replace github.com/serdarburakguneri/hobby-streamer/backend/pkg/constants => ../
 
 replace github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors => ../pkg/errors
 
+replace github.com/serdarburakguneri/hobby-streamer/backend/pkg/keystore => ../pkg/keystore
+
 replace github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger => ../pkg/logger
 
 replace github.com/serdarburakguneri/hobby-streamer/backend/pkg/security => ../pkg/security
 
 replace github.com/serdarburakguneri/hobby-streamer/backend/pkg/resilience => ../pkg/resilience
+
+replace github.com/serdarburakguneri/hobby-streamer/backend/pkg/events => ../pkg/events
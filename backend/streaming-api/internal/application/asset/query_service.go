@@ -26,6 +26,10 @@ func (s *Service) GetAsset(ctx context.Context, slug assetvalueobjects.Slug) (*a
 	return s.repo.GetBySlug(ctx, slug)
 }
 
+func (s *Service) GetAssetByID(ctx context.Context, id assetvalueobjects.AssetID) (*assetentity.Asset, error) {
+	return s.repo.GetByID(ctx, id)
+}
+
 func (s *Service) GetAssets(ctx context.Context) ([]*assetentity.Asset, error) {
 	return s.repo.GetAll(ctx)
 }
@@ -50,19 +54,56 @@ func (s *Service) GetAssetsInBucket(ctx context.Context, key bucketvalueobjects.
 	return s.bucketRepo.GetAssets(ctx, bkt)
 }
 
-func (s *Service) SearchAssets(ctx context.Context, query string, filters *SearchFilters) ([]*assetentity.Asset, error) {
-	list, err := s.repo.GetAll(ctx)
+// SearchAssets runs a ranked full-text search. Only the type, genre, year
+// and published filters apply; the rest of filters is ignored.
+func (s *Service) SearchAssets(ctx context.Context, query string, filters *SearchFilters, limit, offset int) (*assetentity.SearchResult, error) {
+	criteria := assetrepo.SearchCriteria{
+		Query:  strings.TrimSpace(query),
+		Limit:  limit,
+		Offset: offset,
+	}
+	if filters != nil {
+		criteria.Type = filters.AssetType
+		criteria.Genre = filters.Genre
+		criteria.Year = filters.Year
+		criteria.OnlyPublished = filters.OnlyPublished
+	}
+	return s.repo.Search(ctx, criteria)
+}
+
+// GetAdjacentEpisodes finds the episodes before and after episode in
+// viewing order, across season boundaries. Unpublished episodes are
+// skipped so viewers are only pointed at episodes they can play.
+func (s *Service) GetAdjacentEpisodes(ctx context.Context, episode *assetentity.Asset) (*AdjacentEpisodes, error) {
+	adjacent := &AdjacentEpisodes{}
+	if !episode.IsEpisode() {
+		return adjacent, nil
+	}
+	episodes, err := s.repo.GetSeriesEpisodes(ctx, episode.ID())
 	if err != nil {
 		return nil, err
 	}
-	var out []*assetentity.Asset
-	for _, a := range list {
-		if query != "" && !strings.Contains(strings.ToLower(a.Title().Value()), strings.ToLower(query)) {
-			continue
+
+	current := -1
+	var playable []*assetentity.Asset
+	for _, e := range episodes {
+		if e.ID().Equals(episode.ID()) {
+			current = len(playable)
+			playable = append(playable, e)
+		} else if e.IsPublished() {
+			playable = append(playable, e)
 		}
-		out = append(out, a)
 	}
-	return out, nil
+	if current < 0 {
+		return adjacent, nil
+	}
+	if current > 0 {
+		adjacent.Previous = playable[current-1]
+	}
+	if current < len(playable)-1 {
+		adjacent.Next = playable[current+1]
+	}
+	return adjacent, nil
 }
 
 func (s *Service) GetStreamingInfo(ctx context.Context, slug assetvalueobjects.Slug, userID, region string, userAge int) (*StreamingInfo, error) {
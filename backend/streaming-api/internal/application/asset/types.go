@@ -1,12 +1,14 @@
 package asset
 
 import (
+	"github.com/serdarburakguneri/hobby-streamer/backend/streaming-api/internal/domain/asset/entity"
 	"github.com/serdarburakguneri/hobby-streamer/backend/streaming-api/internal/domain/asset/valueobjects"
 )
 
 type SearchFilters struct {
 	AssetType     *valueobjects.AssetType
 	Genre         *valueobjects.Genre
+	Year          *int
 	OnlyPublic    bool
 	OnlyPublished bool
 	OnlyReady     bool
@@ -14,6 +16,13 @@ type SearchFilters struct {
 	HasImage      bool
 }
 
+// AdjacentEpisodes holds the published episodes on either side of an
+// episode. Either is nil at the ends of a series.
+type AdjacentEpisodes struct {
+	Previous *entity.Asset
+	Next     *entity.Asset
+}
+
 type StreamingInfo struct {
 	AssetID      string
 	Title        string
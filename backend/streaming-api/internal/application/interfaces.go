@@ -13,17 +13,23 @@ import (
 
 type AssetServiceInterface interface {
 	GetAsset(ctx context.Context, slug assetvalueobjects.Slug) (*assetentity.Asset, error)
+	GetAssetByID(ctx context.Context, id assetvalueobjects.AssetID) (*assetentity.Asset, error)
 	GetAssets(ctx context.Context) ([]*assetentity.Asset, error)
 	GetPublicAssets(ctx context.Context) ([]*assetentity.Asset, error)
 	GetAssetsByType(ctx context.Context, assetType assetvalueobjects.AssetType) ([]*assetentity.Asset, error)
 	GetAssetsByGenre(ctx context.Context, genre assetvalueobjects.Genre) ([]*assetentity.Asset, error)
 	GetAssetsInBucket(ctx context.Context, bucketKey bucketvalueobjects.BucketKey) ([]*assetentity.Asset, error)
-	SearchAssets(ctx context.Context, query string, filters *appasset.SearchFilters) ([]*assetentity.Asset, error)
+	SearchAssets(ctx context.Context, query string, filters *appasset.SearchFilters, limit, offset int) (*assetentity.SearchResult, error)
 	GetStreamingInfo(ctx context.Context, slug assetvalueobjects.Slug, userID string, region string, userAge int) (*appasset.StreamingInfo, error)
 	GetRecommendedAssets(ctx context.Context, slug assetvalueobjects.Slug, limit int) ([]*assetentity.Asset, error)
+	GetAdjacentEpisodes(ctx context.Context, episode *assetentity.Asset) (*appasset.AdjacentEpisodes, error)
 	GetPublishStatus(ctx context.Context, slug assetvalueobjects.Slug) (constants.PublishStatus, error)
 }
 
+type KeyServiceInterface interface {
+	GetContentKey(ctx context.Context, assetID, videoID, keyName, region string, userAge *int) ([]byte, error)
+}
+
 type BucketServiceInterface interface {
 	GetBuckets(ctx context.Context, limit int, nextKey *string) ([]*bucketentity.Bucket, error)
 	GetBucket(ctx context.Context, key bucketvalueobjects.BucketKey) (*bucketentity.Bucket, error)
@@ -0,0 +1,48 @@
+package key
+
+import (
+	"context"
+
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/keystore"
+	assetentity "github.com/serdarburakguneri/hobby-streamer/backend/streaming-api/internal/domain/asset/entity"
+	assetvalueobjects "github.com/serdarburakguneri/hobby-streamer/backend/streaming-api/internal/domain/asset/valueobjects"
+)
+
+type AssetFinder interface {
+	GetAssetByID(ctx context.Context, id assetvalueobjects.AssetID) (*assetentity.Asset, error)
+}
+
+// Service hands out HLS content keys. A key is only released while the
+// asset's publish rule allows playback for the caller's region and age; a
+// caller whose region or age is unknown fails a rule that restricts it.
+type Service struct {
+	assets AssetFinder
+	store  keystore.Store
+}
+
+func NewService(assets AssetFinder, store keystore.Store) *Service {
+	return &Service{assets: assets, store: store}
+}
+
+func (s *Service) GetContentKey(ctx context.Context, assetID, videoID, keyName, region string, userAge *int) ([]byte, error) {
+	idVO, err := assetvalueobjects.NewAssetID(assetID)
+	if err != nil {
+		return nil, pkgerrors.NewValidationError("invalid asset ID", err)
+	}
+	asset, err := s.assets.GetAssetByID(ctx, *idVO)
+	if err != nil {
+		return nil, err
+	}
+	if asset == nil || !asset.HasVideoID(videoID) {
+		return nil, pkgerrors.NewNotFoundError("video not found", nil)
+	}
+	if !asset.IsStreamableIn(region) || !asset.IsAgeAppropriateFor(userAge) {
+		return nil, pkgerrors.NewForbiddenError("asset is not available for playback", nil)
+	}
+	key, err := s.store.Get(ctx, keystore.KeyID(assetID, videoID, keyName))
+	if err != nil {
+		return nil, err
+	}
+	return key.Key, nil
+}
@@ -119,3 +119,52 @@ func TestAsset_IsAgeAppropriate(t *testing.T) {
 }
 
 func ptr[T any](v T) *T { return &v }
+
+func TestAsset_IsStreamableIn(t *testing.T) {
+	publishAt := time.Now().UTC().Add(-time.Hour)
+	assetID, _ := valueobjects.NewAssetID("test-id")
+	slug, _ := valueobjects.NewSlug("test-slug")
+	assetType, _ := valueobjects.NewAssetType("movie")
+	published, _ := valueobjects.NewStatus(constants.AssetStatusPublished)
+	newAsset := func(regions []string, ageRating *string) *entity.Asset {
+		pr, err := valueobjects.NewPublishRuleValue(&publishAt, nil, regions, ageRating)
+		assert.NoError(t, err)
+		return entity.NewAsset(*assetID, *slug, nil, nil, *assetType, nil, nil, nil, published, time.Now(), time.Now(), nil, nil, nil, nil, pr)
+	}
+
+	restricted := newAsset([]string{"DE", "NL"}, nil)
+	assert.True(t, restricted.IsStreamableIn("de"))
+	assert.False(t, restricted.IsStreamableIn("US"))
+	assert.False(t, restricted.IsStreamableIn(""), "a missing region must not pass a region rule")
+	assert.True(t, newAsset(nil, nil).IsStreamableIn(""))
+
+	rated := newAsset(nil, ptr(constants.AgeRatingR))
+	assert.True(t, rated.IsAgeAppropriateFor(ptr(18)))
+	assert.False(t, rated.IsAgeAppropriateFor(ptr(16)))
+	assert.False(t, rated.IsAgeAppropriateFor(nil), "an unknown age must not pass a rating with a minimum age")
+	assert.True(t, newAsset(nil, ptr(constants.AgeRatingPG)).IsAgeAppropriateFor(nil))
+}
+
+func TestAsset_IsPublished_FollowsStatus(t *testing.T) {
+	publishAt := time.Now().UTC().Add(-time.Hour)
+	pr, err := valueobjects.NewPublishRuleValue(&publishAt, nil, nil, nil)
+	assert.NoError(t, err)
+	assetID, _ := valueobjects.NewAssetID("test-id")
+	slug, _ := valueobjects.NewSlug("test-slug")
+	assetType, _ := valueobjects.NewAssetType("movie")
+
+	for status, want := range map[string]bool{
+		constants.AssetStatusPublished:   true,
+		constants.AssetStatusDraft:       false,
+		constants.AssetStatusScheduled:   false,
+		constants.AssetStatusUnpublished: false,
+		constants.AssetStatusArchived:    false,
+	} {
+		statusVO, _ := valueobjects.NewStatus(status)
+		asset := entity.NewAsset(*assetID, *slug, nil, nil, *assetType, nil, nil, nil, statusVO, time.Now(), time.Now(), nil, nil, nil, nil, pr)
+		assert.Equal(t, want, asset.IsPublished(), status)
+	}
+
+	noStatus := entity.NewAsset(*assetID, *slug, nil, nil, *assetType, nil, nil, nil, nil, time.Now(), time.Now(), nil, nil, nil, nil, pr)
+	assert.False(t, noStatus.IsPublished())
+}
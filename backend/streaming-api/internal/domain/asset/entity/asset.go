@@ -2,6 +2,7 @@ package entity
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/constants"
@@ -25,6 +26,10 @@ type Asset struct {
 	videos      []Video
 	images      []Image
 	publishRule *valueobjects.PublishRuleValue
+
+	parentID      *valueobjects.AssetID
+	seasonNumber  *int
+	episodeNumber *int
 }
 
 func NewAsset(
@@ -129,7 +134,39 @@ func (a *Asset) PublishRule() *valueobjects.PublishRuleValue {
 	return a.publishRule
 }
 
+func (a *Asset) ParentID() *valueobjects.AssetID {
+	return a.parentID
+}
+
+// SeasonNumber is a season's own number. Episodes read through a series
+// tree carry the number of their season.
+func (a *Asset) SeasonNumber() *int {
+	return a.seasonNumber
+}
+
+func (a *Asset) EpisodeNumber() *int {
+	return a.episodeNumber
+}
+
+// SetHierarchy places the asset within a series.
+func (a *Asset) SetHierarchy(parentID *valueobjects.AssetID, seasonNumber, episodeNumber *int) {
+	a.parentID = parentID
+	a.seasonNumber = seasonNumber
+	a.episodeNumber = episodeNumber
+}
+
+func (a *Asset) IsEpisode() bool {
+	return a.assetType.Value() == constants.AssetTypeEpisode
+}
+
+// IsPublished reports whether viewers may see the asset right now: it has to
+// be published in asset-manager's lifecycle and inside its publish window.
+// Unpublishing or archiving an asset takes it off playback at once, whatever
+// its dates say.
 func (a *Asset) IsPublished() bool {
+	if a.status == nil || a.status.Value() != constants.AssetStatusPublished {
+		return false
+	}
 	if a.publishRule == nil {
 		return false
 	}
@@ -221,6 +258,33 @@ func (a *Asset) IsAvailableInRegion(region string) bool {
 	return false
 }
 
+// IsStreamableIn reports whether the publish rule lets viewers in region play
+// the asset right now. An unknown region only passes a rule without regions.
+func (a *Asset) IsStreamableIn(region string) bool {
+	if !a.IsPublished() {
+		return false
+	}
+	return a.IsAvailableInRegion(strings.ToUpper(region))
+}
+
+func (a *Asset) HasVideoID(videoID string) bool {
+	for i := range a.videos {
+		if a.videos[i].ID().Value() == videoID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAgeAppropriateFor is IsAgeAppropriate for a viewer whose age may be
+// unknown. An unknown age only passes ratings without a minimum age.
+func (a *Asset) IsAgeAppropriateFor(userAge *int) bool {
+	if userAge == nil {
+		return a.IsAgeAppropriate(0)
+	}
+	return a.IsAgeAppropriate(*userAge)
+}
+
 func (a *Asset) IsAgeAppropriate(userAge int) bool {
 	if a.publishRule == nil || a.publishRule.AgeRating() == nil {
 		return true
@@ -0,0 +1,22 @@
+package entity
+
+// SearchResult is one page of assets in relevance order. Total counts every
+// match, and the facets count matches by type, genre and release year,
+// each with the other dimensions' filters applied.
+type SearchResult struct {
+	Assets  []*Asset
+	Total   int
+	HasMore bool
+	Facets  SearchFacets
+}
+
+type SearchFacets struct {
+	Types  []FacetCount
+	Genres []FacetCount
+	Years  []FacetCount
+}
+
+type FacetCount struct {
+	Value string
+	Count int
+}
@@ -38,6 +38,7 @@ type Video struct {
 	audioChannels      *int
 	audioSampleRate    *int
 	transcodingInfo    *valueobjects.TranscodingInfo
+	markers            []valueobjects.Marker
 }
 
 func NewVideo(
@@ -235,3 +236,28 @@ func (v *Video) FrameRate() *string                             { return v.frame
 func (v *Video) AudioChannels() *int                            { return v.audioChannels }
 func (v *Video) AudioSampleRate() *int                          { return v.audioSampleRate }
 func (v *Video) TranscodingInfo() *valueobjects.TranscodingInfo { return v.transcodingInfo }
+func (v *Video) Markers() []valueobjects.Marker                 { return v.markers }
+
+func (v *Video) SetMarkers(markers []valueobjects.Marker) {
+	v.markers = markers
+}
+
+// Intro returns the range players offer to skip, if one was marked.
+func (v *Video) Intro() *valueobjects.Marker {
+	return v.markerOfKind(valueobjects.MarkerKindIntro)
+}
+
+// Credits returns where the end credits start, which is when players offer
+// the next episode.
+func (v *Video) Credits() *valueobjects.Marker {
+	return v.markerOfKind(valueobjects.MarkerKindCredits)
+}
+
+func (v *Video) markerOfKind(kind string) *valueobjects.Marker {
+	for i := range v.markers {
+		if v.markers[i].Kind == kind {
+			return &v.markers[i]
+		}
+	}
+	return nil
+}
@@ -7,10 +7,24 @@ import (
 	"github.com/serdarburakguneri/hobby-streamer/backend/streaming-api/internal/domain/asset/valueobjects"
 )
 
+// SearchCriteria is a full-text search. Nil filters match everything.
+type SearchCriteria struct {
+	Query         string
+	Type          *valueobjects.AssetType
+	Genre         *valueobjects.Genre
+	Year          *int
+	OnlyPublished bool
+	Limit         int
+	Offset        int
+}
+
 type Repository interface {
+	GetByID(ctx context.Context, id valueobjects.AssetID) (*entity.Asset, error)
 	GetBySlug(ctx context.Context, slug valueobjects.Slug) (*entity.Asset, error)
 	GetAll(ctx context.Context) ([]*entity.Asset, error)
 	GetPublic(ctx context.Context) ([]*entity.Asset, error)
 	GetByType(ctx context.Context, assetType valueobjects.AssetType) ([]*entity.Asset, error)
 	GetByGenre(ctx context.Context, genre valueobjects.Genre) ([]*entity.Asset, error)
+	GetSeriesEpisodes(ctx context.Context, id valueobjects.AssetID) ([]*entity.Asset, error)
+	Search(ctx context.Context, criteria SearchCriteria) (*entity.SearchResult, error)
 }
@@ -0,0 +1,16 @@
+package valueobjects
+
+const (
+	MarkerKindChapter = "chapter"
+	MarkerKindIntro   = "intro"
+	MarkerKindCredits = "credits"
+)
+
+// Marker is a chapter, intro or end-credits point on a video's timeline, in
+// seconds. Credits markers have no end.
+type Marker struct {
+	Kind  string
+	Start float64
+	End   *float64
+	Title *string
+}
@@ -10,6 +10,7 @@ type StreamInfoValue struct {
 	downloadURL *string
 	cdnPrefix   *string
 	url         *string
+	hlsURL      *string
 }
 
 func NewStreamInfoValue(downloadURL, cdnPrefix, urlStr *string) (*StreamInfoValue, error) {
@@ -38,6 +39,18 @@ func NewStreamInfoValue(downloadURL, cdnPrefix, urlStr *string) (*StreamInfoValu
 	}, nil
 }
 
+// WithHLSURL adds the HLS playlist of a CMAF video, whose URL is the DASH
+// manifest over the same segments.
+func (s StreamInfoValue) WithHLSURL(hlsURL *string) (*StreamInfoValue, error) {
+	if hlsURL != nil {
+		if _, err := url.Parse(*hlsURL); err != nil {
+			return nil, ErrInvalidStreamURL
+		}
+	}
+	s.hlsURL = hlsURL
+	return &s, nil
+}
+
 func (s StreamInfoValue) DownloadURL() *string {
 	return s.downloadURL
 }
@@ -50,10 +63,15 @@ func (s StreamInfoValue) URL() *string {
 	return s.url
 }
 
+func (s StreamInfoValue) HLSURL() *string {
+	return s.hlsURL
+}
+
 func (s StreamInfoValue) Equals(other StreamInfoValue) bool {
 	return s.downloadURL == other.downloadURL &&
 		s.cdnPrefix == other.cdnPrefix &&
-		s.url == other.url
+		s.url == other.url &&
+		s.hlsURL == other.hlsURL
 }
 
 var (
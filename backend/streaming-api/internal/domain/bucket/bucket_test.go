@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/constants"
 	assetentity "github.com/serdarburakguneri/hobby-streamer/backend/streaming-api/internal/domain/asset/entity"
 	assetvalueobjects "github.com/serdarburakguneri/hobby-streamer/backend/streaming-api/internal/domain/asset/valueobjects"
 	bucketentity "github.com/serdarburakguneri/hobby-streamer/backend/streaming-api/internal/domain/bucket/entity"
@@ -121,16 +122,20 @@ func TestBucket_GetPublicAssets(t *testing.T) {
 	typeVO, _ := assetvalueobjects.NewAssetType("movie")
 	createdAt := assetvalueobjects.NewCreatedAt(now)
 	updatedAt := assetvalueobjects.NewUpdatedAt(now)
+	published, _ := assetvalueobjects.NewStatus(constants.AssetStatusPublished)
+	unpublished, _ := assetvalueobjects.NewStatus(constants.AssetStatusUnpublished)
 	pub := assetentity.NewAsset(
 		*id,
 		*slug,
 		nil, nil,
 		*typeVO,
-		nil, nil, nil, nil,
+		nil, nil, nil, published,
 		createdAt.Value(),
 		updatedAt.Value(),
 		nil, nil, nil, nil, pr,
 	)
+	// Inside its publish window, but taken down in asset-manager.
+	takenDown := assetentity.NewAsset(*id, *slug, nil, nil, *typeVO, nil, nil, nil, unpublished, createdAt.Value(), updatedAt.Value(), nil, nil, nil, nil, pr)
 
 	bucketID, _ := bucketvalueobjects.NewBucketID("test-id")
 	bucketKey, _ := bucketvalueobjects.NewBucketKey("test-key")
@@ -140,6 +145,6 @@ func TestBucket_GetPublicAssets(t *testing.T) {
 	bucketUpdatedAt := bucketvalueobjects.NewUpdatedAt(time.Now().UTC())
 	assetIDs, _ := bucketvalueobjects.NewAssetIDs([]string{})
 
-	b := bucketentity.NewBucket(*bucketID, *bucketKey, *bucketName, nil, *bucketType, nil, assetIDs, *bucketCreatedAt, *bucketUpdatedAt, []*assetentity.Asset{pub})
-	assert.Len(t, b.GetPublicAssets(), 1)
+	b := bucketentity.NewBucket(*bucketID, *bucketKey, *bucketName, nil, *bucketType, nil, assetIDs, *bucketCreatedAt, *bucketUpdatedAt, []*assetentity.Asset{pub, takenDown})
+	assert.Equal(t, []*assetentity.Asset{pub}, b.GetPublicAssets())
 }
@@ -58,6 +58,8 @@ func (c *CacheInvalidator) handleAssetEvent(ctx context.Context, ev *pkgevents.E
 		pkgevents.AssetUpdatedEventType,
 		pkgevents.AssetCreatedEventType,
 		pkgevents.AssetDeletedEventType,
+		pkgevents.AssetPublishedEventType,
+		pkgevents.AssetUnpublishedEventType,
 		pkgevents.VideoAddedEventType,
 		pkgevents.VideoRemovedEventType:
 		c.cache.InvalidateAssetsListCache(ctx)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/constants"
 	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
 	resilience "github.com/serdarburakguneri/hobby-streamer/backend/pkg/resilience"
@@ -89,6 +90,107 @@ func (r *AssetRepository) GetAll(ctx context.Context) ([]*entity.Asset, error) {
 	return assets, nil
 }
 
+// GetSeriesEpisodes returns the episodes of the series containing id in
+// viewing order, each carrying the number of its season.
+func (r *AssetRepository) GetSeriesEpisodes(ctx context.Context, id assetvalueobjects.AssetID) ([]*entity.Asset, error) {
+	var response struct {
+		SeriesTree GraphQLSeriesTree `json:"seriesTree"`
+	}
+
+	err := r.circuitBreaker.Execute(ctx, func() error {
+		return r.client.Query(ctx, queries.GetSeriesTreeQuery, map[string]interface{}{"id": id.Value()}, &response)
+	})
+	if err != nil {
+		return nil, pkgerrors.WithContext(err, map[string]interface{}{
+			"operation": "get_series_episodes",
+			"asset_id":  id.Value(),
+		})
+	}
+
+	var episodes []*entity.Asset
+	for _, branch := range response.SeriesTree.Seasons {
+		for _, graphQLAsset := range branch.Episodes {
+			episode, err := ConvertGraphQLAssetToDomain(graphQLAsset)
+			if err != nil {
+				r.logger.WithError(err).Error("Failed to convert GraphQL episode to domain", "asset_id", graphQLAsset.ID)
+				continue
+			}
+			episode.SetHierarchy(episode.ParentID(), branch.Season.SeasonNumber, episode.EpisodeNumber())
+			episodes = append(episodes, episode)
+		}
+	}
+
+	return episodes, nil
+}
+
+// Search runs the asset-manager full-text search. Hits keep the relevance
+// order the asset-manager returns.
+func (r *AssetRepository) Search(ctx context.Context, criteria asset.SearchCriteria) (*entity.SearchResult, error) {
+	filter := map[string]interface{}{}
+	if criteria.Type != nil {
+		filter["type"] = criteria.Type.Value()
+	}
+	if criteria.Genre != nil {
+		filter["genre"] = criteria.Genre.Value()
+	}
+	if criteria.Year != nil {
+		filter["year"] = *criteria.Year
+	}
+	if criteria.OnlyPublished {
+		filter["status"] = constants.AssetStatusPublished
+	}
+	variables := map[string]interface{}{
+		"query":  criteria.Query,
+		"limit":  criteria.Limit,
+		"offset": criteria.Offset,
+		"filter": filter,
+	}
+
+	var response struct {
+		SearchAssets GraphQLAssetSearchResult `json:"searchAssets"`
+	}
+
+	err := r.circuitBreaker.Execute(ctx, func() error {
+		return r.client.Query(ctx, queries.SearchAssetsQuery, variables, &response)
+	})
+	if err != nil {
+		return nil, pkgerrors.WithContext(err, map[string]interface{}{
+			"operation": "search_assets",
+			"query":     criteria.Query,
+		})
+	}
+
+	found := response.SearchAssets
+	result := &entity.SearchResult{
+		Assets:  make([]*entity.Asset, 0, len(found.Hits)),
+		Total:   found.Total,
+		HasMore: found.HasMore,
+		Facets: entity.SearchFacets{
+			Types:  convertGraphQLFacetCounts(found.Facets.Types),
+			Genres: convertGraphQLFacetCounts(found.Facets.Genres),
+			Years:  convertGraphQLFacetCounts(found.Facets.Years),
+		},
+	}
+	for _, hit := range found.Hits {
+		domainAsset, err := ConvertGraphQLAssetToDomain(hit.Asset)
+		if err != nil {
+			r.logger.WithError(err).Error("Failed to convert GraphQL search hit to domain", "asset_id", hit.Asset.ID)
+			continue
+		}
+		result.Assets = append(result.Assets, domainAsset)
+	}
+
+	return result, nil
+}
+
+func convertGraphQLFacetCounts(counts []GraphQLFacetCount) []entity.FacetCount {
+	out := make([]entity.FacetCount, len(counts))
+	for i, c := range counts {
+		out[i] = entity.FacetCount{Value: c.Value, Count: c.Count}
+	}
+	return out
+}
+
 func (r *AssetRepository) GetPublic(ctx context.Context) ([]*entity.Asset, error) {
 	assets, err := r.GetAll(ctx)
 	if err != nil {
@@ -202,6 +304,41 @@ type GraphQLAsset struct {
 	Videos      []GraphQLVideo      `json:"videos"`
 	Images      []GraphQLImage      `json:"images"`
 	PublishRule *GraphQLPublishRule `json:"publishRule"`
+
+	ParentID      *string `json:"parentId"`
+	SeasonNumber  *int    `json:"seasonNumber"`
+	EpisodeNumber *int    `json:"episodeNumber"`
+}
+
+type GraphQLSeriesTree struct {
+	Seasons []GraphQLSeasonBranch `json:"seasons"`
+}
+
+type GraphQLSeasonBranch struct {
+	Season struct {
+		ID           string `json:"id"`
+		SeasonNumber *int   `json:"seasonNumber"`
+	} `json:"season"`
+	Episodes []*GraphQLAsset `json:"episodes"`
+}
+
+type GraphQLAssetSearchResult struct {
+	Hits []struct {
+		Asset *GraphQLAsset `json:"asset"`
+		Score float64       `json:"score"`
+	} `json:"hits"`
+	Total   int  `json:"total"`
+	HasMore bool `json:"hasMore"`
+	Facets  struct {
+		Types  []GraphQLFacetCount `json:"types"`
+		Genres []GraphQLFacetCount `json:"genres"`
+		Years  []GraphQLFacetCount `json:"years"`
+	} `json:"facets"`
+}
+
+type GraphQLFacetCount struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
 }
 
 type GraphQLVideo struct {
@@ -236,6 +373,7 @@ type GraphQLVideo struct {
 	AudioChannels      *int                    `json:"audioChannels"`
 	AudioSampleRate    *int                    `json:"audioSampleRate"`
 	TranscodingInfo    *GraphQLTranscodingInfo `json:"transcodingInfo"`
+	Markers            []GraphQLMarker         `json:"markers"`
 }
 
 type GraphQLImage struct {
@@ -263,6 +401,7 @@ type GraphQLStreamInfo struct {
 	DownloadURL *string `json:"downloadUrl"`
 	CDNPrefix   *string `json:"cdnPrefix"`
 	URL         *string `json:"url"`
+	HLSURL      *string `json:"hlsUrl"`
 }
 
 type GraphQLPublishRule struct {
@@ -280,6 +419,13 @@ type GraphQLTranscodingInfo struct {
 	CompletedAt *time.Time `json:"completedAt"`
 }
 
+type GraphQLMarker struct {
+	Kind  string   `json:"kind"`
+	Start float64  `json:"start"`
+	End   *float64 `json:"end"`
+	Title *string  `json:"title"`
+}
+
 type VideoType string
 type VideoFormat string
 type VideoStatus string
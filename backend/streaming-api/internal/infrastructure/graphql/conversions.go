@@ -133,7 +133,16 @@ func ConvertGraphQLAssetToDomain(graphQLAsset *GraphQLAsset) (*entity.Asset, err
 		publishRule = publishRuleVO
 	}
 
-	return entity.NewAsset(
+	var parentID *assetvalueobjects.AssetID
+	if graphQLAsset.ParentID != nil && *graphQLAsset.ParentID != "" {
+		parentIDVO, err := assetvalueobjects.NewAssetID(*graphQLAsset.ParentID)
+		if err != nil {
+			return nil, err
+		}
+		parentID = parentIDVO
+	}
+
+	a := entity.NewAsset(
 		*assetID,
 		*slug,
 		title,
@@ -150,7 +159,9 @@ func ConvertGraphQLAssetToDomain(graphQLAsset *GraphQLAsset) (*entity.Asset, err
 		videos,
 		images,
 		publishRule,
-	), nil
+	)
+	a.SetHierarchy(parentID, graphQLAsset.SeasonNumber, graphQLAsset.EpisodeNumber)
+	return a, nil
 }
 
 func ConvertGraphQLVideosToDomain(graphQLVideos []GraphQLVideo) ([]entity.Video, error) {
@@ -208,6 +219,10 @@ func ConvertGraphQLVideoToDomain(graphQLVideo GraphQLVideo) (*entity.Video, erro
 		if err != nil {
 			return nil, err
 		}
+		streamInfoVO, err = streamInfoVO.WithHLSURL(graphQLVideo.StreamInfo.HLSURL)
+		if err != nil {
+			return nil, err
+		}
 		streamInfo = streamInfoVO
 	}
 
@@ -246,7 +261,7 @@ func ConvertGraphQLVideoToDomain(graphQLVideo GraphQLVideo) (*entity.Video, erro
 		}
 	}
 
-	return entity.NewVideo(
+	video := entity.NewVideo(
 		*videoID,
 		videoType,
 		format,
@@ -277,7 +292,15 @@ func ConvertGraphQLVideoToDomain(graphQLVideo GraphQLVideo) (*entity.Video, erro
 		graphQLVideo.AudioChannels,
 		graphQLVideo.AudioSampleRate,
 		transcodingInfo,
-	), nil
+	)
+	if len(graphQLVideo.Markers) > 0 {
+		markers := make([]assetvalueobjects.Marker, len(graphQLVideo.Markers))
+		for i, m := range graphQLVideo.Markers {
+			markers[i] = assetvalueobjects.Marker{Kind: m.Kind, Start: m.Start, End: m.End, Title: m.Title}
+		}
+		video.SetMarkers(markers)
+	}
+	return video, nil
 }
 
 func ConvertGraphQLImagesToDomain(graphQLImages []GraphQLImage) ([]entity.Image, error) {
@@ -1,74 +1,149 @@
 package queries
 
+// assetFields selects everything the streaming responses read from an
+// asset.
+const assetFields = `
+  id
+  slug
+  title
+  description
+  type
+  genre
+  genres
+  tags
+  status
+  createdAt
+  updatedAt
+  metadata
+  ownerId
+  parentId
+  seasonNumber
+  episodeNumber
+  videos {
+    id
+    label
+    type
+    format
+    storageLocation { bucket key url }
+    width
+    height
+    duration
+    bitrate
+    codec
+    size
+    contentType
+    streamInfo { downloadUrl cdnPrefix url hlsUrl }
+    metadata
+    status
+    thumbnail { id fileName url type storageLocation { bucket key url } width height size contentType metadata createdAt updatedAt }
+    createdAt
+    updatedAt
+    quality
+    isReady
+    isProcessing
+    isFailed
+    segmentCount
+    videoCodec
+    audioCodec
+    avgSegmentDuration
+    segments
+    frameRate
+    audioChannels
+    audioSampleRate
+    transcodingInfo { jobId progress outputUrl error completedAt }
+    markers { kind start end title }
+  }
+  images {
+    id
+    fileName
+    url
+    type
+    storageLocation { bucket key url }
+    width
+    height
+    size
+    contentType
+    metadata
+    createdAt
+    updatedAt
+  }
+  publishRule {
+    publishAt
+    unpublishAt
+    regions
+    ageRating
+  }
+`
+
 const GetAssetsQuery = `
 query GetAssets {
   assets {
-    items {
-      id
-      slug
-      title
-      description
-      type
-      genre
-      genres
-      tags
-      status
-      createdAt
-      updatedAt
-      metadata
-      ownerId
-      videos {
+    items {` + assetFields + `    }
+  }
+}`
+
+// SearchAssetsQuery runs the asset-manager full-text search.
+const SearchAssetsQuery = `
+query SearchAssets($query: String!, $limit: Int, $offset: Int, $filter: AssetSearchFilter) {
+  searchAssets(query: $query, limit: $limit, offset: $offset, filter: $filter) {
+    hits {
+      asset {` + assetFields + `      }
+      score
+    }
+    total
+    hasMore
+    facets {
+      types { value count }
+      genres { value count }
+      years { value count }
+    }
+  }
+}`
+
+// GetSeriesTreeQuery reads the seasons and episodes of the series containing
+// $id. Only what an episode link needs is selected.
+const GetSeriesTreeQuery = `
+query GetSeriesTree($id: ID!) {
+  seriesTree(id: $id) {
+    seasons {
+      season {
         id
-        label
-        type
-        format
-        storageLocation { bucket key url }
-        width
-        height
-        duration
-        bitrate
-        codec
-        size
-        contentType
-        streamInfo { downloadUrl cdnPrefix url }
-        metadata
-        status
-        thumbnail { id fileName url type storageLocation { bucket key url } width height size contentType metadata createdAt updatedAt }
-        createdAt
-        updatedAt
-        quality
-        isReady
-        isProcessing
-        isFailed
-        segmentCount
-        videoCodec
-        audioCodec
-        avgSegmentDuration
-        segments
-        frameRate
-        audioChannels
-        audioSampleRate
-        transcodingInfo { jobId progress outputUrl error completedAt }
+        seasonNumber
       }
-      images {
+      episodes {
         id
-        fileName
-        url
+        slug
+        title
+        description
         type
-        storageLocation { bucket key url }
-        width
-        height
-        size
-        contentType
-        metadata
+        genre
+        genres
+        tags
+        status
         createdAt
         updatedAt
-      }
-      publishRule {
-        publishAt
-        unpublishAt
-        regions
-        ageRating
+        ownerId
+        parentId
+        episodeNumber
+        images {
+          id
+          fileName
+          url
+          type
+          storageLocation { bucket key url }
+          width
+          height
+          size
+          contentType
+          createdAt
+          updatedAt
+        }
+        publishRule {
+          publishAt
+          unpublishAt
+          regions
+          ageRating
+        }
       }
     }
   }
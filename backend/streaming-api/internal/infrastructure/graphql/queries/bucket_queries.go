@@ -37,7 +37,7 @@ query GetBucket($key: String!) {
         codec
         size
         contentType
-        streamInfo { downloadUrl cdnPrefix url }
+        streamInfo { downloadUrl cdnPrefix url hlsUrl }
         metadata
         status
         thumbnail { id fileName url type storageLocation { bucket key url } width height size contentType metadata createdAt updatedAt }
@@ -56,6 +56,7 @@ query GetBucket($key: String!) {
         audioChannels
         audioSampleRate
         transcodingInfo { jobId progress outputUrl error completedAt }
+        markers { kind start end title }
       }
       images {
         id
@@ -119,7 +120,7 @@ query GetBuckets($limit: Int, $nextKey: String) {
           codec
           size
           contentType
-          streamInfo { downloadUrl cdnPrefix url }
+          streamInfo { downloadUrl cdnPrefix url hlsUrl }
           metadata
           status
           thumbnail { id fileName url type storageLocation { bucket key url } width height size contentType metadata createdAt updatedAt }
@@ -138,6 +139,7 @@ query GetBuckets($limit: Int, $nextKey: String) {
           audioChannels
           audioSampleRate
           transcodingInfo { jobId progress outputUrl error completedAt }
+          markers { kind start end title }
         }
         images {
           id
@@ -4,21 +4,33 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/auth"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/config"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/security"
 	"github.com/serdarburakguneri/hobby-streamer/backend/streaming-api/internal/application"
+	appasset "github.com/serdarburakguneri/hobby-streamer/backend/streaming-api/internal/application/asset"
 	assetvalueobjects "github.com/serdarburakguneri/hobby-streamer/backend/streaming-api/internal/domain/asset/valueobjects"
 	bucketvalueobjects "github.com/serdarburakguneri/hobby-streamer/backend/streaming-api/internal/domain/bucket/valueobjects"
 	"github.com/serdarburakguneri/hobby-streamer/backend/streaming-api/internal/infrastructure/http/responses"
 )
 
+const (
+	viewerCountryHeader = "CloudFront-Viewer-Country"
+
+	// maxSearchLimit caps the page size a search request can ask for.
+	maxSearchLimit = 100
+)
+
 type Handler struct {
 	assetService  application.AssetServiceInterface
 	bucketService application.BucketServiceInterface
+	keyService    application.KeyServiceInterface
+	requireUser   func(http.HandlerFunc) http.HandlerFunc
 	logger        *logger.Logger
 	config        *config.BaseConfig
 }
@@ -32,6 +44,14 @@ func NewHandler(assetService application.AssetServiceInterface, bucketService ap
 	}
 }
 
+// WithKeyDelivery enables the HLS key endpoint. requireUser must reject
+// requests without a valid user token.
+func (h *Handler) WithKeyDelivery(keyService application.KeyServiceInterface, requireUser func(http.HandlerFunc) http.HandlerFunc) *Handler {
+	h.keyService = keyService
+	h.requireUser = requireUser
+	return h
+}
+
 func (h *Handler) SetupRoutes() *mux.Router {
 	router := mux.NewRouter()
 
@@ -52,6 +72,10 @@ func (h *Handler) SetupRoutes() *mux.Router {
 	api.HandleFunc("/assets", h.GetAssets).Methods("GET")
 	api.HandleFunc("/assets/{slug}", h.GetAsset).Methods("GET")
 
+	if h.keyService != nil && h.requireUser != nil {
+		api.HandleFunc("/keys/{assetId}/{videoId}/{keyName}", h.requireUser(h.GetContentKey)).Methods("GET", "OPTIONS")
+	}
+
 	router.HandleFunc("/health", h.HealthCheck).Methods("GET")
 
 	return router
@@ -152,6 +176,11 @@ func (h *Handler) GetAssetsInBucket(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) GetAssets(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	if q := r.URL.Query().Get("q"); q != "" {
+		h.searchAssets(w, r, q)
+		return
+	}
+
 	assets, err := h.assetService.GetAssets(ctx)
 	if err != nil {
 		h.handleError(w, err, "Failed to get assets")
@@ -171,6 +200,59 @@ func (h *Handler) GetAssets(w http.ResponseWriter, r *http.Request) {
 	h.writeJSON(w, http.StatusOK, response)
 }
 
+// searchAssets serves /assets?q=. Viewers only ever see published assets;
+// type, genre and year narrow the hits, and limit and offset page them.
+func (h *Handler) searchAssets(w http.ResponseWriter, r *http.Request, q string) {
+	params := r.URL.Query()
+	filters := &appasset.SearchFilters{OnlyPublished: true}
+
+	if t := params.Get("type"); t != "" {
+		assetType, err := assetvalueobjects.NewAssetType(t)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "Invalid asset type")
+			return
+		}
+		filters.AssetType = assetType
+	}
+	if g := params.Get("genre"); g != "" {
+		genre, err := assetvalueobjects.NewGenre(g)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "Invalid genre")
+			return
+		}
+		filters.Genre = genre
+	}
+	if y := params.Get("year"); y != "" {
+		year, err := strconv.Atoi(y)
+		if err != nil {
+			h.writeError(w, http.StatusBadRequest, "Invalid year")
+			return
+		}
+		filters.Year = &year
+	}
+
+	limit := 20
+	if l := params.Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = min(parsed, maxSearchLimit)
+		}
+	}
+	offset := 0
+	if o := params.Get("offset"); o != "" {
+		if parsed, err := strconv.Atoi(o); err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+
+	result, err := h.assetService.SearchAssets(r.Context(), q, filters, limit, offset)
+	if err != nil {
+		h.handleError(w, err, "Failed to search assets")
+		return
+	}
+
+	h.writeJSON(w, http.StatusOK, responses.NewSearchResponse(result))
+}
+
 func (h *Handler) GetAsset(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	vars := mux.Vars(r)
@@ -194,9 +276,40 @@ func (h *Handler) GetAsset(w http.ResponseWriter, r *http.Request) {
 	}
 
 	assetResponse := responses.NewAssetResponse(asset)
+	if asset.IsEpisode() {
+		adjacent, err := h.assetService.GetAdjacentEpisodes(ctx, asset)
+		if err != nil {
+			// The episode is still served, just without links to its
+			// neighbours.
+			h.logger.WithError(err).Warn("Failed to resolve adjacent episodes", "slug", slug)
+		} else {
+			assetResponse.PreviousEpisode = responses.NewEpisodeLinkResponse(adjacent.Previous)
+			assetResponse.NextEpisode = responses.NewEpisodeLinkResponse(adjacent.Next)
+		}
+	}
 	h.writeJSON(w, http.StatusOK, assetResponse)
 }
 
+func (h *Handler) GetContentKey(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	var userAge *int
+	if user, ok := auth.UserFromContext(r.Context()); ok {
+		userAge = user.Age(time.Now())
+	}
+	key, err := h.keyService.GetContentKey(r.Context(), vars["assetId"], vars["videoId"], vars["keyName"], r.Header.Get(viewerCountryHeader), userAge)
+	if err != nil {
+		h.handleError(w, err, "Failed to get content key")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(key); err != nil {
+		h.logger.WithError(err).Error("Failed to write content key")
+	}
+}
+
 func (h *Handler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	response := responses.HealthResponse{
 		Status:  "healthy",
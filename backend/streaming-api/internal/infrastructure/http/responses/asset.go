@@ -11,6 +11,54 @@ type AssetsResponse struct {
 	Count  int             `json:"count"`
 }
 
+// SearchResponse is one page of search results in relevance order. Total
+// counts every match, and each facet counts matches with the other
+// dimensions' filters applied but not its own.
+type SearchResponse struct {
+	Assets  []AssetResponse `json:"assets"`
+	Count   int             `json:"count"`
+	Total   int             `json:"total"`
+	HasMore bool            `json:"hasMore"`
+	Facets  FacetsResponse  `json:"facets"`
+}
+
+type FacetsResponse struct {
+	Types  []FacetCountResponse `json:"types"`
+	Genres []FacetCountResponse `json:"genres"`
+	Years  []FacetCountResponse `json:"years"`
+}
+
+type FacetCountResponse struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+func NewSearchResponse(result *entity.SearchResult) SearchResponse {
+	assets := make([]AssetResponse, 0, len(result.Assets))
+	for _, a := range result.Assets {
+		assets = append(assets, NewAssetResponse(a))
+	}
+	return SearchResponse{
+		Assets:  assets,
+		Count:   len(assets),
+		Total:   result.Total,
+		HasMore: result.HasMore,
+		Facets: FacetsResponse{
+			Types:  newFacetCountResponses(result.Facets.Types),
+			Genres: newFacetCountResponses(result.Facets.Genres),
+			Years:  newFacetCountResponses(result.Facets.Years),
+		},
+	}
+}
+
+func newFacetCountResponses(counts []entity.FacetCount) []FacetCountResponse {
+	out := make([]FacetCountResponse, len(counts))
+	for i, c := range counts {
+		out[i] = FacetCountResponse{Value: c.Value, Count: c.Count}
+	}
+	return out
+}
+
 type AssetResponse struct {
 	ID          string               `json:"id"`
 	Slug        string               `json:"slug"`
@@ -28,6 +76,39 @@ type AssetResponse struct {
 	Videos      []VideoResponse      `json:"videos,omitempty"`
 	Images      []ImageResponse      `json:"images,omitempty"`
 	PublishRule *PublishRuleResponse `json:"publishRule,omitempty"`
+
+	ParentID        *string              `json:"parentId,omitempty"`
+	SeasonNumber    *int                 `json:"seasonNumber,omitempty"`
+	EpisodeNumber   *int                 `json:"episodeNumber,omitempty"`
+	PreviousEpisode *EpisodeLinkResponse `json:"previousEpisode,omitempty"`
+	NextEpisode     *EpisodeLinkResponse `json:"nextEpisode,omitempty"`
+}
+
+// EpisodeLinkResponse is enough of a neighbouring episode to link to it.
+type EpisodeLinkResponse struct {
+	ID            string  `json:"id"`
+	Slug          string  `json:"slug"`
+	Title         *string `json:"title,omitempty"`
+	SeasonNumber  *int    `json:"seasonNumber,omitempty"`
+	EpisodeNumber *int    `json:"episodeNumber,omitempty"`
+}
+
+func NewEpisodeLinkResponse(a *entity.Asset) *EpisodeLinkResponse {
+	if a == nil {
+		return nil
+	}
+	var title *string
+	if a.Title() != nil {
+		titleVal := a.Title().Value()
+		title = &titleVal
+	}
+	return &EpisodeLinkResponse{
+		ID:            a.ID().Value(),
+		Slug:          a.Slug().Value(),
+		Title:         title,
+		SeasonNumber:  a.SeasonNumber(),
+		EpisodeNumber: a.EpisodeNumber(),
+	}
 }
 
 func NewAssetResponse(a *entity.Asset) AssetResponse {
@@ -75,6 +156,12 @@ func NewAssetResponse(a *entity.Asset) AssetResponse {
 		ownerID = &ownerIDVal
 	}
 
+	var parentID *string
+	if a.ParentID() != nil {
+		parentIDVal := a.ParentID().Value()
+		parentID = &parentIDVal
+	}
+
 	return AssetResponse{
 		ID:          a.ID().Value(),
 		Slug:        a.Slug().Value(),
@@ -92,5 +179,9 @@ func NewAssetResponse(a *entity.Asset) AssetResponse {
 		Videos:      convertVideosToResponse(a.Videos()),
 		Images:      convertImagesToResponse(a.Images()),
 		PublishRule: convertPublishRuleToResponse(a.PublishRule()),
+
+		ParentID:      parentID,
+		SeasonNumber:  a.SeasonNumber(),
+		EpisodeNumber: a.EpisodeNumber(),
 	}
 }
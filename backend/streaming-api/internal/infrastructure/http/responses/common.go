@@ -16,6 +16,7 @@ type StreamInfoResponse struct {
 	DownloadURL *string `json:"downloadUrl,omitempty"`
 	CDNPrefix   *string `json:"cdnPrefix,omitempty"`
 	URL         *string `json:"url,omitempty"`
+	HLSURL      *string `json:"hlsUrl,omitempty"`
 }
 
 type PublishRuleResponse struct {
@@ -61,6 +62,7 @@ func convertStreamInfoToResponse(streamInfo *assetvalueobjects.StreamInfoValue)
 		DownloadURL: streamInfo.DownloadURL(),
 		CDNPrefix:   streamInfo.CDNPrefix(),
 		URL:         streamInfo.URL(),
+		HLSURL:      streamInfo.HLSURL(),
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/serdarburakguneri/hobby-streamer/backend/streaming-api/internal/domain/asset/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/streaming-api/internal/domain/asset/valueobjects"
 )
 
 type VideoResponse struct {
@@ -37,6 +38,16 @@ type VideoResponse struct {
 	AudioChannels      *int                     `json:"audioChannels,omitempty"`
 	AudioSampleRate    *int                     `json:"audioSampleRate,omitempty"`
 	TranscodingInfo    *TranscodingInfoResponse `json:"transcodingInfo,omitempty"`
+	Markers            []MarkerResponse         `json:"markers,omitempty"`
+	Intro              *MarkerResponse          `json:"intro,omitempty"`
+	CreditsStart       *float64                 `json:"creditsStart,omitempty"`
+}
+
+type MarkerResponse struct {
+	Kind  string   `json:"kind"`
+	Start float64  `json:"start"`
+	End   *float64 `json:"end,omitempty"`
+	Title *string  `json:"title,omitempty"`
 }
 
 type TranscodingInfoResponse struct {
@@ -84,6 +95,12 @@ func convertVideosToResponse(videos []entity.Video) []VideoResponse {
 			status = &s
 		}
 
+		var creditsStart *float64
+		if credits := v.Credits(); credits != nil {
+			start := credits.Start
+			creditsStart = &start
+		}
+
 		response = append(response, VideoResponse{
 			ID:                 v.ID().Value(),
 			Type:               videoType,
@@ -115,7 +132,25 @@ func convertVideosToResponse(videos []entity.Video) []VideoResponse {
 			AudioChannels:      v.AudioChannels(),
 			AudioSampleRate:    v.AudioSampleRate(),
 			TranscodingInfo:    transcodingInfo,
+			Markers:            convertMarkersToResponse(v.Markers()),
+			Intro:              convertMarkerToResponse(v.Intro()),
+			CreditsStart:       creditsStart,
 		})
 	}
 	return response
 }
+
+func convertMarkersToResponse(markers []valueobjects.Marker) []MarkerResponse {
+	var response []MarkerResponse
+	for i := range markers {
+		response = append(response, *convertMarkerToResponse(&markers[i]))
+	}
+	return response
+}
+
+func convertMarkerToResponse(m *valueobjects.Marker) *MarkerResponse {
+	if m == nil {
+		return nil
+	}
+	return &MarkerResponse{Kind: m.Kind, Start: m.Start, End: m.End, Title: m.Title}
+}
@@ -5,13 +5,19 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/config"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/keystore"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
-	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/s3"
+	pkgstorage "github.com/serdarburakguneri/hobby-streamer/backend/pkg/storage"
 	appjob "github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/application/job"
+	applive "github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/application/live"
 	domainjob "github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+	transcoderhttp "github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/infrastructure/http"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/infrastructure/jobstore"
 	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/infrastructure/kafka"
 	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/infrastructure/storage"
 	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/infrastructure/transcoding"
@@ -53,23 +59,93 @@ func main() {
 	}
 
 	kafkaEventPublisher := kafka.NewKafkaEventPublisher(completionProducer)
-	s3Client, err := s3.NewClient(ctx)
+	storageRouter, err := pkgstorage.New(ctx, pkgstorage.Config{
+		Backend: dynamicCfg.GetStringFromComponent("storage", "backend"),
+		Root:    dynamicCfg.GetStringFromComponent("storage", "root"),
+	})
 	if err != nil {
-		log.WithError(err).Error("Failed to create S3 client")
+		log.WithError(err).Error("Failed to create storage backend")
 		os.Exit(1)
 	}
-	storageAdapter := storage.NewStorage(s3Client)
-	transcoderRegistry := transcoding.NewRegistry(storageAdapter)
+	storageAdapter := storage.NewStorage(storageRouter)
+	progressInterval := dynamicCfg.GetDurationFromComponent("transcoding", "progress_interval", 2*time.Second)
+	progressReporter := domainjob.NewThrottledProgressReporter(kafkaEventPublisher, progressInterval)
+	var keyStore keystore.Store
+	if backend := dynamicCfg.GetStringFromComponent("keystore", "backend"); backend != "" {
+		keyStore, err = keystore.New(keystore.Config{
+			Backend:       backend,
+			Dir:           dynamicCfg.GetStringFromComponent("keystore", "dir"),
+			RedisAddr:     dynamicCfg.GetStringFromComponent("keystore", "redis_addr"),
+			RedisPassword: secretsManager.Get("keystore_redis_password"),
+			RedisDB:       dynamicCfg.GetIntFromComponent("keystore", "redis_db"),
+		})
+		if err != nil {
+			log.WithError(err).Error("Failed to create key store")
+			os.Exit(1)
+		}
+	}
+	jobRepository, err := jobstore.New(jobstore.Config{
+		Backend: dynamicCfg.GetStringFromComponent("jobstore", "backend"),
+		Dir:     dynamicCfg.GetStringFromComponent("jobstore", "dir"),
+	})
+	if err != nil {
+		log.WithError(err).Error("Failed to create job store")
+		os.Exit(1)
+	}
+	progressReporter.SetRepository(jobRepository)
+	transcoderRegistry := transcoding.NewRegistry(storageAdapter, progressReporter, keyStore)
 	jobDomainService := domainjob.NewDomainService(storageAdapter, transcoderRegistry, kafkaEventPublisher)
-	jobAppService := appjob.NewApplicationService(jobDomainService, dynamicCfg)
+	jobDomainService.SetQualityVerifier(transcoding.NewQualityVerifier())
+	jobDomainService.SetProgressReporter(progressReporter)
+	workerPool := domainjob.NewWorkerPool(domainjob.PoolConfig{
+		MaxConcurrency: dynamicCfg.GetIntFromComponent("workers", "max_concurrency"),
+		Concurrency:    workerConcurrency(dynamicCfg.GetComponentAsMap("workers")),
+		QueueSize:      dynamicCfg.GetIntFromComponent("workers", "queue_size"),
+	})
+	jobAppService := appjob.NewApplicationService(jobDomainService, jobRepository, workerPool, appjob.RecoveryConfig{
+		MaxAttempts:     dynamicCfg.GetIntFromComponent("jobstore", "max_attempts"),
+		RecordRetention: dynamicCfg.GetDurationFromComponent("jobstore", "retention", 7*24*time.Hour),
+	}, dynamicCfg)
+
+	if err := jobAppService.RecoverJobs(ctx); err != nil {
+		log.WithError(err).Error("Failed to recover interrupted jobs")
+	}
 
 	transcoderEventConsumer := kafka.NewTranscoderEventConsumer(jobAppService, completionProducer)
 
+	var liveAppService *applive.ApplicationService
+	if dynamicCfg.GetBoolFromComponent("live", "enabled") {
+		ladder := valueobjects.DefaultLadder()
+		if maxHeight := dynamicCfg.GetIntFromComponent("live", "max_height"); maxHeight > 0 {
+			ladder = ladder.Fit(maxHeight*16/9, maxHeight)
+		}
+		bucket := dynamicCfg.GetStringFromComponent("live", "bucket")
+		if bucket == "" {
+			bucket = dynamicCfg.GetStringFromComponent("s3", "default_output_bucket")
+		}
+		liveIngester := transcoding.NewLiveIngester(storageRouter, transcoding.LiveConfig{
+			Bucket:          bucket,
+			PublicHost:      dynamicCfg.GetStringFromComponent("live", "public_host"),
+			BindHost:        dynamicCfg.GetStringFromComponent("live", "bind_host"),
+			PortStart:       dynamicCfg.GetIntFromComponent("live", "port_start"),
+			PortCount:       dynamicCfg.GetIntFromComponent("live", "max_sessions"),
+			Ladder:          ladder,
+			SegmentDuration: dynamicCfg.GetIntFromComponent("live", "segment_duration"),
+			WindowSize:      dynamicCfg.GetIntFromComponent("live", "window_size"),
+			ConnectTimeout:  dynamicCfg.GetDurationFromComponent("live", "connect_timeout", 10*time.Minute),
+		})
+		liveAppService = applive.NewApplicationService(liveIngester, kafkaEventPublisher, dynamicCfg.GetIntFromComponent("live", "max_sessions"))
+		transcoderEventConsumer.SetLiveService(liveAppService)
+	}
+
 	if err := transcoderEventConsumer.Start(ctx, bootstrapServers); err != nil {
 		log.WithError(err).Error("Failed to start Kafka consumer")
 		os.Exit(1)
 	}
 
+	healthServer := transcoderhttp.NewHealthServer(cfg.Server.Port, workerPool)
+	healthServer.Start()
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
@@ -78,5 +154,29 @@ func main() {
 	if err := transcoderEventConsumer.Stop(); err != nil {
 		log.WithError(err).Error("Failed to stop Kafka consumer")
 	}
+	if liveAppService != nil {
+		// Give running sessions time to close their playlists and upload
+		// their archives.
+		liveCtx, liveCancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		liveAppService.Shutdown(liveCtx)
+		liveCancel()
+	}
+	workerPool.Close()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := healthServer.Stop(shutdownCtx); err != nil {
+		log.WithError(err).Error("Failed to stop health server")
+	}
 	log.Info("Transcoder worker stopped")
 }
+
+// workerConcurrency reads components.workers.concurrency, a map of job kind
+// (analyze, hls, dash, ...) to the most jobs of that kind run at once.
+func workerConcurrency(comp map[string]interface{}) map[string]int {
+	limits, _ := comp["concurrency"].(map[string]interface{})
+	out := make(map[string]int, len(limits))
+	for kind := range limits {
+		out[kind] = config.GetIntFromMap(limits, kind)
+	}
+	return out
+}
@@ -7,12 +7,14 @@ toolchain go1.23.4
 require (
 	github.com/serdarburakguneri/hobby-streamer/backend/pkg/config v0.0.0
 	github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors v0.0.0
-	github.com/serdarburakguneri/hobby-streamer/backend/pkg/resilience v0.0.0
 	github.com/serdarburakguneri/hobby-streamer/backend/pkg/events v0.0.0-00010101000000-000000000000
+	github.com/serdarburakguneri/hobby-streamer/backend/pkg/keystore v0.0.0
 	github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger v0.0.0
 	github.com/serdarburakguneri/hobby-streamer/backend/pkg/messages v0.0.0
-	github.com/serdarburakguneri/hobby-streamer/backend/pkg/s3 v0.0.0-00010101000000-000000000000
+	github.com/serdarburakguneri/hobby-streamer/backend/pkg/resilience v0.0.0
+	github.com/serdarburakguneri/hobby-streamer/backend/pkg/s3 v0.0.0
 	github.com/serdarburakguneri/hobby-streamer/backend/pkg/sqs v0.0.0
+	github.com/serdarburakguneri/hobby-streamer/backend/pkg/storage v0.0.0
 	github.com/stretchr/testify v1.10.0
 )
 
@@ -21,11 +23,13 @@ replace (
 	github.com/serdarburakguneri/hobby-streamer/backend/pkg/constants => ../pkg/constants
 	github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors => ../pkg/errors
 	github.com/serdarburakguneri/hobby-streamer/backend/pkg/events => ../pkg/events
+	github.com/serdarburakguneri/hobby-streamer/backend/pkg/keystore => ../pkg/keystore
 	github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger => ../pkg/logger
 	github.com/serdarburakguneri/hobby-streamer/backend/pkg/messages => ../pkg/messages
+	github.com/serdarburakguneri/hobby-streamer/backend/pkg/resilience => ../pkg/resilience
 	github.com/serdarburakguneri/hobby-streamer/backend/pkg/s3 => ../pkg/s3
 	github.com/serdarburakguneri/hobby-streamer/backend/pkg/sqs => ../pkg/sqs
-	github.com/serdarburakguneri/hobby-streamer/backend/pkg/resilience => ../pkg/resilience
+	github.com/serdarburakguneri/hobby-streamer/backend/pkg/storage => ../pkg/storage
 )
 
 require (
@@ -45,7 +49,9 @@ require (
 	github.com/aws/aws-sdk-go-v2/service/ssooidc v1.30.1 // indirect
 	github.com/aws/aws-sdk-go-v2/service/sts v1.33.20 // indirect
 	github.com/aws/smithy-go v1.22.4 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
 	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
+	github.com/dgryski/go-rendezvous v0.0.0-20200823014737-9f7001d12a5f // indirect
 	github.com/eapache/go-resiliency v1.6.0 // indirect
 	github.com/eapache/go-xerial-snappy v0.0.0-20230731223053-c322873962e3 // indirect
 	github.com/eapache/queue v1.1.0 // indirect
@@ -67,6 +73,7 @@ require (
 	github.com/pierrec/lz4/v4 v4.1.21 // indirect
 	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
 	github.com/rcrowley/go-metrics v0.0.0-20201227073835-cf1acfcdf475 // indirect
+	github.com/redis/go-redis/v9 v9.11.0 // indirect
 	github.com/sagikazarmark/locafero v0.7.0 // indirect
 	github.com/sourcegraph/conc v0.3.0 // indirect
 	github.com/spf13/afero v1.12.0 // indirect
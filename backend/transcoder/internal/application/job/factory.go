@@ -7,11 +7,18 @@ import (
 
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/config"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/messages"
+	pkgstorage "github.com/serdarburakguneri/hobby-streamer/backend/pkg/storage"
 	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
 	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
 )
 
+var (
+	defaultAudioOnlyAssetTypes = []string{"podcast", "music_video"}
+	defaultAudioOnlyBitrates   = []int{64, 128}
+)
+
 type JobFactory struct {
 	config config.ServiceConfig
 }
@@ -31,14 +38,28 @@ func (f *JobFactory) CreateJob(payload messages.JobPayload) (*entity.Job, error)
 		return nil, errors.NewValidationError("invalid video ID", err)
 	}
 
+	var job *entity.Job
 	switch payload.JobType {
 	case string(valueobjects.JobTypeAnalyze):
-		return f.createAnalyzeJob(*assetIDVO, *videoIDVO, payload)
+		job, err = f.createAnalyzeJob(*assetIDVO, *videoIDVO, payload)
 	case string(valueobjects.JobTypeTranscode):
-		return f.createTranscodeJob(*assetIDVO, *videoIDVO, payload)
+		job, err = f.createTranscodeJob(*assetIDVO, *videoIDVO, payload)
 	default:
 		return nil, errors.NewValidationError(fmt.Sprintf("unsupported job type: %s", payload.JobType), nil)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Recovered jobs carry the ID they were first persisted under.
+	if payload.JobID != "" {
+		jobIDVO, err := valueobjects.NewJobID(payload.JobID)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid job ID", err)
+		}
+		job.RestoreID(*jobIDVO)
+	}
+	return job, nil
 }
 
 func (f *JobFactory) applyTemplate(pattern string, data interface{}) (string, error) {
@@ -53,6 +74,13 @@ func (f *JobFactory) applyTemplate(pattern string, data interface{}) (string, er
 	return buf.String(), nil
 }
 
+// outputURL addresses an output under the configured storage backend.
+func (f *JobFactory) outputURL(bucket, key string) string {
+	comp, _ := f.config.GetComponent("storage").(map[string]interface{})
+	cfg := pkgstorage.Config{Backend: config.GetStringFromMap(comp, "backend")}
+	return pkgstorage.Location{Scheme: cfg.Scheme(), Bucket: bucket, Key: key}.String()
+}
+
 func (f *JobFactory) createTranscodeJob(assetID valueobjects.AssetID, videoID valueobjects.VideoID, payload messages.JobPayload) (*entity.Job, error) {
 	comp := f.config.GetComponent("s3").(map[string]interface{})
 	defaultBucket := comp["default_output_bucket"].(string)
@@ -63,9 +91,19 @@ func (f *JobFactory) createTranscodeJob(assetID valueobjects.AssetID, videoID va
 	}
 	input = payload.Input
 
+	quality := payload.Quality
+	overlay, err := overlaySpec(payload)
+	if err != nil {
+		return nil, err
+	}
+	if overlay != nil {
+		// The variant is written under its label, next to the main output.
+		quality = overlay.Label
+	}
+
 	var output string
 	if payload.OutputBucket != "" && payload.OutputKey != "" {
-		output = fmt.Sprintf("s3://%s/%s", payload.OutputBucket, payload.OutputKey)
+		output = f.outputURL(payload.OutputBucket, payload.OutputKey)
 	} else {
 		var pattern string
 		switch payload.Format {
@@ -73,6 +111,12 @@ func (f *JobFactory) createTranscodeJob(assetID valueobjects.AssetID, videoID va
 			pattern = comp["hls_output_key_pattern"].(string)
 		case string(valueobjects.JobFormatDASH):
 			pattern = comp["dash_output_key_pattern"].(string)
+		case string(valueobjects.JobFormatCMAF):
+			pattern, _ = comp["cmaf_output_key_pattern"].(string)
+		case string(valueobjects.JobFormatThumbnails):
+			pattern, _ = comp["thumbnails_output_key_pattern"].(string)
+		case string(valueobjects.JobFormatMarkers):
+			pattern, _ = comp["markers_output_key_pattern"].(string)
 		}
 		if pattern == "" {
 			pattern = "{{.AssetID}}/{{.VideoID}}/{{.Format}}/{{.Quality}}/output"
@@ -80,18 +124,537 @@ func (f *JobFactory) createTranscodeJob(assetID valueobjects.AssetID, videoID va
 		renderedKey, err := f.applyTemplate(pattern, map[string]string{
 			"AssetID": assetID.Value(),
 			"VideoID": videoID.Value(),
-			"Quality": payload.Quality,
+			"Quality": quality,
 			"Format":  payload.Format,
 		})
 		if err != nil {
 			return nil, err
 		}
-		output = fmt.Sprintf("s3://%s/%s", defaultBucket, renderedKey)
+		output = f.outputURL(defaultBucket, renderedKey)
+	}
+
+	job := entity.NewTranscodeJob(assetID, videoID, input, output, quality, valueobjects.JobFormat(payload.Format))
+	if overlay != nil {
+		job.SetOverlay(*overlay)
+	}
+	ladder, err := f.ladder()
+	if err != nil {
+		return nil, errors.NewValidationError("invalid rendition ladder configuration", err)
+	}
+	ladder = ladder.Fit(payload.SourceWidth, payload.SourceHeight)
+	if payload.Complexity > 0 && (job.Format().IsHLS() || job.Format().IsDASH() || job.Format().IsCMAF()) {
+		spec, err := f.complexitySpec()
+		if err != nil {
+			return nil, errors.NewValidationError("invalid complexity configuration", err)
+		}
+		if spec.Enabled {
+			ladder = ladder.Scale(spec.Factor(payload.Complexity))
+			job.SetComplexity(payload.Complexity)
+		}
+	}
+	job.SetLadder(ladder)
+	job.SetSourceDuration(payload.SourceDuration)
+	if job.Format().IsThumbnails() {
+		spec, err := f.thumbnailSpec()
+		if err != nil {
+			return nil, errors.NewValidationError("invalid thumbnails configuration", err)
+		}
+		job.SetThumbnailSpec(spec)
+	}
+	if job.Format().IsMarkers() {
+		spec, err := f.markerSpec()
+		if err != nil {
+			return nil, errors.NewValidationError("invalid markers configuration", err)
+		}
+		job.SetMarkerSpec(spec)
+	}
+	if job.Format().IsHLS() {
+		spec, err := f.hlsEncryption()
+		if err != nil {
+			return nil, errors.NewValidationError("invalid HLS encryption configuration", err)
+		}
+		if spec != nil {
+			job.SetEncryption(*spec)
+		}
+		segmented, err := f.hlsSegmented()
+		if err != nil {
+			return nil, errors.NewValidationError("invalid segmented encoding configuration", err)
+		}
+		job.SetSegmentedSpec(segmented)
+	}
+	if job.Format().IsHLS() || job.Format().IsDASH() || job.Format().IsCMAF() {
+		spec, err := f.qualitySpec()
+		if err != nil {
+			return nil, errors.NewValidationError("invalid quality check configuration", err)
+		}
+		switch {
+		case overlay != nil:
+			// Burned-in pixels would count against the renditions when they
+			// are compared with the source.
+			spec.Enabled = false
+		case !job.Format().IsHLS() || !job.Encryption().IsZero():
+			// Only clear HLS variant playlists can be read back and scored;
+			// DASH and CMAF renditions have none, and encrypted ones point
+			// their keys at streaming-api.
+			spec.Enabled = false
+		}
+		job.SetQualitySpec(spec)
+		loudness, err := f.loudnessSpec()
+		if err != nil {
+			return nil, errors.NewValidationError("invalid loudness configuration", err)
+		}
+		job.SetLoudnessSpec(loudness)
+		input, err := f.inputSpec(payload)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid input validation configuration", err)
+		}
+		job.SetInputSpec(input)
+		audioOnly, err := f.audioOnly(payload)
+		if err != nil {
+			return nil, errors.NewValidationError("invalid audio-only configuration", err)
+		}
+		if audioOnly != nil {
+			job.SetAudioOnly(*audioOnly)
+		}
+	}
+	subtitles, err := subtitleTracks(payload.Subtitles)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid subtitle track", err)
+	}
+	job.SetSubtitles(subtitles)
+	job.SetAudioTracks(audioTracks(payload.AudioTracks))
+	correlationID := payload.CorrelationID
+	if correlationID == "" {
+		correlationID = events.BuildJobCorrelationID(payload.AssetID, payload.VideoID, payload.JobType, payload.Format, job.Quality())
+	}
+	job.SetCorrelationID(correlationID)
+	return job, nil
+}
+
+func (f *JobFactory) ladder() (valueobjects.Ladder, error) {
+	comp, _ := f.config.GetComponent("transcoding").(map[string]interface{})
+	rungs, _ := comp["ladder"].([]interface{})
+	if len(rungs) == 0 {
+		return valueobjects.DefaultLadder(), nil
+	}
+	ladder := make(valueobjects.Ladder, 0, len(rungs))
+	for _, raw := range rungs {
+		rung, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unexpected ladder entry type: %T", raw)
+		}
+		r, err := valueobjects.NewRendition(
+			config.GetStringFromMap(rung, "name"),
+			config.GetIntFromMap(rung, "width"),
+			config.GetIntFromMap(rung, "height"),
+			config.GetIntFromMap(rung, "video_bitrate"),
+			config.GetIntFromMap(rung, "audio_bitrate"),
+		)
+		if err != nil {
+			return nil, err
+		}
+		ladder = append(ladder, *r)
+	}
+	return ladder, nil
+}
+
+// overlaySpec returns nil for a clean transcode. Only the HLS and DASH
+// packagers can burn an overlay in.
+func overlaySpec(payload messages.JobPayload) (*valueobjects.OverlaySpec, error) {
+	o := payload.Overlay
+	if o == nil {
+		return nil, nil
+	}
+	if payload.Format != string(valueobjects.JobFormatHLS) && payload.Format != string(valueobjects.JobFormatDASH) {
+		return nil, errors.NewValidationError(fmt.Sprintf("overlays are not supported for %s jobs", payload.Format), nil)
+	}
+	spec, err := valueobjects.NewOverlaySpec(o.Label, o.Image, o.Position, o.Opacity, o.Text)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid overlay", err)
+	}
+	return spec, nil
+}
+
+func subtitleTracks(payloads []messages.SubtitlePayload) ([]valueobjects.SubtitleTrack, error) {
+	tracks := make([]valueobjects.SubtitleTrack, 0, len(payloads))
+	for _, p := range payloads {
+		t, err := valueobjects.NewSubtitleTrack(p.Language, p.Kind, p.Label, p.Default, p.Input)
+		if err != nil {
+			return nil, err
+		}
+		tracks = append(tracks, *t)
+	}
+	return tracks, nil
+}
+
+func audioTracks(payloads []messages.AudioTrackPayload) valueobjects.AudioTracks {
+	tracks := make(valueobjects.AudioTracks, 0, len(payloads))
+	for _, p := range payloads {
+		tracks = append(tracks, valueobjects.AudioTrack{
+			Index:         p.Index,
+			Language:      p.Language,
+			Codec:         p.Codec,
+			Channels:      p.Channels,
+			ChannelLayout: p.ChannelLayout,
+			SampleRate:    p.SampleRate,
+			Title:         p.Title,
+			Default:       p.Default,
+			Loudness:      loudness(p.Loudness),
+		})
+	}
+	return tracks
+}
+
+func loudness(p *messages.LoudnessPayload) *valueobjects.Loudness {
+	if p == nil {
+		return nil
+	}
+	return &valueobjects.Loudness{
+		Integrated: p.Integrated,
+		TruePeak:   p.TruePeak,
+		LRA:        p.LRA,
+		Threshold:  p.Threshold,
+		Offset:     p.Offset,
+	}
+}
+
+func (f *JobFactory) thumbnailSpec() (valueobjects.ThumbnailSpec, error) {
+	comp, _ := f.config.GetComponent("transcoding").(map[string]interface{})
+	raw, ok := comp["thumbnails"].(map[string]interface{})
+	if !ok {
+		return valueobjects.DefaultThumbnailSpec(), nil
+	}
+	def := valueobjects.DefaultThumbnailSpec()
+	intOr := func(key string, fallback int) int {
+		if _, set := raw[key]; set {
+			return config.GetIntFromMap(raw, key)
+		}
+		return fallback
+	}
+	spec, err := valueobjects.NewThumbnailSpec(
+		intOr("screenshot_count", def.ScreenshotCount),
+		float64(intOr("sprite_interval", int(def.SpriteInterval))),
+		intOr("tile_width", def.TileWidth),
+		intOr("columns", def.Columns),
+		intOr("rows", def.Rows),
+	)
+	if err != nil {
+		return valueobjects.ThumbnailSpec{}, err
+	}
+	return *spec, nil
+}
+
+func (f *JobFactory) markerSpec() (valueobjects.MarkerSpec, error) {
+	comp, _ := f.config.GetComponent("transcoding").(map[string]interface{})
+	raw, ok := comp["markers"].(map[string]interface{})
+	def := valueobjects.DefaultMarkerSpec()
+	if !ok {
+		return def, nil
+	}
+	floatOr := func(key string, fallback float64) float64 {
+		switch v := raw[key].(type) {
+		case float64:
+			return v
+		case int:
+			return float64(v)
+		}
+		return fallback
+	}
+	spec, err := valueobjects.NewMarkerSpec(
+		floatOr("scene_threshold", def.SceneThreshold),
+		floatOr("chapter_interval", def.ChapterInterval),
+		floatOr("intro_window", def.IntroWindow),
+		floatOr("credits_window", def.CreditsWindow),
+	)
+	if err != nil {
+		return valueobjects.MarkerSpec{}, err
+	}
+	return *spec, nil
+}
+
+// hlsEncryption returns nil unless transcoding.hls.encryption is enabled.
+func (f *JobFactory) hlsEncryption() (*valueobjects.EncryptionSpec, error) {
+	comp, _ := f.config.GetComponent("transcoding").(map[string]interface{})
+	hls, _ := comp["hls"].(map[string]interface{})
+	raw, ok := hls["encryption"].(map[string]interface{})
+	if !ok {
+		return nil, nil
+	}
+	if enabled, _ := raw["enabled"].(bool); !enabled {
+		return nil, nil
+	}
+	method, _ := raw["method"].(string)
+	prefix, _ := raw["key_url_prefix"].(string)
+	return valueobjects.NewEncryptionSpec(method, prefix)
+}
+
+func (f *JobFactory) hlsSegmented() (valueobjects.SegmentedSpec, error) {
+	comp, _ := f.config.GetComponent("transcoding").(map[string]interface{})
+	hls, _ := comp["hls"].(map[string]interface{})
+	raw, ok := hls["segmented"].(map[string]interface{})
+	def := valueobjects.DefaultSegmentedSpec()
+	if !ok {
+		return def, nil
+	}
+	floatOr := func(key string, fallback float64) float64 {
+		switch v := raw[key].(type) {
+		case float64:
+			return v
+		case int:
+			return float64(v)
+		}
+		return fallback
 	}
+	parallelism := config.GetIntFromMap(raw, "parallelism")
+	if parallelism == 0 {
+		parallelism = def.Parallelism
+	}
+	spec, err := valueobjects.NewSegmentedSpec(
+		floatOr("min_duration", def.MinDuration),
+		floatOr("chunk_duration", def.ChunkDuration),
+		parallelism,
+	)
+	if err != nil {
+		return valueobjects.SegmentedSpec{}, err
+	}
+	return *spec, nil
+}
 
-	return entity.NewTranscodeJob(assetID, videoID, input, output, payload.Quality, valueobjects.JobFormat(payload.Format)), nil
+func (f *JobFactory) qualitySpec() (valueobjects.QualitySpec, error) {
+	comp, _ := f.config.GetComponent("transcoding").(map[string]interface{})
+	raw, ok := comp["quality"].(map[string]interface{})
+	def := valueobjects.DefaultQualitySpec()
+	if !ok {
+		return def, nil
+	}
+	floatOr := func(key string, fallback float64) float64 {
+		switch v := raw[key].(type) {
+		case float64:
+			return v
+		case int:
+			return float64(v)
+		}
+		return fallback
+	}
+	enabled, _ := raw["enabled"].(bool)
+	sampleCount := config.GetIntFromMap(raw, "sample_count")
+	if sampleCount == 0 {
+		sampleCount = def.SampleCount
+	}
+	spec, err := valueobjects.NewQualitySpec(
+		enabled,
+		sampleCount,
+		floatOr("sample_duration", def.SampleDuration),
+		floatOr("min_psnr", def.MinPSNR),
+		floatOr("min_ssim", def.MinSSIM),
+		floatOr("min_vmaf", def.MinVMAF),
+	)
+	if err != nil {
+		return valueobjects.QualitySpec{}, err
+	}
+	return *spec, nil
+}
+
+// complexitySpec reads transcoding.complexity. Probe points are listed as
+// {crf, reference_bitrate} pairs.
+func (f *JobFactory) complexitySpec() (valueobjects.ComplexitySpec, error) {
+	comp, _ := f.config.GetComponent("transcoding").(map[string]interface{})
+	raw, ok := comp["complexity"].(map[string]interface{})
+	def := valueobjects.DefaultComplexitySpec()
+	if !ok {
+		return def, nil
+	}
+	floatOr := func(key string, fallback float64) float64 {
+		switch v := raw[key].(type) {
+		case float64:
+			return v
+		case int:
+			return float64(v)
+		}
+		return fallback
+	}
+	intOr := func(key string, fallback int) int {
+		if _, set := raw[key]; set {
+			return config.GetIntFromMap(raw, key)
+		}
+		return fallback
+	}
+	points := def.Points
+	if list, _ := raw["points"].([]interface{}); len(list) > 0 {
+		points = make([]valueobjects.ComplexityPoint, 0, len(list))
+		for _, item := range list {
+			point, ok := item.(map[string]interface{})
+			if !ok {
+				return valueobjects.ComplexitySpec{}, fmt.Errorf("unexpected complexity point type: %T", item)
+			}
+			points = append(points, valueobjects.ComplexityPoint{
+				CRF:              config.GetIntFromMap(point, "crf"),
+				ReferenceBitrate: config.GetIntFromMap(point, "reference_bitrate"),
+			})
+		}
+	}
+	enabled, _ := raw["enabled"].(bool)
+	spec, err := valueobjects.NewComplexitySpec(
+		enabled,
+		intOr("sample_count", def.SampleCount),
+		floatOr("sample_duration", def.SampleDuration),
+		intOr("probe_height", def.ProbeHeight),
+		points,
+		floatOr("min_factor", def.MinFactor),
+		floatOr("max_factor", def.MaxFactor),
+	)
+	if err != nil {
+		return valueobjects.ComplexitySpec{}, err
+	}
+	return *spec, nil
+}
+
+func (f *JobFactory) loudnessSpec() (valueobjects.LoudnessSpec, error) {
+	comp, _ := f.config.GetComponent("transcoding").(map[string]interface{})
+	raw, ok := comp["loudness"].(map[string]interface{})
+	def := valueobjects.DefaultLoudnessSpec()
+	if !ok {
+		return def, nil
+	}
+	floatOr := func(key string, fallback float64) float64 {
+		switch v := raw[key].(type) {
+		case float64:
+			return v
+		case int:
+			return float64(v)
+		}
+		return fallback
+	}
+	enabled, _ := raw["enabled"].(bool)
+	spec, err := valueobjects.NewLoudnessSpec(
+		enabled,
+		floatOr("target_i", def.TargetI),
+		floatOr("true_peak", def.TruePeak),
+		floatOr("lra", def.LRA),
+	)
+	if err != nil {
+		return valueobjects.LoudnessSpec{}, err
+	}
+	return *spec, nil
+}
+
+func (f *JobFactory) isAudioOnlyType(assetType string) bool {
+	comp, _ := f.config.GetComponent("transcoding").(map[string]interface{})
+	raw, _ := comp["audio_only"].(map[string]interface{})
+	return stringSet(raw["asset_types"], defaultAudioOnlyAssetTypes)[assetType]
+}
+
+// inputSpec reads transcoding.validation. Sources of audio-only asset types
+// are not required to carry video.
+func (f *JobFactory) inputSpec(payload messages.JobPayload) (valueobjects.InputSpec, error) {
+	comp, _ := f.config.GetComponent("transcoding").(map[string]interface{})
+	raw, ok := comp["validation"].(map[string]interface{})
+	def := valueobjects.DefaultInputSpec()
+	if ok {
+		floatOr := func(key string, fallback float64) float64 {
+			switch v := raw[key].(type) {
+			case float64:
+				return v
+			case int:
+				return float64(v)
+			}
+			return fallback
+		}
+		intOr := func(key string, fallback int) int {
+			if _, set := raw[key]; set {
+				return config.GetIntFromMap(raw, key)
+			}
+			return fallback
+		}
+		boolOr := func(key string, fallback bool) bool {
+			if v, set := raw[key].(bool); set {
+				return v
+			}
+			return fallback
+		}
+		listOr := func(key string, fallback []string) []string {
+			list, set := raw[key].([]interface{})
+			if !set {
+				return fallback
+			}
+			values := make([]string, 0, len(list))
+			for _, v := range list {
+				if s, ok := v.(string); ok {
+					values = append(values, s)
+				}
+			}
+			return values
+		}
+		enabled, _ := raw["enabled"].(bool)
+		spec, err := valueobjects.NewInputSpec(
+			enabled,
+			listOr("containers", def.Containers),
+			listOr("video_codecs", def.VideoCodecs),
+			listOr("audio_codecs", def.AudioCodecs),
+			floatOr("max_duration", def.MaxDuration),
+			intOr("max_width", def.MaxWidth),
+			intOr("max_height", def.MaxHeight),
+			int64(intOr("max_file_size_mb", int(def.MaxFileSize>>20)))<<20,
+			boolOr("require_video", def.RequireVideo),
+			boolOr("require_audio", def.RequireAudio),
+			floatOr("max_vfr", def.MaxVFR),
+			floatOr("max_interlaced", def.MaxInterlaced),
+			floatOr("decode_seconds", def.DecodeSeconds),
+		)
+		if err != nil {
+			return valueobjects.InputSpec{}, err
+		}
+		def = *spec
+	}
+	if f.isAudioOnlyType(payload.AssetType) {
+		def.RequireVideo = false
+	}
+	return def, nil
+}
+
+// audioOnly returns nil unless the asset type is listed under
+// transcoding.audio_only.asset_types. A source analyze found no video in
+// gets audio renditions only.
+func (f *JobFactory) audioOnly(payload messages.JobPayload) (*valueobjects.AudioOnlySpec, error) {
+	if !f.isAudioOnlyType(payload.AssetType) {
+		return nil, nil
+	}
+	comp, _ := f.config.GetComponent("transcoding").(map[string]interface{})
+	raw, _ := comp["audio_only"].(map[string]interface{})
+	bitrates := defaultAudioOnlyBitrates
+	if list, _ := raw["bitrates"].([]interface{}); len(list) > 0 {
+		bitrates = make([]int, 0, len(list))
+		for _, item := range list {
+			switch v := item.(type) {
+			case int:
+				bitrates = append(bitrates, v)
+			case float64:
+				bitrates = append(bitrates, int(v))
+			default:
+				return nil, fmt.Errorf("unexpected audio-only bitrate type: %T", item)
+			}
+		}
+	}
+	noVideo := payload.SourceDuration > 0 && payload.SourceHeight == 0
+	return valueobjects.NewAudioOnlySpec(bitrates, noVideo)
 }
 
 func (f *JobFactory) createAnalyzeJob(assetID valueobjects.AssetID, videoID valueobjects.VideoID, payload messages.JobPayload) (*entity.Job, error) {
-	return entity.NewAnalyzeJob(assetID, videoID, payload.Input), nil
+	job := entity.NewAnalyzeJob(assetID, videoID, payload.Input)
+	spec, err := f.complexitySpec()
+	if err != nil {
+		return nil, errors.NewValidationError("invalid complexity configuration", err)
+	}
+	job.SetComplexitySpec(spec)
+	loudness, err := f.loudnessSpec()
+	if err != nil {
+		return nil, errors.NewValidationError("invalid loudness configuration", err)
+	}
+	job.SetLoudnessSpec(loudness)
+	input, err := f.inputSpec(payload)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid input validation configuration", err)
+	}
+	job.SetInputSpec(input)
+	return job, nil
 }
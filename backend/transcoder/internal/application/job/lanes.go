@@ -0,0 +1,60 @@
+package job
+
+import (
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/config"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/messages"
+	domainjob "github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
+)
+
+var (
+	defaultPriorityKinds      = []string{"analyze"}
+	defaultPriorityVideoTypes = []string{"trailer", "teaser"}
+)
+
+// laneRules decides which jobs jump the queue: short kinds such as analyze,
+// and encodes of short video types such as trailers.
+type laneRules struct {
+	kinds      map[string]bool
+	videoTypes map[string]bool
+}
+
+func newLaneRules(cfg config.ServiceConfig) laneRules {
+	comp, _ := cfg.GetComponent("workers").(map[string]interface{})
+	return laneRules{
+		kinds:      stringSet(comp["priority_kinds"], defaultPriorityKinds),
+		videoTypes: stringSet(comp["priority_video_types"], defaultPriorityVideoTypes),
+	}
+}
+
+func (r laneRules) laneFor(job *entity.Job, payload messages.JobPayload) domainjob.Lane {
+	if r.kinds[jobKind(job)] || r.videoTypes[payload.VideoType] {
+		return domainjob.LanePriority
+	}
+	return domainjob.LaneNormal
+}
+
+// jobKind matches the transcoder registry key the job runs under.
+func jobKind(job *entity.Job) string {
+	if job.Type().IsAnalyze() {
+		return "analyze"
+	}
+	return job.Format().String()
+}
+
+func stringSet(raw interface{}, fallback []string) map[string]bool {
+	values := fallback
+	if list, ok := raw.([]interface{}); ok {
+		values = make([]string, 0, len(list))
+		for _, v := range list {
+			if s, ok := v.(string); ok {
+				values = append(values, s)
+			}
+		}
+	}
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
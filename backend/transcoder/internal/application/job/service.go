@@ -2,34 +2,73 @@ package job
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/config"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/messages"
-	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job"
+	domainjob "github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
 )
 
 type JobApplicationService interface {
 	ProcessJob(ctx context.Context, payload messages.JobPayload) error
+	CancelJob(ctx context.Context, correlationID string, issuedAt time.Time) bool
+}
+
+const (
+	defaultMaxAttempts     = 2
+	defaultRecordRetention = 7 * 24 * time.Hour
+)
+
+// RecoveryConfig controls what happens to jobs a crashed worker left behind:
+// how many times a job may run before it is failed, and how long finished
+// records are kept.
+type RecoveryConfig struct {
+	MaxAttempts     int
+	RecordRetention time.Duration
 }
 
 type ApplicationService struct {
-	domainService job.DomainService
+	domainService domainjob.DomainService
 	jobFactory    *JobFactory
+	running       *domainjob.RunningJobs
+	repository    domainjob.JobRepository
+	pool          *domainjob.WorkerPool
+	lanes         laneRules
+	recovery      RecoveryConfig
 	logger        *logger.Logger
 }
 
-func NewApplicationService(domainService job.DomainService, cfg config.ServiceConfig) *ApplicationService {
+func NewApplicationService(domainService domainjob.DomainService, repository domainjob.JobRepository, pool *domainjob.WorkerPool, recovery RecoveryConfig, cfg config.ServiceConfig) *ApplicationService {
+	if recovery.MaxAttempts <= 0 {
+		recovery.MaxAttempts = defaultMaxAttempts
+	}
+	if recovery.RecordRetention <= 0 {
+		recovery.RecordRetention = defaultRecordRetention
+	}
 	return &ApplicationService{
 		domainService: domainService,
 		jobFactory:    NewJobFactory(cfg),
+		running:       domainjob.NewRunningJobs(),
+		repository:    repository,
+		pool:          pool,
+		lanes:         newLaneRules(cfg),
+		recovery:      recovery,
 		logger:        logger.WithService("job-application-service"),
 	}
 }
 
+// ProcessJob persists the job and hands it to the worker pool. It returns
+// once the pool has accepted the job, blocking while the pool is backed up.
 func (s *ApplicationService) ProcessJob(ctx context.Context, payload messages.JobPayload) error {
-	s.logger.Info("Processing job", "job_type", payload.JobType, "asset_id", payload.AssetID, "video_id", payload.VideoID, "input", payload.Input)
+	return s.submitJob(ctx, payload, 1)
+}
 
+func (s *ApplicationService) submitJob(ctx context.Context, payload messages.JobPayload, attempt int) error {
 	job, err := s.jobFactory.CreateJob(payload)
 	if err != nil {
 		s.logger.WithError(err).Error("Failed to create job", "job_type", payload.JobType)
@@ -41,17 +80,137 @@ func (s *ApplicationService) ProcessJob(ctx context.Context, payload messages.Jo
 		return err
 	}
 
+	record := domainjob.NewJobRecord(job, payload, attempt)
+	s.save(ctx, record, job)
+
+	lane := s.lanes.laneFor(job, payload)
+	s.logger.Info("Queueing job", "job_id", job.ID().Value(), "job_type", payload.JobType, "format", payload.Format, "asset_id", payload.AssetID, "video_id", payload.VideoID, "lane", lane, "attempt", attempt)
+
+	// The job outlives the Kafka handler that queued it.
+	runCtx := context.WithoutCancel(ctx)
+	return s.pool.Submit(ctx, domainjob.PoolTask{
+		Kind: jobKind(job),
+		Lane: lane,
+		Run: func() {
+			_ = s.runJob(runCtx, job, record, payload.RequestedAt)
+		},
+	})
+}
+
+func (s *ApplicationService) runJob(ctx context.Context, job *entity.Job, record *domainjob.JobRecord, requestedAt time.Time) error {
+	s.logger.Info("Processing job", "job_id", job.ID().Value(), "job_type", job.Type().String(), "asset_id", job.AssetID().Value(), "video_id", job.VideoID().Value(), "input", job.Input(), "attempt", record.Attempt)
+
+	ctx, release := s.running.Track(ctx, job.CorrelationID(), requestedAt)
+	defer release()
+
 	job.Start()
+	s.save(ctx, record, job)
 
 	metadata, err := s.domainService.ProcessJob(ctx, job)
+	if errors.Is(err, domainjob.ErrJobCancelled) {
+		job.Cancel()
+		s.save(context.WithoutCancel(ctx), record, job)
+		s.logger.Info("Job cancelled", "job_id", job.ID().Value(), "correlation_id", job.CorrelationID())
+		return nil
+	}
 	if err != nil {
 		s.logger.WithError(err).Error("Job processing failed", "job_id", job.ID().Value())
 		job.Fail(err.Error())
+		s.save(ctx, record, job)
 		return err
 	}
 
 	job.Complete(nil)
+	s.save(ctx, record, job)
 
 	s.logger.Info("Job completed successfully", "job_id", job.ID().Value(), "metadata", metadata)
 	return nil
 }
+
+// save keeps the job going when the store is unavailable; the cost is only
+// that a crash in that window cannot be recovered.
+func (s *ApplicationService) save(ctx context.Context, record *domainjob.JobRecord, job *entity.Job) {
+	record.Capture(job)
+	if err := s.repository.Save(ctx, record); err != nil {
+		s.logger.WithError(err).Warn("Failed to persist job record", "job_id", record.ID, "status", record.Status)
+	}
+}
+
+// RecoverJobs settles jobs a previous run of this worker left pending or
+// running. Each is restarted from scratch until it has run MaxAttempts times;
+// after that it is failed and a failure completion is published so the
+// requesting pipeline step does not wait forever. Restarts go back through
+// the worker pool.
+func (s *ApplicationService) RecoverJobs(ctx context.Context) error {
+	s.pruneRecords(ctx)
+
+	records, err := s.repository.FindByStatus(ctx, valueobjects.JobStatusPending, valueobjects.JobStatusRunning)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	var restarts []*domainjob.JobRecord
+	for _, record := range records {
+		if record.Attempt < s.recovery.MaxAttempts {
+			restarts = append(restarts, record)
+			continue
+		}
+		s.abandon(ctx, record, fmt.Sprintf("job interrupted by worker restart after %d attempts", record.Attempt))
+	}
+	s.logger.Info("Recovering interrupted jobs", "restarting", len(restarts), "failed", len(records)-len(restarts))
+
+	go func() {
+		for _, record := range restarts {
+			s.logger.Info("Restarting interrupted job", "job_id", record.ID, "asset_id", record.Payload.AssetID, "video_id", record.Payload.VideoID, "attempt", record.Attempt+1)
+			if err := s.submitJob(ctx, record.Payload, record.Attempt+1); err != nil {
+				s.logger.WithError(err).Error("Failed to requeue interrupted job", "job_id", record.ID)
+			}
+		}
+	}()
+	return nil
+}
+
+func (s *ApplicationService) abandon(ctx context.Context, record *domainjob.JobRecord, reason string) {
+	s.logger.Warn("Failing interrupted job", "job_id", record.ID, "asset_id", record.Payload.AssetID, "video_id", record.Payload.VideoID, "reason", reason)
+
+	job, err := s.jobFactory.CreateJob(record.Payload)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to rebuild interrupted job", "job_id", record.ID)
+		now := time.Now().UTC()
+		record.Status = valueobjects.JobStatusFailed
+		record.Error = reason
+		record.CompletedAt = &now
+		record.UpdatedAt = now
+		if err := s.repository.Save(ctx, record); err != nil {
+			s.logger.WithError(err).Warn("Failed to persist job record", "job_id", record.ID)
+		}
+		return
+	}
+
+	job.Fail(reason)
+	s.domainService.FailJob(ctx, job, reason)
+	s.save(ctx, record, job)
+}
+
+func (s *ApplicationService) pruneRecords(ctx context.Context) {
+	records, err := s.repository.FindByStatus(ctx, valueobjects.JobStatusCompleted, valueobjects.JobStatusFailed, valueobjects.JobStatusCancelled)
+	if err != nil {
+		s.logger.WithError(err).Warn("Failed to list finished job records")
+		return
+	}
+	cutoff := time.Now().UTC().Add(-s.recovery.RecordRetention)
+	for _, record := range records {
+		if record.UpdatedAt.Before(cutoff) {
+			_ = s.repository.Delete(ctx, record.ID)
+		}
+	}
+}
+
+func (s *ApplicationService) CancelJob(ctx context.Context, correlationID string, issuedAt time.Time) bool {
+	running := s.running.Cancel(correlationID, issuedAt)
+	s.logger.Info("Job cancel requested", "correlation_id", correlationID, "running_here", running)
+	return running
+}
@@ -0,0 +1,148 @@
+package live
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
+	domainlive "github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/live"
+)
+
+type LiveApplicationService interface {
+	StartSession(ctx context.Context, session *domainlive.Session) error
+	StopSession(ctx context.Context, assetID, sessionID string) bool
+}
+
+// ApplicationService runs live sessions in the background. A session
+// outlives the Kafka message that started it, so each one runs on the
+// service's own context rather than the handler's.
+type ApplicationService struct {
+	ingester  domainlive.Ingester
+	publisher domainlive.EventPublisher
+	sessions  *domainlive.Sessions
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	logger    *logger.Logger
+}
+
+func NewApplicationService(ingester domainlive.Ingester, publisher domainlive.EventPublisher, maxSessions int) *ApplicationService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &ApplicationService{
+		ingester:  ingester,
+		publisher: publisher,
+		sessions:  domainlive.NewSessions(maxSessions),
+		ctx:       ctx,
+		cancel:    cancel,
+		logger:    logger.WithService("live-application-service"),
+	}
+}
+
+// StartSession returns once the session is accepted. A redelivered request
+// for a session that is already running is ignored.
+func (s *ApplicationService) StartSession(ctx context.Context, session *domainlive.Session) error {
+	sessionCtx, release, err := s.sessions.Track(s.ctx, session)
+	if errors.Is(err, domainlive.ErrSessionRunning) {
+		s.logger.Info("Live session already running", "asset_id", session.AssetID, "session_id", session.SessionID)
+		return nil
+	}
+	if err != nil {
+		s.logger.WithError(err).Warn("Live session rejected", "asset_id", session.AssetID, "session_id", session.SessionID)
+		s.publishStopped(session, nil, err)
+		return nil
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer release()
+		s.run(sessionCtx, session)
+	}()
+	return nil
+}
+
+func (s *ApplicationService) StopSession(ctx context.Context, assetID, sessionID string) bool {
+	stopped := s.sessions.Stop(assetID, sessionID)
+	if stopped {
+		s.logger.Info("Stopping live session", "asset_id", assetID, "session_id", sessionID)
+	}
+	return stopped
+}
+
+// Shutdown stops every session and waits for them to close their playlists
+// and upload their archives, or for ctx to expire.
+func (s *ApplicationService) Shutdown(ctx context.Context) {
+	s.sessions.StopAll()
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		s.logger.Warn("Live sessions did not finish before shutdown", "active", s.sessions.Count())
+	}
+	s.cancel()
+}
+
+func (s *ApplicationService) run(ctx context.Context, session *domainlive.Session) {
+	log := s.logger.WithFields(map[string]any{"asset_id": session.AssetID, "session_id": session.SessionID})
+	hooks := domainlive.Hooks{
+		Listening: func(ingestURL string) {
+			log.Info("Live listener ready", "ingest_url", ingestURL, "protocol", session.Protocol)
+			s.publish(&domainlive.StreamReadyEvent{
+				AssetID:   session.AssetID,
+				SessionID: session.SessionID,
+				IngestURL: ingestURL,
+			})
+		},
+		Started: func(playbackURL string) {
+			log.Info("Live stream started", "playback_url", playbackURL)
+			s.publish(&domainlive.StreamStartedEvent{
+				AssetID:     session.AssetID,
+				SessionID:   session.SessionID,
+				PlaybackURL: playbackURL,
+				StartedAt:   time.Now().UTC().Format(time.RFC3339),
+			})
+		},
+	}
+
+	result, err := s.ingester.Ingest(ctx, session, hooks)
+	if err != nil {
+		log.WithError(err).Error("Live session failed")
+	} else {
+		log.Info("Live session ended", "archive_url", result.ArchiveURL, "duration", result.Duration.String())
+	}
+	s.publishStopped(session, result, err)
+}
+
+func (s *ApplicationService) publishStopped(session *domainlive.Session, result *domainlive.Result, err error) {
+	ev := &domainlive.StreamStoppedEvent{
+		AssetID:   session.AssetID,
+		SessionID: session.SessionID,
+		Success:   err == nil,
+		EndedAt:   time.Now().UTC().Format(time.RFC3339),
+	}
+	if err != nil {
+		ev.ErrorMessage = err.Error()
+	}
+	if result != nil {
+		ev.PlaybackURL = result.PlaybackURL
+		ev.ArchiveURL = result.ArchiveURL
+		ev.Duration = result.Duration.Seconds()
+	}
+	s.publish(ev)
+}
+
+// publish uses a fresh context: the stopped event in particular is sent
+// after the session's context is gone.
+func (s *ApplicationService) publish(ev domainlive.Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.publisher.PublishLiveEvent(ctx, ev); err != nil {
+		s.logger.WithError(err).Error("Failed to publish live event", "topic", ev.Topic(), "session_id", ev.ID())
+	}
+}
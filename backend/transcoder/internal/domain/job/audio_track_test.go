@@ -0,0 +1,54 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+func TestAudioTracks(t *testing.T) {
+	tests := []struct {
+		name        string
+		tracks      valueobjects.AudioTracks
+		wantDefault int
+		wantMulti   bool
+		wantNames   []string
+	}{
+		{name: "no tracks", wantDefault: 0},
+		{
+			name:        "single untagged track",
+			tracks:      valueobjects.AudioTracks{{Index: 0}},
+			wantDefault: 0,
+			wantNames:   []string{"audio_und_0"},
+		},
+		{
+			name:        "flagged default wins",
+			tracks:      valueobjects.AudioTracks{{Index: 0, Language: "eng"}, {Index: 1, Language: "TUR", Default: true}},
+			wantDefault: 1,
+			wantMulti:   true,
+			wantNames:   []string{"audio_eng_0", "audio_tur_1"},
+		},
+		{
+			name:        "first track without a flag",
+			tracks:      valueobjects.AudioTracks{{Index: 0, Language: "eng"}, {Index: 1, Language: "eng"}},
+			wantDefault: 0,
+			wantMulti:   true,
+			wantNames:   []string{"audio_eng_0", "audio_eng_1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tracks.DefaultIndex(); got != tt.wantDefault {
+				t.Errorf("default index = %d, want %d", got, tt.wantDefault)
+			}
+			if got := tt.tracks.IsMulti(); got != tt.wantMulti {
+				t.Errorf("multi = %v, want %v", got, tt.wantMulti)
+			}
+			for i, track := range tt.tracks {
+				if track.Name() != tt.wantNames[i] {
+					t.Errorf("name[%d] = %q, want %q", i, track.Name(), tt.wantNames[i])
+				}
+			}
+		})
+	}
+}
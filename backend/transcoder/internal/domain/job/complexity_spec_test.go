@@ -0,0 +1,76 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+func TestComplexitySpec_Score(t *testing.T) {
+	spec := valueobjects.DefaultComplexitySpec()
+
+	tests := []struct {
+		name   string
+		probes []valueobjects.ComplexityProbe
+		want   float64
+	}{
+		{
+			name:   "twice the reference at every point",
+			probes: []valueobjects.ComplexityProbe{{CRF: 23, Bitrate: 2800}, {CRF: 28, Bitrate: 1400}},
+			want:   2,
+		},
+		{
+			name:   "geometric mean across points",
+			probes: []valueobjects.ComplexityProbe{{CRF: 23, Bitrate: 2800}, {CRF: 28, Bitrate: 350}},
+			want:   1,
+		},
+		{
+			name:   "unknown CRFs are ignored",
+			probes: []valueobjects.ComplexityProbe{{CRF: 18, Bitrate: 9000}, {CRF: 28, Bitrate: 350}},
+			want:   0.5,
+		},
+		{
+			name: "no usable probe",
+			want: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := spec.Score(tt.probes); got != tt.want {
+				t.Errorf("Score() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComplexitySpec_Factor(t *testing.T) {
+	spec := valueobjects.DefaultComplexitySpec()
+	for score, want := range map[float64]float64{0: 1, 0.2: 0.5, 0.8: 0.8, 3: 1.6} {
+		if got := spec.Factor(score); got != want {
+			t.Errorf("Factor(%v) = %v, want %v", score, got, want)
+		}
+	}
+
+	if _, err := valueobjects.NewComplexitySpec(true, 3, 4, 540, nil, 0.5, 1.6); err == nil {
+		t.Error("expected error without probe points")
+	}
+	if _, err := valueobjects.NewComplexitySpec(true, 3, 4, 540, spec.Points, 1.5, 1.2); err == nil {
+		t.Error("expected error for inverted factor bounds")
+	}
+}
+
+func TestLadder_Scale(t *testing.T) {
+	scaled := valueobjects.DefaultLadder().Scale(0.5)
+	if scaled[0].VideoBitrate != 200 || scaled[0].BaseVideoBitrate != 400 {
+		t.Fatalf("240p = %d (base %d), want 200 (base 400)", scaled[0].VideoBitrate, scaled[0].BaseVideoBitrate)
+	}
+	if scaled[2].VideoBitrate != 1400 || scaled[2].Height != 720 || scaled[2].AudioBitrate != 128 {
+		t.Fatalf("720p rung changed beyond its video bitrate: %+v", scaled[2])
+	}
+
+	rescaled := scaled.Scale(1.25)
+	if rescaled[3].VideoBitrate != 6250 || rescaled[3].BaseVideoBitrate != 5000 {
+		t.Fatalf("rescaling should start from the configured bitrate, got %+v", rescaled[3])
+	}
+}
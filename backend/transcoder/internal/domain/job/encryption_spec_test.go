@@ -0,0 +1,45 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+func TestNewEncryptionSpec(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		prefix     string
+		wantErr    bool
+		wantMethod string
+	}{
+		{name: "method defaults to AES-128", prefix: "https://keys.example.com/api/v1/keys/", wantMethod: valueobjects.EncryptionMethodAES128},
+		{name: "method is normalised", method: " aes-128 ", prefix: "http://localhost:8084/keys", wantMethod: valueobjects.EncryptionMethodAES128},
+		{name: "sample-aes is rejected", method: "sample-aes", prefix: "http://localhost:8084/keys", wantErr: true},
+		{name: "unknown method", method: "AES-256", prefix: "https://keys.example.com", wantErr: true},
+		{name: "prefix must be http", method: "AES-128", prefix: "s3://bucket/keys", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := valueobjects.NewEncryptionSpec(tt.method, tt.prefix)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", spec)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if spec.Method != tt.wantMethod {
+				t.Errorf("method = %q, want %q", spec.Method, tt.wantMethod)
+			}
+		})
+	}
+
+	spec, _ := valueobjects.NewEncryptionSpec("", "https://keys.example.com/api/v1/keys/")
+	if got := spec.KeyURI("a1", "v1", "j1"); got != "https://keys.example.com/api/v1/keys/a1/v1/j1" {
+		t.Errorf("key URI = %q", got)
+	}
+}
@@ -14,8 +14,24 @@ type Job struct {
 	assetID     valueobjects.AssetID
 	videoID     valueobjects.VideoID
 	input       string
+	inputSpec   valueobjects.InputSpec
 	output      string
 	quality     string
+	ladder      valueobjects.Ladder
+	thumbnails  valueobjects.ThumbnailSpec
+	encryption  valueobjects.EncryptionSpec
+	markers     valueobjects.MarkerSpec
+	segmented   valueobjects.SegmentedSpec
+	qc          valueobjects.QualitySpec
+	probe       valueobjects.ComplexitySpec
+	complexity  float64
+	loudness    valueobjects.LoudnessSpec
+	audioOnly   valueobjects.AudioOnlySpec
+	overlay     valueobjects.OverlaySpec
+	subtitles   []valueobjects.SubtitleTrack
+	audioTracks valueobjects.AudioTracks
+	sourceDur   float64
+	correlation string
 	status      valueobjects.JobStatus
 	progress    float64
 	error       string
@@ -65,6 +81,11 @@ func (j *Job) ID() valueobjects.JobID {
 	return j.id
 }
 
+// RestoreID gives a job rebuilt from a persisted record its original ID.
+func (j *Job) RestoreID(id valueobjects.JobID) {
+	j.id = id
+}
+
 func (j *Job) Type() valueobjects.JobType {
 	return j.jobType
 }
@@ -85,6 +106,16 @@ func (j *Job) Input() string {
 	return j.input
 }
 
+// InputSpec holds the rules the source is checked against before encoding.
+func (j *Job) InputSpec() valueobjects.InputSpec {
+	return j.inputSpec
+}
+
+func (j *Job) SetInputSpec(spec valueobjects.InputSpec) {
+	j.inputSpec = spec
+	j.updatedAt = time.Now().UTC()
+}
+
 func (j *Job) Output() string {
 	return j.output
 }
@@ -96,6 +127,146 @@ func (j *Job) Quality() string {
 	return j.quality
 }
 
+func (j *Job) Ladder() valueobjects.Ladder {
+	return j.ladder
+}
+
+func (j *Job) SetLadder(ladder valueobjects.Ladder) {
+	j.ladder = ladder
+	j.updatedAt = time.Now().UTC()
+}
+
+func (j *Job) ThumbnailSpec() valueobjects.ThumbnailSpec {
+	return j.thumbnails
+}
+
+func (j *Job) SetThumbnailSpec(spec valueobjects.ThumbnailSpec) {
+	j.thumbnails = spec
+	j.updatedAt = time.Now().UTC()
+}
+
+func (j *Job) Encryption() valueobjects.EncryptionSpec {
+	return j.encryption
+}
+
+func (j *Job) SetEncryption(spec valueobjects.EncryptionSpec) {
+	j.encryption = spec
+	j.updatedAt = time.Now().UTC()
+}
+
+func (j *Job) MarkerSpec() valueobjects.MarkerSpec {
+	return j.markers
+}
+
+func (j *Job) SetMarkerSpec(spec valueobjects.MarkerSpec) {
+	j.markers = spec
+	j.updatedAt = time.Now().UTC()
+}
+
+func (j *Job) SegmentedSpec() valueobjects.SegmentedSpec {
+	return j.segmented
+}
+
+func (j *Job) SetSegmentedSpec(spec valueobjects.SegmentedSpec) {
+	j.segmented = spec
+	j.updatedAt = time.Now().UTC()
+}
+
+func (j *Job) QualitySpec() valueobjects.QualitySpec {
+	return j.qc
+}
+
+func (j *Job) SetQualitySpec(spec valueobjects.QualitySpec) {
+	j.qc = spec
+	j.updatedAt = time.Now().UTC()
+}
+
+// ComplexitySpec drives the probe encodes of an analyze job.
+func (j *Job) ComplexitySpec() valueobjects.ComplexitySpec {
+	return j.probe
+}
+
+func (j *Job) SetComplexitySpec(spec valueobjects.ComplexitySpec) {
+	j.probe = spec
+	j.updatedAt = time.Now().UTC()
+}
+
+// LoudnessSpec is the normalization target; analyze jobs measure against
+// it and transcode jobs apply it.
+func (j *Job) LoudnessSpec() valueobjects.LoudnessSpec {
+	return j.loudness
+}
+
+func (j *Job) SetLoudnessSpec(spec valueobjects.LoudnessSpec) {
+	j.loudness = spec
+	j.updatedAt = time.Now().UTC()
+}
+
+func (j *Job) AudioOnly() valueobjects.AudioOnlySpec {
+	return j.audioOnly
+}
+
+func (j *Job) SetAudioOnly(spec valueobjects.AudioOnlySpec) {
+	j.audioOnly = spec
+	j.updatedAt = time.Now().UTC()
+}
+
+// Overlay is burned into every rendition; a zero spec means a clean encode.
+func (j *Job) Overlay() valueobjects.OverlaySpec {
+	return j.overlay
+}
+
+func (j *Job) SetOverlay(spec valueobjects.OverlaySpec) {
+	j.overlay = spec
+	j.updatedAt = time.Now().UTC()
+}
+
+// Complexity is the source's score from analyze, carried on transcode jobs
+// whose ladder was scaled by it. Zero means the ladder is as configured.
+func (j *Job) Complexity() float64 {
+	return j.complexity
+}
+
+func (j *Job) SetComplexity(score float64) {
+	j.complexity = score
+	j.updatedAt = time.Now().UTC()
+}
+
+func (j *Job) Subtitles() []valueobjects.SubtitleTrack {
+	return j.subtitles
+}
+
+func (j *Job) SetSubtitles(tracks []valueobjects.SubtitleTrack) {
+	j.subtitles = tracks
+	j.updatedAt = time.Now().UTC()
+}
+
+func (j *Job) AudioTracks() valueobjects.AudioTracks {
+	return j.audioTracks
+}
+
+func (j *Job) SetAudioTracks(tracks valueobjects.AudioTracks) {
+	j.audioTracks = tracks
+	j.updatedAt = time.Now().UTC()
+}
+
+func (j *Job) SourceDuration() float64 {
+	return j.sourceDur
+}
+
+func (j *Job) SetSourceDuration(seconds float64) {
+	j.sourceDur = seconds
+	j.updatedAt = time.Now().UTC()
+}
+
+func (j *Job) CorrelationID() string {
+	return j.correlation
+}
+
+func (j *Job) SetCorrelationID(correlationID string) {
+	j.correlation = correlationID
+}
+
 func (j *Job) Status() valueobjects.JobStatus {
 	return j.status
 }
@@ -161,6 +332,13 @@ func (j *Job) Fail(errorMessage string) {
 	j.updatedAt = now
 }
 
+func (j *Job) Cancel() {
+	now := time.Now().UTC()
+	j.status = valueobjects.JobStatusCancelled
+	j.completedAt = &now
+	j.updatedAt = now
+}
+
 func (j *Job) IsCompleted() bool {
 	return j.status == valueobjects.JobStatusCompleted
 }
@@ -169,6 +347,10 @@ func (j *Job) IsFailed() bool {
 	return j.status == valueobjects.JobStatusFailed
 }
 
+func (j *Job) IsCancelled() bool {
+	return j.status == valueobjects.JobStatusCancelled
+}
+
 func (j *Job) IsRunning() bool {
 	return j.status == valueobjects.JobStatusRunning
 }
@@ -8,4 +8,5 @@ import (
 
 type EventPublisher interface {
 	PublishJobCompleted(ctx context.Context, event events.CompletedEvent) error
+	PublishJobProgress(ctx context.Context, event *events.JobProgressEvent) error
 }
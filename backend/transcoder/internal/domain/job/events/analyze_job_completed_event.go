@@ -2,20 +2,23 @@ package events
 
 import (
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
 )
 
 type AnalyzeJobCompletedEvent struct {
 	JobCompletedBase
-	URL         string  `json:"url,omitempty"`
-	Bucket      string  `json:"bucket,omitempty"`
-	Key         string  `json:"key,omitempty"`
-	Width       int     `json:"width,omitempty"`
-	Height      int     `json:"height,omitempty"`
-	Duration    float64 `json:"duration,omitempty"`
-	Bitrate     int     `json:"bitrate,omitempty"`
-	Codec       string  `json:"codec,omitempty"`
-	Size        int64   `json:"size,omitempty"`
-	ContentType string  `json:"contentType,omitempty"`
+	URL         string                    `json:"url,omitempty"`
+	Bucket      string                    `json:"bucket,omitempty"`
+	Key         string                    `json:"key,omitempty"`
+	Width       int                       `json:"width,omitempty"`
+	Height      int                       `json:"height,omitempty"`
+	Duration    float64                   `json:"duration,omitempty"`
+	Bitrate     int                       `json:"bitrate,omitempty"`
+	Codec       string                    `json:"codec,omitempty"`
+	Size        int64                     `json:"size,omitempty"`
+	ContentType string                    `json:"contentType,omitempty"`
+	AudioTracks []valueobjects.AudioTrack `json:"audioTracks,omitempty"`
+	Complexity  float64                   `json:"complexity,omitempty"`
 }
 
 func (*AnalyzeJobCompletedEvent) Topic() string          { return events.AnalyzeJobCompletedTopic }
@@ -0,0 +1,38 @@
+package events
+
+import (
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+type CMAFJobCompletedEvent struct {
+	JobCompletedBase
+	Format             string                           `json:"format"`
+	URL                string                           `json:"url,omitempty"`
+	Bucket             string                           `json:"bucket,omitempty"`
+	Key                string                           `json:"key,omitempty"`
+	PlaylistURL        string                           `json:"playlistUrl,omitempty"`
+	PlaylistKey        string                           `json:"playlistKey,omitempty"`
+	Width              int                              `json:"width,omitempty"`
+	Height             int                              `json:"height,omitempty"`
+	Duration           float64                          `json:"duration,omitempty"`
+	Bitrate            int                              `json:"bitrate,omitempty"`
+	ContentType        string                           `json:"contentType,omitempty"`
+	SegmentCount       int                              `json:"segmentCount,omitempty"`
+	VideoCodec         string                           `json:"videoCodec,omitempty"`
+	AudioCodec         string                           `json:"audioCodec,omitempty"`
+	AvgSegmentDuration float64                          `json:"avgSegmentDuration,omitempty"`
+	Segments           []string                         `json:"segments,omitempty"`
+	FrameRate          string                           `json:"frameRate,omitempty"`
+	AudioChannels      int                              `json:"audioChannels,omitempty"`
+	AudioSampleRate    int                              `json:"audioSampleRate,omitempty"`
+	Renditions         []valueobjects.RenditionMetadata `json:"renditions,omitempty"`
+	QualityCheck       *valueobjects.QualityCheck       `json:"qualityCheck,omitempty"`
+	Complexity         float64                          `json:"complexity,omitempty"`
+	Ladder             valueobjects.Ladder              `json:"ladder,omitempty"`
+}
+
+func (*CMAFJobCompletedEvent) Topic() string          { return events.CMAFJobCompletedTopic }
+func (*CMAFJobCompletedEvent) CloudEventType() string { return events.JobTranscodeCompletedEventType }
+func (e *CMAFJobCompletedEvent) Type() string         { return "job.transcode.completed" }
+func (e *CMAFJobCompletedEvent) Data() interface{}    { return e }
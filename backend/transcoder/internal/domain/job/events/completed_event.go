@@ -1,11 +1,15 @@
 package events
 
+import "github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+
 type CompletedEvent interface {
 	Type() string
 	Data() interface{}
 	Topic() string
 	CloudEventType() string
 	ID() string
+	MarkCancelled()
+	SetRejections(rejections []valueobjects.InputRejection)
 }
 
 type JobCompletedBase struct {
@@ -13,8 +17,19 @@ type JobCompletedBase struct {
 	AssetID      string `json:"assetId"`
 	VideoID      string `json:"videoId"`
 	Success      bool   `json:"success"`
+	Cancelled    bool   `json:"cancelled,omitempty"`
 	ErrorMessage string `json:"errorMessage,omitempty"`
 	CompletedAt  string `json:"completedAt"`
+	// Rejections is set when the source failed input validation.
+	Rejections []valueobjects.InputRejection `json:"rejections,omitempty"`
+	// Variant is the overlay label of a watermarked or branded output.
+	Variant string `json:"variant,omitempty"`
 }
 
 func (b JobCompletedBase) ID() string { return b.JobID }
+
+func (b *JobCompletedBase) MarkCancelled() { b.Cancelled = true }
+
+func (b *JobCompletedBase) SetRejections(rejections []valueobjects.InputRejection) {
+	b.Rejections = rejections
+}
@@ -30,6 +30,8 @@ func NewAnalyzeJobCompletedEvent(job *entity.Job, success bool, metadata interfa
 			ev.Codec = m.Codec
 			ev.Size = m.Size
 			ev.ContentType = m.ContentType
+			ev.AudioTracks = m.AudioTracks
+			ev.Complexity = m.Complexity
 		}
 	}
 	return ev
@@ -44,6 +46,7 @@ func NewHLSJobCompletedEvent(job *entity.Job, success bool, metadata interface{}
 			Success:      success,
 			ErrorMessage: errorMessage,
 			CompletedAt:  time.Now().UTC().Format(time.RFC3339),
+			Variant:      job.Overlay().Label,
 		},
 		Format: "hls",
 	}
@@ -65,6 +68,10 @@ func NewHLSJobCompletedEvent(job *entity.Job, success bool, metadata interface{}
 			ev.FrameRate = m.FrameRate
 			ev.AudioChannels = m.AudioChannels
 			ev.AudioSampleRate = m.AudioSampleRate
+			ev.Renditions = m.Renditions
+			ev.QualityCheck = m.QualityCheck
+			ev.Complexity = m.Complexity
+			ev.Ladder = m.Ladder
 		}
 	}
 	return ev
@@ -79,6 +86,7 @@ func NewDASHJobCompletedEvent(job *entity.Job, success bool, metadata interface{
 			Success:      success,
 			ErrorMessage: errorMessage,
 			CompletedAt:  time.Now().UTC().Format(time.RFC3339),
+			Variant:      job.Overlay().Label,
 		},
 		Format: "dash",
 	}
@@ -100,6 +108,104 @@ func NewDASHJobCompletedEvent(job *entity.Job, success bool, metadata interface{
 			ev.FrameRate = m.FrameRate
 			ev.AudioChannels = m.AudioChannels
 			ev.AudioSampleRate = m.AudioSampleRate
+			ev.Renditions = m.Renditions
+			ev.QualityCheck = m.QualityCheck
+			ev.Complexity = m.Complexity
+			ev.Ladder = m.Ladder
+		}
+	}
+	return ev
+}
+
+func NewCMAFJobCompletedEvent(job *entity.Job, success bool, metadata interface{}, errorMessage string) CompletedEvent {
+	ev := &CMAFJobCompletedEvent{
+		JobCompletedBase: JobCompletedBase{
+			JobID:        job.ID().Value(),
+			AssetID:      job.AssetID().Value(),
+			VideoID:      job.VideoID().Value(),
+			Success:      success,
+			ErrorMessage: errorMessage,
+			CompletedAt:  time.Now().UTC().Format(time.RFC3339),
+		},
+		Format: "cmaf",
+	}
+	if success && metadata != nil {
+		if m, ok := metadata.(*valueobjects.TranscodeMetadata); ok {
+			ev.URL = m.OutputURL
+			ev.Bucket = m.Bucket
+			ev.Key = m.Key
+			ev.PlaylistURL = m.PlaylistURL
+			ev.PlaylistKey = m.PlaylistKey
+			ev.Width = m.Width
+			ev.Height = m.Height
+			ev.Duration = m.Duration
+			ev.Bitrate = m.Bitrate
+			ev.ContentType = m.ContentType
+			ev.SegmentCount = m.SegmentCount
+			ev.VideoCodec = m.VideoCodec
+			ev.AudioCodec = m.AudioCodec
+			ev.AvgSegmentDuration = m.AvgSegmentDuration
+			ev.Segments = m.Segments
+			ev.FrameRate = m.FrameRate
+			ev.AudioChannels = m.AudioChannels
+			ev.AudioSampleRate = m.AudioSampleRate
+			ev.Renditions = m.Renditions
+			ev.QualityCheck = m.QualityCheck
+			ev.Complexity = m.Complexity
+			ev.Ladder = m.Ladder
+		}
+	}
+	return ev
+}
+
+func NewThumbnailsJobCompletedEvent(job *entity.Job, success bool, metadata interface{}, errorMessage string) CompletedEvent {
+	ev := &ThumbnailsJobCompletedEvent{
+		JobCompletedBase: JobCompletedBase{
+			JobID:        job.ID().Value(),
+			AssetID:      job.AssetID().Value(),
+			VideoID:      job.VideoID().Value(),
+			Success:      success,
+			ErrorMessage: errorMessage,
+			CompletedAt:  time.Now().UTC().Format(time.RFC3339),
+		},
+		Format: "thumbnails",
+	}
+	if success && metadata != nil {
+		if m, ok := metadata.(*valueobjects.TranscodeMetadata); ok {
+			ev.URL = m.OutputURL
+			ev.Bucket = m.Bucket
+			ev.Key = m.Key
+			ev.Width = m.Width
+			ev.Height = m.Height
+			ev.Duration = m.Duration
+			ev.ContentType = m.ContentType
+			ev.Images = m.Images
+			ev.TrackURL = m.TrackURL
+			ev.TrackKey = m.TrackKey
+		}
+	}
+	return ev
+}
+
+func NewMarkersJobCompletedEvent(job *entity.Job, success bool, metadata interface{}, errorMessage string) CompletedEvent {
+	ev := &MarkersJobCompletedEvent{
+		JobCompletedBase: JobCompletedBase{
+			JobID:        job.ID().Value(),
+			AssetID:      job.AssetID().Value(),
+			VideoID:      job.VideoID().Value(),
+			Success:      success,
+			ErrorMessage: errorMessage,
+			CompletedAt:  time.Now().UTC().Format(time.RFC3339),
+		},
+		Format: "markers",
+	}
+	if success && metadata != nil {
+		if m, ok := metadata.(*valueobjects.TranscodeMetadata); ok {
+			ev.URL = m.OutputURL
+			ev.Bucket = m.Bucket
+			ev.Key = m.Key
+			ev.Duration = m.Duration
+			ev.Markers = m.Markers
 		}
 	}
 	return ev
@@ -109,6 +215,10 @@ var builderMap = map[string]func(*entity.Job, bool, interface{}, string) Complet
 	"analyze":        NewAnalyzeJobCompletedEvent,
 	"transcode:hls":  NewHLSJobCompletedEvent,
 	"transcode:dash": NewDASHJobCompletedEvent,
+	"transcode:cmaf": NewCMAFJobCompletedEvent,
+
+	"transcode:thumbnails": NewThumbnailsJobCompletedEvent,
+	"transcode:markers":    NewMarkersJobCompletedEvent,
 }
 
 func BuildCompletedEvent(job *entity.Job, success bool, metadata interface{}, errorMessage string) CompletedEvent {
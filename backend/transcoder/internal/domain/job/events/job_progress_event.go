@@ -0,0 +1,38 @@
+package events
+
+import (
+	"time"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
+)
+
+type JobProgressEvent struct {
+	JobID          string  `json:"jobId"`
+	JobType        string  `json:"jobType"`
+	AssetID        string  `json:"assetId"`
+	VideoID        string  `json:"videoId"`
+	Format         string  `json:"format,omitempty"`
+	Progress       float64 `json:"progress"`
+	OutTimeSeconds float64 `json:"outTimeSeconds,omitempty"`
+	ReportedAt     string  `json:"reportedAt"`
+}
+
+func NewJobProgressEvent(job *entity.Job, progress, outTimeSeconds float64) *JobProgressEvent {
+	return &JobProgressEvent{
+		JobID:          job.ID().Value(),
+		JobType:        job.Type().String(),
+		AssetID:        job.AssetID().Value(),
+		VideoID:        job.VideoID().Value(),
+		Format:         job.Format().String(),
+		Progress:       progress,
+		OutTimeSeconds: outTimeSeconds,
+		ReportedAt:     time.Now().UTC().Format(time.RFC3339),
+	}
+}
+
+func (*JobProgressEvent) Topic() string          { return events.TranscodeJobProgressTopic }
+func (*JobProgressEvent) CloudEventType() string { return events.JobTranscodeProgressEventType }
+func (e *JobProgressEvent) Type() string         { return "job.transcode.progress" }
+func (e *JobProgressEvent) Data() interface{}    { return e }
+func (e *JobProgressEvent) ID() string           { return e.JobID }
@@ -0,0 +1,23 @@
+package events
+
+import (
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+type MarkersJobCompletedEvent struct {
+	JobCompletedBase
+	Format   string                `json:"format"`
+	URL      string                `json:"url,omitempty"`
+	Bucket   string                `json:"bucket,omitempty"`
+	Key      string                `json:"key,omitempty"`
+	Duration float64               `json:"duration,omitempty"`
+	Markers  []valueobjects.Marker `json:"markers,omitempty"`
+}
+
+func (*MarkersJobCompletedEvent) Topic() string { return events.MarkersJobCompletedTopic }
+func (*MarkersJobCompletedEvent) CloudEventType() string {
+	return events.JobTranscodeCompletedEventType
+}
+func (e *MarkersJobCompletedEvent) Type() string      { return "job.transcode.completed" }
+func (e *MarkersJobCompletedEvent) Data() interface{} { return e }
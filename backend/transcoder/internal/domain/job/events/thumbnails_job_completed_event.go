@@ -0,0 +1,28 @@
+package events
+
+import (
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+type ThumbnailsJobCompletedEvent struct {
+	JobCompletedBase
+	Format      string                       `json:"format"`
+	URL         string                       `json:"url,omitempty"`
+	Bucket      string                       `json:"bucket,omitempty"`
+	Key         string                       `json:"key,omitempty"`
+	Width       int                          `json:"width,omitempty"`
+	Height      int                          `json:"height,omitempty"`
+	Duration    float64                      `json:"duration,omitempty"`
+	ContentType string                       `json:"contentType,omitempty"`
+	Images      []valueobjects.ImageMetadata `json:"images,omitempty"`
+	TrackURL    string                       `json:"trackUrl,omitempty"`
+	TrackKey    string                       `json:"trackKey,omitempty"`
+}
+
+func (*ThumbnailsJobCompletedEvent) Topic() string { return events.ThumbnailsJobCompletedTopic }
+func (*ThumbnailsJobCompletedEvent) CloudEventType() string {
+	return events.JobTranscodeCompletedEventType
+}
+func (e *ThumbnailsJobCompletedEvent) Type() string      { return "job.transcode.completed" }
+func (e *ThumbnailsJobCompletedEvent) Data() interface{} { return e }
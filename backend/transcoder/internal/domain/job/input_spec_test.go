@@ -0,0 +1,139 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+func TestInputSpec_Check(t *testing.T) {
+	spec := valueobjects.DefaultInputSpec()
+	spec.Enabled = true
+	clean := valueobjects.InputProbe{
+		Container:   "mov,mp4,m4a,3gp,3g2,mj2",
+		Size:        200 << 20,
+		Duration:    600,
+		VideoCodec:  "h264",
+		Width:       1920,
+		Height:      1080,
+		FieldOrder:  "progressive",
+		AudioCodecs: []string{"aac"},
+	}
+
+	tests := []struct {
+		name  string
+		spec  func(valueobjects.InputSpec) valueobjects.InputSpec
+		probe func(valueobjects.InputProbe) valueobjects.InputProbe
+		want  []string
+	}{
+		{name: "clean source"},
+		{
+			name: "unknown container and codecs",
+			probe: func(p valueobjects.InputProbe) valueobjects.InputProbe {
+				p.Container, p.VideoCodec, p.AudioCodecs = "rm", "rv40", []string{"aac", "cook"}
+				return p
+			},
+			want: []string{valueobjects.RejectionUnsupportedContainer, valueobjects.RejectionUnsupportedVideoCodec, valueobjects.RejectionUnsupportedAudioCodec},
+		},
+		{
+			name: "over every limit",
+			probe: func(p valueobjects.InputProbe) valueobjects.InputProbe {
+				p.Size, p.Duration, p.Width = 200<<30, 7*3600, 8192
+				return p
+			},
+			want: []string{valueobjects.RejectionFileTooLarge, valueobjects.RejectionDurationExceeded, valueobjects.RejectionResolutionExceeded},
+		},
+		{
+			name: "no streams",
+			probe: func(p valueobjects.InputProbe) valueobjects.InputProbe {
+				p.VideoCodec, p.AudioCodecs = "", nil
+				return p
+			},
+			want: []string{valueobjects.RejectionMissingVideo, valueobjects.RejectionMissingAudio},
+		},
+		{
+			name: "audio-only source where video is optional",
+			spec: func(s valueobjects.InputSpec) valueobjects.InputSpec {
+				s.RequireVideo = false
+				return s
+			},
+			probe: func(p valueobjects.InputProbe) valueobjects.InputProbe {
+				p.Container, p.VideoCodec = "mp3", ""
+				return p
+			},
+		},
+		{
+			name: "variable frame rate and interlaced frames",
+			probe: func(p valueobjects.InputProbe) valueobjects.InputProbe {
+				p.VFR, p.Interlaced = 0.4, 0.9
+				return p
+			},
+			want: []string{valueobjects.RejectionVariableFrameRate, valueobjects.RejectionInterlaced},
+		},
+		{
+			name: "interlaced by field order alone",
+			probe: func(p valueobjects.InputProbe) valueobjects.InputProbe {
+				p.FieldOrder = "tt"
+				return p
+			},
+			want: []string{valueobjects.RejectionInterlaced},
+		},
+		{
+			name: "decode errors",
+			probe: func(p valueobjects.InputProbe) valueobjects.InputProbe {
+				p.DecodeErrors = []string{"Invalid NAL unit size"}
+				return p
+			},
+			want: []string{valueobjects.RejectionCorruptedStream},
+		},
+		{
+			name: "empty allow-lists and zero limits are not enforced",
+			spec: func(valueobjects.InputSpec) valueobjects.InputSpec {
+				return valueobjects.InputSpec{Enabled: true}
+			},
+			probe: func(p valueobjects.InputProbe) valueobjects.InputProbe {
+				p.Container, p.Size, p.Width = "rm", 1<<40, 16384
+				return p
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, p := spec, clean
+			if tt.spec != nil {
+				s = tt.spec(s)
+			}
+			if tt.probe != nil {
+				p = tt.probe(p)
+			}
+			got := s.Check(p)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Check() = %+v, want codes %v", got, tt.want)
+			}
+			for i, r := range got {
+				if r.Code != tt.want[i] || r.Message == "" {
+					t.Errorf("rejection[%d] = %+v, want code %s", i, r, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestNewInputSpec(t *testing.T) {
+	spec, err := valueobjects.NewInputSpec(true, []string{" MP4 ", ""}, nil, nil, 3600, 1920, 1080, 1<<30, true, false, 0.1, 0.1, 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(spec.Containers) != 1 || spec.Containers[0] != "mp4" {
+		t.Errorf("containers = %v, want [mp4]", spec.Containers)
+	}
+	if _, err := valueobjects.NewInputSpec(true, nil, nil, nil, -1, 0, 0, 0, true, true, 0, 0, 10); err == nil {
+		t.Error("expected error for a negative limit")
+	}
+	if _, err := valueobjects.NewInputSpec(true, nil, nil, nil, 0, 0, 0, 0, true, true, 1.5, 0, 10); err == nil {
+		t.Error("expected error for a ratio above 1")
+	}
+	if _, err := valueobjects.NewInputSpec(true, nil, nil, nil, 0, 0, 0, 0, true, true, 0, 0, 0); err == nil {
+		t.Error("expected error without a decode window")
+	}
+}
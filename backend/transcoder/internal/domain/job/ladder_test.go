@@ -0,0 +1,72 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+func TestLadder_Fit(t *testing.T) {
+	tests := []struct {
+		name         string
+		sourceWidth  int
+		sourceHeight int
+		wantNames    []string
+		wantTopWidth int
+	}{
+		{
+			name:         "1080p source keeps full ladder",
+			sourceWidth:  1920,
+			sourceHeight: 1080,
+			wantNames:    []string{"240p", "480p", "720p", "1080p"},
+			wantTopWidth: 1920,
+		},
+		{
+			name:         "720p source drops taller rungs",
+			sourceWidth:  1280,
+			sourceHeight: 720,
+			wantNames:    []string{"240p", "480p", "720p"},
+			wantTopWidth: 1280,
+		},
+		{
+			name:         "scope source recomputes widths",
+			sourceWidth:  1920,
+			sourceHeight: 800,
+			wantNames:    []string{"240p", "480p", "720p"},
+			wantTopWidth: 1728,
+		},
+		{
+			name:         "tiny source keeps a single native rung",
+			sourceWidth:  320,
+			sourceHeight: 180,
+			wantNames:    []string{"180p"},
+			wantTopWidth: 320,
+		},
+		{
+			name:         "unknown source keeps configured ladder",
+			wantNames:    []string{"240p", "480p", "720p", "1080p"},
+			wantTopWidth: 1920,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fitted := valueobjects.DefaultLadder().Fit(tt.sourceWidth, tt.sourceHeight)
+			if len(fitted) != len(tt.wantNames) {
+				t.Fatalf("Fit() returned %d rungs, want %d", len(fitted), len(tt.wantNames))
+			}
+			for i, r := range fitted {
+				if r.Name != tt.wantNames[i] {
+					t.Errorf("rung %d name = %s, want %s", i, r.Name, tt.wantNames[i])
+				}
+				if tt.sourceHeight > 0 && r.Height > tt.sourceHeight {
+					t.Errorf("rung %s upscales to %d over source %d", r.Name, r.Height, tt.sourceHeight)
+				}
+			}
+			top, _ := fitted.Top()
+			if top.Width != tt.wantTopWidth {
+				t.Errorf("top rung width = %d, want %d", top.Width, tt.wantTopWidth)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+func TestNewLoudnessSpec(t *testing.T) {
+	tests := []struct {
+		name     string
+		targetI  float64
+		truePeak float64
+		lra      float64
+		wantErr  bool
+	}{
+		{name: "EBU R128 broadcast", targetI: -23, truePeak: -1, lra: 11},
+		{name: "streaming target", targetI: -16, truePeak: -1.5, lra: 7},
+		{name: "integrated too quiet", targetI: -80, truePeak: -1, lra: 11, wantErr: true},
+		{name: "integrated too loud", targetI: -3, truePeak: -1, lra: 11, wantErr: true},
+		{name: "true peak above full scale", targetI: -23, truePeak: 1, lra: 11, wantErr: true},
+		{name: "range below one", targetI: -23, truePeak: -1, lra: 0.5, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := valueobjects.NewLoudnessSpec(true, tt.targetI, tt.truePeak, tt.lra)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (!spec.Enabled || spec.TargetI != tt.targetI) {
+				t.Errorf("spec = %+v", spec)
+			}
+		})
+	}
+}
+
+func TestNewAudioOnlySpec(t *testing.T) {
+	spec, err := valueobjects.NewAudioOnlySpec([]int{64, 128}, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if spec.IsZero() || !spec.Exclusive {
+		t.Errorf("spec = %+v", spec)
+	}
+	if !(valueobjects.AudioOnlySpec{}).IsZero() {
+		t.Error("empty spec should be zero")
+	}
+
+	if _, err := valueobjects.NewAudioOnlySpec(nil, false); err == nil {
+		t.Error("expected error without bitrates")
+	}
+	if _, err := valueobjects.NewAudioOnlySpec([]int{64, 0}, false); err == nil {
+		t.Error("expected error for a zero bitrate")
+	}
+}
+
+func TestAudioTracks_Default(t *testing.T) {
+	if _, ok := (valueobjects.AudioTracks{}).Default(); ok {
+		t.Error("no tracks should have no default")
+	}
+	tracks := valueobjects.AudioTracks{
+		{Index: 0, Language: "eng"},
+		{Index: 1, Language: "tur", Default: true, Loudness: &valueobjects.Loudness{Integrated: -19.4}},
+	}
+	got, ok := tracks.Default()
+	if !ok || got.Index != 1 || got.Loudness == nil || got.Loudness.Integrated != -19.4 {
+		t.Errorf("Default() = %+v, %v", got, ok)
+	}
+}
@@ -0,0 +1,71 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+func TestMarkerSpec_ProposeMarkers(t *testing.T) {
+	spec, err := valueobjects.NewMarkerSpec(0.4, 600, 300, 300)
+	if err != nil {
+		t.Fatalf("NewMarkerSpec: %v", err)
+	}
+	blacks := []valueobjects.Interval{
+		{Start: 20, End: 21},
+		{Start: 110, End: 111},
+		{Start: 1590, End: 1592},
+		{Start: 1700, End: 1701},
+	}
+	silences := []valueobjects.Interval{
+		{Start: 19.5, End: 21.5},
+		{Start: 109.8, End: 111.2},
+		{Start: 1589, End: 1593},
+	}
+	scenes := []float64{450, 640, 1250}
+
+	markers := spec.ProposeMarkers(1800, scenes, blacks, silences)
+	want := []valueobjects.Marker{
+		{Kind: valueobjects.MarkerKindIntro, Start: 21, End: 110},
+		{Kind: valueobjects.MarkerKindCredits, Start: 1592},
+		{Kind: valueobjects.MarkerKindChapter, Start: 0, End: 640},
+		{Kind: valueobjects.MarkerKindChapter, Start: 640, End: 1250},
+		{Kind: valueobjects.MarkerKindChapter, Start: 1250, End: 1592},
+	}
+	if len(markers) != len(want) {
+		t.Fatalf("got %d markers, want %d: %+v", len(markers), len(want), markers)
+	}
+	for i, w := range want {
+		got := markers[i]
+		if got.Kind != w.Kind || got.Start != w.Start || got.End != w.End {
+			t.Errorf("marker %d = %s %.0f-%.0f, want %s %.0f-%.0f", i, got.Kind, got.Start, got.End, w.Kind, w.Start, w.End)
+		}
+	}
+}
+
+func TestMarkerSpec_CreditsFallsBackToBlack(t *testing.T) {
+	spec := valueobjects.DefaultMarkerSpec()
+	blacks := []valueobjects.Interval{{Start: 1400, End: 1402}}
+
+	markers := spec.ProposeMarkers(1800, nil, blacks, nil)
+	for _, m := range markers {
+		if m.Kind == valueobjects.MarkerKindIntro {
+			t.Errorf("unexpected intro without hard breaks: %+v", m)
+		}
+		if m.Kind == valueobjects.MarkerKindCredits && m.Start != 1402 {
+			t.Errorf("credits start = %.0f, want 1402", m.Start)
+		}
+	}
+	if markers[0].Kind != valueobjects.MarkerKindCredits {
+		t.Fatalf("first marker = %s, want credits", markers[0].Kind)
+	}
+}
+
+func TestNewMarkerSpec_Validation(t *testing.T) {
+	if _, err := valueobjects.NewMarkerSpec(1.2, 300, 300, 600); err == nil {
+		t.Error("expected error for scene threshold above 1")
+	}
+	if _, err := valueobjects.NewMarkerSpec(0.4, 0, 300, 600); err == nil {
+		t.Error("expected error for zero chapter interval")
+	}
+}
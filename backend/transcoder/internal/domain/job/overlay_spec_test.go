@@ -0,0 +1,60 @@
+package job
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+func TestNewOverlaySpec(t *testing.T) {
+	tests := []struct {
+		name         string
+		label        string
+		image        string
+		position     string
+		opacity      float64
+		text         string
+		wantErr      bool
+		wantLabel    string
+		wantPosition valueobjects.OverlayPosition
+		wantOpacity  float64
+	}{
+		{name: "logo defaults to opaque bottom right", label: "trailer-bug", image: "s3://content/a1/logo.png", wantLabel: "trailer-bug", wantPosition: valueobjects.OverlayBottomRight, wantOpacity: 1},
+		{name: "text only screener", label: "Screener_Acme", position: "top_left", opacity: 0.4, text: "ACME-0042", wantLabel: "screener_acme", wantPosition: valueobjects.OverlayTopLeft, wantOpacity: 0.4},
+		{name: "needs an image or text", label: "empty", wantErr: true},
+		{name: "label cannot be main", label: "main", text: "x", wantErr: true},
+		{name: "label must be a path segment", label: "a/b", text: "x", wantErr: true},
+		{name: "unknown position", label: "bug", image: "s3://content/logo.png", position: "left", wantErr: true},
+		{name: "opacity above one", label: "bug", image: "s3://content/logo.png", opacity: 1.5, wantErr: true},
+		{name: "text must be one line", label: "screener", text: "line one\nline two", wantErr: true},
+		{name: "text too long", label: "screener", text: strings.Repeat("x", 101), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := valueobjects.NewOverlaySpec(tt.label, tt.image, tt.position, tt.opacity, tt.text)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", spec)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if spec.Label != tt.wantLabel {
+				t.Errorf("label = %q, want %q", spec.Label, tt.wantLabel)
+			}
+			if spec.Position != tt.wantPosition {
+				t.Errorf("position = %q, want %q", spec.Position, tt.wantPosition)
+			}
+			if spec.Opacity != tt.wantOpacity {
+				t.Errorf("opacity = %v, want %v", spec.Opacity, tt.wantOpacity)
+			}
+		})
+	}
+
+	if !(valueobjects.OverlaySpec{}).IsZero() {
+		t.Error("zero spec should report IsZero")
+	}
+}
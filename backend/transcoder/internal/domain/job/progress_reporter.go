@@ -0,0 +1,106 @@
+package job
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/events"
+)
+
+type ProgressReporter interface {
+	Report(ctx context.Context, job *entity.Job, outTimeSeconds float64, done bool)
+	// Forget drops what is held for a job once it has ended, whether it
+	// completed, failed or was cancelled.
+	Forget(jobID string)
+}
+
+type progressMark struct {
+	at       time.Time
+	progress float64
+}
+
+// ThrottledProgressReporter turns encoder output time into a percentage of the
+// source duration and publishes at most one event per interval for each job.
+// When a repository is set, each published value is also checkpointed there.
+type ThrottledProgressReporter struct {
+	publisher  EventPublisher
+	repository JobRepository
+	interval   time.Duration
+	now        func() time.Time
+	mu         sync.Mutex
+	last       map[string]progressMark
+}
+
+func NewThrottledProgressReporter(publisher EventPublisher, interval time.Duration) *ThrottledProgressReporter {
+	return &ThrottledProgressReporter{
+		publisher: publisher,
+		interval:  interval,
+		now:       time.Now,
+		last:      map[string]progressMark{},
+	}
+}
+
+func (r *ThrottledProgressReporter) Report(ctx context.Context, job *entity.Job, outTimeSeconds float64, done bool) {
+	progress, ok := progressPercent(job.SourceDuration(), outTimeSeconds, done)
+	if !ok {
+		return
+	}
+
+	id := job.ID().Value()
+	now := r.now()
+	r.mu.Lock()
+	mark, seen := r.last[id]
+	if seen && progress <= mark.progress {
+		r.mu.Unlock()
+		return
+	}
+	if seen && !done && now.Sub(mark.at) < r.interval {
+		r.mu.Unlock()
+		return
+	}
+	if done {
+		delete(r.last, id)
+	} else {
+		r.last[id] = progressMark{at: now, progress: progress}
+	}
+	r.mu.Unlock()
+
+	job.UpdateProgress(progress)
+	r.publisher.PublishJobProgress(ctx, events.NewJobProgressEvent(job, progress, outTimeSeconds))
+	r.checkpoint(ctx, job)
+}
+
+func (r *ThrottledProgressReporter) Forget(jobID string) {
+	r.mu.Lock()
+	delete(r.last, jobID)
+	r.mu.Unlock()
+}
+
+func (r *ThrottledProgressReporter) SetRepository(repository JobRepository) {
+	r.repository = repository
+}
+
+// checkpoint is best effort: a missed write only means a recovered job shows
+// older progress.
+func (r *ThrottledProgressReporter) checkpoint(ctx context.Context, job *entity.Job) {
+	if r.repository == nil {
+		return
+	}
+	_ = r.repository.UpdateProgress(ctx, job.ID().Value(), job.Progress(), job.UpdatedAt())
+}
+
+// Encoding is not finished at out_time == duration (muxing and upload follow),
+// so percentages stay below 100 until ffmpeg reports progress=end.
+func progressPercent(duration, outTimeSeconds float64, done bool) (float64, bool) {
+	if done {
+		return 100, true
+	}
+	if duration <= 0 || outTimeSeconds <= 0 {
+		return 0, false
+	}
+	pct := math.Min(outTimeSeconds/duration*100, 99)
+	return math.Round(pct*10) / 10, true
+}
@@ -0,0 +1,100 @@
+package job
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/events"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+type recordingPublisher struct {
+	TestEventPublisher
+	progress []float64
+}
+
+func (p *recordingPublisher) PublishJobProgress(ctx context.Context, event *events.JobProgressEvent) error {
+	p.progress = append(p.progress, event.Progress)
+	return nil
+}
+
+func TestThrottledProgressReporter_Report(t *testing.T) {
+	assetID, _ := valueobjects.NewAssetID("aid")
+	videoID, _ := valueobjects.NewVideoID("vid")
+
+	type step struct {
+		after   time.Duration
+		outTime float64
+		done    bool
+	}
+	tests := []struct {
+		name           string
+		sourceDuration float64
+		steps          []step
+		want           []float64
+	}{
+		{
+			name:           "throttles updates inside the interval",
+			sourceDuration: 100,
+			steps: []step{
+				{after: 0, outTime: 10},
+				{after: time.Second, outTime: 20},
+				{after: 3 * time.Second, outTime: 30},
+			},
+			want: []float64{10, 30},
+		},
+		{
+			name:           "ignores regressions after a retry",
+			sourceDuration: 100,
+			steps: []step{
+				{after: 0, outTime: 50},
+				{after: 5 * time.Second, outTime: 5},
+			},
+			want: []float64{50},
+		},
+		{
+			name:           "caps at 99 until the encoder finishes",
+			sourceDuration: 10,
+			steps: []step{
+				{after: 0, outTime: 10.2},
+				{after: time.Millisecond, outTime: 10.2, done: true},
+			},
+			want: []float64{99, 100},
+		},
+		{
+			name: "unknown duration only reports completion",
+			steps: []step{
+				{after: 0, outTime: 10},
+				{after: 5 * time.Second, outTime: 20, done: true},
+			},
+			want: []float64{100},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := &recordingPublisher{}
+			reporter := NewThrottledProgressReporter(publisher, 2*time.Second)
+			clock := time.Unix(0, 0)
+			reporter.now = func() time.Time { return clock }
+
+			job := entity.NewTranscodeJob(*assetID, *videoID, "in", "s3://b/k", "main", valueobjects.JobFormatHLS)
+			job.SetSourceDuration(tt.sourceDuration)
+			for _, s := range tt.steps {
+				clock = clock.Add(s.after)
+				reporter.Report(context.Background(), job, s.outTime, s.done)
+			}
+
+			if len(publisher.progress) != len(tt.want) {
+				t.Fatalf("published %v, want %v", publisher.progress, tt.want)
+			}
+			for i := range tt.want {
+				if publisher.progress[i] != tt.want[i] {
+					t.Errorf("event %d progress = %v, want %v", i, publisher.progress[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
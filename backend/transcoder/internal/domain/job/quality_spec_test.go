@@ -0,0 +1,56 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+func TestQualitySpec_Windows(t *testing.T) {
+	spec, err := valueobjects.NewQualitySpec(true, 3, 5, 0, 0, 0)
+	if err != nil {
+		t.Fatalf("NewQualitySpec: %v", err)
+	}
+
+	got := spec.Windows(300)
+	want := []valueobjects.Interval{{Start: 47.5, End: 52.5}, {Start: 147.5, End: 152.5}, {Start: 247.5, End: 252.5}}
+	if len(got) != len(want) {
+		t.Fatalf("Windows() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("window %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+
+	if short := spec.Windows(12); len(short) != 1 || short[0].End != 12 {
+		t.Fatalf("short source should be sampled whole, got %+v", short)
+	}
+}
+
+func TestQualitySpec_Check(t *testing.T) {
+	spec, _ := valueobjects.NewQualitySpec(true, 3, 5, 35, 0.95, 80)
+	lowVMAF := 72.0
+	highVMAF := 91.0
+
+	renditions := []valueobjects.RenditionMetadata{
+		{Name: "1080p", Quality: &valueobjects.QualityScores{PSNR: 41, SSIM: 0.98, VMAF: &highVMAF}},
+		{Name: "240p", Quality: &valueobjects.QualityScores{PSNR: 31.5, SSIM: 0.97, VMAF: &lowVMAF}},
+		{Name: "audio"},
+	}
+
+	check := spec.Check(renditions)
+	if check.Passed {
+		t.Fatal("expected the 240p rendition to fail QC")
+	}
+	if len(check.Reasons) != 2 {
+		t.Fatalf("expected PSNR and VMAF reasons, got %v", check.Reasons)
+	}
+
+	if !spec.Check(renditions[:1]).Passed {
+		t.Fatal("expected the 1080p rendition alone to pass")
+	}
+	if _, err := valueobjects.NewQualitySpec(true, 3, 5, 0, 1.5, 0); err == nil {
+		t.Fatal("expected error for SSIM threshold above 1")
+	}
+}
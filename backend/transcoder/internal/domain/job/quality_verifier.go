@@ -0,0 +1,14 @@
+package job
+
+import (
+	"context"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+// QualityVerifier scores the renditions described by metadata against the
+// local source, filling in each rendition's Quality.
+type QualityVerifier interface {
+	Measure(ctx context.Context, job *entity.Job, sourcePath, outputPath string, metadata *valueobjects.TranscodeMetadata) error
+}
@@ -0,0 +1,57 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/messages"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+var ErrJobRecordNotFound = pkgerrors.NewNotFoundError("job record not found", nil)
+
+// JobRecord is what survives a worker restart: the request the job was built
+// from, so it can be rebuilt, and how far it had got.
+type JobRecord struct {
+	ID          string                 `json:"id"`
+	Payload     messages.JobPayload    `json:"payload"`
+	Status      valueobjects.JobStatus `json:"status"`
+	Progress    float64                `json:"progress"`
+	Attempt     int                    `json:"attempt"`
+	Error       string                 `json:"error,omitempty"`
+	StartedAt   *time.Time             `json:"startedAt,omitempty"`
+	CompletedAt *time.Time             `json:"completedAt,omitempty"`
+	UpdatedAt   time.Time              `json:"updatedAt"`
+}
+
+func NewJobRecord(job *entity.Job, payload messages.JobPayload, attempt int) *JobRecord {
+	payload.JobID = job.ID().Value()
+	record := &JobRecord{ID: job.ID().Value(), Payload: payload, Attempt: attempt}
+	record.Capture(job)
+	return record
+}
+
+// Capture copies the job's current state into the record.
+func (r *JobRecord) Capture(job *entity.Job) {
+	r.Status = job.Status()
+	r.Progress = job.Progress()
+	r.Error = job.Error()
+	r.StartedAt = job.StartedAt()
+	r.CompletedAt = job.CompletedAt()
+	r.UpdatedAt = job.UpdatedAt()
+}
+
+func (r *JobRecord) IsTerminal() bool {
+	return r.Status.IsCompleted() || r.Status.IsFailed() || r.Status.IsCancelled()
+}
+
+type JobRepository interface {
+	Save(ctx context.Context, record *JobRecord) error
+	// UpdateProgress leaves records that are already terminal untouched.
+	UpdateProgress(ctx context.Context, id string, progress float64, at time.Time) error
+	FindByID(ctx context.Context, id string) (*JobRecord, error)
+	FindByStatus(ctx context.Context, statuses ...valueobjects.JobStatus) ([]*JobRecord, error)
+	Delete(ctx context.Context, id string) error
+}
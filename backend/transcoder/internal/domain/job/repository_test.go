@@ -0,0 +1,36 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/messages"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+func TestJobRecord_Capture(t *testing.T) {
+	assetID, _ := valueobjects.NewAssetID("aid")
+	videoID, _ := valueobjects.NewVideoID("vid")
+	job := entity.NewTranscodeJob(*assetID, *videoID, "s3://in/source.mp4", "s3://out/playlist.m3u8", "main", valueobjects.JobFormatHLS)
+
+	record := NewJobRecord(job, messages.JobPayload{JobType: "transcode", AssetID: "aid", VideoID: "vid"}, 1)
+	if record.ID != job.ID().Value() || record.Payload.JobID != job.ID().Value() {
+		t.Fatalf("record and payload must carry the job ID, got %q / %q", record.ID, record.Payload.JobID)
+	}
+	if !record.Status.IsPending() || record.IsTerminal() {
+		t.Fatalf("new record should be pending, got %s", record.Status)
+	}
+
+	job.Start()
+	job.UpdateProgress(42)
+	record.Capture(job)
+	if !record.Status.IsRunning() || record.Progress != 42 || record.StartedAt == nil {
+		t.Fatalf("running capture mismatch: %+v", record)
+	}
+
+	job.Fail("boom")
+	record.Capture(job)
+	if !record.IsTerminal() || record.Error != "boom" || record.CompletedAt == nil {
+		t.Fatalf("failed capture mismatch: %+v", record)
+	}
+}
@@ -0,0 +1,86 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrJobCancelled = errors.New("job cancelled")
+
+// pendingCancelTTL bounds how long a cancel for a job this worker has not
+// picked up yet is remembered.
+const pendingCancelTTL = 24 * time.Hour
+
+// RunningJobs maps correlation IDs to the cancel functions of jobs in flight.
+// A cancel that arrives before its job starts is held until the job shows up.
+// Either way it only applies to a job requested before the cancel was issued,
+// so re-requesting a cancelled transcode is not caught by the old cancel.
+type RunningJobs struct {
+	mu      sync.Mutex
+	running map[string]*runningJob
+	pending map[string]time.Time
+	now     func() time.Time
+}
+
+type runningJob struct {
+	cancel      context.CancelCauseFunc
+	requestedAt time.Time
+}
+
+func NewRunningJobs() *RunningJobs {
+	return &RunningJobs{
+		running: map[string]*runningJob{},
+		pending: map[string]time.Time{},
+		now:     time.Now,
+	}
+}
+
+func (r *RunningJobs) Track(ctx context.Context, correlationID string, requestedAt time.Time) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	if correlationID == "" {
+		return ctx, func() { cancel(nil) }
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if at, ok := r.pending[correlationID]; ok {
+		delete(r.pending, correlationID)
+		if requestedAt.Before(at) && r.now().Sub(at) < pendingCancelTTL {
+			cancel(ErrJobCancelled)
+		}
+	}
+	entry := &runningJob{cancel: cancel, requestedAt: requestedAt}
+	r.running[correlationID] = entry
+	return ctx, func() {
+		r.mu.Lock()
+		if r.running[correlationID] == entry {
+			delete(r.running, correlationID)
+		}
+		r.mu.Unlock()
+		cancel(nil)
+	}
+}
+
+// Cancel reports whether a job with the correlation ID was running here.
+func (r *RunningJobs) Cancel(correlationID string, issuedAt time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if entry, ok := r.running[correlationID]; ok && entry.requestedAt.Before(issuedAt) {
+		entry.cancel(ErrJobCancelled)
+		return true
+	}
+	now := r.now()
+	for id, at := range r.pending {
+		if now.Sub(at) >= pendingCancelTTL {
+			delete(r.pending, id)
+		}
+	}
+	r.pending[correlationID] = issuedAt
+	return false
+}
+
+func IsCancelled(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrJobCancelled)
+}
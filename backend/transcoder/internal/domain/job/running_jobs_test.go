@@ -0,0 +1,52 @@
+package job
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRunningJobs_Cancel(t *testing.T) {
+	tests := []struct {
+		name          string
+		cancelBefore  bool
+		cancelDuring  bool
+		pendingAge    time.Duration
+		requestedLate bool
+		wantCancelled bool
+	}{
+		{name: "not cancelled"},
+		{name: "cancelled while running", cancelDuring: true, wantCancelled: true},
+		{name: "cancel arrives before the job", cancelBefore: true, wantCancelled: true},
+		{name: "stale pending cancel is dropped", cancelBefore: true, pendingAge: 2 * pendingCancelTTL},
+		{name: "re-request after a cancel runs", cancelBefore: true, requestedLate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRunningJobs()
+			clock := time.Unix(0, 0)
+			r.now = func() time.Time { return clock }
+
+			requestedAt := clock.Add(-time.Minute)
+			if tt.requestedLate {
+				requestedAt = clock.Add(time.Minute)
+			}
+			if tt.cancelBefore {
+				if r.Cancel("job-1", clock) {
+					t.Fatal("Cancel() reported a running job before Track()")
+				}
+				clock = clock.Add(tt.pendingAge)
+			}
+			ctx, release := r.Track(context.Background(), "job-1", requestedAt)
+			defer release()
+			if tt.cancelDuring && !r.Cancel("job-1", clock) {
+				t.Fatal("Cancel() did not find the running job")
+			}
+
+			if got := IsCancelled(ctx); got != tt.wantCancelled {
+				t.Errorf("IsCancelled() = %v, want %v", got, tt.wantCancelled)
+			}
+		})
+	}
+}
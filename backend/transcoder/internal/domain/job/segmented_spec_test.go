@@ -0,0 +1,77 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+func TestSegmentedSpec_Chunks(t *testing.T) {
+	spec, err := valueobjects.NewSegmentedSpec(1800, 295, 4)
+	if err != nil {
+		t.Fatalf("NewSegmentedSpec: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		duration float64
+		want     []valueobjects.Chunk
+	}{
+		{
+			name:     "chunk length snaps to the segment grid",
+			duration: 900,
+			want: []valueobjects.Chunk{
+				{Index: 0, Start: 0, Duration: 300},
+				{Index: 1, Start: 300, Duration: 300},
+				{Index: 2, Start: 600, Duration: 300},
+			},
+		},
+		{
+			name:     "long tail gets its own chunk",
+			duration: 800,
+			want: []valueobjects.Chunk{
+				{Index: 0, Start: 0, Duration: 300},
+				{Index: 1, Start: 300, Duration: 300},
+				{Index: 2, Start: 600, Duration: 200},
+			},
+		},
+		{
+			name:     "short tail folds into the previous chunk",
+			duration: 640,
+			want: []valueobjects.Chunk{
+				{Index: 0, Start: 0, Duration: 300},
+				{Index: 1, Start: 300, Duration: 340},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := spec.Chunks(tt.duration, 10)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Chunks() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("chunk %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSegmentedSpec_Applies(t *testing.T) {
+	spec, _ := valueobjects.NewSegmentedSpec(1800, 300, 4)
+	if spec.Applies(1200) {
+		t.Error("should not apply below the threshold")
+	}
+	if !spec.Applies(7200) {
+		t.Error("should apply above the threshold")
+	}
+	if valueobjects.DefaultSegmentedSpec().Applies(7200) {
+		t.Error("default spec should leave the mode off")
+	}
+	if _, err := valueobjects.NewSegmentedSpec(1800, 300, 0); err == nil {
+		t.Error("expected error for zero parallelism")
+	}
+}
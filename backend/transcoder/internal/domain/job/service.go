@@ -8,4 +8,5 @@ import (
 
 type DomainService interface {
 	ProcessJob(ctx context.Context, job *entity.Job) (interface{}, error)
+	FailJob(ctx context.Context, job *entity.Job, reason string)
 }
@@ -2,30 +2,57 @@ package job
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"strings"
+	"time"
 
 	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
 	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
 	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/events"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
 )
 
 type DomainServiceImpl struct {
 	storage            Storage
 	transcoderRegistry TranscoderRegistry
 	eventPublisher     EventPublisher
+	qualityVerifier    QualityVerifier
+	progress           ProgressReporter
 }
 
 func NewDomainService(storage Storage, transcoderRegistry TranscoderRegistry, eventPublisher EventPublisher) *DomainServiceImpl {
 	return &DomainServiceImpl{storage: storage, transcoderRegistry: transcoderRegistry, eventPublisher: eventPublisher}
 }
 
+// SetQualityVerifier turns on post-encode verification for jobs whose
+// quality spec is enabled.
+func (s *DomainServiceImpl) SetQualityVerifier(verifier QualityVerifier) {
+	s.qualityVerifier = verifier
+}
+
+// SetProgressReporter lets the service release a job's progress state when
+// the job ends, however it ends.
+func (s *DomainServiceImpl) SetProgressReporter(progress ProgressReporter) {
+	s.progress = progress
+}
+
+// buildOutputDir gives every job its own scratch directory. Jobs for the same
+// asset, format and quality can run side by side in different pool lanes, and
+// each one removes its directory when it ends.
 func buildOutputDir(job *entity.Job) string {
-	return fmt.Sprintf("/tmp/%s/%s/%s", job.AssetID().Value(), job.Format(), job.Quality())
+	return fmt.Sprintf("/tmp/%s/%s/%s/%s/%s", job.AssetID().Value(), job.VideoID().Value(), job.Format(), job.Quality(), job.ID().Value())
 }
 
 func (s *DomainServiceImpl) ProcessJob(ctx context.Context, jobObj *entity.Job) (interface{}, error) {
-	inputIsS3 := strings.HasPrefix(jobObj.Input(), "s3://")
+	if s.progress != nil {
+		defer s.progress.Forget(jobObj.ID().Value())
+	}
+	ctx, uploads := WithUploadLog(ctx)
+	if IsCancelled(ctx) {
+		s.publishJobCancelled(ctx, jobObj)
+		return nil, ErrJobCancelled
+	}
+	inputIsRemote := s.storage.IsRemote(jobObj.Input())
 	localPath, err := s.storage.Download(ctx, jobObj.Input())
 	if err != nil {
 		s.publishJobCompletion(ctx, jobObj, false, nil, err.Error())
@@ -45,7 +72,7 @@ func (s *DomainServiceImpl) ProcessJob(ctx context.Context, jobObj *entity.Job)
 	}
 	strategy := s.transcoderRegistry.Get(strategyKey)
 	if strategy == nil {
-		if inputIsS3 {
+		if inputIsRemote {
 			s.storage.Remove(localPath)
 		}
 		s.publishJobCompletion(ctx, jobObj, false, nil, "unsupported job type/format: "+strategyKey)
@@ -53,24 +80,37 @@ func (s *DomainServiceImpl) ProcessJob(ctx context.Context, jobObj *entity.Job)
 	}
 
 	if err := jobObj.Validate(); err != nil {
-		if inputIsS3 {
+		if inputIsRemote {
 			s.storage.Remove(localPath)
 		}
 		s.publishJobCompletion(ctx, jobObj, false, nil, err.Error())
 		return nil, err
 	}
 
+	if err := strategy.ValidateInput(ctx, jobObj, localPath); err != nil {
+		if inputIsRemote {
+			s.storage.Remove(localPath)
+		}
+		s.publishJobRejected(ctx, jobObj, err)
+		return nil, err
+	}
+
 	outputPath, err := strategy.Transcode(ctx, jobObj, localPath, outputDir)
 	if err != nil {
-		if inputIsS3 {
+		if inputIsRemote {
 			s.storage.Remove(localPath)
 		}
+		if IsCancelled(ctx) {
+			s.discardOutput(uploads)
+			s.publishJobCancelled(ctx, jobObj)
+			return nil, ErrJobCancelled
+		}
 		s.publishJobCompletion(ctx, jobObj, false, nil, err.Error())
 		return nil, pkgerrors.NewInternalError("failed to transcode", err)
 	}
 
 	if err := strategy.ValidateOutput(jobObj); err != nil {
-		if inputIsS3 {
+		if inputIsRemote {
 			s.storage.Remove(localPath)
 		}
 		s.publishJobCompletion(ctx, jobObj, false, nil, err.Error())
@@ -79,14 +119,16 @@ func (s *DomainServiceImpl) ProcessJob(ctx context.Context, jobObj *entity.Job)
 
 	metadata, metaErr := strategy.ExtractMetadata(ctx, outputPath, jobObj)
 	if metaErr != nil {
-		if inputIsS3 {
+		if inputIsRemote {
 			s.storage.Remove(localPath)
 		}
 		s.publishJobCompletion(ctx, jobObj, false, nil, metaErr.Error())
 		return nil, pkgerrors.NewInternalError("failed to extract metadata", metaErr)
 	}
 
-	if inputIsS3 {
+	s.verifyQuality(ctx, jobObj, localPath, outputPath, metadata)
+
+	if inputIsRemote {
 		s.storage.Remove(localPath)
 	}
 
@@ -95,7 +137,59 @@ func (s *DomainServiceImpl) ProcessJob(ctx context.Context, jobObj *entity.Job)
 	return metadata, nil
 }
 
+// FailJob reports a job that will not run (again) as failed, so whoever
+// requested it stops waiting.
+func (s *DomainServiceImpl) FailJob(ctx context.Context, jobObj *entity.Job, reason string) {
+	s.publishJobCompletion(ctx, jobObj, false, nil, reason)
+}
+
+// verifyQuality attaches scores and a verdict to the metadata. A measurement
+// that cannot run leaves the job unverified rather than failing an encode
+// that already succeeded.
+func (s *DomainServiceImpl) verifyQuality(ctx context.Context, jobObj *entity.Job, sourcePath, outputPath string, metadata *valueobjects.TranscodeMetadata) {
+	spec := jobObj.QualitySpec()
+	if s.qualityVerifier == nil || !spec.Enabled || metadata == nil {
+		return
+	}
+	if err := s.qualityVerifier.Measure(ctx, jobObj, sourcePath, outputPath, metadata); err != nil {
+		return
+	}
+	check := spec.Check(metadata.Renditions)
+	metadata.QualityCheck = &check
+}
+
 func (s *DomainServiceImpl) publishJobCompletion(ctx context.Context, jobObj *entity.Job, success bool, metadata interface{}, errorMessage string) {
 	completionEvent := events.BuildCompletedEvent(jobObj, success, metadata, errorMessage)
 	s.eventPublisher.PublishJobCompleted(ctx, completionEvent)
 }
+
+// publishJobRejected fails the job, attaching the rejection reasons when the
+// source broke the input rules rather than failing to probe.
+func (s *DomainServiceImpl) publishJobRejected(ctx context.Context, jobObj *entity.Job, err error) {
+	completionEvent := events.BuildCompletedEvent(jobObj, false, nil, err.Error())
+	var rejected *InputRejectedError
+	if errors.As(err, &rejected) {
+		completionEvent.SetRejections(rejected.Rejections)
+	}
+	s.eventPublisher.PublishJobCompleted(ctx, completionEvent)
+}
+
+// discardOutput removes the objects a cancelled job had uploaded, and only
+// those: outputs are uploaded after a successful encode, so a job cancelled
+// earlier leaves the rendition already at its output untouched. It runs on a
+// fresh context because the job's own context is already cancelled.
+func (s *DomainServiceImpl) discardOutput(uploads *UploadLog) {
+	locations := uploads.Locations()
+	if len(locations) == 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	s.storage.DeleteObjects(ctx, locations)
+}
+
+func (s *DomainServiceImpl) publishJobCancelled(ctx context.Context, jobObj *entity.Job) {
+	cancelledEvent := events.BuildCompletedEvent(jobObj, false, nil, ErrJobCancelled.Error())
+	cancelledEvent.MarkCancelled()
+	s.eventPublisher.PublishJobCompleted(context.WithoutCancel(ctx), cancelledEvent)
+}
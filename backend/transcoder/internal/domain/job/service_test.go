@@ -2,7 +2,9 @@ package job
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
 	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/events"
@@ -15,14 +17,20 @@ func (m *TestEventPublisher) PublishJobCompleted(ctx context.Context, event even
 	return nil
 }
 
+func (m *TestEventPublisher) PublishJobProgress(ctx context.Context, event *events.JobProgressEvent) error {
+	return nil
+}
+
 // noop storage implementation for testing
 type nopStorage struct{}
 
-func (nopStorage) Download(ctx context.Context, input string) (string, error) { return "", nil }
-func (nopStorage) CreateDir(path string) error                                { return nil }
-func (nopStorage) Remove(path string) error                                   { return nil }
-func (nopStorage) RemoveAll(path string) error                                { return nil }
-func (nopStorage) Upload(ctx context.Context, localDir, s3Path string) error  { return nil }
+func (nopStorage) Download(ctx context.Context, input string) (string, error)  { return "", nil }
+func (nopStorage) CreateDir(path string) error                                 { return nil }
+func (nopStorage) Remove(path string) error                                    { return nil }
+func (nopStorage) RemoveAll(path string) error                                 { return nil }
+func (nopStorage) Upload(ctx context.Context, localDir, output string) error   { return nil }
+func (nopStorage) DeleteObjects(ctx context.Context, locations []string) error { return nil }
+func (nopStorage) IsRemote(location string) bool                               { return false }
 
 // noop registry and strategy for testing
 type nopRegistry struct{}
@@ -31,7 +39,9 @@ func (nopRegistry) Get(format string) TranscodeStrategy { return &nopStrategy{}
 
 type nopStrategy struct{}
 
-func (n *nopStrategy) ValidateInput(ctx context.Context, job *entity.Job) error { return nil }
+func (n *nopStrategy) ValidateInput(ctx context.Context, job *entity.Job, localPath string) error {
+	return nil
+}
 func (n *nopStrategy) Transcode(ctx context.Context, job *entity.Job, localPath, outputDir string) (string, error) {
 	return "", nil
 }
@@ -79,3 +89,191 @@ func TestJobDomainService_ProcessJob_Errors(t *testing.T) {
 		})
 	}
 }
+
+type completionRecorder struct {
+	TestEventPublisher
+	completed []events.CompletedEvent
+}
+
+func (r *completionRecorder) PublishJobCompleted(ctx context.Context, event events.CompletedEvent) error {
+	r.completed = append(r.completed, event)
+	return nil
+}
+
+type rejectingRegistry struct{ err error }
+
+func (r rejectingRegistry) Get(format string) TranscodeStrategy {
+	return &rejectingStrategy{err: r.err}
+}
+
+type rejectingStrategy struct {
+	nopStrategy
+	err error
+}
+
+func (r *rejectingStrategy) ValidateInput(ctx context.Context, job *entity.Job, localPath string) error {
+	return r.err
+}
+
+func (r *rejectingStrategy) Transcode(ctx context.Context, job *entity.Job, localPath, outputDir string) (string, error) {
+	panic("rejected input must not be transcoded")
+}
+
+func TestJobDomainService_ProcessJob_InputRejected(t *testing.T) {
+	rejections := []valueobjects.InputRejection{
+		{Code: valueobjects.RejectionMissingAudio, Message: "source has no audio stream"},
+		{Code: valueobjects.RejectionInterlaced, Message: "source is interlaced"},
+	}
+	assetID, _ := valueobjects.NewAssetID("aid")
+	videoID, _ := valueobjects.NewVideoID("vid")
+
+	for _, tt := range []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "rule violations", err: fmt.Errorf("wrapped: %w", &InputRejectedError{Rejections: rejections}), want: 2},
+		{name: "probe failure", err: fmt.Errorf("ffprobe not found"), want: 0},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			publisher := &completionRecorder{}
+			ds := NewDomainService(nopStorage{}, rejectingRegistry{err: tt.err}, publisher)
+			job := entity.NewTranscodeJob(*assetID, *videoID, "input.mp4", "s3://bucket/key", "main", valueobjects.JobFormatHLS)
+
+			if _, err := ds.ProcessJob(context.Background(), job); err == nil {
+				t.Fatal("expected the job to fail")
+			}
+			if len(publisher.completed) != 1 {
+				t.Fatalf("published %d completion events, want 1", len(publisher.completed))
+			}
+			ev, ok := publisher.completed[0].(*events.HLSJobCompletedEvent)
+			if !ok {
+				t.Fatalf("unexpected event type %T", publisher.completed[0])
+			}
+			if ev.Success || len(ev.Rejections) != tt.want || ev.ErrorMessage == "" {
+				t.Errorf("event = success %v, %d rejections, error %q", ev.Success, len(ev.Rejections), ev.ErrorMessage)
+			}
+		})
+	}
+}
+
+type failingRegistry struct{ progress ProgressReporter }
+
+func (r failingRegistry) Get(format string) TranscodeStrategy {
+	return &failingStrategy{progress: r.progress}
+}
+
+// failingStrategy reports some progress and then fails mid-encode.
+type failingStrategy struct {
+	nopStrategy
+	progress ProgressReporter
+}
+
+func (f *failingStrategy) Transcode(ctx context.Context, job *entity.Job, localPath, outputDir string) (string, error) {
+	f.progress.Report(ctx, job, 30, false)
+	return "", fmt.Errorf("ffmpeg exited with status 1")
+}
+
+func TestJobDomainService_ProcessJob_ForgetsProgress(t *testing.T) {
+	assetID, _ := valueobjects.NewAssetID("aid")
+	videoID, _ := valueobjects.NewVideoID("vid")
+	reporter := NewThrottledProgressReporter(&TestEventPublisher{}, time.Second)
+	ds := NewDomainService(nopStorage{}, failingRegistry{progress: reporter}, &TestEventPublisher{})
+	ds.SetProgressReporter(reporter)
+
+	job := entity.NewTranscodeJob(*assetID, *videoID, "input.mp4", "s3://bucket/key", "main", valueobjects.JobFormatHLS)
+	job.SetSourceDuration(100)
+	if _, err := ds.ProcessJob(context.Background(), job); err == nil {
+		t.Fatal("expected the job to fail")
+	}
+	if n := len(reporter.last); n != 0 {
+		t.Errorf("reporter still holds %d jobs after the job failed", n)
+	}
+}
+
+func TestBuildOutputDir_UniquePerJob(t *testing.T) {
+	assetID, _ := valueobjects.NewAssetID("aid")
+	main, _ := valueobjects.NewVideoID("main")
+	trailer, _ := valueobjects.NewVideoID("trailer")
+
+	dirs := map[string]bool{}
+	for _, job := range []*entity.Job{
+		entity.NewTranscodeJob(*assetID, *main, "main.mp4", "s3://bucket/main", "main", valueobjects.JobFormatHLS),
+		entity.NewTranscodeJob(*assetID, *main, "main.mp4", "s3://bucket/main", "main", valueobjects.JobFormatHLS),
+		entity.NewTranscodeJob(*assetID, *trailer, "trailer.mp4", "s3://bucket/trailer", "main", valueobjects.JobFormatHLS),
+	} {
+		dir := buildOutputDir(job)
+		if dirs[dir] {
+			t.Errorf("output dir %s is shared by two jobs", dir)
+		}
+		dirs[dir] = true
+	}
+}
+
+type deletingStorage struct {
+	nopStorage
+	deleted [][]string
+}
+
+func (d *deletingStorage) IsRemote(location string) bool { return true }
+
+func (d *deletingStorage) DeleteObjects(ctx context.Context, locations []string) error {
+	d.deleted = append(d.deleted, locations)
+	return nil
+}
+
+type cancelledRegistry struct {
+	cancel   context.CancelCauseFunc
+	uploaded []string
+}
+
+func (r cancelledRegistry) Get(format string) TranscodeStrategy {
+	return &cancelledStrategy{cancel: r.cancel, uploaded: r.uploaded}
+}
+
+// cancelledStrategy uploads some objects and is then cancelled.
+type cancelledStrategy struct {
+	nopStrategy
+	cancel   context.CancelCauseFunc
+	uploaded []string
+}
+
+func (c *cancelledStrategy) Transcode(ctx context.Context, job *entity.Job, localPath, outputDir string) (string, error) {
+	for _, location := range c.uploaded {
+		RecordUpload(ctx, location)
+	}
+	c.cancel(ErrJobCancelled)
+	return "", context.Cause(ctx)
+}
+
+func TestJobDomainService_ProcessJob_CancelDeletesOwnUploads(t *testing.T) {
+	assetID, _ := valueobjects.NewAssetID("aid")
+	videoID, _ := valueobjects.NewVideoID("vid")
+
+	for _, tt := range []struct {
+		name     string
+		uploaded []string
+		want     int
+	}{
+		{name: "cancelled while encoding", want: 0},
+		{name: "cancelled while uploading", uploaded: []string{"s3://bucket/aid/vid/hls/main/playlist.m3u8", "s3://bucket/aid/vid/hls/main/720p_000.ts"}, want: 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := &deletingStorage{}
+			ctx, cancel := context.WithCancelCause(context.Background())
+			defer cancel(nil)
+			ds := NewDomainService(storage, cancelledRegistry{cancel: cancel, uploaded: tt.uploaded}, &TestEventPublisher{})
+			job := entity.NewTranscodeJob(*assetID, *videoID, "s3://bucket/input.mp4", "s3://bucket/aid/vid/hls/main/playlist.m3u8", "main", valueobjects.JobFormatHLS)
+
+			if _, err := ds.ProcessJob(ctx, job); err != ErrJobCancelled {
+				t.Fatalf("ProcessJob() error = %v, want ErrJobCancelled", err)
+			}
+			if len(storage.deleted) != tt.want {
+				t.Fatalf("deleted %d times, want %d", len(storage.deleted), tt.want)
+			}
+			if tt.want > 0 && fmt.Sprint(storage.deleted[0]) != fmt.Sprint(tt.uploaded) {
+				t.Errorf("deleted %v, want %v", storage.deleted[0], tt.uploaded)
+			}
+		})
+	}
+}
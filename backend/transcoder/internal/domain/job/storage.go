@@ -1,13 +1,55 @@
 package job
 
-import "context"
+import (
+	"context"
+	"sync"
+)
 
 //go:generate mockgen -destination=mock_storage.go -package job github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job Storage
 
+// Storage moves job inputs and outputs between object storage and the local
+// disk. Locations are s3://, file:// or mem:// URLs; IsRemote tells them
+// apart from plain local paths, which are used as they are.
 type Storage interface {
 	Download(ctx context.Context, input string) (string, error)
 	CreateDir(path string) error
 	Remove(path string) error
 	RemoveAll(path string) error
-	Upload(ctx context.Context, localDir, s3Path string) error
+	// Upload records every object it writes in ctx's UploadLog, if any.
+	Upload(ctx context.Context, localDir, output string) error
+	DeleteObjects(ctx context.Context, locations []string) error
+	IsRemote(location string) bool
+}
+
+// UploadLog collects the objects a job has written to storage, so a
+// cancelled job removes exactly those and leaves whatever an earlier job
+// published next to them alone.
+type UploadLog struct {
+	mu        sync.Mutex
+	locations []string
+}
+
+type uploadLogKey struct{}
+
+func WithUploadLog(ctx context.Context) (context.Context, *UploadLog) {
+	log := &UploadLog{}
+	return context.WithValue(ctx, uploadLogKey{}, log), log
+}
+
+// RecordUpload notes a written object in ctx's upload log. It does nothing
+// when ctx has no log.
+func RecordUpload(ctx context.Context, location string) {
+	log, ok := ctx.Value(uploadLogKey{}).(*UploadLog)
+	if !ok {
+		return
+	}
+	log.mu.Lock()
+	defer log.mu.Unlock()
+	log.locations = append(log.locations, location)
+}
+
+func (l *UploadLog) Locations() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.locations...)
 }
@@ -2,13 +2,17 @@ package job
 
 import (
 	"context"
+	"strings"
 
 	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
 	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
 )
 
 type TranscodeStrategy interface {
-	ValidateInput(ctx context.Context, job *entity.Job) error
+	// ValidateInput checks the downloaded source at localPath before it is
+	// encoded. Sources that break the job's input rules are reported with an
+	// InputRejectedError.
+	ValidateInput(ctx context.Context, job *entity.Job, localPath string) error
 	Transcode(ctx context.Context, job *entity.Job, localPath, outputDir string) (string, error)
 	ExtractMetadata(ctx context.Context, filePath string, job *entity.Job) (*valueobjects.TranscodeMetadata, error)
 	ValidateOutput(job *entity.Job) error
@@ -17,3 +21,17 @@ type TranscodeStrategy interface {
 type TranscoderRegistry interface {
 	Get(format string) TranscodeStrategy
 }
+
+// InputRejectedError carries the reasons a source was turned away. They are
+// sent on the failed completion event so editors can see what to fix.
+type InputRejectedError struct {
+	Rejections []valueobjects.InputRejection
+}
+
+func (e *InputRejectedError) Error() string {
+	messages := make([]string, len(e.Rejections))
+	for i, r := range e.Rejections {
+		messages[i] = r.Message
+	}
+	return "input rejected: " + strings.Join(messages, "; ")
+}
@@ -0,0 +1,44 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+func TestNewSubtitleTrack(t *testing.T) {
+	tests := []struct {
+		name      string
+		language  string
+		kind      string
+		wantErr   bool
+		wantName  string
+		wantLabel string
+	}{
+		{name: "kind defaults to subtitles", language: "en", wantName: "subs_en", wantLabel: "en"},
+		{name: "captions are suffixed", language: "en", kind: "captions", wantName: "subs_en_captions", wantLabel: "en"},
+		{name: "region subtag is kept", language: "pt-BR", kind: "forced", wantName: "subs_pt_br_forced", wantLabel: "pt-BR"},
+		{name: "unknown kind", language: "en", kind: "karaoke", wantErr: true},
+		{name: "missing language", kind: "subtitles", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			track, err := valueobjects.NewSubtitleTrack(tt.language, tt.kind, "", false, "s3://bucket/subs.srt")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %+v", track)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if track.Name() != tt.wantName {
+				t.Errorf("name = %q, want %q", track.Name(), tt.wantName)
+			}
+			if track.Label != tt.wantLabel {
+				t.Errorf("label = %q, want %q", track.Label, tt.wantLabel)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package job
+
+import (
+	"testing"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+func TestThumbnailSpec_SpriteCues(t *testing.T) {
+	spec, err := valueobjects.NewThumbnailSpec(3, 10, 160, 2, 2)
+	if err != nil {
+		t.Fatalf("NewThumbnailSpec: %v", err)
+	}
+	tileHeight := spec.TileHeight(1920, 1080)
+	if tileHeight != 90 {
+		t.Fatalf("tile height = %d, want 90", tileHeight)
+	}
+
+	cues := spec.SpriteCues(45, tileHeight)
+	if len(cues) != 5 {
+		t.Fatalf("got %d cues, want 5", len(cues))
+	}
+	tests := []struct {
+		index      int
+		sheet      int
+		x, y       int
+		start, end float64
+	}{
+		{index: 0, sheet: 1, x: 0, y: 0, start: 0, end: 10},
+		{index: 1, sheet: 1, x: 160, y: 0, start: 10, end: 20},
+		{index: 3, sheet: 1, x: 160, y: 90, start: 30, end: 40},
+		{index: 4, sheet: 2, x: 0, y: 0, start: 40, end: 45},
+	}
+	for _, tt := range tests {
+		c := cues[tt.index]
+		if c.Sheet != tt.sheet || c.X != tt.x || c.Y != tt.y || c.Start != tt.start || c.End != tt.end {
+			t.Errorf("cue %d = %+v, want sheet=%d x=%d y=%d %v-%v", tt.index, c, tt.sheet, tt.x, tt.y, tt.start, tt.end)
+		}
+	}
+}
+
+func TestThumbnailSpec_ScreenshotOffsets(t *testing.T) {
+	spec := valueobjects.DefaultThumbnailSpec()
+	offsets := spec.ScreenshotOffsets(60)
+	want := []float64{10, 20, 30, 40, 50}
+	if len(offsets) != len(want) {
+		t.Fatalf("got %v, want %v", offsets, want)
+	}
+	for i := range want {
+		if offsets[i] != want[i] {
+			t.Errorf("offset %d = %v, want %v", i, offsets[i], want[i])
+		}
+	}
+	if got := spec.ScreenshotOffsets(0); got != nil {
+		t.Errorf("unknown duration should yield no offsets, got %v", got)
+	}
+	if got := spec.PosterOffset(3600); got != 60 {
+		t.Errorf("poster offset = %v, want 60", got)
+	}
+}
@@ -0,0 +1,63 @@
+package valueobjects
+
+import (
+	"fmt"
+	"strings"
+)
+
+const undeterminedLanguage = "und"
+
+// AudioTrack is one audio stream of the source, addressed by its position
+// among the audio streams (ffmpeg's a:N).
+type AudioTrack struct {
+	Index         int    `json:"index"`
+	Language      string `json:"language,omitempty"`
+	Codec         string `json:"codec,omitempty"`
+	Channels      int    `json:"channels,omitempty"`
+	ChannelLayout string `json:"channelLayout,omitempty"`
+	SampleRate    int    `json:"sampleRate,omitempty"`
+	Title         string `json:"title,omitempty"`
+	Default       bool   `json:"default,omitempty"`
+	// Loudness is set once analyze has measured the track.
+	Loudness *Loudness `json:"loudness,omitempty"`
+}
+
+// LanguageOrUnd returns the track language, or "und" when the source has no
+// language tag.
+func (t AudioTrack) LanguageOrUnd() string {
+	if t.Language == "" {
+		return undeterminedLanguage
+	}
+	return strings.ToLower(t.Language)
+}
+
+// Name is the rendition name used in playlists and segment file names. The
+// index keeps two tracks in the same language apart.
+func (t AudioTrack) Name() string {
+	return fmt.Sprintf("audio_%s_%d", t.LanguageOrUnd(), t.Index)
+}
+
+type AudioTracks []AudioTrack
+
+// DefaultIndex returns the position of the track flagged as default, falling
+// back to the first track.
+func (tracks AudioTracks) DefaultIndex() int {
+	for i, t := range tracks {
+		if t.Default {
+			return i
+		}
+	}
+	return 0
+}
+
+// Default returns the track DefaultIndex points at.
+func (tracks AudioTracks) Default() (AudioTrack, bool) {
+	if len(tracks) == 0 {
+		return AudioTrack{}, false
+	}
+	return tracks[tracks.DefaultIndex()], true
+}
+
+func (tracks AudioTracks) IsMulti() bool {
+	return len(tracks) > 1
+}
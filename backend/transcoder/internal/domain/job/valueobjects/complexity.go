@@ -0,0 +1,120 @@
+package valueobjects
+
+import (
+	"fmt"
+	"math"
+)
+
+// ComplexityPoint is one probe encode: the CRF it runs at and the bitrate, in
+// kbps at the probe resolution, that content of average complexity reaches
+// at that CRF.
+type ComplexityPoint struct {
+	CRF              int `json:"crf"`
+	ReferenceBitrate int `json:"referenceBitrate"`
+}
+
+// ComplexityProbe is the bitrate a probe encode actually reached, normalised
+// to a 16:9 frame at the probe height.
+type ComplexityProbe struct {
+	CRF     int `json:"crf"`
+	Bitrate int `json:"bitrate"`
+}
+
+// ComplexitySpec configures per-title encoding. Analyze jobs encode short
+// samples of the source at each point and score the source against the
+// reference bitrates; transcode jobs scale the ladder by that score, kept
+// within MinFactor and MaxFactor.
+type ComplexitySpec struct {
+	Enabled        bool              `json:"enabled"`
+	SampleCount    int               `json:"sampleCount"`
+	SampleDuration float64           `json:"sampleDuration"`
+	ProbeHeight    int               `json:"probeHeight"`
+	Points         []ComplexityPoint `json:"points"`
+	MinFactor      float64           `json:"minFactor"`
+	MaxFactor      float64           `json:"maxFactor"`
+}
+
+func DefaultComplexitySpec() ComplexitySpec {
+	return ComplexitySpec{
+		SampleCount:    3,
+		SampleDuration: 4,
+		ProbeHeight:    540,
+		Points:         []ComplexityPoint{{CRF: 23, ReferenceBitrate: 1400}, {CRF: 28, ReferenceBitrate: 700}},
+		MinFactor:      0.5,
+		MaxFactor:      1.6,
+	}
+}
+
+func NewComplexitySpec(enabled bool, sampleCount int, sampleDuration float64, probeHeight int, points []ComplexityPoint, minFactor, maxFactor float64) (*ComplexitySpec, error) {
+	if sampleCount < 1 {
+		return nil, fmt.Errorf("sample count must be at least 1")
+	}
+	if sampleDuration <= 0 {
+		return nil, fmt.Errorf("sample duration must be positive")
+	}
+	if probeHeight <= 0 || probeHeight%2 != 0 {
+		return nil, fmt.Errorf("probe height must be a positive even number")
+	}
+	if len(points) == 0 {
+		return nil, fmt.Errorf("at least one probe point is required")
+	}
+	for _, p := range points {
+		if p.CRF < 0 || p.CRF > 51 {
+			return nil, fmt.Errorf("probe CRF must be between 0 and 51")
+		}
+		if p.ReferenceBitrate <= 0 {
+			return nil, fmt.Errorf("probe reference bitrate must be positive")
+		}
+	}
+	if minFactor <= 0 || maxFactor < minFactor {
+		return nil, fmt.Errorf("ladder factor bounds must be positive and ordered")
+	}
+	return &ComplexitySpec{
+		Enabled:        enabled,
+		SampleCount:    sampleCount,
+		SampleDuration: sampleDuration,
+		ProbeHeight:    probeHeight,
+		Points:         points,
+		MinFactor:      minFactor,
+		MaxFactor:      maxFactor,
+	}, nil
+}
+
+// Windows picks the stretches of the source the probe encodes sample.
+func (s ComplexitySpec) Windows(duration float64) []Interval {
+	return sampleWindows(duration, s.SampleCount, s.SampleDuration)
+}
+
+// Score is the geometric mean of each probe's bitrate over its point's
+// reference, so 1 is average content, 2 needs twice the bits for the same
+// quality and 0.5 half. Probes at CRFs not in the spec are ignored; no usable
+// probe gives 0.
+func (s ComplexitySpec) Score(probes []ComplexityProbe) float64 {
+	refs := make(map[int]int, len(s.Points))
+	for _, p := range s.Points {
+		refs[p.CRF] = p.ReferenceBitrate
+	}
+	var sum float64
+	n := 0
+	for _, p := range probes {
+		ref, ok := refs[p.CRF]
+		if !ok || p.Bitrate <= 0 {
+			continue
+		}
+		sum += math.Log(float64(p.Bitrate) / float64(ref))
+		n++
+	}
+	if n == 0 {
+		return 0
+	}
+	return math.Round(math.Exp(sum/float64(n))*1000) / 1000
+}
+
+// Factor is the ladder bitrate multiplier for a score. A missing score
+// leaves the ladder as configured.
+func (s ComplexitySpec) Factor(score float64) float64 {
+	if score <= 0 {
+		return 1
+	}
+	return math.Max(s.MinFactor, math.Min(s.MaxFactor, score))
+}
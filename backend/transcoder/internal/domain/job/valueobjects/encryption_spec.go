@@ -0,0 +1,41 @@
+package valueobjects
+
+import (
+	"fmt"
+	"strings"
+)
+
+const EncryptionMethodAES128 = "AES-128"
+
+// EncryptionSpec turns on HLS segment encryption. Players fetch the key from
+// KeyURLPrefix/<assetId>/<videoId>/<keyName>. Only AES-128 is accepted: SAMPLE-AES needs
+// a packager the ffmpeg HLS muxer does not provide.
+type EncryptionSpec struct {
+	Method       string `json:"method"`
+	KeyURLPrefix string `json:"keyUrlPrefix"`
+}
+
+func NewEncryptionSpec(method, keyURLPrefix string) (*EncryptionSpec, error) {
+	method = strings.ToUpper(strings.TrimSpace(method))
+	if method == "" {
+		method = EncryptionMethodAES128
+	}
+	if method == "SAMPLE-AES" {
+		return nil, fmt.Errorf("SAMPLE-AES is not supported by the ffmpeg HLS muxer; use AES-128")
+	}
+	if method != EncryptionMethodAES128 {
+		return nil, fmt.Errorf("unsupported encryption method: %s", method)
+	}
+	if !strings.HasPrefix(keyURLPrefix, "http://") && !strings.HasPrefix(keyURLPrefix, "https://") {
+		return nil, fmt.Errorf("key URL prefix must be an http(s) URL")
+	}
+	return &EncryptionSpec{Method: method, KeyURLPrefix: strings.TrimRight(keyURLPrefix, "/")}, nil
+}
+
+func (s EncryptionSpec) IsZero() bool {
+	return s == EncryptionSpec{}
+}
+
+func (s EncryptionSpec) KeyURI(assetID, videoID, keyName string) string {
+	return s.KeyURLPrefix + "/" + assetID + "/" + videoID + "/" + keyName
+}
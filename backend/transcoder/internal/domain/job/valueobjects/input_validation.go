@@ -0,0 +1,195 @@
+package valueobjects
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rejection codes, stable so asset-manager and the CMS can key on them.
+const (
+	RejectionUnsupportedContainer  = "unsupported_container"
+	RejectionUnsupportedVideoCodec = "unsupported_video_codec"
+	RejectionUnsupportedAudioCodec = "unsupported_audio_codec"
+	RejectionDurationExceeded      = "duration_exceeded"
+	RejectionResolutionExceeded    = "resolution_exceeded"
+	RejectionFileTooLarge          = "file_too_large"
+	RejectionMissingVideo          = "missing_video"
+	RejectionMissingAudio          = "missing_audio"
+	RejectionVariableFrameRate     = "variable_frame_rate"
+	RejectionInterlaced            = "interlaced"
+	RejectionCorruptedStream       = "corrupted_stream"
+)
+
+// InputRejection is one reason a source was turned away before encoding.
+type InputRejection struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// InputProbe is what ffprobe and a short decode of the source found. VFR and
+// Interlaced are the fractions of decoded frames vfrdet and idet flagged.
+type InputProbe struct {
+	Container    string
+	Size         int64
+	Duration     float64
+	VideoCodec   string
+	Width        int
+	Height       int
+	FieldOrder   string
+	VFR          float64
+	Interlaced   float64
+	AudioCodecs  []string
+	DecodeErrors []string
+}
+
+func (p InputProbe) HasVideo() bool {
+	return p.VideoCodec != ""
+}
+
+// InputSpec is the set of rules a source has to pass before any encode.
+// Empty allow-lists and zero limits are not enforced. DecodeSeconds bounds
+// the decode used for the corruption, VFR and interlace checks.
+type InputSpec struct {
+	Enabled       bool     `json:"enabled"`
+	Containers    []string `json:"containers,omitempty"`
+	VideoCodecs   []string `json:"videoCodecs,omitempty"`
+	AudioCodecs   []string `json:"audioCodecs,omitempty"`
+	MaxDuration   float64  `json:"maxDuration,omitempty"`
+	MaxWidth      int      `json:"maxWidth,omitempty"`
+	MaxHeight     int      `json:"maxHeight,omitempty"`
+	MaxFileSize   int64    `json:"maxFileSize,omitempty"`
+	RequireVideo  bool     `json:"requireVideo"`
+	RequireAudio  bool     `json:"requireAudio"`
+	MaxVFR        float64  `json:"maxVfr,omitempty"`
+	MaxInterlaced float64  `json:"maxInterlaced,omitempty"`
+	DecodeSeconds float64  `json:"decodeSeconds,omitempty"`
+}
+
+func DefaultInputSpec() InputSpec {
+	return InputSpec{
+		Containers:    []string{"mov", "mp4", "m4a", "matroska", "webm", "mpegts", "avi", "mxf", "wav", "mp3", "flac"},
+		VideoCodecs:   []string{"h264", "hevc", "vp9", "av1", "prores", "mpeg2video", "dnxhd"},
+		AudioCodecs:   []string{"aac", "mp3", "ac3", "eac3", "opus", "vorbis", "flac", "pcm_s16le", "pcm_s24le"},
+		MaxDuration:   6 * 3600,
+		MaxWidth:      7680,
+		MaxHeight:     4320,
+		MaxFileSize:   100 << 30,
+		RequireVideo:  true,
+		RequireAudio:  true,
+		MaxVFR:        0.05,
+		MaxInterlaced: 0.1,
+		DecodeSeconds: 30,
+	}
+}
+
+func NewInputSpec(enabled bool, containers, videoCodecs, audioCodecs []string, maxDuration float64, maxWidth, maxHeight int, maxFileSize int64, requireVideo, requireAudio bool, maxVFR, maxInterlaced, decodeSeconds float64) (*InputSpec, error) {
+	if maxDuration < 0 || maxWidth < 0 || maxHeight < 0 || maxFileSize < 0 {
+		return nil, fmt.Errorf("input limits cannot be negative")
+	}
+	if maxVFR < 0 || maxVFR > 1 || maxInterlaced < 0 || maxInterlaced > 1 {
+		return nil, fmt.Errorf("frame ratios must be between 0 and 1")
+	}
+	if decodeSeconds <= 0 {
+		return nil, fmt.Errorf("decode check duration must be positive")
+	}
+	return &InputSpec{
+		Enabled:       enabled,
+		Containers:    lowerAll(containers),
+		VideoCodecs:   lowerAll(videoCodecs),
+		AudioCodecs:   lowerAll(audioCodecs),
+		MaxDuration:   maxDuration,
+		MaxWidth:      maxWidth,
+		MaxHeight:     maxHeight,
+		MaxFileSize:   maxFileSize,
+		RequireVideo:  requireVideo,
+		RequireAudio:  requireAudio,
+		MaxVFR:        maxVFR,
+		MaxInterlaced: maxInterlaced,
+		DecodeSeconds: decodeSeconds,
+	}, nil
+}
+
+// Check returns every rule the probe breaks, in a fixed order. ffprobe lists
+// container aliases comma-separated, so any one of them being allowed is
+// enough.
+func (s InputSpec) Check(p InputProbe) []InputRejection {
+	var rejections []InputRejection
+	reject := func(code, format string, args ...interface{}) {
+		rejections = append(rejections, InputRejection{Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+	if len(s.Containers) > 0 && !anyAllowed(strings.Split(p.Container, ","), s.Containers) {
+		reject(RejectionUnsupportedContainer, "container %q is not supported", p.Container)
+	}
+	if s.MaxFileSize > 0 && p.Size > s.MaxFileSize {
+		reject(RejectionFileTooLarge, "file is %d MB, the limit is %d MB", p.Size>>20, s.MaxFileSize>>20)
+	}
+	if s.MaxDuration > 0 && p.Duration > s.MaxDuration {
+		reject(RejectionDurationExceeded, "duration %.0fs exceeds the %.0fs limit", p.Duration, s.MaxDuration)
+	}
+	if !p.HasVideo() {
+		if s.RequireVideo {
+			reject(RejectionMissingVideo, "source has no video stream")
+		}
+	} else {
+		if len(s.VideoCodecs) > 0 && !anyAllowed([]string{p.VideoCodec}, s.VideoCodecs) {
+			reject(RejectionUnsupportedVideoCodec, "video codec %q is not supported", p.VideoCodec)
+		}
+		if (s.MaxWidth > 0 && p.Width > s.MaxWidth) || (s.MaxHeight > 0 && p.Height > s.MaxHeight) {
+			reject(RejectionResolutionExceeded, "resolution %dx%d exceeds %dx%d", p.Width, p.Height, s.MaxWidth, s.MaxHeight)
+		}
+		if s.MaxVFR > 0 && p.VFR > s.MaxVFR {
+			reject(RejectionVariableFrameRate, "%.0f%% of frames have a variable duration", p.VFR*100)
+		}
+		if s.MaxInterlaced > 0 && (p.Interlaced > s.MaxInterlaced || isInterlacedFieldOrder(p.FieldOrder)) {
+			reject(RejectionInterlaced, "source is interlaced (field order %q, %.0f%% of frames)", p.FieldOrder, p.Interlaced*100)
+		}
+	}
+	if len(p.AudioCodecs) == 0 {
+		if s.RequireAudio {
+			reject(RejectionMissingAudio, "source has no audio stream")
+		}
+	} else if len(s.AudioCodecs) > 0 {
+		for _, codec := range p.AudioCodecs {
+			if !anyAllowed([]string{codec}, s.AudioCodecs) {
+				reject(RejectionUnsupportedAudioCodec, "audio codec %q is not supported", codec)
+				break
+			}
+		}
+	}
+	if len(p.DecodeErrors) > 0 {
+		reject(RejectionCorruptedStream, "%d decode errors, first: %s", len(p.DecodeErrors), p.DecodeErrors[0])
+	}
+	return rejections
+}
+
+// isInterlacedFieldOrder reports the field orders ffprobe uses for
+// interlaced streams; "progressive" and "unknown" are not among them.
+func isInterlacedFieldOrder(order string) bool {
+	switch order {
+	case "tt", "bb", "tb", "bt":
+		return true
+	}
+	return false
+}
+
+func anyAllowed(values, allowed []string) bool {
+	for _, v := range values {
+		v = strings.ToLower(strings.TrimSpace(v))
+		for _, a := range allowed {
+			if v == a {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func lowerAll(values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
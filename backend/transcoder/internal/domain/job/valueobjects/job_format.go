@@ -5,6 +5,10 @@ type JobFormat string
 const (
 	JobFormatHLS  JobFormat = "hls"
 	JobFormatDASH JobFormat = "dash"
+	JobFormatCMAF JobFormat = "cmaf"
+
+	JobFormatThumbnails JobFormat = "thumbnails"
+	JobFormatMarkers    JobFormat = "markers"
 )
 
 func (jf JobFormat) String() string {
@@ -18,3 +22,15 @@ func (jf JobFormat) IsHLS() bool {
 func (jf JobFormat) IsDASH() bool {
 	return jf == JobFormatDASH
 }
+
+func (jf JobFormat) IsCMAF() bool {
+	return jf == JobFormatCMAF
+}
+
+func (jf JobFormat) IsThumbnails() bool {
+	return jf == JobFormatThumbnails
+}
+
+func (jf JobFormat) IsMarkers() bool {
+	return jf == JobFormatMarkers
+}
@@ -7,6 +7,7 @@ const (
 	JobStatusRunning   JobStatus = "running"
 	JobStatusCompleted JobStatus = "completed"
 	JobStatusFailed    JobStatus = "failed"
+	JobStatusCancelled JobStatus = "cancelled"
 )
 
 func (js JobStatus) String() string {
@@ -28,3 +29,7 @@ func (js JobStatus) IsCompleted() bool {
 func (js JobStatus) IsFailed() bool {
 	return js == JobStatusFailed
 }
+
+func (js JobStatus) IsCancelled() bool {
+	return js == JobStatusCancelled
+}
@@ -0,0 +1,66 @@
+package valueobjects
+
+import "fmt"
+
+// Loudness is an EBU R128 measurement of one audio track, as reported by the
+// first loudnorm pass: integrated loudness and threshold in LUFS, true peak
+// in dBTP, loudness range in LU and the gain offset loudnorm suggests.
+type Loudness struct {
+	Integrated float64 `json:"integrated"`
+	TruePeak   float64 `json:"truePeak"`
+	LRA        float64 `json:"lra"`
+	Threshold  float64 `json:"threshold"`
+	Offset     float64 `json:"offset"`
+}
+
+// LoudnessSpec is the normalization target. Analyze jobs measure every audio
+// track when it is enabled; transcode jobs use the measurement as the first
+// pass of a two-pass loudnorm.
+type LoudnessSpec struct {
+	Enabled  bool    `json:"enabled"`
+	TargetI  float64 `json:"targetI"`
+	TruePeak float64 `json:"truePeak"`
+	LRA      float64 `json:"lra"`
+}
+
+func DefaultLoudnessSpec() LoudnessSpec {
+	return LoudnessSpec{TargetI: -23, TruePeak: -1, LRA: 11}
+}
+
+// NewLoudnessSpec checks the target against the ranges loudnorm accepts.
+func NewLoudnessSpec(enabled bool, targetI, truePeak, lra float64) (*LoudnessSpec, error) {
+	if targetI < -70 || targetI > -5 {
+		return nil, fmt.Errorf("integrated loudness target must be between -70 and -5 LUFS")
+	}
+	if truePeak < -9 || truePeak > 0 {
+		return nil, fmt.Errorf("true peak target must be between -9 and 0 dBTP")
+	}
+	if lra < 1 || lra > 50 {
+		return nil, fmt.Errorf("loudness range target must be between 1 and 50 LU")
+	}
+	return &LoudnessSpec{Enabled: enabled, TargetI: targetI, TruePeak: truePeak, LRA: lra}, nil
+}
+
+// AudioOnlySpec adds renditions without video at the listed AAC bitrates
+// (kbps), encoded from the default audio track. Exclusive is set when the
+// source has no video, so the output carries no video renditions at all.
+type AudioOnlySpec struct {
+	Bitrates  []int `json:"bitrates,omitempty"`
+	Exclusive bool  `json:"exclusive,omitempty"`
+}
+
+func NewAudioOnlySpec(bitrates []int, exclusive bool) (*AudioOnlySpec, error) {
+	if len(bitrates) == 0 {
+		return nil, fmt.Errorf("audio-only renditions need at least one bitrate")
+	}
+	for _, b := range bitrates {
+		if b <= 0 {
+			return nil, fmt.Errorf("audio-only bitrate must be positive")
+		}
+	}
+	return &AudioOnlySpec{Bitrates: bitrates, Exclusive: exclusive}, nil
+}
+
+func (s AudioOnlySpec) IsZero() bool {
+	return len(s.Bitrates) == 0
+}
@@ -0,0 +1,191 @@
+package valueobjects
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+const (
+	MarkerKindChapter = "chapter"
+	MarkerKindIntro   = "intro"
+	MarkerKindCredits = "credits"
+
+	minIntroLength = 10.0
+	maxIntroLength = 180.0
+)
+
+// Marker is a proposed point or range on the video timeline. Chapters and the
+// intro are ranges; credits only has a start.
+type Marker struct {
+	Kind  string  `json:"kind"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end,omitempty"`
+	Title string  `json:"title,omitempty"`
+}
+
+// Interval is a detected black or silent stretch.
+type Interval struct {
+	Start float64
+	End   float64
+}
+
+func (i Interval) overlaps(o Interval) bool {
+	return i.Start < o.End && o.Start < i.End
+}
+
+// MarkerSpec tunes marker detection: the scene-change threshold handed to
+// ffmpeg, the target chapter length and how far into the video the intro and
+// credits are searched for.
+type MarkerSpec struct {
+	SceneThreshold  float64 `json:"sceneThreshold"`
+	ChapterInterval float64 `json:"chapterInterval"`
+	IntroWindow     float64 `json:"introWindow"`
+	CreditsWindow   float64 `json:"creditsWindow"`
+}
+
+func DefaultMarkerSpec() MarkerSpec {
+	return MarkerSpec{SceneThreshold: 0.4, ChapterInterval: 300, IntroWindow: 300, CreditsWindow: 600}
+}
+
+func NewMarkerSpec(sceneThreshold, chapterInterval, introWindow, creditsWindow float64) (*MarkerSpec, error) {
+	if sceneThreshold <= 0 || sceneThreshold >= 1 {
+		return nil, fmt.Errorf("scene threshold must be between 0 and 1")
+	}
+	if chapterInterval <= 0 {
+		return nil, fmt.Errorf("chapter interval must be positive")
+	}
+	if introWindow < 0 || creditsWindow < 0 {
+		return nil, fmt.Errorf("intro and credits windows cannot be negative")
+	}
+	return &MarkerSpec{
+		SceneThreshold:  sceneThreshold,
+		ChapterInterval: chapterInterval,
+		IntroWindow:     introWindow,
+		CreditsWindow:   creditsWindow,
+	}, nil
+}
+
+func (s MarkerSpec) IsZero() bool {
+	return s == MarkerSpec{}
+}
+
+// ProposeMarkers turns raw detections into markers. Stretches that are both
+// black and silent are treated as hard breaks; scene changes only place
+// chapter boundaries when no break is close enough.
+func (s MarkerSpec) ProposeMarkers(duration float64, scenes []float64, blacks, silences []Interval) []Marker {
+	if duration <= 0 {
+		return nil
+	}
+	breaks := hardBreaks(blacks, silences)
+	var markers []Marker
+
+	intro, hasIntro := s.intro(breaks)
+	if hasIntro {
+		markers = append(markers, intro)
+	}
+	end := duration
+	if credits, ok := s.credits(duration, breaks, blacks); ok {
+		markers = append(markers, credits)
+		end = credits.Start
+	}
+	return append(markers, s.chapters(end, breaks, scenes)...)
+}
+
+func hardBreaks(blacks, silences []Interval) []Interval {
+	var breaks []Interval
+	for _, b := range blacks {
+		for _, s := range silences {
+			if b.overlaps(s) {
+				breaks = append(breaks, b)
+				break
+			}
+		}
+	}
+	sort.Slice(breaks, func(i, j int) bool { return breaks[i].Start < breaks[j].Start })
+	return breaks
+}
+
+// intro picks the longest stretch between breaks inside the intro window
+// that is plausible as a title sequence.
+func (s MarkerSpec) intro(breaks []Interval) (Marker, bool) {
+	bounds := []Interval{{Start: 0, End: 0}}
+	for _, b := range breaks {
+		if b.Start > s.IntroWindow {
+			break
+		}
+		bounds = append(bounds, b)
+	}
+	var best Marker
+	for i := 1; i < len(bounds); i++ {
+		start, end := bounds[i-1].End, bounds[i].Start
+		length := end - start
+		if length < minIntroLength || length > maxIntroLength {
+			continue
+		}
+		if length > best.End-best.Start {
+			best = Marker{Kind: MarkerKindIntro, Start: start, End: end, Title: "Intro"}
+		}
+	}
+	return best, best.Kind != ""
+}
+
+// credits starts after the last break inside the credits window, falling
+// back to a plain black stretch when the audio never goes silent.
+func (s MarkerSpec) credits(duration float64, breaks, blacks []Interval) (Marker, bool) {
+	from := duration - s.CreditsWindow
+	for _, candidates := range [][]Interval{breaks, blacks} {
+		for i := len(candidates) - 1; i >= 0; i-- {
+			c := candidates[i]
+			if c.Start < from || c.End >= duration {
+				continue
+			}
+			return Marker{Kind: MarkerKindCredits, Start: c.End, Title: "Credits"}, true
+		}
+	}
+	return Marker{}, false
+}
+
+// chapters cuts roughly every ChapterInterval seconds, snapping each cut to
+// the nearest break or, failing that, scene change within a quarter interval.
+func (s MarkerSpec) chapters(end float64, breaks []Interval, scenes []float64) []Marker {
+	points := make([]float64, 0, len(breaks))
+	for _, b := range breaks {
+		points = append(points, b.End)
+	}
+	tolerance := s.ChapterInterval / 4
+	cuts := []float64{0}
+	for target := s.ChapterInterval; target < end-s.ChapterInterval/2; {
+		cut, ok := nearest(points, target, tolerance)
+		if !ok {
+			cut, ok = nearest(scenes, target, tolerance)
+		}
+		if !ok {
+			cut = target
+		}
+		cuts = append(cuts, cut)
+		target = cut + s.ChapterInterval
+	}
+	markers := make([]Marker, 0, len(cuts))
+	for i, start := range cuts {
+		stop := end
+		if i+1 < len(cuts) {
+			stop = cuts[i+1]
+		}
+		markers = append(markers, Marker{Kind: MarkerKindChapter, Start: start, End: stop, Title: fmt.Sprintf("Chapter %d", i+1)})
+	}
+	return markers
+}
+
+func nearest(points []float64, target, tolerance float64) (float64, bool) {
+	best, found := 0.0, false
+	for _, p := range points {
+		if math.Abs(p-target) > tolerance {
+			continue
+		}
+		if !found || math.Abs(p-target) < math.Abs(best-target) {
+			best, found = p, true
+		}
+	}
+	return best, found
+}
@@ -0,0 +1,96 @@
+package valueobjects
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type OverlayPosition string
+
+const (
+	OverlayTopLeft     OverlayPosition = "top_left"
+	OverlayTopRight    OverlayPosition = "top_right"
+	OverlayBottomLeft  OverlayPosition = "bottom_left"
+	OverlayBottomRight OverlayPosition = "bottom_right"
+	OverlayCenter      OverlayPosition = "center"
+)
+
+const maxOverlayText = 100
+
+// overlayLabelPattern keeps the label usable as a path segment of the
+// output key.
+var overlayLabelPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,39}$`)
+
+// OverlaySpec burns a logo, a line of text or both into every rendition. A
+// job with an overlay writes a variant under Label instead of the main
+// output, so the clean rendition stays untouched.
+type OverlaySpec struct {
+	Label    string          `json:"label"`
+	Image    string          `json:"image,omitempty"`
+	Position OverlayPosition `json:"position"`
+	Opacity  float64         `json:"opacity"`
+	Text     string          `json:"text,omitempty"`
+}
+
+// NewOverlaySpec defaults to a fully opaque overlay in the bottom right
+// corner.
+func NewOverlaySpec(label, image, position string, opacity float64, text string) (*OverlaySpec, error) {
+	label = strings.ToLower(strings.TrimSpace(label))
+	if !overlayLabelPattern.MatchString(label) {
+		return nil, fmt.Errorf("overlay label must be 1-40 lowercase letters, digits, '-' or '_'")
+	}
+	if label == "main" {
+		return nil, fmt.Errorf("overlay label cannot be main")
+	}
+	text = strings.TrimSpace(text)
+	if image == "" && text == "" {
+		return nil, fmt.Errorf("overlay needs an image or text")
+	}
+	if utf8.RuneCountInString(text) > maxOverlayText {
+		return nil, fmt.Errorf("overlay text must be at most %d characters", maxOverlayText)
+	}
+	if strings.IndexFunc(text, unicode.IsControl) >= 0 {
+		return nil, fmt.Errorf("overlay text must be a single line")
+	}
+	pos := OverlayPosition(strings.ToLower(strings.TrimSpace(position)))
+	switch pos {
+	case "":
+		pos = OverlayBottomRight
+	case OverlayTopLeft, OverlayTopRight, OverlayBottomLeft, OverlayBottomRight, OverlayCenter:
+	default:
+		return nil, fmt.Errorf("unsupported overlay position: %s", position)
+	}
+	if opacity == 0 {
+		opacity = 1
+	}
+	if opacity < 0 || opacity > 1 {
+		return nil, fmt.Errorf("overlay opacity must be between 0 and 1")
+	}
+	return &OverlaySpec{Label: label, Image: image, Position: pos, Opacity: opacity, Text: text}, nil
+}
+
+func (s OverlaySpec) IsZero() bool {
+	return s.Label == ""
+}
+
+func (s OverlaySpec) HasImage() bool { return s.Image != "" }
+func (s OverlaySpec) HasText() bool  { return s.Text != "" }
+
+func (p OverlayPosition) IsTop() bool {
+	return p == OverlayTopLeft || p == OverlayTopRight
+}
+
+func (p OverlayPosition) IsBottom() bool {
+	return p == OverlayBottomLeft || p == OverlayBottomRight
+}
+
+func (p OverlayPosition) IsLeft() bool {
+	return p == OverlayTopLeft || p == OverlayBottomLeft
+}
+
+func (p OverlayPosition) IsRight() bool {
+	return p == OverlayTopRight || p == OverlayBottomRight
+}
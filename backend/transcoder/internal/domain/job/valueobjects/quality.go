@@ -0,0 +1,110 @@
+package valueobjects
+
+import (
+	"fmt"
+)
+
+// QualityScores are a rendition's objective scores against the source,
+// averaged over the sampled windows. VMAF is only set when ffmpeg was built
+// with libvmaf.
+type QualityScores struct {
+	PSNR float64  `json:"psnr"`
+	SSIM float64  `json:"ssim"`
+	VMAF *float64 `json:"vmaf,omitempty"`
+}
+
+// QualityCheck is the verdict on a job's renditions. Reasons lists every
+// rendition score that fell below its threshold.
+type QualityCheck struct {
+	Passed  bool     `json:"passed"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// QualitySpec configures post-encode verification: how many windows of the
+// output are compared with the source, how long each is, and the minimum
+// scores a rendition needs. A zero threshold is not checked.
+type QualitySpec struct {
+	Enabled        bool    `json:"enabled"`
+	SampleCount    int     `json:"sampleCount"`
+	SampleDuration float64 `json:"sampleDuration"`
+	MinPSNR        float64 `json:"minPsnr"`
+	MinSSIM        float64 `json:"minSsim"`
+	MinVMAF        float64 `json:"minVmaf"`
+}
+
+func DefaultQualitySpec() QualitySpec {
+	return QualitySpec{SampleCount: 3, SampleDuration: 5}
+}
+
+func NewQualitySpec(enabled bool, sampleCount int, sampleDuration, minPSNR, minSSIM, minVMAF float64) (*QualitySpec, error) {
+	if sampleCount < 1 {
+		return nil, fmt.Errorf("sample count must be at least 1")
+	}
+	if sampleDuration <= 0 {
+		return nil, fmt.Errorf("sample duration must be positive")
+	}
+	if minPSNR < 0 {
+		return nil, fmt.Errorf("minimum PSNR cannot be negative")
+	}
+	if minSSIM < 0 || minSSIM > 1 {
+		return nil, fmt.Errorf("minimum SSIM must be between 0 and 1")
+	}
+	if minVMAF < 0 || minVMAF > 100 {
+		return nil, fmt.Errorf("minimum VMAF must be between 0 and 100")
+	}
+	return &QualitySpec{
+		Enabled:        enabled,
+		SampleCount:    sampleCount,
+		SampleDuration: sampleDuration,
+		MinPSNR:        minPSNR,
+		MinSSIM:        minSSIM,
+		MinVMAF:        minVMAF,
+	}, nil
+}
+
+// Windows picks the stretches of the source each rendition is compared on.
+func (s QualitySpec) Windows(duration float64) []Interval {
+	return sampleWindows(duration, s.SampleCount, s.SampleDuration)
+}
+
+// sampleWindows spreads count samples of the given length evenly over the
+// source, each centred in its share of the timeline. Short sources get a
+// single window from the start.
+func sampleWindows(duration float64, count int, length float64) []Interval {
+	if duration <= 0 || count < 1 {
+		return nil
+	}
+	if duration <= length*float64(count) {
+		return []Interval{{Start: 0, End: duration}}
+	}
+	share := duration / float64(count)
+	windows := make([]Interval, 0, count)
+	for i := 0; i < count; i++ {
+		start := share*float64(i) + (share-length)/2
+		windows = append(windows, Interval{Start: start, End: start + length})
+	}
+	return windows
+}
+
+// Check compares every scored rendition against the thresholds. Renditions
+// without scores are not judged.
+func (s QualitySpec) Check(renditions []RenditionMetadata) QualityCheck {
+	check := QualityCheck{Passed: true}
+	for _, r := range renditions {
+		q := r.Quality
+		if q == nil {
+			continue
+		}
+		if s.MinPSNR > 0 && q.PSNR < s.MinPSNR {
+			check.Reasons = append(check.Reasons, fmt.Sprintf("%s: PSNR %.2f below %.2f", r.Name, q.PSNR, s.MinPSNR))
+		}
+		if s.MinSSIM > 0 && q.SSIM < s.MinSSIM {
+			check.Reasons = append(check.Reasons, fmt.Sprintf("%s: SSIM %.4f below %.4f", r.Name, q.SSIM, s.MinSSIM))
+		}
+		if s.MinVMAF > 0 && q.VMAF != nil && *q.VMAF < s.MinVMAF {
+			check.Reasons = append(check.Reasons, fmt.Sprintf("%s: VMAF %.2f below %.2f", r.Name, *q.VMAF, s.MinVMAF))
+		}
+	}
+	check.Passed = len(check.Reasons) == 0
+	return check
+}
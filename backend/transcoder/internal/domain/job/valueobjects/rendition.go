@@ -0,0 +1,118 @@
+package valueobjects
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+type Rendition struct {
+	Name         string `json:"name"`
+	Width        int    `json:"width"`
+	Height       int    `json:"height"`
+	VideoBitrate int    `json:"videoBitrate"`
+	AudioBitrate int    `json:"audioBitrate"`
+	// BaseVideoBitrate is the configured bitrate before per-title scaling;
+	// zero when the rung was not scaled.
+	BaseVideoBitrate int `json:"baseVideoBitrate,omitempty"`
+}
+
+func NewRendition(name string, width, height, videoBitrate, audioBitrate int) (*Rendition, error) {
+	if height <= 0 {
+		return nil, fmt.Errorf("rendition height must be positive")
+	}
+	if videoBitrate <= 0 {
+		return nil, fmt.Errorf("rendition video bitrate must be positive")
+	}
+	if name == "" {
+		name = fmt.Sprintf("%dp", height)
+	}
+	return &Rendition{Name: name, Width: width, Height: height, VideoBitrate: videoBitrate, AudioBitrate: audioBitrate}, nil
+}
+
+func (r Rendition) Bandwidth() int {
+	return (r.VideoBitrate + r.AudioBitrate) * 1000
+}
+
+type Ladder []Rendition
+
+func DefaultLadder() Ladder {
+	return Ladder{
+		{Name: "240p", Width: 426, Height: 240, VideoBitrate: 400, AudioBitrate: 64},
+		{Name: "480p", Width: 854, Height: 480, VideoBitrate: 1200, AudioBitrate: 96},
+		{Name: "720p", Width: 1280, Height: 720, VideoBitrate: 2800, AudioBitrate: 128},
+		{Name: "1080p", Width: 1920, Height: 1080, VideoBitrate: 5000, AudioBitrate: 128},
+	}
+}
+
+func (l Ladder) IsEmpty() bool {
+	return len(l) == 0
+}
+
+func (l Ladder) Sorted() Ladder {
+	sorted := make(Ladder, len(l))
+	copy(sorted, l)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Height < sorted[j].Height })
+	return sorted
+}
+
+// Fit drops every rung taller than the source so the ladder never upscales.
+// Widths are recomputed from the source aspect ratio. When the source is
+// smaller than the lowest rung, a single rung at source resolution is kept.
+func (l Ladder) Fit(sourceWidth, sourceHeight int) Ladder {
+	sorted := l.Sorted()
+	if sourceWidth <= 0 || sourceHeight <= 0 || len(sorted) == 0 {
+		return sorted
+	}
+	fitted := make(Ladder, 0, len(sorted))
+	for _, r := range sorted {
+		if r.Height > sourceHeight {
+			continue
+		}
+		r.Width = evenWidth(sourceWidth, sourceHeight, r.Height)
+		fitted = append(fitted, r)
+	}
+	if len(fitted) == 0 {
+		lowest := sorted[0]
+		fitted = append(fitted, Rendition{
+			Name:         fmt.Sprintf("%dp", sourceHeight),
+			Width:        sourceWidth,
+			Height:       sourceHeight,
+			VideoBitrate: lowest.VideoBitrate,
+			AudioBitrate: lowest.AudioBitrate,
+		})
+	}
+	return fitted
+}
+
+// Scale multiplies every rung's video bitrate by factor, rounded to 50 kbps,
+// and keeps the configured value in BaseVideoBitrate. Resolutions and audio
+// are left alone.
+func (l Ladder) Scale(factor float64) Ladder {
+	scaled := make(Ladder, len(l))
+	for i, r := range l {
+		base := r.VideoBitrate
+		if r.BaseVideoBitrate > 0 {
+			base = r.BaseVideoBitrate
+		}
+		r.BaseVideoBitrate = base
+		r.VideoBitrate = int(math.Max(50, math.Round(float64(base)*factor/50)*50))
+		scaled[i] = r
+	}
+	return scaled
+}
+
+func (l Ladder) Top() (Rendition, bool) {
+	if len(l) == 0 {
+		return Rendition{}, false
+	}
+	return l.Sorted()[len(l)-1], true
+}
+
+func evenWidth(sourceWidth, sourceHeight, height int) int {
+	w := (sourceWidth*height + sourceHeight/2) / sourceHeight
+	if w%2 != 0 {
+		w++
+	}
+	return w
+}
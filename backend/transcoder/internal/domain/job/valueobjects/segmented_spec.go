@@ -0,0 +1,71 @@
+package valueobjects
+
+import (
+	"fmt"
+	"math"
+)
+
+// SegmentedSpec switches long HLS sources to split-encode-stitch: the source
+// is encoded as independent chunks in parallel and the chunk playlists are
+// joined afterwards.
+type SegmentedSpec struct {
+	// MinDuration is the source length, in seconds, from which the mode
+	// applies. Zero disables it.
+	MinDuration float64 `json:"minDuration"`
+	// ChunkDuration is the target chunk length in seconds.
+	ChunkDuration float64 `json:"chunkDuration"`
+	// Parallelism is how many chunks are encoded at once.
+	Parallelism int `json:"parallelism"`
+}
+
+// Chunk is one independently encoded slice of the source.
+type Chunk struct {
+	Index    int
+	Start    float64
+	Duration float64
+}
+
+func DefaultSegmentedSpec() SegmentedSpec {
+	return SegmentedSpec{MinDuration: 0, ChunkDuration: 300, Parallelism: 4}
+}
+
+func NewSegmentedSpec(minDuration, chunkDuration float64, parallelism int) (*SegmentedSpec, error) {
+	if minDuration < 0 {
+		return nil, fmt.Errorf("segmented encoding threshold cannot be negative")
+	}
+	if chunkDuration <= 0 {
+		return nil, fmt.Errorf("chunk duration must be positive")
+	}
+	if parallelism < 1 {
+		return nil, fmt.Errorf("parallelism must be at least 1")
+	}
+	return &SegmentedSpec{MinDuration: minDuration, ChunkDuration: chunkDuration, Parallelism: parallelism}, nil
+}
+
+func (s SegmentedSpec) Applies(sourceDuration float64) bool {
+	return s.MinDuration > 0 && sourceDuration >= s.MinDuration && sourceDuration > s.ChunkDuration
+}
+
+// Chunks cuts the source on the segment grid. Every chunk except the last is
+// a whole number of segments long, so the keyframe each chunk starts on falls
+// exactly where the single-pass encode would have forced one and the stitched
+// playlists keep the same segment boundaries. A short tail is folded into the
+// chunk before it.
+func (s SegmentedSpec) Chunks(sourceDuration float64, segmentDuration int) []Chunk {
+	if sourceDuration <= 0 || segmentDuration <= 0 {
+		return nil
+	}
+	seg := float64(segmentDuration)
+	length := math.Max(seg, math.Round(s.ChunkDuration/seg)*seg)
+
+	var chunks []Chunk
+	for start := 0.0; start < sourceDuration; start += length {
+		remaining := sourceDuration - start
+		if remaining < length/2 && len(chunks) > 0 {
+			chunks[len(chunks)-1].Duration += remaining
+			break
+		}
+		chunks = append(chunks, Chunk{Index: len(chunks), Start: start, Duration: math.Min(length, remaining)})
+	}
+	return chunks
+}
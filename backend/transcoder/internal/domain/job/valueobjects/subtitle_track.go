@@ -0,0 +1,53 @@
+package valueobjects
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	SubtitleKindSubtitles = "subtitles"
+	SubtitleKindCaptions  = "captions"
+	SubtitleKindForced    = "forced"
+)
+
+// SubtitleTrack is a sidecar SRT or WebVTT file packaged next to the video.
+type SubtitleTrack struct {
+	Language string `json:"language"`
+	Kind     string `json:"kind"`
+	Label    string `json:"label,omitempty"`
+	Default  bool   `json:"default,omitempty"`
+	Input    string `json:"input"`
+}
+
+func NewSubtitleTrack(language, kind, label string, isDefault bool, input string) (*SubtitleTrack, error) {
+	if language == "" {
+		return nil, fmt.Errorf("subtitle language is required")
+	}
+	if input == "" {
+		return nil, fmt.Errorf("subtitle input is required")
+	}
+	switch kind {
+	case "":
+		kind = SubtitleKindSubtitles
+	case SubtitleKindSubtitles, SubtitleKindCaptions, SubtitleKindForced:
+	default:
+		return nil, fmt.Errorf("unsupported subtitle kind: %s", kind)
+	}
+	if label == "" {
+		label = language
+	}
+	return &SubtitleTrack{Language: language, Kind: kind, Label: label, Default: isDefault, Input: input}, nil
+}
+
+// Name is the file stem used for the track's playlist and segments.
+func (t SubtitleTrack) Name() string {
+	name := "subs_" + strings.ToLower(strings.ReplaceAll(t.Language, "-", "_"))
+	if t.Kind != SubtitleKindSubtitles {
+		name += "_" + t.Kind
+	}
+	return name
+}
+
+func (t SubtitleTrack) IsForced() bool   { return t.Kind == SubtitleKindForced }
+func (t SubtitleTrack) IsCaptions() bool { return t.Kind == SubtitleKindCaptions }
@@ -0,0 +1,106 @@
+package valueobjects
+
+import (
+	"fmt"
+	"math"
+)
+
+// ThumbnailSpec describes the stills produced by a thumbnails job: one poster
+// frame, evenly spaced screenshots and a trickplay sprite sheet.
+type ThumbnailSpec struct {
+	ScreenshotCount int     `json:"screenshotCount"`
+	SpriteInterval  float64 `json:"spriteInterval"`
+	TileWidth       int     `json:"tileWidth"`
+	Columns         int     `json:"columns"`
+	Rows            int     `json:"rows"`
+}
+
+func DefaultThumbnailSpec() ThumbnailSpec {
+	return ThumbnailSpec{ScreenshotCount: 5, SpriteInterval: 10, TileWidth: 160, Columns: 10, Rows: 10}
+}
+
+func NewThumbnailSpec(screenshotCount int, spriteInterval float64, tileWidth, columns, rows int) (*ThumbnailSpec, error) {
+	if screenshotCount < 0 {
+		return nil, fmt.Errorf("screenshot count cannot be negative")
+	}
+	if spriteInterval <= 0 {
+		return nil, fmt.Errorf("sprite interval must be positive")
+	}
+	if tileWidth <= 0 || columns <= 0 || rows <= 0 {
+		return nil, fmt.Errorf("sprite tile width, columns and rows must be positive")
+	}
+	return &ThumbnailSpec{
+		ScreenshotCount: screenshotCount,
+		SpriteInterval:  spriteInterval,
+		TileWidth:       tileWidth + tileWidth%2,
+		Columns:         columns,
+		Rows:            rows,
+	}, nil
+}
+
+func (s ThumbnailSpec) IsZero() bool {
+	return s == ThumbnailSpec{}
+}
+
+// PosterOffset skips the opening tenth of the video, where logos and black
+// frames usually sit.
+func (s ThumbnailSpec) PosterOffset(duration float64) float64 {
+	if duration <= 0 {
+		return 0
+	}
+	return math.Min(duration*0.1, 60)
+}
+
+func (s ThumbnailSpec) ScreenshotOffsets(duration float64) []float64 {
+	if duration <= 0 || s.ScreenshotCount == 0 {
+		return nil
+	}
+	offsets := make([]float64, s.ScreenshotCount)
+	step := duration / float64(s.ScreenshotCount+1)
+	for i := range offsets {
+		offsets[i] = step * float64(i+1)
+	}
+	return offsets
+}
+
+func (s ThumbnailSpec) TileHeight(sourceWidth, sourceHeight int) int {
+	if sourceWidth <= 0 || sourceHeight <= 0 {
+		sourceWidth, sourceHeight = 16, 9
+	}
+	return evenWidth(sourceHeight, sourceWidth, s.TileWidth)
+}
+
+type SpriteCue struct {
+	Start  float64
+	End    float64
+	Sheet  int
+	X      int
+	Y      int
+	Width  int
+	Height int
+}
+
+// SpriteCues lays frames sampled every SpriteInterval seconds out row by row
+// across sheets of Columns x Rows tiles. Sheets are numbered from 1 to match
+// ffmpeg's image2 muxer.
+func (s ThumbnailSpec) SpriteCues(duration float64, tileHeight int) []SpriteCue {
+	if duration <= 0 || s.SpriteInterval <= 0 {
+		return nil
+	}
+	perSheet := s.Columns * s.Rows
+	count := int(math.Ceil(duration / s.SpriteInterval))
+	cues := make([]SpriteCue, count)
+	for i := range cues {
+		pos := i % perSheet
+		cues[i] = SpriteCue{
+			Start:  float64(i) * s.SpriteInterval,
+			End:    math.Min(float64(i+1)*s.SpriteInterval, duration),
+			Sheet:  i/perSheet + 1,
+			X:      (pos % s.Columns) * s.TileWidth,
+			Y:      (pos / s.Columns) * tileHeight,
+			Width:  s.TileWidth,
+			Height: tileHeight,
+		}
+	}
+	return cues
+}
@@ -11,23 +11,61 @@ type VideoMetadata struct {
 }
 
 type TranscodeMetadata struct {
-	OutputURL          string   `json:"outputUrl"`
-	Bucket             string   `json:"bucket"`
-	Key                string   `json:"key"`
-	Width              int      `json:"width,omitempty"`
-	Height             int      `json:"height,omitempty"`
-	Duration           float64  `json:"duration"`
-	Bitrate            int      `json:"bitrate"`
-	Codec              string   `json:"codec,omitempty"`
-	Size               int64    `json:"size"`
-	ContentType        string   `json:"contentType"`
-	Format             string   `json:"format"`
-	SegmentCount       int      `json:"segmentCount,omitempty"`
-	VideoCodec         string   `json:"videoCodec,omitempty"`
-	AudioCodec         string   `json:"audioCodec,omitempty"`
-	AvgSegmentDuration float64  `json:"avgSegmentDuration,omitempty"`
-	Segments           []string `json:"segments,omitempty"`
-	FrameRate          string   `json:"frameRate,omitempty"`
-	AudioChannels      int      `json:"audioChannels,omitempty"`
-	AudioSampleRate    int      `json:"audioSampleRate,omitempty"`
+	OutputURL          string              `json:"outputUrl"`
+	Bucket             string              `json:"bucket"`
+	Key                string              `json:"key"`
+	PlaylistURL        string              `json:"playlistUrl,omitempty"`
+	PlaylistKey        string              `json:"playlistKey,omitempty"`
+	Width              int                 `json:"width,omitempty"`
+	Height             int                 `json:"height,omitempty"`
+	Duration           float64             `json:"duration"`
+	Bitrate            int                 `json:"bitrate"`
+	Codec              string              `json:"codec,omitempty"`
+	Size               int64               `json:"size"`
+	ContentType        string              `json:"contentType"`
+	Format             string              `json:"format"`
+	SegmentCount       int                 `json:"segmentCount,omitempty"`
+	VideoCodec         string              `json:"videoCodec,omitempty"`
+	AudioCodec         string              `json:"audioCodec,omitempty"`
+	AvgSegmentDuration float64             `json:"avgSegmentDuration,omitempty"`
+	Segments           []string            `json:"segments,omitempty"`
+	FrameRate          string              `json:"frameRate,omitempty"`
+	AudioChannels      int                 `json:"audioChannels,omitempty"`
+	AudioSampleRate    int                 `json:"audioSampleRate,omitempty"`
+	Renditions         []RenditionMetadata `json:"renditions,omitempty"`
+	Images             []ImageMetadata     `json:"images,omitempty"`
+	TrackURL           string              `json:"trackUrl,omitempty"`
+	TrackKey           string              `json:"trackKey,omitempty"`
+	AudioTracks        AudioTracks         `json:"audioTracks,omitempty"`
+	Markers            []Marker            `json:"markers,omitempty"`
+	QualityCheck       *QualityCheck       `json:"qualityCheck,omitempty"`
+	Complexity         float64             `json:"complexity,omitempty"`
+	Ladder             Ladder              `json:"ladder,omitempty"`
+}
+
+type RenditionMetadata struct {
+	Name         string         `json:"name"`
+	URI          string         `json:"uri,omitempty"`
+	ContentType  string         `json:"contentType,omitempty"`
+	Width        int            `json:"width,omitempty"`
+	Height       int            `json:"height,omitempty"`
+	Bandwidth    int            `json:"bandwidth,omitempty"`
+	Codecs       string         `json:"codecs,omitempty"`
+	SegmentCount int            `json:"segmentCount,omitempty"`
+	Duration     float64        `json:"duration,omitempty"`
+	Segments     []string       `json:"segments,omitempty"`
+	Quality      *QualityScores `json:"quality,omitempty"`
+}
+
+type ImageMetadata struct {
+	Kind        string  `json:"kind"`
+	Index       int     `json:"index,omitempty"`
+	URL         string  `json:"url"`
+	Bucket      string  `json:"bucket"`
+	Key         string  `json:"key"`
+	Width       int     `json:"width,omitempty"`
+	Height      int     `json:"height,omitempty"`
+	Size        int64   `json:"size,omitempty"`
+	ContentType string  `json:"contentType"`
+	Offset      float64 `json:"offset,omitempty"`
 }
@@ -0,0 +1,179 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+var ErrPoolClosed = errors.New("worker pool is closed")
+
+// Lane orders queued work: priority tasks start before normal ones whenever
+// a slot frees up.
+type Lane string
+
+const (
+	LanePriority Lane = "priority"
+	LaneNormal   Lane = "normal"
+)
+
+var lanes = []Lane{LanePriority, LaneNormal}
+
+type PoolConfig struct {
+	// MaxConcurrency caps running tasks across all kinds.
+	MaxConcurrency int
+	// Concurrency caps running tasks per kind (analyze, hls, dash, ...).
+	// Kinds not listed are only bound by MaxConcurrency.
+	Concurrency map[string]int
+	// QueueSize is how many accepted tasks may wait for a slot in each lane.
+	// Submit blocks once a lane's queue is full, which holds back the Kafka
+	// consumer for that lane only.
+	QueueSize int
+}
+
+type PoolTask struct {
+	Kind string
+	Lane Lane
+	Run  func()
+}
+
+type PoolStats struct {
+	MaxConcurrency int            `json:"maxConcurrency"`
+	QueueSize      int            `json:"queueSize"`
+	Running        map[string]int `json:"running"`
+	Queued         map[Lane]int   `json:"queued"`
+}
+
+// WorkerPool runs tasks on a bounded number of goroutines. Within a lane
+// tasks start in arrival order, except that a task whose kind is at its cap
+// does not hold back other kinds behind it.
+type WorkerPool struct {
+	cfg     PoolConfig
+	slots   map[Lane]chan struct{}
+	done    chan struct{}
+	mu      sync.Mutex
+	queues  map[Lane][]PoolTask
+	running map[string]int
+	total   int
+	closed  bool
+}
+
+func NewWorkerPool(cfg PoolConfig) *WorkerPool {
+	if cfg.MaxConcurrency <= 0 {
+		cfg.MaxConcurrency = 1
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = cfg.MaxConcurrency
+	}
+	slots := make(map[Lane]chan struct{}, len(lanes))
+	for _, lane := range lanes {
+		slots[lane] = make(chan struct{}, cfg.QueueSize)
+	}
+	return &WorkerPool{
+		cfg:     cfg,
+		slots:   slots,
+		done:    make(chan struct{}),
+		queues:  map[Lane][]PoolTask{},
+		running: map[string]int{},
+	}
+}
+
+// Submit queues the task, waiting for space in its lane if that lane is
+// backed up. It returns once the task is accepted, not when it has run.
+func (p *WorkerPool) Submit(ctx context.Context, task PoolTask) error {
+	if task.Lane == "" {
+		task.Lane = LaneNormal
+	}
+	slots, ok := p.slots[task.Lane]
+	if !ok {
+		return fmt.Errorf("unknown pool lane %q", task.Lane)
+	}
+	select {
+	case slots <- struct{}{}:
+	case <-p.done:
+		return ErrPoolClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		<-slots
+		return ErrPoolClosed
+	}
+	p.queues[task.Lane] = append(p.queues[task.Lane], task)
+	p.dispatchLocked()
+	return nil
+}
+
+// Close stops the pool from accepting or starting tasks. Running tasks are
+// left to finish; queued ones are dropped, since their job records let the
+// next worker run pick them up.
+func (p *WorkerPool) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.closed {
+		p.closed = true
+		close(p.done)
+	}
+}
+
+func (p *WorkerPool) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats := PoolStats{
+		MaxConcurrency: p.cfg.MaxConcurrency,
+		QueueSize:      p.cfg.QueueSize,
+		Running:        make(map[string]int, len(p.running)),
+		Queued:         make(map[Lane]int, len(lanes)),
+	}
+	for kind, n := range p.running {
+		if n > 0 {
+			stats.Running[kind] = n
+		}
+	}
+	for _, lane := range lanes {
+		stats.Queued[lane] = len(p.queues[lane])
+	}
+	return stats
+}
+
+func (p *WorkerPool) dispatchLocked() {
+	for !p.closed && p.total < p.cfg.MaxConcurrency {
+		task, ok := p.nextLocked()
+		if !ok {
+			return
+		}
+		<-p.slots[task.Lane]
+		p.running[task.Kind]++
+		p.total++
+		go p.run(task)
+	}
+}
+
+func (p *WorkerPool) nextLocked() (PoolTask, bool) {
+	for _, lane := range lanes {
+		queue := p.queues[lane]
+		for i, task := range queue {
+			if limit, ok := p.cfg.Concurrency[task.Kind]; ok && limit > 0 && p.running[task.Kind] >= limit {
+				continue
+			}
+			p.queues[lane] = append(queue[:i:i], queue[i+1:]...)
+			return task, true
+		}
+	}
+	return PoolTask{}, false
+}
+
+func (p *WorkerPool) run(task PoolTask) {
+	defer func() {
+		p.mu.Lock()
+		p.running[task.Kind]--
+		p.total--
+		p.dispatchLocked()
+		p.mu.Unlock()
+	}()
+	task.Run()
+}
@@ -0,0 +1,136 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockingTask records when it starts and runs until released.
+func blockingTask(kind string, lane Lane, started chan<- string, release <-chan struct{}) PoolTask {
+	return PoolTask{Kind: kind, Lane: lane, Run: func() {
+		started <- kind
+		<-release
+	}}
+}
+
+func waitStarted(t *testing.T, started <-chan string) string {
+	t.Helper()
+	select {
+	case kind := <-started:
+		return kind
+	case <-time.After(time.Second):
+		t.Fatal("task did not start")
+		return ""
+	}
+}
+
+func TestWorkerPool_PriorityLaneGoesFirst(t *testing.T) {
+	pool := NewWorkerPool(PoolConfig{MaxConcurrency: 1, QueueSize: 4})
+	started := make(chan string, 4)
+	release := make(chan struct{})
+	ctx := context.Background()
+
+	_ = pool.Submit(ctx, blockingTask("hls", LaneNormal, started, release))
+	waitStarted(t, started)
+
+	_ = pool.Submit(ctx, blockingTask("dash", LaneNormal, started, release))
+	_ = pool.Submit(ctx, blockingTask("analyze", LanePriority, started, release))
+	if stats := pool.Stats(); stats.Queued[LaneNormal] != 1 || stats.Queued[LanePriority] != 1 {
+		t.Fatalf("unexpected queue: %+v", stats.Queued)
+	}
+
+	release <- struct{}{}
+	if kind := waitStarted(t, started); kind != "analyze" {
+		t.Fatalf("expected priority task next, got %s", kind)
+	}
+	release <- struct{}{}
+	if kind := waitStarted(t, started); kind != "dash" {
+		t.Fatalf("expected normal task last, got %s", kind)
+	}
+	close(release)
+}
+
+func TestWorkerPool_PerKindConcurrency(t *testing.T) {
+	pool := NewWorkerPool(PoolConfig{MaxConcurrency: 3, QueueSize: 4, Concurrency: map[string]int{"hls": 1}})
+	started := make(chan string, 4)
+	release := make(chan struct{})
+	ctx := context.Background()
+
+	_ = pool.Submit(ctx, blockingTask("hls", LaneNormal, started, release))
+	_ = pool.Submit(ctx, blockingTask("hls", LaneNormal, started, release))
+	_ = pool.Submit(ctx, blockingTask("analyze", LaneNormal, started, release))
+
+	got := map[string]int{}
+	got[waitStarted(t, started)]++
+	got[waitStarted(t, started)]++
+	if got["hls"] != 1 || got["analyze"] != 1 {
+		t.Fatalf("capped kind should not block others: %v", got)
+	}
+	if stats := pool.Stats(); stats.Running["hls"] != 1 || stats.Queued[LaneNormal] != 1 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+	close(release)
+}
+
+func TestWorkerPool_LanesQueueSeparately(t *testing.T) {
+	pool := NewWorkerPool(PoolConfig{MaxConcurrency: 1, QueueSize: 1})
+	started := make(chan string, 3)
+	release := make(chan struct{})
+
+	_ = pool.Submit(context.Background(), blockingTask("hls", LaneNormal, started, release))
+	waitStarted(t, started)
+	_ = pool.Submit(context.Background(), blockingTask("dash", LaneNormal, started, release))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := pool.Submit(ctx, blockingTask("hls", LaneNormal, started, release)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the full normal lane to block, got %v", err)
+	}
+	if err := pool.Submit(context.Background(), blockingTask("analyze", LanePriority, started, release)); err != nil {
+		t.Fatalf("priority Submit() blocked behind the normal lane: %v", err)
+	}
+
+	release <- struct{}{}
+	if kind := waitStarted(t, started); kind != "analyze" {
+		t.Fatalf("expected priority task next, got %s", kind)
+	}
+	pool.Close()
+	close(release)
+}
+
+func TestWorkerPool_Backpressure(t *testing.T) {
+	pool := NewWorkerPool(PoolConfig{MaxConcurrency: 1, QueueSize: 1})
+	started := make(chan string, 2)
+	release := make(chan struct{})
+
+	_ = pool.Submit(context.Background(), blockingTask("hls", LaneNormal, started, release))
+	waitStarted(t, started)
+	_ = pool.Submit(context.Background(), blockingTask("hls", LaneNormal, started, release))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := pool.Submit(ctx, blockingTask("hls", LaneNormal, started, release)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected Submit to block on a full queue, got %v", err)
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := pool.Submit(context.Background(), blockingTask("hls", LaneNormal, started, release)); err != nil {
+			t.Errorf("Submit() after space freed: %v", err)
+		}
+	}()
+	release <- struct{}{}
+	waitStarted(t, started)
+	wg.Wait()
+
+	pool.Close()
+	if err := pool.Submit(context.Background(), PoolTask{Kind: "hls", Run: func() {}}); !errors.Is(err, ErrPoolClosed) {
+		t.Fatalf("expected ErrPoolClosed, got %v", err)
+	}
+	close(release)
+}
@@ -0,0 +1,57 @@
+package live
+
+import (
+	"context"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+)
+
+type Event interface {
+	Topic() string
+	CloudEventType() string
+	Data() interface{}
+	ID() string
+}
+
+type EventPublisher interface {
+	PublishLiveEvent(ctx context.Context, event Event) error
+}
+
+type StreamReadyEvent struct {
+	AssetID   string `json:"assetId"`
+	SessionID string `json:"sessionId"`
+	IngestURL string `json:"ingestUrl"`
+}
+
+func (*StreamReadyEvent) Topic() string          { return events.LiveStreamReadyTopic }
+func (*StreamReadyEvent) CloudEventType() string { return events.LiveStreamReadyEventType }
+func (e *StreamReadyEvent) Data() interface{}    { return e }
+func (e *StreamReadyEvent) ID() string           { return e.SessionID }
+
+type StreamStartedEvent struct {
+	AssetID     string `json:"assetId"`
+	SessionID   string `json:"sessionId"`
+	PlaybackURL string `json:"playbackUrl"`
+	StartedAt   string `json:"startedAt"`
+}
+
+func (*StreamStartedEvent) Topic() string          { return events.LiveStreamStartedTopic }
+func (*StreamStartedEvent) CloudEventType() string { return events.LiveStreamStartedEventType }
+func (e *StreamStartedEvent) Data() interface{}    { return e }
+func (e *StreamStartedEvent) ID() string           { return e.SessionID }
+
+type StreamStoppedEvent struct {
+	AssetID      string  `json:"assetId"`
+	SessionID    string  `json:"sessionId"`
+	Success      bool    `json:"success"`
+	ErrorMessage string  `json:"errorMessage,omitempty"`
+	PlaybackURL  string  `json:"playbackUrl,omitempty"`
+	ArchiveURL   string  `json:"archiveUrl,omitempty"`
+	Duration     float64 `json:"duration,omitempty"`
+	EndedAt      string  `json:"endedAt"`
+}
+
+func (*StreamStoppedEvent) Topic() string          { return events.LiveStreamStoppedTopic }
+func (*StreamStoppedEvent) CloudEventType() string { return events.LiveStreamStoppedEventType }
+func (e *StreamStoppedEvent) Data() interface{}    { return e }
+func (e *StreamStoppedEvent) ID() string           { return e.SessionID }
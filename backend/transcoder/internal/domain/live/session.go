@@ -0,0 +1,59 @@
+package live
+
+import (
+	"context"
+	"time"
+
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+)
+
+// ProtocolSRT is the only ingest protocol. The SRT listener rejects callers
+// without the passphrase; an ffmpeg RTMP listener only logs an unexpected
+// stream name and takes the publish anyway, so RTMP is not offered.
+const ProtocolSRT = "srt"
+
+// Session is one live event on an asset, from the ingest request until the
+// broadcaster disconnects or the event is stopped.
+type Session struct {
+	AssetID   string
+	SessionID string
+	StreamKey string
+	Protocol  string
+}
+
+func NewSession(assetID, sessionID, streamKey, protocol string) (*Session, error) {
+	if assetID == "" || sessionID == "" {
+		return nil, pkgerrors.NewValidationError("live session needs an asset and a session ID", nil)
+	}
+	// SRT uses the key as its passphrase, which must be 10 to 79 characters.
+	if len(streamKey) < 10 || len(streamKey) > 79 {
+		return nil, pkgerrors.NewValidationError("stream key must be 10 to 79 characters", nil)
+	}
+	if protocol != ProtocolSRT {
+		return nil, pkgerrors.NewValidationError("unsupported live protocol: "+protocol, nil)
+	}
+	return &Session{AssetID: assetID, SessionID: sessionID, StreamKey: streamKey, Protocol: protocol}, nil
+}
+
+// Ingester runs one session's listener and encoder until the broadcaster
+// disconnects or ctx is cancelled. Cancelling ctx is a normal stop: the
+// encoder is asked to finish, so the playlist is closed and the archive is
+// kept.
+type Ingester interface {
+	Ingest(ctx context.Context, s *Session, hooks Hooks) (*Result, error)
+}
+
+type Hooks struct {
+	// Listening is called once the listener is bound, with the URL
+	// broadcasters push to. The stream key is not part of it.
+	Listening func(ingestURL string)
+	// Started is called when the first segments are in storage.
+	Started func(playbackURL string)
+}
+
+// Result is what an ended session left in storage.
+type Result struct {
+	PlaybackURL string
+	ArchiveURL  string
+	Duration    time.Duration
+}
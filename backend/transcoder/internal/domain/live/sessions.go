@@ -0,0 +1,96 @@
+package live
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+var (
+	ErrSessionActive    = errors.New("another live session is active for the asset")
+	ErrSessionRunning   = errors.New("live session is already running")
+	ErrTooManySessions  = errors.New("live session limit reached")
+	ErrSessionCancelled = errors.New("live session stopped")
+)
+
+// Sessions tracks the live sessions running on this worker, at most one per
+// asset. Each listener holds a port and an encoder, so the total is capped.
+type Sessions struct {
+	mu     sync.Mutex
+	max    int
+	active map[string]*activeSession
+}
+
+type activeSession struct {
+	sessionID string
+	cancel    context.CancelCauseFunc
+}
+
+func NewSessions(max int) *Sessions {
+	if max <= 0 {
+		max = 1
+	}
+	return &Sessions{max: max, active: map[string]*activeSession{}}
+}
+
+// Track registers the session and returns a context that Stop cancels, plus
+// a release func the caller runs once the session has ended. A redelivered
+// request for a session that is already running returns ErrSessionRunning.
+func (s *Sessions) Track(ctx context.Context, session *Session) (context.Context, func(), error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if current, ok := s.active[session.AssetID]; ok {
+		if current.sessionID == session.SessionID {
+			return nil, nil, ErrSessionRunning
+		}
+		return nil, nil, ErrSessionActive
+	}
+	if len(s.active) >= s.max {
+		return nil, nil, ErrTooManySessions
+	}
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	entry := &activeSession{sessionID: session.SessionID, cancel: cancel}
+	s.active[session.AssetID] = entry
+	return ctx, func() {
+		s.mu.Lock()
+		if s.active[session.AssetID] == entry {
+			delete(s.active, session.AssetID)
+		}
+		s.mu.Unlock()
+		cancel(nil)
+	}, nil
+}
+
+// Stop reports whether the session was running here. An empty sessionID
+// stops whatever session the asset has.
+func (s *Sessions) Stop(assetID, sessionID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current, ok := s.active[assetID]
+	if !ok || (sessionID != "" && current.sessionID != sessionID) {
+		return false
+	}
+	current.cancel(ErrSessionCancelled)
+	return true
+}
+
+func (s *Sessions) StopAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, current := range s.active {
+		current.cancel(ErrSessionCancelled)
+	}
+}
+
+func (s *Sessions) Count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.active)
+}
+
+// IsStopped reports whether ctx ended because the session was stopped, as
+// opposed to the worker shutting down.
+func IsStopped(ctx context.Context) bool {
+	return errors.Is(context.Cause(ctx), ErrSessionCancelled)
+}
@@ -0,0 +1,98 @@
+package live
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func testSession(t *testing.T, assetID, sessionID string) *Session {
+	t.Helper()
+	s, err := NewSession(assetID, sessionID, "0123456789abcdef", ProtocolSRT)
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	return s
+}
+
+func TestNewSession(t *testing.T) {
+	tests := []struct {
+		name      string
+		assetID   string
+		streamKey string
+		protocol  string
+		wantErr   bool
+	}{
+		{name: "srt", assetID: "asset-1", streamKey: "0123456789", protocol: ProtocolSRT},
+		{name: "missing asset", streamKey: "0123456789", protocol: ProtocolSRT, wantErr: true},
+		{name: "short key", assetID: "asset-1", streamKey: "short", protocol: ProtocolSRT, wantErr: true},
+		{name: "rtmp", assetID: "asset-1", streamKey: "0123456789", protocol: "rtmp", wantErr: true},
+		{name: "unknown protocol", assetID: "asset-1", streamKey: "0123456789", protocol: "webrtc", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewSession(tt.assetID, "session-1", tt.streamKey, tt.protocol)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewSession() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSessions_Track(t *testing.T) {
+	sessions := NewSessions(2)
+	ctx := context.Background()
+
+	first, release, err := sessions.Track(ctx, testSession(t, "asset-1", "session-1"))
+	if err != nil {
+		t.Fatalf("Track() error = %v", err)
+	}
+	if _, _, err := sessions.Track(ctx, testSession(t, "asset-1", "session-1")); !errors.Is(err, ErrSessionRunning) {
+		t.Errorf("redelivered Track() error = %v, want ErrSessionRunning", err)
+	}
+	if _, _, err := sessions.Track(ctx, testSession(t, "asset-1", "session-2")); !errors.Is(err, ErrSessionActive) {
+		t.Errorf("second session Track() error = %v, want ErrSessionActive", err)
+	}
+
+	_, releaseSecond, err := sessions.Track(ctx, testSession(t, "asset-2", "session-3"))
+	if err != nil {
+		t.Fatalf("Track() error = %v", err)
+	}
+	if _, _, err := sessions.Track(ctx, testSession(t, "asset-3", "session-4")); !errors.Is(err, ErrTooManySessions) {
+		t.Errorf("Track() over the limit error = %v, want ErrTooManySessions", err)
+	}
+
+	if sessions.Stop("asset-1", "session-9") {
+		t.Error("Stop() stopped a session with another ID")
+	}
+	if !sessions.Stop("asset-1", "session-1") {
+		t.Fatal("Stop() did not find the running session")
+	}
+	<-first.Done()
+	if !IsStopped(first) {
+		t.Error("IsStopped() = false after Stop()")
+	}
+
+	release()
+	releaseSecond()
+	if n := sessions.Count(); n != 0 {
+		t.Errorf("Count() = %d after release, want 0", n)
+	}
+}
+
+func TestSessions_ShutdownIsNotStop(t *testing.T) {
+	parent, cancel := context.WithCancel(context.Background())
+	sessions := NewSessions(1)
+
+	ctx, release, err := sessions.Track(parent, testSession(t, "asset-1", "session-1"))
+	if err != nil {
+		t.Fatalf("Track() error = %v", err)
+	}
+	defer release()
+
+	cancel()
+	<-ctx.Done()
+	if IsStopped(ctx) {
+		t.Error("IsStopped() = true when the parent context was cancelled")
+	}
+}
@@ -0,0 +1,67 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
+	domainjob "github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job"
+)
+
+type PoolStatsProvider interface {
+	Stats() domainjob.PoolStats
+}
+
+type HealthResponse struct {
+	Status  string              `json:"status"`
+	Service string              `json:"service"`
+	Pool    domainjob.PoolStats `json:"pool"`
+}
+
+// HealthServer is the worker's only HTTP surface: a /health endpoint that
+// also reports worker pool occupancy.
+type HealthServer struct {
+	server *http.Server
+	pool   PoolStatsProvider
+	logger *logger.Logger
+}
+
+func NewHealthServer(port string, pool PoolStatsProvider) *HealthServer {
+	s := &HealthServer{pool: pool, logger: logger.WithService("transcoder-health")}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /health", s.HealthCheck)
+	s.server = &http.Server{
+		Addr:              ":" + port,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return s
+}
+
+func (s *HealthServer) Start() {
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.WithError(err).Error("Health server error")
+		}
+	}()
+}
+
+func (s *HealthServer) Stop(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+func (s *HealthServer) HealthCheck(w http.ResponseWriter, r *http.Request) {
+	response := HealthResponse{
+		Status:  "healthy",
+		Service: "transcoder",
+		Pool:    s.pool.Stats(),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		s.logger.WithError(err).Error("Failed to encode health response")
+	}
+}
@@ -0,0 +1,161 @@
+package jobstore
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+	domainjob "github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+// FileRepository keeps one JSON file per job. The directory belongs to a
+// single worker; on startup that worker recovers whatever it finds there.
+type FileRepository struct {
+	dir string
+	mu  sync.Mutex
+}
+
+func NewFileRepository(dir string) (*FileRepository, error) {
+	if dir == "" {
+		return nil, pkgerrors.NewValidationError("job store directory is required", nil)
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, pkgerrors.NewInternalError("failed to create job store directory", err)
+	}
+	return &FileRepository{dir: dir}, nil
+}
+
+func (r *FileRepository) Save(ctx context.Context, record *domainjob.JobRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.write(record)
+}
+
+func (r *FileRepository) UpdateProgress(ctx context.Context, id string, progress float64, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	record, err := r.read(id)
+	if err != nil {
+		return err
+	}
+	if record.IsTerminal() {
+		return nil
+	}
+	record.Progress = progress
+	record.UpdatedAt = at
+	return r.write(record)
+}
+
+func (r *FileRepository) FindByID(ctx context.Context, id string) (*domainjob.JobRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.read(id)
+}
+
+func (r *FileRepository) FindByStatus(ctx context.Context, statuses ...valueobjects.JobStatus) ([]*domainjob.JobRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	paths, err := filepath.Glob(filepath.Join(r.dir, "*.json"))
+	if err != nil {
+		return nil, pkgerrors.NewInternalError("failed to list job records", err)
+	}
+	var records []*domainjob.JobRecord
+	for _, path := range paths {
+		record, err := r.read(strings.TrimSuffix(filepath.Base(path), ".json"))
+		if err != nil {
+			return nil, err
+		}
+		if hasStatus(record, statuses) {
+			records = append(records, record)
+		}
+	}
+	sortByStart(records)
+	return records, nil
+}
+
+func (r *FileRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	path, err := r.path(id)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return pkgerrors.NewInternalError("failed to delete job record", err)
+	}
+	return nil
+}
+
+func (r *FileRepository) read(id string) (*domainjob.JobRecord, error) {
+	path, err := r.path(id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, domainjob.ErrJobRecordNotFound
+	}
+	if err != nil {
+		return nil, pkgerrors.NewInternalError("failed to read job record", err)
+	}
+	var record domainjob.JobRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, pkgerrors.NewInternalError("failed to unmarshal job record", err)
+	}
+	return &record, nil
+}
+
+// write goes through a temp file so a crash mid-write never leaves a
+// truncated record behind.
+func (r *FileRepository) write(record *domainjob.JobRecord) error {
+	path, err := r.path(record.ID)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(record)
+	if err != nil {
+		return pkgerrors.NewInternalError("failed to marshal job record", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return pkgerrors.NewInternalError("failed to write job record", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return pkgerrors.NewInternalError("failed to write job record", err)
+	}
+	return nil
+}
+
+func (r *FileRepository) path(id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.Contains(id, "..") {
+		return "", pkgerrors.NewValidationError("invalid job ID", nil)
+	}
+	return filepath.Join(r.dir, id+".json"), nil
+}
+
+func hasStatus(record *domainjob.JobRecord, statuses []valueobjects.JobStatus) bool {
+	if len(statuses) == 0 {
+		return true
+	}
+	for _, status := range statuses {
+		if record.Status == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Oldest first, so recovered jobs restart in the order they arrived.
+func sortByStart(records []*domainjob.JobRecord) {
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Payload.RequestedAt.Before(records[j].Payload.RequestedAt)
+	})
+}
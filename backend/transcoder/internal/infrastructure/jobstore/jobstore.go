@@ -0,0 +1,29 @@
+package jobstore
+
+import (
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+	domainjob "github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job"
+)
+
+const (
+	BackendFile   = "file"
+	BackendMemory = "memory"
+)
+
+type Config struct {
+	Backend string
+	Dir     string
+}
+
+// New returns the configured job repository. Without a backend jobs are only
+// kept in memory and nothing survives a restart.
+func New(cfg Config) (domainjob.JobRepository, error) {
+	switch cfg.Backend {
+	case BackendFile:
+		return NewFileRepository(cfg.Dir)
+	case BackendMemory, "":
+		return NewMemoryRepository(), nil
+	default:
+		return nil, pkgerrors.NewValidationError("unknown job store backend: "+cfg.Backend, nil)
+	}
+}
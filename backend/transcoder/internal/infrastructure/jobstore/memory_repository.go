@@ -0,0 +1,73 @@
+package jobstore
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	domainjob "github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+type MemoryRepository struct {
+	mu      sync.Mutex
+	records map[string]domainjob.JobRecord
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{records: map[string]domainjob.JobRecord{}}
+}
+
+func (r *MemoryRepository) Save(ctx context.Context, record *domainjob.JobRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records[record.ID] = *record
+	return nil
+}
+
+func (r *MemoryRepository) UpdateProgress(ctx context.Context, id string, progress float64, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	record, ok := r.records[id]
+	if !ok {
+		return domainjob.ErrJobRecordNotFound
+	}
+	if record.IsTerminal() {
+		return nil
+	}
+	record.Progress = progress
+	record.UpdatedAt = at
+	r.records[id] = record
+	return nil
+}
+
+func (r *MemoryRepository) FindByID(ctx context.Context, id string) (*domainjob.JobRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	record, ok := r.records[id]
+	if !ok {
+		return nil, domainjob.ErrJobRecordNotFound
+	}
+	return &record, nil
+}
+
+func (r *MemoryRepository) FindByStatus(ctx context.Context, statuses ...valueobjects.JobStatus) ([]*domainjob.JobRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var records []*domainjob.JobRecord
+	for _, record := range r.records {
+		record := record
+		if hasStatus(&record, statuses) {
+			records = append(records, &record)
+		}
+	}
+	sortByStart(records)
+	return records, nil
+}
+
+func (r *MemoryRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.records, id)
+	return nil
+}
@@ -7,13 +7,16 @@ import (
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
 	appjob "github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/application/job"
+	applive "github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/application/live"
 )
 
 type TranscoderEventConsumer struct {
-	jobService appjob.JobApplicationService
-	producer   *events.Producer
-	consumer   *events.Consumer
-	logger     *logger.Logger
+	jobService  appjob.JobApplicationService
+	liveService applive.LiveApplicationService
+	producer    *events.Producer
+	consumer    *events.Consumer
+	cancels     *events.BroadcastConsumer
+	logger      *logger.Logger
 }
 
 func NewTranscoderEventConsumer(jobService appjob.JobApplicationService, producer *events.Producer) *TranscoderEventConsumer {
@@ -24,11 +27,20 @@ func NewTranscoderEventConsumer(jobService appjob.JobApplicationService, produce
 	}
 }
 
+// SetLiveService makes the worker take live ingest requests. Workers without
+// it leave them to the ones that have it.
+func (c *TranscoderEventConsumer) SetLiveService(liveService applive.LiveApplicationService) {
+	c.liveService = liveService
+}
+
 func (c *TranscoderEventConsumer) Start(ctx context.Context, bootstrapServers string) error {
 	cfg := events.DefaultConsumerConfig()
 	cfg.BootstrapServers = []string{bootstrapServers}
 	cfg.GroupID = events.TranscoderGroupID
-	cfg.Topics = []string{events.AnalyzeJobRequestedTopic, events.HLSJobRequestedTopic, events.DASHJobRequestedTopic}
+	cfg.Topics = []string{events.AnalyzeJobRequestedTopic, events.HLSJobRequestedTopic, events.DASHJobRequestedTopic, events.CMAFJobRequestedTopic, events.ThumbnailsJobRequestedTopic, events.MarkersJobRequestedTopic}
+	if c.liveService != nil {
+		cfg.Topics = append(cfg.Topics, events.LiveIngestRequestedTopic)
+	}
 
 	consumer, err := events.NewConsumer(ctx, cfg)
 	if err != nil {
@@ -40,6 +52,12 @@ func (c *TranscoderEventConsumer) Start(ctx context.Context, bootstrapServers st
 	consumer.Subscribe(events.AnalyzeJobRequestedTopic, c.HandleAnalyzeJobRequested)
 	consumer.Subscribe(events.HLSJobRequestedTopic, c.HandleHLSJobRequested)
 	consumer.Subscribe(events.DASHJobRequestedTopic, c.HandleDASHJobRequested)
+	consumer.Subscribe(events.CMAFJobRequestedTopic, c.HandleCMAFJobRequested)
+	consumer.Subscribe(events.ThumbnailsJobRequestedTopic, c.HandleThumbnailsJobRequested)
+	consumer.Subscribe(events.MarkersJobRequestedTopic, c.HandleMarkersJobRequested)
+	if c.liveService != nil {
+		consumer.Subscribe(events.LiveIngestRequestedTopic, c.HandleLiveIngestRequested)
+	}
 
 	c.logger.Info("Starting Transcoder Kafka event consumer", "group_id", events.TranscoderGroupID, "topics", []string{events.AnalyzeJobRequestedTopic, events.HLSJobRequestedTopic})
 
@@ -49,10 +67,47 @@ func (c *TranscoderEventConsumer) Start(ctx context.Context, bootstrapServers st
 		}
 	}()
 
+	return c.startCancelConsumer(ctx, bootstrapServers)
+}
+
+// Cancels and live stops must reach every worker, not just one member of the
+// job group, so each worker reads those topics without a group from the
+// newest offset. Only the workers running when a cancel is sent can hold the
+// job, so nothing older is needed and no per-worker group is left behind.
+func (c *TranscoderEventConsumer) startCancelConsumer(ctx context.Context, bootstrapServers string) error {
+	cfg := events.DefaultConsumerConfig()
+	cfg.BootstrapServers = []string{bootstrapServers}
+	cfg.Topics = []string{events.TranscodeJobCancelTopic}
+	if c.liveService != nil {
+		cfg.Topics = append(cfg.Topics, events.LiveIngestStopTopic)
+	}
+
+	consumer, err := events.NewBroadcastConsumer(ctx, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create Kafka cancel consumer: %w", err)
+	}
+
+	c.cancels = consumer
+	consumer.Subscribe(events.TranscodeJobCancelTopic, c.HandleTranscodeCancel)
+	if c.liveService != nil {
+		consumer.Subscribe(events.LiveIngestStopTopic, c.HandleLiveIngestStop)
+	}
+
+	c.logger.Info("Starting Transcoder Kafka cancel consumer", "topics", cfg.Topics)
+
+	go func() {
+		if err := consumer.Start(ctx); err != nil {
+			c.logger.WithError(err).Error("Kafka cancel consumer error")
+		}
+	}()
+
 	return nil
 }
 
 func (c *TranscoderEventConsumer) Stop() error {
+	if c.cancels != nil {
+		c.cancels.Stop()
+	}
 	if c.consumer != nil {
 		c.logger.Info("Stopping Transcoder Kafka event consumer")
 		return c.consumer.Stop()
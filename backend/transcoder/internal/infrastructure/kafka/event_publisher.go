@@ -6,6 +6,7 @@ import (
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
 	jobevents "github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/events"
+	domainlive "github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/live"
 )
 
 type KafkaEventPublisher struct {
@@ -31,3 +32,27 @@ func (p *KafkaEventPublisher) PublishJobCompleted(ctx context.Context, ev jobeve
 	p.logger.Info("Published job completion event", "topic", ev.Topic(), "job_id", ev.ID())
 	return nil
 }
+
+func (p *KafkaEventPublisher) PublishJobProgress(ctx context.Context, ev *jobevents.JobProgressEvent) error {
+	ce := events.NewEvent(ev.CloudEventType(), ev.Data()).
+		SetSource("transcoder").
+		AddExtension("subject", ev.ID())
+	if err := p.producer.SendEvent(ctx, ev.Topic(), ce); err != nil {
+		p.logger.WithError(err).Warn("Failed to publish job progress event", "topic", ev.Topic(), "job_id", ev.ID())
+		return err
+	}
+	p.logger.Debug("Published job progress event", "job_id", ev.ID(), "progress", ev.Progress)
+	return nil
+}
+
+func (p *KafkaEventPublisher) PublishLiveEvent(ctx context.Context, ev domainlive.Event) error {
+	ce := events.NewEvent(ev.CloudEventType(), ev.Data()).
+		SetSource("transcoder").
+		AddExtension("subject", ev.ID())
+	if err := p.producer.SendEvent(ctx, ev.Topic(), ce); err != nil {
+		p.logger.WithError(err).Error("Failed to publish live event", "topic", ev.Topic(), "session_id", ev.ID())
+		return err
+	}
+	p.logger.Info("Published live event", "topic", ev.Topic(), "session_id", ev.ID())
+	return nil
+}
@@ -8,10 +8,11 @@ import (
 )
 
 type JobAnalyzeRequestedEvent struct {
-	AssetID string `json:"assetId"`
-	VideoID string `json:"videoId"`
-	Input   string `json:"input"`
-	JobID   string `json:"jobId,omitempty"`
+	AssetID   string `json:"assetId"`
+	VideoID   string `json:"videoId"`
+	AssetType string `json:"assetType,omitempty"`
+	Input     string `json:"input"`
+	JobID     string `json:"jobId,omitempty"`
 }
 
 func (c *TranscoderEventConsumer) HandleAnalyzeJobRequested(ctx context.Context, event *events.Event) error {
@@ -24,18 +25,19 @@ func (c *TranscoderEventConsumer) HandleAnalyzeJobRequested(ctx context.Context,
 	}
 
 	payload := messages.JobPayload{
-		JobID:   e.JobID,
-		JobType: "analyze",
-		AssetID: e.AssetID,
-		VideoID: e.VideoID,
-		Input:   e.Input,
+		JobID:     e.JobID,
+		JobType:   "analyze",
+		AssetID:   e.AssetID,
+		VideoID:   e.VideoID,
+		AssetType: e.AssetType,
+		Input:     e.Input,
 	}
 
 	if err := c.jobService.ProcessJob(ctx, payload); err != nil {
-		c.logger.WithError(err).Error("Failed to process analyze job", "asset_id", e.AssetID, "video_id", e.VideoID)
+		c.logger.WithError(err).Error("Failed to queue analyze job", "asset_id", e.AssetID, "video_id", e.VideoID)
 		return err
 	}
 
-	c.logger.Info("Analyze job processed successfully", "asset_id", e.AssetID, "video_id", e.VideoID)
+	c.logger.Info("Analyze job queued", "asset_id", e.AssetID, "video_id", e.VideoID)
 	return nil
 }
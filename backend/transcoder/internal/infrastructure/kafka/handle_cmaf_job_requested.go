@@ -0,0 +1,63 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/messages"
+)
+
+type CMAFJobRequestedEvent struct {
+	AssetID        string                       `json:"assetId"`
+	VideoID        string                       `json:"videoId"`
+	AssetType      string                       `json:"assetType,omitempty"`
+	VideoType      string                       `json:"videoType,omitempty"`
+	Input          string                       `json:"input"`
+	JobID          string                       `json:"jobId,omitempty"`
+	SourceWidth    int                          `json:"sourceWidth,omitempty"`
+	SourceHeight   int                          `json:"sourceHeight,omitempty"`
+	SourceDuration float64                      `json:"sourceDuration,omitempty"`
+	Complexity     float64                      `json:"complexity,omitempty"`
+	Subtitles      []messages.SubtitlePayload   `json:"subtitles,omitempty"`
+	AudioTracks    []messages.AudioTrackPayload `json:"audioTracks,omitempty"`
+	Overlay        *messages.OverlayPayload     `json:"overlay,omitempty"`
+}
+
+func (c *TranscoderEventConsumer) HandleCMAFJobRequested(ctx context.Context, event *events.Event) error {
+	c.logger.Info("CMAF job requested event received", "event_id", event.ID, "source", event.Source)
+
+	var e CMAFJobRequestedEvent
+	if err := c.unmarshalEventData(event, &e); err != nil {
+		c.logger.WithError(err).Error("Failed to unmarshal CMAF job event")
+		return err
+	}
+
+	payload := messages.JobPayload{
+		JobID:          e.JobID,
+		JobType:        "transcode",
+		AssetID:        e.AssetID,
+		VideoID:        e.VideoID,
+		AssetType:      e.AssetType,
+		VideoType:      e.VideoType,
+		Input:          e.Input,
+		Format:         "cmaf",
+		Quality:        "main",
+		SourceWidth:    e.SourceWidth,
+		SourceHeight:   e.SourceHeight,
+		SourceDuration: e.SourceDuration,
+		Complexity:     e.Complexity,
+		CorrelationID:  event.CorrelationID,
+		RequestedAt:    event.Time,
+		Subtitles:      e.Subtitles,
+		AudioTracks:    e.AudioTracks,
+		Overlay:        e.Overlay,
+	}
+
+	if err := c.jobService.ProcessJob(ctx, payload); err != nil {
+		c.logger.WithError(err).Error("Failed to queue CMAF job", "asset_id", e.AssetID, "video_id", e.VideoID)
+		return err
+	}
+
+	c.logger.Info("CMAF job queued", "asset_id", e.AssetID, "video_id", e.VideoID)
+	return nil
+}
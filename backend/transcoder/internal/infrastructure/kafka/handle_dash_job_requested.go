@@ -8,10 +8,19 @@ import (
 )
 
 type DASHJobRequestedEvent struct {
-	AssetID string `json:"assetId"`
-	VideoID string `json:"videoId"`
-	Input   string `json:"input"`
-	JobID   string `json:"jobId,omitempty"`
+	AssetID        string                       `json:"assetId"`
+	VideoID        string                       `json:"videoId"`
+	AssetType      string                       `json:"assetType,omitempty"`
+	VideoType      string                       `json:"videoType,omitempty"`
+	Input          string                       `json:"input"`
+	JobID          string                       `json:"jobId,omitempty"`
+	SourceWidth    int                          `json:"sourceWidth,omitempty"`
+	SourceHeight   int                          `json:"sourceHeight,omitempty"`
+	SourceDuration float64                      `json:"sourceDuration,omitempty"`
+	Complexity     float64                      `json:"complexity,omitempty"`
+	Subtitles      []messages.SubtitlePayload   `json:"subtitles,omitempty"`
+	AudioTracks    []messages.AudioTrackPayload `json:"audioTracks,omitempty"`
+	Overlay        *messages.OverlayPayload     `json:"overlay,omitempty"`
 }
 
 func (c *TranscoderEventConsumer) HandleDASHJobRequested(ctx context.Context, event *events.Event) error {
@@ -24,20 +33,31 @@ func (c *TranscoderEventConsumer) HandleDASHJobRequested(ctx context.Context, ev
 	}
 
 	payload := messages.JobPayload{
-		JobID:   e.JobID,
-		JobType: "transcode",
-		AssetID: e.AssetID,
-		VideoID: e.VideoID,
-		Input:   e.Input,
-		Format:  "dash",
-		Quality: "main",
+		JobID:          e.JobID,
+		JobType:        "transcode",
+		AssetID:        e.AssetID,
+		VideoID:        e.VideoID,
+		AssetType:      e.AssetType,
+		VideoType:      e.VideoType,
+		Input:          e.Input,
+		Format:         "dash",
+		Quality:        "main",
+		SourceWidth:    e.SourceWidth,
+		SourceHeight:   e.SourceHeight,
+		SourceDuration: e.SourceDuration,
+		Complexity:     e.Complexity,
+		CorrelationID:  event.CorrelationID,
+		RequestedAt:    event.Time,
+		Subtitles:      e.Subtitles,
+		AudioTracks:    e.AudioTracks,
+		Overlay:        e.Overlay,
 	}
 
 	if err := c.jobService.ProcessJob(ctx, payload); err != nil {
-		c.logger.WithError(err).Error("Failed to process DASH job", "asset_id", e.AssetID, "video_id", e.VideoID)
+		c.logger.WithError(err).Error("Failed to queue DASH job", "asset_id", e.AssetID, "video_id", e.VideoID)
 		return err
 	}
 
-	c.logger.Info("DASH job processed successfully", "asset_id", e.AssetID, "video_id", e.VideoID)
+	c.logger.Info("DASH job queued", "asset_id", e.AssetID, "video_id", e.VideoID)
 	return nil
 }
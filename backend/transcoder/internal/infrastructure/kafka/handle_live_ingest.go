@@ -0,0 +1,47 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+	domainlive "github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/live"
+)
+
+type LiveIngestRequestedEvent struct {
+	AssetID   string `json:"assetId"`
+	SessionID string `json:"sessionId"`
+	StreamKey string `json:"streamKey"`
+	Protocol  string `json:"protocol"`
+}
+
+type LiveIngestStopEvent struct {
+	AssetID   string `json:"assetId"`
+	SessionID string `json:"sessionId"`
+}
+
+func (c *TranscoderEventConsumer) HandleLiveIngestRequested(ctx context.Context, event *events.Event) error {
+	var e LiveIngestRequestedEvent
+	if err := c.unmarshalEventData(event, &e); err != nil {
+		c.logger.WithError(err).Error("Failed to unmarshal live ingest event")
+		return err
+	}
+
+	session, err := domainlive.NewSession(e.AssetID, e.SessionID, e.StreamKey, e.Protocol)
+	if err != nil {
+		// A malformed request will not get better on redelivery.
+		c.logger.WithError(err).Error("Invalid live ingest request", "asset_id", e.AssetID, "session_id", e.SessionID)
+		return nil
+	}
+	c.logger.Info("Live ingest requested", "asset_id", e.AssetID, "session_id", e.SessionID, "protocol", e.Protocol)
+	return c.liveService.StartSession(ctx, session)
+}
+
+func (c *TranscoderEventConsumer) HandleLiveIngestStop(ctx context.Context, event *events.Event) error {
+	var e LiveIngestStopEvent
+	if err := c.unmarshalEventData(event, &e); err != nil {
+		c.logger.WithError(err).Error("Failed to unmarshal live stop event")
+		return err
+	}
+	c.liveService.StopSession(ctx, e.AssetID, e.SessionID)
+	return nil
+}
@@ -0,0 +1,47 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/messages"
+)
+
+type MarkersJobRequestedEvent struct {
+	AssetID        string  `json:"assetId"`
+	VideoID        string  `json:"videoId"`
+	Input          string  `json:"input"`
+	JobID          string  `json:"jobId,omitempty"`
+	SourceDuration float64 `json:"sourceDuration,omitempty"`
+}
+
+func (c *TranscoderEventConsumer) HandleMarkersJobRequested(ctx context.Context, event *events.Event) error {
+	c.logger.Info("Markers job requested event received", "event_id", event.ID, "source", event.Source)
+
+	var e MarkersJobRequestedEvent
+	if err := c.unmarshalEventData(event, &e); err != nil {
+		c.logger.WithError(err).Error("Failed to unmarshal markers job event")
+		return err
+	}
+
+	payload := messages.JobPayload{
+		JobID:          e.JobID,
+		JobType:        "transcode",
+		AssetID:        e.AssetID,
+		VideoID:        e.VideoID,
+		Input:          e.Input,
+		Format:         "markers",
+		Quality:        "main",
+		SourceDuration: e.SourceDuration,
+		CorrelationID:  event.CorrelationID,
+		RequestedAt:    event.Time,
+	}
+
+	if err := c.jobService.ProcessJob(ctx, payload); err != nil {
+		c.logger.WithError(err).Error("Failed to queue markers job", "asset_id", e.AssetID, "video_id", e.VideoID)
+		return err
+	}
+
+	c.logger.Info("Markers job queued", "asset_id", e.AssetID, "video_id", e.VideoID)
+	return nil
+}
@@ -0,0 +1,51 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/messages"
+)
+
+type ThumbnailsJobRequestedEvent struct {
+	AssetID        string  `json:"assetId"`
+	VideoID        string  `json:"videoId"`
+	Input          string  `json:"input"`
+	JobID          string  `json:"jobId,omitempty"`
+	SourceWidth    int     `json:"sourceWidth,omitempty"`
+	SourceHeight   int     `json:"sourceHeight,omitempty"`
+	SourceDuration float64 `json:"sourceDuration,omitempty"`
+}
+
+func (c *TranscoderEventConsumer) HandleThumbnailsJobRequested(ctx context.Context, event *events.Event) error {
+	c.logger.Info("Thumbnails job requested event received", "event_id", event.ID, "source", event.Source)
+
+	var e ThumbnailsJobRequestedEvent
+	if err := c.unmarshalEventData(event, &e); err != nil {
+		c.logger.WithError(err).Error("Failed to unmarshal thumbnails job event")
+		return err
+	}
+
+	payload := messages.JobPayload{
+		JobID:          e.JobID,
+		JobType:        "transcode",
+		AssetID:        e.AssetID,
+		VideoID:        e.VideoID,
+		Input:          e.Input,
+		Format:         "thumbnails",
+		Quality:        "main",
+		SourceWidth:    e.SourceWidth,
+		SourceHeight:   e.SourceHeight,
+		SourceDuration: e.SourceDuration,
+		CorrelationID:  event.CorrelationID,
+		RequestedAt:    event.Time,
+	}
+
+	if err := c.jobService.ProcessJob(ctx, payload); err != nil {
+		c.logger.WithError(err).Error("Failed to queue thumbnails job", "asset_id", e.AssetID, "video_id", e.VideoID)
+		return err
+	}
+
+	c.logger.Info("Thumbnails job queued", "asset_id", e.AssetID, "video_id", e.VideoID)
+	return nil
+}
@@ -0,0 +1,28 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/events"
+)
+
+type TranscodeCancelEvent struct {
+	AssetID string `json:"assetId"`
+	VideoID string `json:"videoId"`
+	Format  string `json:"format"`
+}
+
+func (c *TranscoderEventConsumer) HandleTranscodeCancel(ctx context.Context, event *events.Event) error {
+	var e TranscodeCancelEvent
+	if err := c.unmarshalEventData(event, &e); err != nil {
+		c.logger.WithError(err).Error("Failed to unmarshal transcode cancel event")
+		return err
+	}
+
+	correlationID := event.CorrelationID
+	if correlationID == "" {
+		correlationID = events.BuildJobCorrelationID(e.AssetID, e.VideoID, "transcode", e.Format, "main")
+	}
+	c.jobService.CancelJob(ctx, correlationID, event.Time)
+	return nil
+}
@@ -9,22 +9,31 @@ import (
 	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
 	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
 	resilience "github.com/serdarburakguneri/hobby-streamer/backend/pkg/resilience"
-	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/s3"
+	pkgstorage "github.com/serdarburakguneri/hobby-streamer/backend/pkg/storage"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job"
 )
 
 type Storage struct {
-	client *s3.Client
+	router *pkgstorage.Router
 }
 
-func NewStorage(client *s3.Client) *Storage {
-	return &Storage{client: client}
+func NewStorage(router *pkgstorage.Router) *Storage {
+	return &Storage{router: router}
 }
 
 func (s *Storage) Download(ctx context.Context, input string) (string, error) {
-	if strings.HasPrefix(input, "s3://") {
-		return s.client.Download(ctx, input)
+	if !s.IsRemote(input) {
+		return input, nil
 	}
-	return input, nil
+	loc, err := pkgstorage.ParseURL(input)
+	if err != nil {
+		return "", err
+	}
+	return s.router.Download(ctx, loc)
+}
+
+func (s *Storage) IsRemote(location string) bool {
+	return pkgstorage.IsURL(location)
 }
 
 func (s *Storage) CreateDir(path string) error {
@@ -39,20 +48,16 @@ func (s *Storage) RemoveAll(path string) error {
 	return os.RemoveAll(path)
 }
 
-func (s *Storage) Upload(ctx context.Context, localDir, s3Path string) error {
-	if !strings.HasPrefix(s3Path, "s3://") {
-		return pkgerrors.NewValidationError("output must be an S3 path", nil)
-	}
-	parts := strings.SplitN(s3Path[5:], "/", 2)
-	if len(parts) != 2 {
-		return pkgerrors.NewValidationError("invalid S3 path: "+s3Path, nil)
+func (s *Storage) Upload(ctx context.Context, localDir, output string) error {
+	loc, err := outputLocation(output)
+	if err != nil {
+		return err
 	}
-	bucket := parts[0]
-	keyPrefix := parts[1]
+	keyPrefix := loc.Key
 	manifestName := filepath.Base(keyPrefix)
 	manifestDir := filepath.Dir(keyPrefix)
 
-	err := filepath.Walk(localDir, func(path string, info os.FileInfo, err error) error {
+	err = filepath.Walk(localDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -64,27 +69,29 @@ func (s *Storage) Upload(ctx context.Context, localDir, s3Path string) error {
 			return err
 		}
 
-		var s3Key string
+		var key string
 		if relPath == manifestName {
-			s3Key = keyPrefix
+			key = keyPrefix
 		} else {
-			s3Key = manifestDir
-			if s3Key != "" && !strings.HasSuffix(s3Key, "/") {
-				s3Key += "/"
+			key = manifestDir
+			if key != "" && !strings.HasSuffix(key, "/") {
+				key += "/"
 			}
-			s3Key += relPath
+			key += filepath.ToSlash(relPath)
 		}
 
+		target := loc.WithKey(key)
 		retryFunc := func(ctx context.Context) error {
-			return s.client.Upload(ctx, path, bucket, s3Key)
+			return s.router.Upload(ctx, path, target)
 		}
 		retryErr := resilience.RetryWithBackoff(ctx, retryFunc, 3)
 		if retryErr != nil {
-			logger.Get().WithError(retryErr).Error("Failed to upload file to S3 after retries", "local_file", path, "s3_key", s3Key)
-			return pkgerrors.NewExternalError("failed to upload file to S3", retryErr)
+			logger.Get().WithError(retryErr).Error("Failed to upload output file after retries", "local_file", path, "target", target.String())
+			return pkgerrors.NewExternalError("failed to upload output file", retryErr)
 		}
 
-		logger.Get().Info("Successfully uploaded file to S3", "local_file", path, "s3_key", s3Key)
+		job.RecordUpload(ctx, target.String())
+		logger.Get().Info("Successfully uploaded output file", "local_file", path, "target", target.String())
 		return nil
 	})
 	if err != nil {
@@ -92,3 +99,27 @@ func (s *Storage) Upload(ctx context.Context, localDir, s3Path string) error {
 	}
 	return nil
 }
+
+// DeleteObjects removes the objects at the given locations, as recorded by
+// Upload. Every object is tried even when one fails.
+func (s *Storage) DeleteObjects(ctx context.Context, locations []string) error {
+	var firstErr error
+	for _, location := range locations {
+		loc, err := outputLocation(location)
+		if err == nil {
+			err = s.router.DeletePrefix(ctx, loc)
+		}
+		if err != nil && firstErr == nil {
+			firstErr = pkgerrors.NewExternalError("failed to delete output", err)
+		}
+	}
+	return firstErr
+}
+
+func outputLocation(output string) (pkgstorage.Location, error) {
+	loc, err := pkgstorage.ParseURL(output)
+	if err != nil {
+		return pkgstorage.Location{}, pkgerrors.NewValidationError("output must be a storage URL", err)
+	}
+	return loc, nil
+}
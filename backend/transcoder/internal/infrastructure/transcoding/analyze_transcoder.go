@@ -21,16 +21,8 @@ func NewAnalyzeTranscoder() *AnalyzeTranscoder {
 	return &AnalyzeTranscoder{}
 }
 
-func (a *AnalyzeTranscoder) ValidateInput(ctx context.Context, job *entity.Job) error {
-	retryFunc := func(ctx context.Context) error {
-		cmd := exec.CommandContext(ctx, "ffmpeg", "-i", job.Input(), "-f", "null", "-")
-		_, err := cmd.CombinedOutput()
-		return err
-	}
-	if err := resilience.RetryWithBackoff(ctx, retryFunc, 2); err != nil {
-		return pkgerrors.NewValidationError("video validation failed", err)
-	}
-	return nil
+func (a *AnalyzeTranscoder) ValidateInput(ctx context.Context, job *entity.Job, localPath string) error {
+	return validateInput(ctx, localPath, job.InputSpec())
 }
 
 func (a *AnalyzeTranscoder) Transcode(ctx context.Context, job *entity.Job, localPath, outputDir string) (string, error) {
@@ -94,10 +86,21 @@ func (a *AnalyzeTranscoder) ExtractMetadata(ctx context.Context, filePath string
 			FormatName string `json:"format_name"`
 		} `json:"format"`
 		Streams []struct {
-			CodecType string `json:"codec_type"`
-			CodecName string `json:"codec_name"`
-			Width     int    `json:"width"`
-			Height    int    `json:"height"`
+			CodecType     string `json:"codec_type"`
+			CodecName     string `json:"codec_name"`
+			Width         int    `json:"width"`
+			Height        int    `json:"height"`
+			Channels      int    `json:"channels"`
+			ChannelLayout string `json:"channel_layout"`
+			SampleRate    string `json:"sample_rate"`
+			Tags          struct {
+				Language string `json:"language"`
+				Title    string `json:"title"`
+			} `json:"tags"`
+			Disposition struct {
+				Default     int `json:"default"`
+				AttachedPic int `json:"attached_pic"`
+			} `json:"disposition"`
 		} `json:"streams"`
 	}
 	if err := json.Unmarshal(out, &probeResult); err != nil {
@@ -106,15 +109,35 @@ func (a *AnalyzeTranscoder) ExtractMetadata(ctx context.Context, filePath string
 	}
 
 	metadata := &valueobjects.TranscodeMetadata{}
+	videoFound := false
 	for _, stream := range probeResult.Streams {
-		if stream.CodecType == "video" {
+		switch {
+		case stream.CodecType == "video" && stream.Disposition.AttachedPic == 0 && !videoFound:
 			metadata.VideoCodec = stream.CodecName
 			metadata.Codec = stream.CodecName
 			metadata.Width = stream.Width
 			metadata.Height = stream.Height
-			break
+			videoFound = true
+		case stream.CodecType == "audio":
+			sampleRate, _ := strconv.Atoi(stream.SampleRate)
+			metadata.AudioTracks = append(metadata.AudioTracks, valueobjects.AudioTrack{
+				Index:         len(metadata.AudioTracks),
+				Language:      stream.Tags.Language,
+				Codec:         stream.CodecName,
+				Channels:      stream.Channels,
+				ChannelLayout: stream.ChannelLayout,
+				SampleRate:    sampleRate,
+				Title:         stream.Tags.Title,
+				Default:       stream.Disposition.Default == 1,
+			})
 		}
 	}
+	if len(metadata.AudioTracks) > 0 {
+		main := metadata.AudioTracks[metadata.AudioTracks.DefaultIndex()]
+		metadata.AudioCodec = main.Codec
+		metadata.AudioChannels = main.Channels
+		metadata.AudioSampleRate = main.SampleRate
+	}
 	if probeResult.Format.Duration != "" {
 		if d, err := strconv.ParseFloat(probeResult.Format.Duration, 64); err == nil {
 			metadata.Duration = d
@@ -133,5 +156,29 @@ func (a *AnalyzeTranscoder) ExtractMetadata(ctx context.Context, filePath string
 	if probeResult.Format.FormatName != "" {
 		metadata.ContentType = getContentTypeFromFormat(probeResult.Format.FormatName)
 	}
+	if spec := job.LoudnessSpec(); spec.Enabled {
+		// Tracks without a measurement are encoded as they are.
+		for i := range metadata.AudioTracks {
+			t := &metadata.AudioTracks[i]
+			loudness, err := measureLoudness(ctx, filePath, t.Index, spec)
+			if err != nil {
+				logger.Get().WithError(err).Warn("Loudness measurement failed", "job_id", job.ID().Value(), "track", t.Index)
+				continue
+			}
+			t.Loudness = loudness
+			logger.Get().Info("Loudness measured", "job_id", job.ID().Value(), "track", t.Index, "integrated", loudness.Integrated, "true_peak", loudness.TruePeak)
+		}
+	}
+	if spec := job.ComplexitySpec(); spec.Enabled && videoFound {
+		// Without a score the source is encoded with the configured ladder,
+		// so a failed probe is not worth failing the analysis over.
+		probes, err := probeComplexity(ctx, filePath, metadata.Width, metadata.Height, metadata.Duration, spec)
+		if err != nil {
+			logger.Get().WithError(err).Warn("Complexity probe failed", "job_id", job.ID().Value())
+		} else {
+			metadata.Complexity = spec.Score(probes)
+			logger.Get().Info("Complexity probed", "job_id", job.ID().Value(), "score", metadata.Complexity, "probes", probes)
+		}
+	}
 	return metadata, nil
 }
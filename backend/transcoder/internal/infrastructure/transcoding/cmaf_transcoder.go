@@ -0,0 +1,103 @@
+package transcoding
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+	resilience "github.com/serdarburakguneri/hobby-streamer/backend/pkg/resilience"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+const cmafPlaylistName = "playlist.m3u8"
+
+type CMAFTranscoder struct {
+	storage  job.Storage
+	progress job.ProgressReporter
+}
+
+func NewCMAFTranscoder(storage job.Storage, progress job.ProgressReporter) *CMAFTranscoder {
+	return &CMAFTranscoder{storage: storage, progress: progress}
+}
+
+func (c *CMAFTranscoder) ValidateInput(ctx context.Context, job *entity.Job, localPath string) error {
+	return validateInput(ctx, localPath, job.InputSpec())
+}
+
+func (c *CMAFTranscoder) Transcode(ctx context.Context, job *entity.Job, localPath, outputDir string) (string, error) {
+	outputPath := filepath.Join(outputDir, "manifest.mpd")
+	args := cmafArgs(localPath, outputPath, jobLadder(job), sourceAudio(ctx, job, localPath))
+	retryFunc := func(ctx context.Context) error {
+		return runFFmpeg(ctx, args, jobProgress(ctx, c.progress, job))
+	}
+	if err := resilience.RetryWithBackoff(ctx, retryFunc, 2); err != nil {
+		return "", pkgerrors.NewInternalError("CMAF packaging failed", err)
+	}
+	if err := packageSubtitles(ctx, c.storage, job, outputDir, filepath.Join(outputDir, cmafPlaylistName), outputPath, ""); err != nil {
+		return "", err
+	}
+	if job.Type().IsTranscode() && c.storage.IsRemote(job.Output()) {
+		if err := c.storage.Upload(ctx, outputDir, job.Output()); err != nil {
+			return "", pkgerrors.NewExternalError("failed to upload CMAF output", err)
+		}
+	}
+	return outputPath, nil
+}
+
+func cmafArgs(localPath, outputPath string, ladder valueobjects.Ladder, audio audioEncoding) []string {
+	return dashArgs(localPath, outputPath, ladder, nil, audio,
+		"-dash_segment_type", "mp4",
+		"-hls_playlist", "1",
+		"-hls_master_name", cmafPlaylistName,
+	)
+}
+
+func (c *CMAFTranscoder) ValidateOutput(job *entity.Job) error {
+	return validateOutputURL(job)
+}
+
+func (c *CMAFTranscoder) ExtractMetadata(ctx context.Context, filePath string, job *entity.Job) (*valueobjects.TranscodeMetadata, error) {
+	metadata, err := NewDASHTranscoder(c.storage, nil).ExtractMetadata(ctx, filePath, job)
+	if err != nil {
+		return nil, pkgerrors.NewInternalError("failed to read CMAF manifest", err)
+	}
+	metadata.Format = valueobjects.JobFormatCMAF.String()
+	if metadata.Key != "" {
+		metadata.PlaylistKey = path.Join(path.Dir(metadata.Key), cmafPlaylistName)
+		metadata.PlaylistURL = outputLocation(job).WithKey(metadata.PlaylistKey).String()
+	}
+
+	baseDir := filepath.Dir(filePath)
+	for i := range metadata.Renditions {
+		r := &metadata.Renditions[i]
+		uri := "media_" + r.Name + ".m3u8"
+		data, err := os.ReadFile(filepath.Join(baseDir, uri))
+		if err != nil {
+			continue
+		}
+		r.URI = uri
+		r.Segments, r.Duration = parseMediaPlaylist(string(data))
+		r.SegmentCount = len(r.Segments)
+	}
+
+	var top *valueobjects.RenditionMetadata
+	for i := range metadata.Renditions {
+		r := &metadata.Renditions[i]
+		if r.ContentType == "video" && (top == nil || r.Height > top.Height) {
+			top = r
+		}
+	}
+	if top != nil && top.SegmentCount > 0 {
+		metadata.Segments = top.Segments
+		metadata.SegmentCount = top.SegmentCount
+		metadata.AvgSegmentDuration = top.Duration / float64(top.SegmentCount)
+		if metadata.Duration == 0 {
+			metadata.Duration = top.Duration
+		}
+	}
+	return metadata, nil
+}
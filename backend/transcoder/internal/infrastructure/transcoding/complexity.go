@@ -0,0 +1,62 @@
+package transcoding
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+// probeComplexity encodes the spec's sample windows at every probe point and
+// reports the bitrate each point reached. Bitrates are scaled to a 16:9 frame
+// at the probe height so sources of different aspect ratios score alike.
+func probeComplexity(ctx context.Context, localPath string, width, height int, duration float64, spec valueobjects.ComplexitySpec) ([]valueobjects.ComplexityProbe, error) {
+	windows := spec.Windows(duration)
+	if len(windows) == 0 || width <= 0 || height <= 0 {
+		return nil, fmt.Errorf("source has no duration or dimensions to probe")
+	}
+	scratch, err := os.MkdirTemp("", "complexity-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(scratch)
+
+	aspect := (16.0 / 9.0) / (float64(width) / float64(height))
+	probes := make([]valueobjects.ComplexityProbe, 0, len(spec.Points))
+	for _, point := range spec.Points {
+		var bytes int64
+		var seconds float64
+		for i, w := range windows {
+			out := filepath.Join(scratch, fmt.Sprintf("crf%d_%d.mkv", point.CRF, i))
+			if err := runFFmpeg(ctx, complexityProbeArgs(localPath, out, w, spec.ProbeHeight, point.CRF), nil); err != nil {
+				return nil, fmt.Errorf("probe at CRF %d: %w", point.CRF, err)
+			}
+			info, err := os.Stat(out)
+			if err != nil {
+				return nil, err
+			}
+			bytes += info.Size()
+			seconds += w.End - w.Start
+		}
+		kbps := float64(bytes) * 8 / seconds / 1000 * aspect
+		probes = append(probes, valueobjects.ComplexityProbe{CRF: point.CRF, Bitrate: int(math.Round(kbps))})
+	}
+	return probes, nil
+}
+
+func complexityProbeArgs(localPath, out string, window valueobjects.Interval, probeHeight, crf int) []string {
+	return []string{
+		"-hide_banner", "-y",
+		"-ss", formatSeconds(window.Start),
+		"-t", formatSeconds(window.End - window.Start),
+		"-i", localPath,
+		"-map", "0:v:0", "-an", "-sn",
+		"-vf", fmt.Sprintf("scale=-2:%d", probeHeight),
+		"-c:v", "libx264", "-preset", "veryfast", "-crf", strconv.Itoa(crf),
+		"-f", "matroska", out,
+	}
+}
@@ -0,0 +1,92 @@
+package transcoding
+
+import (
+	"encoding/xml"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+type mpdRepresentation struct {
+	ID        string `xml:"id,attr"`
+	Bandwidth int    `xml:"bandwidth,attr"`
+	Width     int    `xml:"width,attr"`
+	Height    int    `xml:"height,attr"`
+	Codecs    string `xml:"codecs,attr"`
+	MimeType  string `xml:"mimeType,attr"`
+}
+
+type mpdAdaptationSet struct {
+	ContentType     string              `xml:"contentType,attr"`
+	MimeType        string              `xml:"mimeType,attr"`
+	Codecs          string              `xml:"codecs,attr"`
+	Representations []mpdRepresentation `xml:"Representation"`
+}
+
+type mpdDocument struct {
+	MediaPresentationDuration string `xml:"mediaPresentationDuration,attr"`
+	Periods                   []struct {
+		AdaptationSets []mpdAdaptationSet `xml:"AdaptationSet"`
+	} `xml:"Period"`
+}
+
+func (a mpdAdaptationSet) kind(rep mpdRepresentation) string {
+	for _, candidate := range []string{a.ContentType, rep.MimeType, a.MimeType} {
+		if candidate == "" {
+			continue
+		}
+		return strings.SplitN(candidate, "/", 2)[0]
+	}
+	if rep.Height > 0 {
+		return "video"
+	}
+	return "audio"
+}
+
+func parseMPD(data []byte) ([]valueobjects.RenditionMetadata, float64, error) {
+	var doc mpdDocument
+	if err := xml.Unmarshal(data, &doc); err != nil {
+		return nil, 0, err
+	}
+	var renditions []valueobjects.RenditionMetadata
+	for _, period := range doc.Periods {
+		for _, set := range period.AdaptationSets {
+			for _, rep := range set.Representations {
+				codecs := rep.Codecs
+				if codecs == "" {
+					codecs = set.Codecs
+				}
+				renditions = append(renditions, valueobjects.RenditionMetadata{
+					Name:        rep.ID,
+					ContentType: set.kind(rep),
+					Width:       rep.Width,
+					Height:      rep.Height,
+					Bandwidth:   rep.Bandwidth,
+					Codecs:      codecs,
+				})
+			}
+		}
+	}
+	return renditions, parseISODuration(doc.MediaPresentationDuration), nil
+}
+
+var isoDurationPattern = regexp.MustCompile(`^P(?:(\d+(?:\.\d+)?)D)?(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+func parseISODuration(value string) float64 {
+	m := isoDurationPattern.FindStringSubmatch(value)
+	if m == nil {
+		return 0
+	}
+	var total float64
+	for i, unit := range []float64{86400, 3600, 60, 1} {
+		if m[i+1] == "" {
+			continue
+		}
+		if v, err := strconv.ParseFloat(m[i+1], 64); err == nil {
+			total += v * unit
+		}
+	}
+	return total
+}
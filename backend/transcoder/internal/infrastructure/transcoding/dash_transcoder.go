@@ -2,11 +2,8 @@ package transcoding
 
 import (
 	"context"
-	"encoding/json"
-	"encoding/xml"
-	"io/ioutil"
+	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -19,44 +16,95 @@ import (
 )
 
 type DASHTranscoder struct {
-	storage job.Storage
+	storage  job.Storage
+	progress job.ProgressReporter
 }
 
-func NewDASHTranscoder(storage job.Storage) *DASHTranscoder {
-	return &DASHTranscoder{storage: storage}
+func NewDASHTranscoder(storage job.Storage, progress job.ProgressReporter) *DASHTranscoder {
+	return &DASHTranscoder{storage: storage, progress: progress}
 }
 
-func (d *DASHTranscoder) ValidateInput(ctx context.Context, job *entity.Job) error {
-	return nil
+func (d *DASHTranscoder) ValidateInput(ctx context.Context, job *entity.Job, localPath string) error {
+	return validateInput(ctx, localPath, job.InputSpec())
 }
 
 func (d *DASHTranscoder) Transcode(ctx context.Context, job *entity.Job, localPath, outputDir string) (string, error) {
 	outputPath := filepath.Join(outputDir, "manifest.mpd")
+	overlay, cleanupOverlay, err := prepareOverlay(ctx, d.storage, job)
+	if err != nil {
+		return "", err
+	}
+	defer cleanupOverlay()
+	args := dashArgs(localPath, outputPath, jobLadder(job), overlay, sourceAudio(ctx, job, localPath))
 	retryFunc := func(ctx context.Context) error {
-		cmd := exec.CommandContext(ctx, "ffmpeg",
-			"-i", localPath,
-			"-c:v", "libx264",
-			"-c:a", "aac",
-			"-f", "dash",
-			"-seg_duration", "10",
-			"-use_template", "1",
-			"-use_timeline", "1",
-			"-init_seg_name", "init-$RepresentationID$.m4s",
-			"-media_seg_name", "chunk-$RepresentationID$-$Number%05d$.m4s",
-			outputPath)
-		return cmd.Run()
+		return runFFmpeg(ctx, args, jobProgress(ctx, d.progress, job))
 	}
 	if err := resilience.RetryWithBackoff(ctx, retryFunc, 2); err != nil {
 		return "", pkgerrors.NewInternalError("DASH transcoding failed", err)
 	}
-	if job.Type().IsTranscode() && strings.HasPrefix(job.Output(), "s3://") {
+	if err := packageSubtitles(ctx, d.storage, job, outputDir, "", outputPath, ""); err != nil {
+		return "", err
+	}
+	if job.Type().IsTranscode() && d.storage.IsRemote(job.Output()) {
 		if err := d.storage.Upload(ctx, outputDir, job.Output()); err != nil {
-			return "", pkgerrors.NewExternalError("failed to upload DASH output to S3", err)
+			return "", pkgerrors.NewExternalError("failed to upload DASH output", err)
 		}
 	}
 	return outputPath, nil
 }
 
+func dashArgs(localPath, outputPath string, ladder valueobjects.Ladder, overlay *overlayInput, audio audioEncoding, extra ...string) []string {
+	args := []string{"-y", "-i", localPath}
+	args = append(args, overlay.inputArgs()...)
+	args = append(args, ladderVideoArgs(ladder, overlay)...)
+	var sets []string
+	audioStreams := 0
+	switch {
+	case ladder.IsEmpty():
+		// Audio-only sources get the audio-only set and nothing else.
+	case audio.tracks.IsMulti():
+		// One AdaptationSet per language so players can switch between them.
+		args = append(args, audioTrackArgs(ladder, audio)...)
+		sets = append(sets, "id=0,streams=v")
+		for i := range audio.tracks {
+			sets = append(sets, fmt.Sprintf("id=%d,streams=%d", i+1, len(ladder)+i))
+		}
+		audioStreams = len(audio.tracks)
+	case audio.silent:
+		sets = append(sets, "id=0,streams=v")
+	default:
+		main := audio.mainTrack()
+		args = append(args,
+			"-map", fmt.Sprintf("0:a:%d", main.Index),
+			"-c:a:0", "aac",
+			"-b:a:0", fmt.Sprintf("%dk", ladderAudioBitrate(ladder)),
+		)
+		args = append(args, audio.filterArgs(0, main)...)
+		sets = append(sets, "id=0,streams=v", fmt.Sprintf("id=1,streams=%d", len(ladder)))
+		audioStreams = 1
+	}
+	if !audio.audioOnly.IsZero() {
+		// The audio-only bitrates are representations of one extra set.
+		args = append(args, audio.audioOnlyArgs(audioStreams)...)
+		streams := make([]string, len(audio.audioOnly.Bitrates))
+		for i := range streams {
+			streams[i] = strconv.Itoa(len(ladder) + audioStreams + i)
+		}
+		sets = append(sets, fmt.Sprintf("id=%d,streams=%s", len(sets), strings.Join(streams, ",")))
+	}
+	args = append(args,
+		"-f", "dash",
+		"-seg_duration", strconv.Itoa(segmentDuration),
+		"-use_template", "1",
+		"-use_timeline", "1",
+		"-adaptation_sets", strings.Join(sets, " "),
+		"-init_seg_name", "init-$RepresentationID$.m4s",
+		"-media_seg_name", "chunk-$RepresentationID$-$Number%05d$.m4s",
+	)
+	args = append(args, extra...)
+	return append(args, outputPath)
+}
+
 func (d *DASHTranscoder) ValidateOutput(job *entity.Job) error {
 	return nil
 }
@@ -67,14 +115,8 @@ func (d *DASHTranscoder) ExtractMetadata(ctx context.Context, filePath string, j
 		return nil, pkgerrors.NewInternalError("failed to stat DASH manifest", err)
 	}
 	outputURL := job.Output()
-	var bucket, key string
-	if strings.HasPrefix(outputURL, "s3://") {
-		parts := strings.SplitN(outputURL[5:], "/", 2)
-		if len(parts) == 2 {
-			bucket = parts[0]
-			key = parts[1]
-		}
-	}
+	loc := outputLocation(job)
+	bucket, key := loc.Bucket, loc.Key
 	metadata := &valueobjects.TranscodeMetadata{
 		OutputURL:   outputURL,
 		Bucket:      bucket,
@@ -83,89 +125,47 @@ func (d *DASHTranscoder) ExtractMetadata(ctx context.Context, filePath string, j
 		Format:      valueobjects.JobFormatDASH.String(),
 		ContentType: "application/dash+xml",
 	}
-	data, err := ioutil.ReadFile(filePath)
+	metadata.Ladder = jobLadder(job)
+	metadata.Complexity = job.Complexity()
+	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return metadata, nil
 	}
-	type Representation struct {
-		Codecs string `xml:"codecs,attr"`
-	}
-	type MPD struct {
-		Period struct {
-			AdaptationSet struct {
-				Representations []Representation `xml:"Representation"`
-			} `xml:"AdaptationSet"`
-		} `xml:"Period"`
+	renditions, duration, err := parseMPD(data)
+	if err != nil {
+		return metadata, nil
 	}
-	var mpd MPD
-	if err := xml.Unmarshal(data, &mpd); err == nil && len(mpd.Period.AdaptationSet.Representations) > 0 {
-		codecs := mpd.Period.AdaptationSet.Representations[0].Codecs
-		metadata.VideoCodec = codecs
-		metadata.Codec = codecs
+	metadata.Renditions = renditions
+	metadata.Duration = duration
+
+	var top, audio *valueobjects.RenditionMetadata
+	for i := range renditions {
+		r := &renditions[i]
+		switch r.ContentType {
+		case "video":
+			if top == nil || r.Height > top.Height || (r.Height == top.Height && r.Bandwidth > top.Bandwidth) {
+				top = r
+			}
+		case "audio":
+			if audio == nil {
+				audio = r
+			}
+		}
 	}
 
 	baseDir := filepath.Dir(filePath)
-	files, _ := ioutil.ReadDir(baseDir)
-	var firstSeg string
-	for _, f := range files {
-		if strings.HasSuffix(f.Name(), ".m4s") {
-			firstSeg = filepath.Join(baseDir, f.Name())
-			break
-		}
+	if audio != nil {
+		metadata.AudioCodec = audio.Codecs
+		applySegmentProbe(ctx, filepath.Join(baseDir, "init-"+audio.Name+".m4s"), metadata)
 	}
-	if firstSeg != "" {
-		probe := exec.CommandContext(ctx, "ffprobe", "-v", "quiet", "-print_format", "json", "-show_format", "-show_streams", firstSeg)
-		if out, err := probe.CombinedOutput(); err == nil {
-			type Stream struct {
-				CodecType    string `json:"codec_type"`
-				CodecName    string `json:"codec_name"`
-				Width        int    `json:"width"`
-				Height       int    `json:"height"`
-				SampleRate   string `json:"sample_rate"`
-				Channels     int    `json:"channels"`
-				AvgFrameRate string `json:"avg_frame_rate"`
-			}
-			var pr struct {
-				Format struct {
-					BitRate string `json:"bit_rate"`
-				} `json:"format"`
-				Streams []Stream `json:"streams"`
-			}
-			if json.Unmarshal(out, &pr) == nil {
-				for _, s := range pr.Streams {
-					if s.CodecType == "video" {
-						if s.Width > 0 {
-							metadata.Width = s.Width
-						}
-						if s.Height > 0 {
-							metadata.Height = s.Height
-						}
-						if s.CodecName != "" {
-							metadata.VideoCodec = s.CodecName
-						}
-						if s.AvgFrameRate != "" {
-							metadata.FrameRate = s.AvgFrameRate
-						}
-					} else if s.CodecType == "audio" {
-						if s.Channels > 0 {
-							metadata.AudioChannels = s.Channels
-						}
-						if s.SampleRate != "" {
-							if sr, err := strconv.Atoi(s.SampleRate); err == nil {
-								metadata.AudioSampleRate = sr
-							}
-						}
-						if s.CodecName != "" {
-							metadata.AudioCodec = s.CodecName
-						}
-					}
-				}
-				if pr.Format.BitRate != "" {
-					if b, err := strconv.Atoi(pr.Format.BitRate); err == nil {
-						metadata.Bitrate = b
-					}
-				}
-			}
+	if top != nil {
+		applySegmentProbe(ctx, filepath.Join(baseDir, "init-"+top.Name+".m4s"), metadata)
+		metadata.Width = top.Width
+		metadata.Height = top.Height
+		metadata.Bitrate = top.Bandwidth
+		if metadata.VideoCodec == "" {
+			metadata.VideoCodec = top.Codecs
+			metadata.Codec = top.Codecs
 		}
 	}
 	return metadata, nil
@@ -0,0 +1,86 @@
+package transcoding
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
+)
+
+type progressFunc func(outTimeSeconds float64, done bool)
+
+func jobProgress(ctx context.Context, reporter job.ProgressReporter, j *entity.Job) progressFunc {
+	if reporter == nil {
+		return nil
+	}
+	return func(outTimeSeconds float64, done bool) {
+		reporter.Report(ctx, j, outTimeSeconds, done)
+	}
+}
+
+// runFFmpeg runs ffmpeg with machine-readable progress on stdout. Completion
+// is only reported once the process exits cleanly.
+func runFFmpeg(ctx context.Context, args []string, onProgress progressFunc) error {
+	return execFFmpeg(ctx, args, onProgress, nil)
+}
+
+// runFFmpegLog is runFFmpeg for detection filters, which report their
+// findings in the log on stderr.
+func runFFmpegLog(ctx context.Context, args []string, onProgress progressFunc) (string, error) {
+	var stderr bytes.Buffer
+	err := execFFmpeg(ctx, args, onProgress, &stderr)
+	return stderr.String(), err
+}
+
+func execFFmpeg(ctx context.Context, args []string, onProgress progressFunc, stderr io.Writer) error {
+	if onProgress == nil {
+		cmd := exec.CommandContext(ctx, "ffmpeg", args...)
+		cmd.Stderr = stderr
+		return cmd.Run()
+	}
+	cmd := exec.CommandContext(ctx, "ffmpeg", append([]string{"-progress", "pipe:1", "-nostats"}, args...)...)
+	cmd.Stderr = stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	outTime := scanProgress(stdout, onProgress)
+	if err := cmd.Wait(); err != nil {
+		return err
+	}
+	onProgress(outTime, true)
+	return nil
+}
+
+func scanProgress(r io.Reader, onProgress progressFunc) float64 {
+	var outTime float64
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		// out_time_ms is microseconds as well; older builds only emit that one.
+		case "out_time_us", "out_time_ms":
+			if us, err := strconv.ParseInt(value, 10, 64); err == nil && us > 0 {
+				outTime = float64(us) / 1e6
+			}
+		case "progress":
+			if value == "continue" {
+				onProgress(outTime, false)
+			}
+		}
+	}
+	io.Copy(io.Discard, r)
+	return outTime
+}
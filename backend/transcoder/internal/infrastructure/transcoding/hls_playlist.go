@@ -0,0 +1,88 @@
+package transcoding
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+func parseMasterPlaylist(data string) []valueobjects.RenditionMetadata {
+	var renditions []valueobjects.RenditionMetadata
+	var pending *valueobjects.RenditionMetadata
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "#EXT-X-STREAM-INF:"):
+			attrs := parseAttributeList(strings.TrimPrefix(line, "#EXT-X-STREAM-INF:"))
+			r := valueobjects.RenditionMetadata{Codecs: attrs["CODECS"]}
+			if b, err := strconv.Atoi(attrs["BANDWIDTH"]); err == nil {
+				r.Bandwidth = b
+			}
+			if res := strings.SplitN(attrs["RESOLUTION"], "x", 2); len(res) == 2 {
+				r.Width, _ = strconv.Atoi(res[0])
+				r.Height, _ = strconv.Atoi(res[1])
+			} else {
+				// Audio-only variants are the only ones without a resolution.
+				r.ContentType = "audio"
+			}
+			pending = &r
+		case strings.HasPrefix(line, "#"):
+			continue
+		case pending != nil:
+			pending.URI = line
+			pending.Name = strings.TrimSuffix(line[strings.LastIndex(line, "/")+1:], ".m3u8")
+			renditions = append(renditions, *pending)
+			pending = nil
+		}
+	}
+	return renditions
+}
+
+func parseMediaPlaylist(data string) ([]string, float64) {
+	var segments []string
+	var total float64
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "#EXTINF:"):
+			durStr := strings.TrimPrefix(line, "#EXTINF:")
+			if i := strings.Index(durStr, ","); i >= 0 {
+				durStr = durStr[:i]
+			}
+			if d, err := strconv.ParseFloat(durStr, 64); err == nil {
+				total += d
+			}
+		case strings.HasPrefix(line, "#"):
+			continue
+		default:
+			segments = append(segments, line)
+		}
+	}
+	return segments, total
+}
+
+func parseAttributeList(list string) map[string]string {
+	attrs := map[string]string{}
+	inQuotes := false
+	start := 0
+	for i := 0; i <= len(list); i++ {
+		if i < len(list) {
+			if list[i] == '"' {
+				inQuotes = !inQuotes
+			}
+			if list[i] != ',' || inQuotes {
+				continue
+			}
+		}
+		if kv := strings.SplitN(list[start:i], "=", 2); len(kv) == 2 {
+			attrs[strings.TrimSpace(kv[0])] = strings.Trim(kv[1], `"`)
+		}
+		start = i + 1
+	}
+	return attrs
+}
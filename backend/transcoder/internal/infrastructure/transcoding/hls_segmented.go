@@ -0,0 +1,352 @@
+package transcoding
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+	resilience "github.com/serdarburakguneri/hobby-streamer/backend/pkg/resilience"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+// ffmpegAudioGroup is the GROUP-ID ffmpeg writes for audioGroupID.
+const ffmpegAudioGroup = "group_" + audioGroupID
+
+var bandwidthAttr = regexp.MustCompile(`(AVERAGE-BANDWIDTH|BANDWIDTH)=(\d+)`)
+
+// transcodeSegmented encodes the video as independent chunks, several at a
+// time, and stitches the chunk playlists into one set in outputDir. Audio is
+// encoded once over the whole source next to the chunks: AAC encoder priming
+// at every chunk start would otherwise leave an audible gap at each joint.
+// Segments go straight to outputDir; playlists are written to a scratch
+// directory that is never uploaded and copied over once joined.
+func (h *HLSTranscoder) transcodeSegmented(ctx context.Context, job *entity.Job, localPath, outputDir string, spec valueobjects.SegmentedSpec, overlay *overlayInput, extra []string) error {
+	ladder := jobLadder(job)
+	audio := sourceAudio(ctx, job, localPath)
+	var chunks []valueobjects.Chunk
+	if !ladder.IsEmpty() {
+		chunks = spec.Chunks(job.SourceDuration(), segmentDuration)
+	}
+	scratch, err := os.MkdirTemp("", "hls-chunks-")
+	if err != nil {
+		return pkgerrors.NewInternalError("failed to create chunk directory", err)
+	}
+	defer os.RemoveAll(scratch)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	progress := newChunkProgress(chunks, jobProgress(ctx, h.progress, job))
+	sem := make(chan struct{}, spec.Parallelism)
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+	run := func(label string, args []string, onProgress progressFunc) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+			retryFunc := func(ctx context.Context) error {
+				return runFFmpeg(ctx, args, onProgress)
+			}
+			if err := resilience.RetryWithBackoff(ctx, retryFunc, 2); err != nil {
+				once.Do(func() {
+					firstErr = fmt.Errorf("%s: %w", label, err)
+					cancel()
+				})
+			}
+		}()
+	}
+
+	audioDir := filepath.Join(scratch, "audio")
+	hasAudio := !audio.silent && (!ladder.IsEmpty() || !audio.audioOnly.IsZero())
+	if hasAudio {
+		if err := os.MkdirAll(audioDir, 0755); err != nil {
+			return pkgerrors.NewInternalError("failed to create chunk directory", err)
+		}
+		// Progress follows the chunks, unless there is no video to chunk.
+		var onProgress progressFunc
+		if len(chunks) == 0 {
+			onProgress = jobProgress(ctx, h.progress, job)
+		}
+		run("audio", hlsAudioArgs(localPath, audioDir, filepath.Join(outputDir, "%v_%03d.ts"), ladder, audio, extra...), onProgress)
+	}
+
+	// The chunks carry video only.
+	videoOnly := audioEncoding{silent: true}
+	dirs := make([]string, len(chunks))
+	for _, chunk := range chunks {
+		dir := filepath.Join(scratch, fmt.Sprintf("%03d", chunk.Index))
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			cancel()
+			wg.Wait()
+			return pkgerrors.NewInternalError("failed to create chunk directory", err)
+		}
+		dirs[chunk.Index] = dir
+		args := hlsOutputArgs(
+			chunkInputArgs(localPath, chunk),
+			dir,
+			filepath.Join(outputDir, fmt.Sprintf("%%v_c%03d_%%03d.ts", chunk.Index)),
+			ladder,
+			overlay,
+			videoOnly,
+			append([]string{"-output_ts_offset", formatSeconds(chunk.Start)}, extra...)...,
+		)
+		run(fmt.Sprintf("chunk %d at %ss", chunk.Index, formatSeconds(chunk.Start)), args, progress.forChunk(chunk))
+	}
+	wg.Wait()
+
+	if firstErr == nil && ctx.Err() != nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr != nil {
+		return pkgerrors.NewInternalError("HLS segmented transcoding failed", firstErr)
+	}
+
+	var videoMaster, audioMaster string
+	if len(dirs) > 0 {
+		if videoMaster, err = stitchChunkPlaylists(dirs, outputDir); err != nil {
+			return pkgerrors.NewInternalError("failed to stitch chunk playlists", err)
+		}
+	}
+	if hasAudio {
+		if audioMaster, err = stitchChunkPlaylists([]string{audioDir}, outputDir); err != nil {
+			return pkgerrors.NewInternalError("failed to copy audio playlists", err)
+		}
+	}
+	master := mergeMasterPlaylists(videoMaster, audioMaster, ladderAudioBitrate(ladder)*1000)
+	if err := os.WriteFile(filepath.Join(outputDir, "playlist.m3u8"), []byte(master), 0644); err != nil {
+		return pkgerrors.NewInternalError("failed to write master playlist", err)
+	}
+	if len(chunks) > 0 {
+		progress.finish()
+	}
+	return nil
+}
+
+// chunkInputArgs seeks before -i so ffmpeg decodes from the keyframe ahead of
+// the chunk start and drops frames up to it; the chunk's own first frame is
+// then encoded as a keyframe.
+func chunkInputArgs(localPath string, chunk valueobjects.Chunk) []string {
+	return []string{
+		"-ss", formatSeconds(chunk.Start),
+		"-t", formatSeconds(chunk.Duration),
+		"-i", localPath,
+	}
+}
+
+// stitchChunkPlaylists joins every media playlist across chunks in order,
+// writes the result to outputDir and returns the first chunk's master
+// playlist.
+func stitchChunkPlaylists(chunkDirs []string, outputDir string) (string, error) {
+	if len(chunkDirs) == 0 {
+		return "", fmt.Errorf("no chunks to stitch")
+	}
+	master, err := os.ReadFile(filepath.Join(chunkDirs[0], "playlist.m3u8"))
+	if err != nil {
+		return "", err
+	}
+	media, err := filepath.Glob(filepath.Join(chunkDirs[0], "*.m3u8"))
+	if err != nil {
+		return "", err
+	}
+	for _, path := range media {
+		name := filepath.Base(path)
+		if name == "playlist.m3u8" {
+			continue
+		}
+		parts := make([]string, 0, len(chunkDirs))
+		for _, dir := range chunkDirs {
+			data, err := os.ReadFile(filepath.Join(dir, name))
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, string(data))
+		}
+		if err := os.WriteFile(filepath.Join(outputDir, name), []byte(joinMediaPlaylists(parts)), 0644); err != nil {
+			return "", err
+		}
+	}
+	return string(master), nil
+}
+
+// mergeMasterPlaylists combines the video-only master of the chunk encode
+// with the master of the audio encode: the audio group's renditions, then
+// the video variants pointed at the group, then any audio-only variants.
+// Each video variant's bandwidth grows by audioBandwidth and its CODECS gain
+// AAC, since the variant now plays with the group's audio. Either playlist
+// may be empty when the job has no video or the source no audio.
+func mergeMasterPlaylists(video, audio string, audioBandwidth int) string {
+	if audio == "" {
+		return video
+	}
+	if video == "" {
+		return audio
+	}
+	header, _, variants := splitMasterPlaylist(video)
+	_, media, audioOnly := splitMasterPlaylist(audio)
+	grouped := len(media) > 0
+
+	var b strings.Builder
+	for _, line := range header {
+		b.WriteString(line + "\n")
+	}
+	for _, line := range media {
+		b.WriteString(line + "\n")
+	}
+	for _, line := range variants {
+		if grouped && strings.HasPrefix(line, "#EXT-X-STREAM-INF:") {
+			line = withAudioGroup(line, audioBandwidth)
+		}
+		b.WriteString(line + "\n")
+	}
+	for _, line := range audioOnly {
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// splitMasterPlaylist sorts master playlist lines into the header, the
+// EXT-X-MEDIA renditions and the variants, each STREAM-INF with its URI.
+func splitMasterPlaylist(data string) (header, media, variants []string) {
+	for _, line := range strings.Split(data, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "#EXT-X-MEDIA:"):
+			media = append(media, line)
+		case strings.HasPrefix(line, "#EXT-X-STREAM-INF:"), !strings.HasPrefix(line, "#"):
+			variants = append(variants, line)
+		case len(variants) == 0:
+			header = append(header, line)
+		}
+	}
+	return header, media, variants
+}
+
+func withAudioGroup(streamInf string, audioBandwidth int) string {
+	streamInf = bandwidthAttr.ReplaceAllStringFunc(streamInf, func(attr string) string {
+		kv := strings.SplitN(attr, "=", 2)
+		n, _ := strconv.Atoi(kv[1])
+		return fmt.Sprintf("%s=%d", kv[0], n+audioBandwidth)
+	})
+	if i := strings.Index(streamInf, `CODECS="`); i >= 0 {
+		if j := strings.Index(streamInf[i+len(`CODECS="`):], `"`); j >= 0 {
+			end := i + len(`CODECS="`) + j
+			streamInf = streamInf[:end] + ",mp4a.40.2" + streamInf[end:]
+		}
+	}
+	return streamInf + `,AUDIO="` + ffmpegAudioGroup + `"`
+}
+
+// joinMediaPlaylists keeps the first playlist's header (including any
+// EXT-X-KEY, which every chunk shares) and appends each playlist's segments.
+// Chunk timestamps are already continuous through -output_ts_offset, so no
+// discontinuity tags are needed.
+func joinMediaPlaylists(playlists []string) string {
+	var header, body []string
+	var target float64
+	for i, playlist := range playlists {
+		inBody := false
+		for _, line := range strings.Split(playlist, "\n") {
+			line = strings.TrimSpace(line)
+			switch {
+			case line == "", line == "#EXT-X-ENDLIST":
+				continue
+			case strings.HasPrefix(line, "#EXTINF:"):
+				inBody = true
+				durStr := strings.TrimPrefix(line, "#EXTINF:")
+				if j := strings.Index(durStr, ","); j >= 0 {
+					durStr = durStr[:j]
+				}
+				if d, err := strconv.ParseFloat(durStr, 64); err == nil {
+					target = math.Max(target, d)
+				}
+				body = append(body, line)
+			case !inBody:
+				if i == 0 && !strings.HasPrefix(line, "#EXT-X-TARGETDURATION:") {
+					header = append(header, line)
+				}
+			case strings.HasPrefix(line, "#"):
+				body = append(body, line)
+			default:
+				body = append(body, filepath.Base(line))
+			}
+		}
+	}
+
+	var b strings.Builder
+	for i, line := range header {
+		b.WriteString(line + "\n")
+		if i == 0 {
+			fmt.Fprintf(&b, "#EXT-X-TARGETDURATION:%d\n", int(math.Ceil(target)))
+		}
+	}
+	for _, line := range body {
+		b.WriteString(line + "\n")
+	}
+	b.WriteString("#EXT-X-ENDLIST\n")
+	return b.String()
+}
+
+// chunkProgress sums encoded time across chunks running in parallel, so the
+// job reports one progress figure against the full source duration.
+type chunkProgress struct {
+	mu     sync.Mutex
+	done   []float64
+	report progressFunc
+}
+
+func newChunkProgress(chunks []valueobjects.Chunk, report progressFunc) *chunkProgress {
+	return &chunkProgress{done: make([]float64, len(chunks)), report: report}
+}
+
+func (p *chunkProgress) forChunk(chunk valueobjects.Chunk) progressFunc {
+	if p.report == nil {
+		return nil
+	}
+	return func(outTimeSeconds float64, finished bool) {
+		// Depending on the ffmpeg version out_time may include the
+		// -output_ts_offset; bring it back to chunk-relative time.
+		if outTimeSeconds > chunk.Duration {
+			outTimeSeconds -= chunk.Start
+		}
+		encoded := math.Max(0, math.Min(outTimeSeconds, chunk.Duration))
+		if finished {
+			encoded = chunk.Duration
+		}
+		p.mu.Lock()
+		p.done[chunk.Index] = encoded
+		var total float64
+		for _, d := range p.done {
+			total += d
+		}
+		p.mu.Unlock()
+		p.report(total, false)
+	}
+}
+
+func (p *chunkProgress) finish() {
+	if p.report == nil {
+		return
+	}
+	var total float64
+	for _, d := range p.done {
+		total += d
+	}
+	p.report(total, true)
+}
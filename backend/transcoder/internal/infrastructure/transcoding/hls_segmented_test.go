@@ -0,0 +1,159 @@
+package transcoding
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+const chunk0 = `#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-TARGETDURATION:10
+#EXT-X-MEDIA-SEQUENCE:0
+#EXT-X-PLAYLIST-TYPE:VOD
+#EXT-X-KEY:METHOD=AES-128,URI="https://keys.example.com/a/v/j",IV=0x0123
+#EXTINF:10.000000,
+/tmp/out/720p_c000_000.ts
+#EXTINF:10.000000,
+/tmp/out/720p_c000_001.ts
+#EXT-X-ENDLIST
+`
+
+const chunk1 = `#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-TARGETDURATION:11
+#EXT-X-MEDIA-SEQUENCE:0
+#EXT-X-PLAYLIST-TYPE:VOD
+#EXT-X-KEY:METHOD=AES-128,URI="https://keys.example.com/a/v/j",IV=0x0123
+#EXTINF:10.400000,
+720p_c001_000.ts
+#EXTINF:4.200000,
+720p_c001_001.ts
+#EXT-X-ENDLIST
+`
+
+func TestJoinMediaPlaylists(t *testing.T) {
+	tests := []struct {
+		name      string
+		playlists []string
+		want      string
+	}{
+		{
+			name:      "single chunk",
+			playlists: []string{chunk0},
+			want: `#EXTM3U
+#EXT-X-TARGETDURATION:10
+#EXT-X-VERSION:3
+#EXT-X-MEDIA-SEQUENCE:0
+#EXT-X-PLAYLIST-TYPE:VOD
+#EXT-X-KEY:METHOD=AES-128,URI="https://keys.example.com/a/v/j",IV=0x0123
+#EXTINF:10.000000,
+720p_c000_000.ts
+#EXTINF:10.000000,
+720p_c000_001.ts
+#EXT-X-ENDLIST
+`,
+		},
+		{
+			name:      "chunks in order",
+			playlists: []string{chunk0, chunk1},
+			want: `#EXTM3U
+#EXT-X-TARGETDURATION:11
+#EXT-X-VERSION:3
+#EXT-X-MEDIA-SEQUENCE:0
+#EXT-X-PLAYLIST-TYPE:VOD
+#EXT-X-KEY:METHOD=AES-128,URI="https://keys.example.com/a/v/j",IV=0x0123
+#EXTINF:10.000000,
+720p_c000_000.ts
+#EXTINF:10.000000,
+720p_c000_001.ts
+#EXTINF:10.400000,
+720p_c001_000.ts
+#EXTINF:4.200000,
+720p_c001_001.ts
+#EXT-X-ENDLIST
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := joinMediaPlaylists(tt.playlists)
+			if got != tt.want {
+				t.Errorf("joinMediaPlaylists() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinMediaPlaylists_HeaderOnce(t *testing.T) {
+	got := joinMediaPlaylists([]string{chunk0, chunk1, chunk1})
+	for _, tag := range []string{"#EXTM3U", "#EXT-X-TARGETDURATION:", "#EXT-X-KEY:", "#EXT-X-ENDLIST"} {
+		if n := strings.Count(got, tag); n != 1 {
+			t.Errorf("%s appears %d times, want once", tag, n)
+		}
+	}
+	if n := strings.Count(got, "#EXTINF:"); n != 6 {
+		t.Errorf("got %d segments, want 6", n)
+	}
+}
+
+func TestMergeMasterPlaylists(t *testing.T) {
+	video := `#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-STREAM-INF:BANDWIDTH=3080000,RESOLUTION=1280x720,CODECS="avc1.64001f"
+720p.m3u8
+
+#EXT-X-STREAM-INF:BANDWIDTH=880000,RESOLUTION=640x360,CODECS="avc1.64001e"
+360p.m3u8
+`
+	audio := `#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-MEDIA:TYPE=AUDIO,GROUP-ID="group_audio",NAME="audio_und_0",DEFAULT=YES,URI="audio_und_0.m3u8"
+
+#EXT-X-STREAM-INF:BANDWIDTH=140800,CODECS="mp4a.40.2"
+audio_128k.m3u8
+`
+	want := `#EXTM3U
+#EXT-X-VERSION:3
+#EXT-X-MEDIA:TYPE=AUDIO,GROUP-ID="group_audio",NAME="audio_und_0",DEFAULT=YES,URI="audio_und_0.m3u8"
+#EXT-X-STREAM-INF:BANDWIDTH=3208000,RESOLUTION=1280x720,CODECS="avc1.64001f,mp4a.40.2",AUDIO="group_audio"
+720p.m3u8
+#EXT-X-STREAM-INF:BANDWIDTH=1008000,RESOLUTION=640x360,CODECS="avc1.64001e,mp4a.40.2",AUDIO="group_audio"
+360p.m3u8
+#EXT-X-STREAM-INF:BANDWIDTH=140800,CODECS="mp4a.40.2"
+audio_128k.m3u8
+`
+	if got := mergeMasterPlaylists(video, audio, 128000); got != want {
+		t.Errorf("mergeMasterPlaylists() =\n%s\nwant\n%s", got, want)
+	}
+	if got := mergeMasterPlaylists(video, "", 128000); got != video {
+		t.Errorf("silent source should keep the video master, got\n%s", got)
+	}
+	if got := mergeMasterPlaylists("", audio, 128000); got != audio {
+		t.Errorf("audio-only job should keep the audio master, got\n%s", got)
+	}
+
+	renditions := parseMasterPlaylist(want)
+	if len(renditions) != 3 || renditions[0].Name != "720p" || renditions[2].ContentType != "audio" {
+		t.Errorf("merged master parses to %+v", renditions)
+	}
+}
+
+func TestHLSAudioArgs(t *testing.T) {
+	audio := audioEncoding{audioOnly: valueobjects.AudioOnlySpec{Bitrates: []int{64}}}
+	args := hlsAudioArgs("in.mp4", "scratch/audio", "out/%v_%03d.ts", testLadder, audio)
+
+	want := "a:0,agroup:audio,language:und,name:audio_und_0,default:yes a:1,name:audio_64k"
+	if got := argValue(args, "-var_stream_map"); got != want {
+		t.Errorf("var_stream_map = %q, want %q", got, want)
+	}
+	for _, a := range args {
+		if strings.HasPrefix(a, "-c:v") || a == "-filter_complex" {
+			t.Fatalf("audio pass encodes video: %v", args)
+		}
+	}
+	if got := argValue(args, "-b:a:0"); got != "128k" {
+		t.Errorf("group bitrate = %q, want the top rung's 128k", got)
+	}
+}
@@ -2,15 +2,14 @@ package transcoding
 
 import (
 	"context"
-	"encoding/json"
-	"io/ioutil"
+	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
 
 	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/keystore"
 	resilience "github.com/serdarburakguneri/hobby-streamer/backend/pkg/resilience"
 	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job"
 	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
@@ -18,50 +17,210 @@ import (
 )
 
 type HLSTranscoder struct {
-	storage job.Storage
+	storage  job.Storage
+	progress job.ProgressReporter
+	keys     keystore.Store
 }
 
-func NewHLSTranscoder(storage job.Storage) *HLSTranscoder {
-	return &HLSTranscoder{storage: storage}
+func NewHLSTranscoder(storage job.Storage, progress job.ProgressReporter, keys keystore.Store) *HLSTranscoder {
+	return &HLSTranscoder{storage: storage, progress: progress, keys: keys}
 }
 
-func (h *HLSTranscoder) ValidateInput(ctx context.Context, job *entity.Job) error {
-	return nil
+func (h *HLSTranscoder) ValidateInput(ctx context.Context, job *entity.Job, localPath string) error {
+	if !job.Encryption().IsZero() && h.keys == nil {
+		return pkgerrors.NewValidationError("HLS encryption is enabled but no key store is configured", nil)
+	}
+	return validateInput(ctx, localPath, job.InputSpec())
 }
 
 func (h *HLSTranscoder) Transcode(ctx context.Context, job *entity.Job, localPath, outputDir string) (string, error) {
 	outputPath := filepath.Join(outputDir, "playlist.m3u8")
-	retryFunc := func(ctx context.Context) error {
-		cmd := exec.CommandContext(ctx, "ffmpeg",
-			"-i", localPath,
-			"-c:v", "libx264",
-			"-c:a", "aac",
-			"-hls_time", "10",
-			"-hls_list_size", "0",
-			"-hls_segment_filename", filepath.Join(outputDir, "segment_%03d.ts"),
-			outputPath)
-		return cmd.Run()
-	}
-	if err := resilience.RetryWithBackoff(ctx, retryFunc, 2); err != nil {
-		return "", pkgerrors.NewInternalError("HLS transcoding failed", err)
-	}
-	if job.Type().IsTranscode() && strings.HasPrefix(job.Output(), "s3://") {
+	var extra []string
+	var key *keystore.ContentKey
+	if !job.Encryption().IsZero() {
+		var keyInfo string
+		var cleanup func()
+		var err error
+		key, keyInfo, cleanup, err = prepareEncryption(job)
+		if err != nil {
+			return "", err
+		}
+		defer cleanup()
+		extra = append(extra, "-hls_key_info_file", keyInfo)
+	}
+	overlay, cleanupOverlay, err := prepareOverlay(ctx, h.storage, job)
+	if err != nil {
+		return "", err
+	}
+	defer cleanupOverlay()
+	if spec := job.SegmentedSpec(); spec.Applies(job.SourceDuration()) {
+		if err := h.transcodeSegmented(ctx, job, localPath, outputDir, spec, overlay, extra); err != nil {
+			return "", err
+		}
+	} else {
+		args := hlsArgs(localPath, outputDir, jobLadder(job), overlay, sourceAudio(ctx, job, localPath), extra...)
+		retryFunc := func(ctx context.Context) error {
+			return runFFmpeg(ctx, args, jobProgress(ctx, h.progress, job))
+		}
+		if err := resilience.RetryWithBackoff(ctx, retryFunc, 2); err != nil {
+			return "", pkgerrors.NewInternalError("HLS transcoding failed", err)
+		}
+	}
+	if err := packageSubtitles(ctx, h.storage, job, outputDir, outputPath, "", mpegtsTimestampMap); err != nil {
+		return "", err
+	}
+	if job.Type().IsTranscode() && h.storage.IsRemote(job.Output()) {
 		if err := h.storage.Upload(ctx, outputDir, job.Output()); err != nil {
-			return "", pkgerrors.NewExternalError("failed to upload HLS output to S3", err)
+			return "", pkgerrors.NewExternalError("failed to upload HLS output", err)
+		}
+	}
+	// The key is only stored once its segments are in place, so a failed or
+	// cancelled job leaves nothing behind.
+	if key != nil {
+		if err := h.keys.Put(ctx, key); err != nil {
+			return "", err
 		}
 	}
 	return outputPath, nil
 }
 
-func (h *HLSTranscoder) ValidateOutput(job *entity.Job) error {
-	if !strings.HasPrefix(job.Output(), "s3://") {
-		return pkgerrors.NewValidationError("output must be an S3 path", nil)
+// prepareEncryption generates a content key for this job's output and writes
+// the ffmpeg key info file. The key is named after the job, so the key of an
+// already-published output of the same video stays valid while this one
+// encodes. The key file stays outside outputDir so it is never uploaded next
+// to the segments.
+func prepareEncryption(job *entity.Job) (*keystore.ContentKey, string, func(), error) {
+	enc := job.Encryption()
+	assetID, videoID, name := job.AssetID().Value(), job.VideoID().Value(), job.ID().Value()
+	key, err := keystore.NewContentKey(assetID, videoID, name, enc.Method)
+	if err != nil {
+		return nil, "", nil, err
 	}
-	parts := strings.SplitN(job.Output()[5:], "/", 2)
-	if len(parts) != 2 {
-		return pkgerrors.NewValidationError("invalid S3 path: "+job.Output(), nil)
+	dir, err := os.MkdirTemp("", "hls-key-")
+	if err != nil {
+		return nil, "", nil, pkgerrors.NewInternalError("failed to create key directory", err)
+	}
+	cleanup := func() { os.RemoveAll(dir) }
+	keyPath := filepath.Join(dir, "content.key")
+	if err := os.WriteFile(keyPath, key.Key, 0600); err != nil {
+		cleanup()
+		return nil, "", nil, pkgerrors.NewInternalError("failed to write content key", err)
 	}
-	return nil
+	keyInfo := filepath.Join(dir, "content.keyinfo")
+	uri := enc.KeyURI(assetID, videoID, name)
+	if err := os.WriteFile(keyInfo, []byte(uri+"\n"+keyPath+"\n"+key.IVHex()+"\n"), 0600); err != nil {
+		cleanup()
+		return nil, "", nil, pkgerrors.NewInternalError("failed to write key info file", err)
+	}
+	return key, keyInfo, cleanup, nil
+}
+
+func hlsArgs(localPath, outputDir string, ladder valueobjects.Ladder, overlay *overlayInput, audio audioEncoding, extra ...string) []string {
+	return hlsOutputArgs([]string{"-i", localPath}, outputDir, filepath.Join(outputDir, "%v_%03d.ts"), ladder, overlay, audio, extra...)
+}
+
+// hlsOutputArgs writes the master and variant playlists to playlistDir and the
+// segments to segmentPattern, which may point elsewhere.
+func hlsOutputArgs(input []string, playlistDir, segmentPattern string, ladder valueobjects.Ladder, overlay *overlayInput, audio audioEncoding, extra ...string) []string {
+	args := append([]string{"-y"}, input...)
+	args = append(args, overlay.inputArgs()...)
+	args = append(args, ladderVideoArgs(ladder, overlay)...)
+	var streamMap []string
+	audioStreams := 0
+	switch {
+	case ladder.IsEmpty():
+		// Audio-only sources get audio variants and nothing else.
+	case audio.tracks.IsMulti():
+		// Each source track becomes one alternate rendition in a shared
+		// audio group; the video variants carry no audio of their own.
+		args = append(args, audioTrackArgs(ladder, audio)...)
+		streamMap = audioGroupStreamMap(audio.tracks)
+		for i, r := range ladder {
+			streamMap = append(streamMap, fmt.Sprintf("v:%d,agroup:%s,name:%s", i, audioGroupID, r.Name))
+		}
+		audioStreams = len(audio.tracks)
+	case audio.silent:
+		for i, r := range ladder {
+			streamMap = append(streamMap, fmt.Sprintf("v:%d,name:%s", i, r.Name))
+		}
+	default:
+		main := audio.mainTrack()
+		for i, r := range ladder {
+			args = append(args,
+				"-map", fmt.Sprintf("0:a:%d", main.Index),
+				fmt.Sprintf("-c:a:%d", i), "aac",
+				fmt.Sprintf("-b:a:%d", i), fmt.Sprintf("%dk", r.AudioBitrate),
+			)
+			args = append(args, audio.filterArgs(i, main)...)
+			streamMap = append(streamMap, fmt.Sprintf("v:%d,a:%d,name:%s", i, i, r.Name))
+		}
+		audioStreams = len(ladder)
+	}
+	args = append(args, audio.audioOnlyArgs(audioStreams)...)
+	streamMap = append(streamMap, audioOnlyStreamMap(audio, audioStreams)...)
+	args = append(args, extra...)
+	return append(args, hlsMuxerArgs(playlistDir, segmentPattern, streamMap)...)
+}
+
+// hlsAudioArgs encodes only the audio of the source: its tracks as alternate
+// renditions in the shared audio group, a single track as a group of one,
+// plus any audio-only variants.
+func hlsAudioArgs(localPath, playlistDir, segmentPattern string, ladder valueobjects.Ladder, audio audioEncoding, extra ...string) []string {
+	args := []string{"-y", "-i", localPath}
+	var streamMap []string
+	if !ladder.IsEmpty() {
+		group := audio
+		if !audio.tracks.IsMulti() {
+			group.tracks = valueobjects.AudioTracks{audio.mainTrack()}
+		}
+		args = append(args, audioTrackArgs(ladder, group)...)
+		streamMap = audioGroupStreamMap(group.tracks)
+	}
+	args = append(args, audio.audioOnlyArgs(len(streamMap))...)
+	streamMap = append(streamMap, audioOnlyStreamMap(audio, len(streamMap))...)
+	args = append(args, extra...)
+	return append(args, hlsMuxerArgs(playlistDir, segmentPattern, streamMap)...)
+}
+
+// audioGroupStreamMap puts every track in the shared audio group.
+func audioGroupStreamMap(tracks valueobjects.AudioTracks) []string {
+	def := tracks.DefaultIndex()
+	streamMap := make([]string, 0, len(tracks))
+	for i, t := range tracks {
+		entry := fmt.Sprintf("a:%d,agroup:%s,language:%s,name:%s", i, audioGroupID, t.LanguageOrUnd(), t.Name())
+		if i == def {
+			entry += ",default:yes"
+		}
+		streamMap = append(streamMap, entry)
+	}
+	return streamMap
+}
+
+// audioOnlyStreamMap lists the audio-only variants, whose output streams
+// follow the first audio streams already mapped.
+func audioOnlyStreamMap(audio audioEncoding, first int) []string {
+	streamMap := make([]string, 0, len(audio.audioOnly.Bitrates))
+	for i, b := range audio.audioOnly.Bitrates {
+		streamMap = append(streamMap, fmt.Sprintf("a:%d,name:%s", first+i, audioOnlyName(b)))
+	}
+	return streamMap
+}
+
+func hlsMuxerArgs(playlistDir, segmentPattern string, streamMap []string) []string {
+	return []string{
+		"-f", "hls",
+		"-hls_time", strconv.Itoa(segmentDuration),
+		"-hls_list_size", "0",
+		"-hls_playlist_type", "vod",
+		"-hls_segment_filename", segmentPattern,
+		"-master_pl_name", "playlist.m3u8",
+		"-var_stream_map", strings.Join(streamMap, " "),
+		filepath.Join(playlistDir, "%v.m3u8"),
+	}
+}
+
+func (h *HLSTranscoder) ValidateOutput(job *entity.Job) error {
+	return validateOutputURL(job)
 }
 
 func (h *HLSTranscoder) ExtractMetadata(ctx context.Context, filePath string, job *entity.Job) (*valueobjects.TranscodeMetadata, error) {
@@ -70,14 +229,8 @@ func (h *HLSTranscoder) ExtractMetadata(ctx context.Context, filePath string, jo
 		return nil, pkgerrors.NewInternalError("failed to stat HLS playlist", err)
 	}
 	outputURL := job.Output()
-	var bucket, key string
-	if strings.HasPrefix(outputURL, "s3://") {
-		parts := strings.SplitN(outputURL[5:], "/", 2)
-		if len(parts) == 2 {
-			bucket = parts[0]
-			key = parts[1]
-		}
-	}
+	loc := outputLocation(job)
+	bucket, key := loc.Bucket, loc.Key
 	metadata := &valueobjects.TranscodeMetadata{
 		OutputURL:   outputURL,
 		Bucket:      bucket,
@@ -86,100 +239,48 @@ func (h *HLSTranscoder) ExtractMetadata(ctx context.Context, filePath string, jo
 		Format:      valueobjects.JobFormatHLS.String(),
 		ContentType: "application/x-mpegURL",
 	}
-	data, err := ioutil.ReadFile(filePath)
+	// The ladder the job was encoded with, after per-title scaling.
+	metadata.Ladder = jobLadder(job)
+	metadata.Complexity = job.Complexity()
+	data, err := os.ReadFile(filePath)
 	if err != nil {
 		return metadata, nil
 	}
-	lines := strings.Split(string(data), "\n")
-	var totalDur float64
-	var segments []string
-	for _, line := range lines {
-		if strings.HasPrefix(line, "#EXTINF:") {
-			parts := strings.SplitN(line, ":", 2)
-			if len(parts) == 2 {
-				durStr := strings.TrimSuffix(parts[1], ",")
-				if d, err := strconv.ParseFloat(durStr, 64); err == nil {
-					totalDur += d
-				}
-			}
-		} else if strings.HasSuffix(line, ".ts") {
-			segments = append(segments, line)
+	baseDir := filepath.Dir(filePath)
+	renditions := parseMasterPlaylist(string(data))
+	for i := range renditions {
+		media, err := os.ReadFile(filepath.Join(baseDir, renditions[i].URI))
+		if err != nil {
+			continue
 		}
+		renditions[i].Segments, renditions[i].Duration = parseMediaPlaylist(string(media))
+		renditions[i].SegmentCount = len(renditions[i].Segments)
 	}
-	count := len(segments)
-	metadata.Segments = segments
-	metadata.SegmentCount = count
-	metadata.Duration = totalDur
-	if count > 0 {
-		metadata.AvgSegmentDuration = totalDur / float64(count)
+	metadata.Renditions = renditions
+	if len(renditions) == 0 {
+		return metadata, nil
 	}
 
-	if count > 0 {
-		firstSeg := segments[0]
-		segPath := firstSeg
-		if !filepath.IsAbs(firstSeg) {
-			segPath = filepath.Join(filepath.Dir(filePath), firstSeg)
-		}
-		var out []byte
-		probe := exec.CommandContext(ctx, "ffprobe",
-			"-v", "quiet",
-			"-print_format", "json",
-			"-show_format",
-			"-show_streams",
-			segPath)
-		out, _ = probe.CombinedOutput()
-		type Stream struct {
-			CodecType    string `json:"codec_type"`
-			CodecName    string `json:"codec_name"`
-			Width        int    `json:"width"`
-			Height       int    `json:"height"`
-			SampleRate   string `json:"sample_rate"`
-			Channels     int    `json:"channels"`
-			RFrameRate   string `json:"r_frame_rate"`
-			AvgFrameRate string `json:"avg_frame_rate"`
-		}
-		var probeResult struct {
-			Format struct {
-				BitRate string `json:"bit_rate"`
-			} `json:"format"`
-			Streams []Stream `json:"streams"`
+	top := renditions[0]
+	for _, r := range renditions[1:] {
+		if r.Height > top.Height || (r.Height == top.Height && r.Bandwidth > top.Bandwidth) {
+			top = r
 		}
-		if len(out) > 0 && json.Unmarshal(out, &probeResult) == nil {
-			for _, s := range probeResult.Streams {
-				if s.CodecType == "video" {
-					if s.Width > 0 {
-						metadata.Width = s.Width
-					}
-					if s.Height > 0 {
-						metadata.Height = s.Height
-					}
-					if s.CodecName != "" {
-						metadata.VideoCodec = s.CodecName
-						metadata.Codec = s.CodecName
-					}
-					if s.AvgFrameRate != "" {
-						metadata.FrameRate = s.AvgFrameRate
-					}
-				} else if s.CodecType == "audio" {
-					if s.Channels > 0 {
-						metadata.AudioChannels = s.Channels
-					}
-					if s.SampleRate != "" {
-						if sr, err := strconv.Atoi(s.SampleRate); err == nil {
-							metadata.AudioSampleRate = sr
-						}
-					}
-					if s.CodecName != "" {
-						metadata.AudioCodec = s.CodecName
-					}
-				}
-			}
-			if probeResult.Format.BitRate != "" {
-				if b, err := strconv.Atoi(probeResult.Format.BitRate); err == nil {
-					metadata.Bitrate = b
-				}
-			}
+	}
+	count := top.SegmentCount
+	metadata.Segments = top.Segments
+	metadata.SegmentCount = count
+	metadata.Duration = top.Duration
+	metadata.Width = top.Width
+	metadata.Height = top.Height
+	metadata.Bitrate = top.Bandwidth
+	if count > 0 {
+		metadata.AvgSegmentDuration = top.Duration / float64(count)
+		segPath := top.Segments[0]
+		if !filepath.IsAbs(segPath) {
+			segPath = filepath.Join(baseDir, filepath.Dir(top.URI), segPath)
 		}
+		applySegmentProbe(ctx, segPath, metadata)
 	}
 	return metadata, nil
 }
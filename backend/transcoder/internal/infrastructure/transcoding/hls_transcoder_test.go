@@ -0,0 +1,40 @@
+package transcoding
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+func TestPrepareEncryption_KeyPerJob(t *testing.T) {
+	assetID, _ := valueobjects.NewAssetID("a1")
+	videoID, _ := valueobjects.NewVideoID("v1")
+	spec, _ := valueobjects.NewEncryptionSpec("", "https://keys.example.com/api/v1/keys")
+
+	var ids []string
+	for _, quality := range []string{"main", "screener"} {
+		job := entity.NewTranscodeJob(*assetID, *videoID, "in.mp4", "s3://bucket/a1/hls/"+quality, quality, valueobjects.JobFormatHLS)
+		job.SetEncryption(*spec)
+
+		key, keyInfo, cleanup, err := prepareEncryption(job)
+		if err != nil {
+			t.Fatalf("prepareEncryption() error: %v", err)
+		}
+		defer cleanup()
+		data, err := os.ReadFile(keyInfo)
+		if err != nil {
+			t.Fatalf("read key info: %v", err)
+		}
+		uri := strings.SplitN(string(data), "\n", 2)[0]
+		if want := spec.KeyURI("a1", "v1", job.ID().Value()); uri != want {
+			t.Errorf("key URI = %q, want %q", uri, want)
+		}
+		ids = append(ids, key.ID)
+	}
+	if ids[0] == ids[1] {
+		t.Errorf("two outputs of one video share key %s", ids[0])
+	}
+}
@@ -0,0 +1,140 @@
+package transcoding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+var (
+	idetPattern   = regexp.MustCompile(`Multi frame detection: TFF:\s*(\d+)\s+BFF:\s*(\d+)\s+Progressive:\s*(\d+)`)
+	vfrdetPattern = regexp.MustCompile(`VFR:([0-9.]+)`)
+)
+
+// validateInput probes the local source and decodes its opening seconds,
+// rejecting it with an InputRejectedError when it breaks the spec.
+func validateInput(ctx context.Context, localPath string, spec valueobjects.InputSpec) error {
+	if !spec.Enabled {
+		return nil
+	}
+	probe, err := probeInput(ctx, localPath)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// ffprobe fails on files it cannot make sense of at all.
+		return pkgerrors.NewValidationError("source rejected", &job.InputRejectedError{
+			Rejections: []valueobjects.InputRejection{{
+				Code:    valueobjects.RejectionCorruptedStream,
+				Message: "source could not be read: " + err.Error(),
+			}},
+		})
+	}
+	if err := decodeCheck(ctx, localPath, spec.DecodeSeconds, probe); err != nil {
+		return err
+	}
+	if rejections := spec.Check(*probe); len(rejections) > 0 {
+		return pkgerrors.NewValidationError("source rejected", &job.InputRejectedError{Rejections: rejections})
+	}
+	return nil
+}
+
+func probeInput(ctx context.Context, localPath string) (*valueobjects.InputProbe, error) {
+	out, err := exec.CommandContext(ctx, "ffprobe",
+		"-v", "error",
+		"-print_format", "json",
+		"-show_format",
+		"-show_streams",
+		localPath).Output()
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Format struct {
+			FormatName string `json:"format_name"`
+			Duration   string `json:"duration"`
+			Size       string `json:"size"`
+		} `json:"format"`
+		Streams []struct {
+			CodecType  string `json:"codec_type"`
+			CodecName  string `json:"codec_name"`
+			Width      int    `json:"width"`
+			Height     int    `json:"height"`
+			FieldOrder string `json:"field_order"`
+			// Cover art shows up as a one-frame video stream.
+			Disposition struct {
+				AttachedPic int `json:"attached_pic"`
+			} `json:"disposition"`
+		} `json:"streams"`
+	}
+	if err := json.Unmarshal(out, &result); err != nil {
+		return nil, fmt.Errorf("parse ffprobe output: %w", err)
+	}
+	probe := &valueobjects.InputProbe{Container: result.Format.FormatName}
+	probe.Duration, _ = strconv.ParseFloat(result.Format.Duration, 64)
+	probe.Size, _ = strconv.ParseInt(result.Format.Size, 10, 64)
+	for _, s := range result.Streams {
+		switch {
+		case s.CodecType == "video" && s.Disposition.AttachedPic == 0 && !probe.HasVideo():
+			probe.VideoCodec = s.CodecName
+			probe.Width = s.Width
+			probe.Height = s.Height
+			probe.FieldOrder = s.FieldOrder
+		case s.CodecType == "audio":
+			probe.AudioCodecs = append(probe.AudioCodecs, s.CodecName)
+		}
+	}
+	return probe, nil
+}
+
+// decodeCheck decodes the first seconds of the source, collecting decoder
+// errors and, for video, the idet and vfrdet frame statistics.
+func decodeCheck(ctx context.Context, localPath string, seconds float64, probe *valueobjects.InputProbe) error {
+	log, err := runFFmpegLog(ctx, decodeCheckArgs(localPath, seconds, probe.HasVideo()), nil)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	for _, line := range strings.Split(log, "\n") {
+		if strings.Contains(line, "[error]") || strings.Contains(line, "[fatal]") {
+			probe.DecodeErrors = append(probe.DecodeErrors, strings.TrimSpace(line))
+		}
+	}
+	if err != nil && len(probe.DecodeErrors) == 0 {
+		probe.DecodeErrors = append(probe.DecodeErrors, "decoder exited: "+err.Error())
+	}
+	if m := idetPattern.FindStringSubmatch(log); m != nil {
+		tff, _ := strconv.Atoi(m[1])
+		bff, _ := strconv.Atoi(m[2])
+		progressive, _ := strconv.Atoi(m[3])
+		if total := tff + bff + progressive; total > 0 {
+			probe.Interlaced = float64(tff+bff) / float64(total)
+		}
+	}
+	if m := vfrdetPattern.FindStringSubmatch(log); m != nil {
+		probe.VFR, _ = strconv.ParseFloat(m[1], 64)
+	}
+	return nil
+}
+
+// decodeCheckArgs prefixes every log line with its level so decoder errors
+// can be told apart from the filters' info output.
+func decodeCheckArgs(localPath string, seconds float64, hasVideo bool) []string {
+	args := []string{
+		"-hide_banner", "-nostats",
+		"-loglevel", "level+info",
+		"-t", formatSeconds(seconds),
+		"-i", localPath,
+	}
+	if hasVideo {
+		args = append(args, "-map", "0:v:0", "-vf", "idet,vfrdet")
+	}
+	return append(args, "-map", "0:a?", "-f", "null", "-")
+}
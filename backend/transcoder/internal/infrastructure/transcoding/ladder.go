@@ -0,0 +1,182 @@
+package transcoding
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+const (
+	segmentDuration = 10
+	audioGroupID    = "audio"
+)
+
+// jobLadder is empty for an audio-only job, which encodes no video.
+func jobLadder(job *entity.Job) valueobjects.Ladder {
+	if job.AudioOnly().Exclusive {
+		return nil
+	}
+	ladder := job.Ladder()
+	if ladder.IsEmpty() {
+		return valueobjects.DefaultLadder()
+	}
+	return ladder.Sorted()
+}
+
+// ladderFilterComplex splits the source, with the overlay drawn on first if
+// there is one, into one scaled stream per rendition.
+func ladderFilterComplex(ladder valueobjects.Ladder, overlay *overlayInput) string {
+	var b strings.Builder
+	source := "[0:v]"
+	if overlay != nil {
+		b.WriteString(overlay.filter("src") + ";")
+		source = "[src]"
+	}
+	fmt.Fprintf(&b, "%ssplit=%d", source, len(ladder))
+	for i := range ladder {
+		fmt.Fprintf(&b, "[v%d]", i)
+	}
+	for i, r := range ladder {
+		fmt.Fprintf(&b, ";[v%d]scale=-2:%d[v%dout]", i, r.Height, i)
+	}
+	return b.String()
+}
+
+func ladderVideoArgs(ladder valueobjects.Ladder, overlay *overlayInput) []string {
+	return ladderVideoArgsEvery(ladder, segmentDuration, overlay)
+}
+
+// ladderVideoArgsEvery forces a keyframe every segment seconds, so segments
+// cut at the same points in every rendition.
+func ladderVideoArgsEvery(ladder valueobjects.Ladder, segment int, overlay *overlayInput) []string {
+	if ladder.IsEmpty() {
+		return nil
+	}
+	args := []string{"-filter_complex", ladderFilterComplex(ladder, overlay)}
+	for i, r := range ladder {
+		args = append(args,
+			"-map", fmt.Sprintf("[v%dout]", i),
+			fmt.Sprintf("-c:v:%d", i), "libx264",
+			fmt.Sprintf("-b:v:%d", i), fmt.Sprintf("%dk", r.VideoBitrate),
+			fmt.Sprintf("-maxrate:v:%d", i), fmt.Sprintf("%dk", r.VideoBitrate*107/100),
+			fmt.Sprintf("-bufsize:v:%d", i), fmt.Sprintf("%dk", r.VideoBitrate*3/2),
+		)
+	}
+	return append(args,
+		"-preset", "veryfast",
+		"-sc_threshold", "0",
+		"-force_key_frames", fmt.Sprintf("expr:gte(t,n_forced*%d)", segment),
+	)
+}
+
+func ladderAudioBitrate(ladder valueobjects.Ladder) int {
+	if top, ok := ladder.Top(); ok && top.AudioBitrate > 0 {
+		return top.AudioBitrate
+	}
+	return 128
+}
+
+// audioEncoding is what the packagers need to encode a job's audio: the
+// source tracks analyze found, the loudness target and any audio-only
+// renditions. silent marks a source without any audio stream, which the
+// outputs then carry no audio for.
+type audioEncoding struct {
+	tracks    valueobjects.AudioTracks
+	normalize valueobjects.LoudnessSpec
+	audioOnly valueobjects.AudioOnlySpec
+	silent    bool
+}
+
+func jobAudio(job *entity.Job) audioEncoding {
+	return audioEncoding{
+		tracks:    job.AudioTracks(),
+		normalize: job.LoudnessSpec(),
+		audioOnly: job.AudioOnly(),
+	}
+}
+
+// sourceAudio is jobAudio checked against the source. Without analyzed
+// tracks the source is probed, since input validation lets a silent source
+// through when require_audio is off and mapping its missing audio would fail.
+func sourceAudio(ctx context.Context, job *entity.Job, localPath string) audioEncoding {
+	audio := jobAudio(job)
+	if len(audio.tracks) > 0 {
+		return audio
+	}
+	if probe, err := probeInput(ctx, localPath); err == nil && len(probe.AudioCodecs) == 0 {
+		audio.silent = true
+		audio.audioOnly = valueobjects.AudioOnlySpec{}
+	}
+	return audio
+}
+
+// mainTrack is the track single-track outputs and audio-only renditions are
+// encoded from. Jobs without analyzed tracks fall back to the first stream.
+func (a audioEncoding) mainTrack() valueobjects.AudioTrack {
+	t, _ := a.tracks.Default()
+	return t
+}
+
+// filterArgs applies the second loudnorm pass to output audio stream out,
+// using the measurement analyze stored on the track. linear=true keeps the
+// gain constant where the measurement allows it instead of compressing the
+// dynamics; loudnorm resamples to 192 kHz internally, hence aresample.
+func (a audioEncoding) filterArgs(out int, t valueobjects.AudioTrack) []string {
+	if !a.normalize.Enabled || t.Loudness == nil {
+		return nil
+	}
+	m := t.Loudness
+	filter := fmt.Sprintf(
+		"loudnorm=I=%g:TP=%g:LRA=%g:measured_I=%g:measured_TP=%g:measured_LRA=%g:measured_thresh=%g:offset=%g:linear=true,aresample=48000",
+		a.normalize.TargetI, a.normalize.TruePeak, a.normalize.LRA,
+		m.Integrated, m.TruePeak, m.LRA, m.Threshold, m.Offset,
+	)
+	return []string{fmt.Sprintf("-filter:a:%d", out), filter}
+}
+
+func audioOnlyName(bitrate int) string {
+	return fmt.Sprintf("audio_%dk", bitrate)
+}
+
+// audioOnlyArgs encodes the main track once per audio-only bitrate, starting
+// at output audio stream first.
+func (a audioEncoding) audioOnlyArgs(first int) []string {
+	t := a.mainTrack()
+	var args []string
+	for i, b := range a.audioOnly.Bitrates {
+		out := first + i
+		args = append(args,
+			"-map", fmt.Sprintf("0:a:%d", t.Index),
+			fmt.Sprintf("-c:a:%d", out), "aac",
+			fmt.Sprintf("-b:a:%d", out), fmt.Sprintf("%dk", b),
+		)
+		args = append(args, a.filterArgs(out, t)...)
+	}
+	return args
+}
+
+// audioTrackArgs encodes every source audio track once, tagged with its
+// language and with the default track flagged in the stream disposition.
+func audioTrackArgs(ladder valueobjects.Ladder, audio audioEncoding) []string {
+	bitrate := fmt.Sprintf("%dk", ladderAudioBitrate(ladder))
+	def := audio.tracks.DefaultIndex()
+	var args []string
+	for i, t := range audio.tracks {
+		disposition := "0"
+		if i == def {
+			disposition = "default"
+		}
+		args = append(args,
+			"-map", fmt.Sprintf("0:a:%d", t.Index),
+			fmt.Sprintf("-c:a:%d", i), "aac",
+			fmt.Sprintf("-b:a:%d", i), bitrate,
+			fmt.Sprintf("-metadata:s:a:%d", i), "language="+t.LanguageOrUnd(),
+			fmt.Sprintf("-disposition:a:%d", i), disposition,
+		)
+		args = append(args, audio.filterArgs(i, t)...)
+	}
+	return args
+}
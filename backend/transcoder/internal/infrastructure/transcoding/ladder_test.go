@@ -0,0 +1,96 @@
+package transcoding
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+var testLadder = valueobjects.Ladder{
+	{Name: "720p", Width: 1280, Height: 720, VideoBitrate: 2800, AudioBitrate: 128},
+	{Name: "360p", Width: 640, Height: 360, VideoBitrate: 800, AudioBitrate: 96},
+}
+
+// argValue returns the value following flag in args.
+func argValue(args []string, flag string) string {
+	for i, a := range args {
+		if a == flag && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+func mapsAudio(args []string) bool {
+	for i, a := range args {
+		if a == "-map" && i+1 < len(args) && strings.HasPrefix(args[i+1], "0:a:") {
+			return true
+		}
+	}
+	return false
+}
+
+func TestHLSArgs_AudioMaps(t *testing.T) {
+	tests := []struct {
+		name      string
+		audio     audioEncoding
+		streamMap string
+		mapsAudio bool
+	}{
+		{
+			name:      "single track",
+			audio:     audioEncoding{},
+			streamMap: "v:0,a:0,name:720p v:1,a:1,name:360p",
+			mapsAudio: true,
+		},
+		{
+			name:      "silent source",
+			audio:     audioEncoding{silent: true},
+			streamMap: "v:0,name:720p v:1,name:360p",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := hlsArgs("in.mp4", "out", testLadder, nil, tt.audio)
+			if got := argValue(args, "-var_stream_map"); got != tt.streamMap {
+				t.Errorf("var_stream_map = %q, want %q", got, tt.streamMap)
+			}
+			if got := mapsAudio(args); got != tt.mapsAudio {
+				t.Errorf("maps audio = %v, want %v", got, tt.mapsAudio)
+			}
+		})
+	}
+}
+
+func TestDASHArgs_AudioMaps(t *testing.T) {
+	tests := []struct {
+		name      string
+		audio     audioEncoding
+		sets      string
+		mapsAudio bool
+	}{
+		{
+			name:      "single track",
+			audio:     audioEncoding{},
+			sets:      "id=0,streams=v id=1,streams=2",
+			mapsAudio: true,
+		},
+		{
+			name:  "silent source",
+			audio: audioEncoding{silent: true},
+			sets:  "id=0,streams=v",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := dashArgs("in.mp4", "out/manifest.mpd", testLadder, nil, tt.audio)
+			if got := argValue(args, "-adaptation_sets"); got != tt.sets {
+				t.Errorf("adaptation_sets = %q, want %q", got, tt.sets)
+			}
+			if got := mapsAudio(args); got != tt.mapsAudio {
+				t.Errorf("maps audio = %v, want %v", got, tt.mapsAudio)
+			}
+		})
+	}
+}
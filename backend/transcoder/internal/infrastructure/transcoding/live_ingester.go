@@ -0,0 +1,310 @@
+package transcoding
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
+	pkgstorage "github.com/serdarburakguneri/hobby-streamer/backend/pkg/storage"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/live"
+)
+
+const (
+	liveSyncInterval = time.Second
+	// liveStopGrace is how long ffmpeg gets to close the playlists and the
+	// archive after SIGINT before it is killed.
+	liveStopGrace = 15 * time.Second
+	// liveFinishTimeout bounds the final sync and the archive upload, which
+	// run after the session's context is gone.
+	liveFinishTimeout = 10 * time.Minute
+)
+
+type LiveConfig struct {
+	// Bucket is where playlists, segments and the archive are written.
+	Bucket string
+	// PublicHost is the host broadcasters connect to; BindHost is what the
+	// listener binds.
+	PublicHost string
+	BindHost   string
+	// Each session listens on its own port from [PortStart, PortStart+PortCount).
+	PortStart int
+	PortCount int
+	Ladder    valueobjects.Ladder
+	// SegmentDuration is in seconds. Live segments are shorter than VOD ones
+	// to keep the delay down.
+	SegmentDuration int
+	// WindowSize is how many segments the live playlists keep.
+	WindowSize int
+	// ConnectTimeout is how long a listener waits for the broadcaster.
+	ConnectTimeout time.Duration
+	WorkDir        string
+}
+
+func (c LiveConfig) withDefaults() LiveConfig {
+	if c.BindHost == "" {
+		c.BindHost = "0.0.0.0"
+	}
+	if c.PublicHost == "" {
+		c.PublicHost = "localhost"
+	}
+	if c.PortStart <= 0 {
+		c.PortStart = 1935
+	}
+	if c.PortCount <= 0 {
+		c.PortCount = 1
+	}
+	if c.Ladder.IsEmpty() {
+		c.Ladder = valueobjects.DefaultLadder()
+	}
+	c.Ladder = c.Ladder.Sorted()
+	if c.SegmentDuration <= 0 {
+		c.SegmentDuration = 4
+	}
+	if c.WindowSize <= 0 {
+		c.WindowSize = 6
+	}
+	if c.ConnectTimeout <= 0 {
+		c.ConnectTimeout = 10 * time.Minute
+	}
+	return c
+}
+
+// LiveIngester listens for one SRT broadcaster per session, encodes
+// the ladder into a sliding-window HLS playlist that is synced to storage as
+// segments are cut, and keeps a copy of the source as the archive.
+type LiveIngester struct {
+	storage *pkgstorage.Router
+	cfg     LiveConfig
+	ports   *portPool
+	logger  *logger.Logger
+}
+
+func NewLiveIngester(storage *pkgstorage.Router, cfg LiveConfig) *LiveIngester {
+	cfg = cfg.withDefaults()
+	return &LiveIngester{
+		storage: storage,
+		cfg:     cfg,
+		ports:   newPortPool(cfg.PortStart, cfg.PortCount),
+		logger:  logger.WithService("live-ingester"),
+	}
+}
+
+func (l *LiveIngester) Ingest(ctx context.Context, s *live.Session, hooks live.Hooks) (*live.Result, error) {
+	port, err := l.ports.acquire()
+	if err != nil {
+		return nil, err
+	}
+	defer l.ports.release(port)
+
+	workDir, err := os.MkdirTemp(l.cfg.WorkDir, "live-"+s.SessionID+"-")
+	if err != nil {
+		return nil, pkgerrors.NewInternalError("failed to create live work directory", err)
+	}
+	defer os.RemoveAll(workDir)
+	hlsDir := filepath.Join(workDir, "hls")
+	if err := os.MkdirAll(hlsDir, 0750); err != nil {
+		return nil, pkgerrors.NewInternalError("failed to create live output directory", err)
+	}
+	archivePath := filepath.Join(workDir, "archive.ts")
+
+	prefix := path.Join(s.AssetID, "live", s.SessionID)
+	syncer := newLiveSync(l.storage, l.storage.Location(l.cfg.Bucket, prefix+"/hls/"), hlsDir)
+
+	input, ingestURL := l.inputArgs(s, port)
+	args := liveArgs(input, hlsDir, archivePath, l.cfg)
+
+	var stderr tailBuffer
+	cmd := exec.Command("ffmpeg", args...)
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return nil, pkgerrors.NewInternalError("failed to start live encoder", err)
+	}
+	if hooks.Listening != nil {
+		hooks.Listening(ingestURL)
+	}
+
+	exited := make(chan error, 1)
+	go func() { exited <- cmd.Wait() }()
+
+	var (
+		startedAt time.Time
+		stopping  bool
+		kill      <-chan time.Time
+		exitErr   error
+	)
+	// Syncing carries on while ffmpeg winds down after a stop.
+	syncCtx := context.WithoutCancel(ctx)
+	ticker := time.NewTicker(liveSyncInterval)
+	defer ticker.Stop()
+	done := ctx.Done()
+loop:
+	for {
+		select {
+		case exitErr = <-exited:
+			break loop
+		case <-done:
+			stopping = true
+			done = nil
+			cmd.Process.Signal(syscall.SIGINT)
+			kill = time.After(liveStopGrace)
+		case <-kill:
+			l.logger.Warn("Live encoder did not stop in time, killing it", "session_id", s.SessionID)
+			cmd.Process.Kill()
+		case <-ticker.C:
+			if err := syncer.run(syncCtx); err != nil {
+				l.logger.WithError(err).Warn("Live sync failed", "session_id", s.SessionID)
+			}
+			if startedAt.IsZero() && syncer.started() {
+				startedAt = time.Now()
+				if hooks.Started != nil {
+					hooks.Started(syncer.playbackURL())
+				}
+			}
+		}
+	}
+
+	finishCtx, cancel := context.WithTimeout(context.Background(), liveFinishTimeout)
+	defer cancel()
+	if err := syncer.run(finishCtx); err != nil {
+		return nil, pkgerrors.NewExternalError("failed to sync final live playlists", err)
+	}
+	if !syncer.started() {
+		if exitErr != nil && !stopping {
+			return nil, pkgerrors.NewExternalError("live encoder failed before the stream started: "+stderr.String(), exitErr)
+		}
+		return nil, pkgerrors.NewValidationError("no stream was received", nil)
+	}
+	if startedAt.IsZero() {
+		startedAt = time.Now()
+	}
+	if exitErr != nil && !stopping {
+		// Broadcasters that drop the connection make ffmpeg exit with an
+		// error; what was encoded up to then is still good.
+		l.logger.WithError(exitErr).Warn("Live encoder exited with an error", "session_id", s.SessionID, "stderr", stderr.String())
+	}
+
+	result := &live.Result{PlaybackURL: syncer.playbackURL(), Duration: time.Since(startedAt)}
+	if info, err := os.Stat(archivePath); err == nil && info.Size() > 0 {
+		archive := l.storage.Location(l.cfg.Bucket, prefix+"/archive.ts")
+		if err := l.storage.Upload(finishCtx, archivePath, archive); err != nil {
+			l.logger.WithError(err).Error("Failed to upload live archive", "session_id", s.SessionID)
+		} else {
+			result.ArchiveURL = archive.String()
+		}
+	}
+	return result, nil
+}
+
+// inputArgs returns the SRT listener input and the URL broadcasters push to.
+// The stream key is the SRT passphrase: the listener refuses callers that do
+// not have it, and it never appears in the ingest URL.
+func (l *LiveIngester) inputArgs(s *live.Session, port int) ([]string, string) {
+	bind := net.JoinHostPort(l.cfg.BindHost, strconv.Itoa(port))
+	public := net.JoinHostPort(l.cfg.PublicHost, strconv.Itoa(port))
+	q := url.Values{}
+	q.Set("mode", "listener")
+	q.Set("passphrase", s.StreamKey)
+	q.Set("listen_timeout", strconv.FormatInt(l.cfg.ConnectTimeout.Microseconds(), 10))
+	return []string{"-i", "srt://" + bind + "?" + q.Encode()}, "srt://" + public
+}
+
+// liveArgs encodes the ladder into a rolling HLS window and, as a second
+// output, copies the source untouched into the archive.
+func liveArgs(input []string, hlsDir, archivePath string, cfg LiveConfig) []string {
+	args := append([]string{"-y"}, input...)
+	args = append(args, ladderVideoArgsEvery(cfg.Ladder, cfg.SegmentDuration, nil)...)
+	args = append(args, "-tune", "zerolatency")
+	streamMap := make([]string, 0, len(cfg.Ladder))
+	for i, r := range cfg.Ladder {
+		args = append(args,
+			"-map", "0:a:0",
+			fmt.Sprintf("-c:a:%d", i), "aac",
+			fmt.Sprintf("-b:a:%d", i), fmt.Sprintf("%dk", r.AudioBitrate),
+		)
+		streamMap = append(streamMap, fmt.Sprintf("v:%d,a:%d,name:%s", i, i, r.Name))
+	}
+	return append(args,
+		"-f", "hls",
+		"-hls_time", strconv.Itoa(cfg.SegmentDuration),
+		"-hls_list_size", strconv.Itoa(cfg.WindowSize),
+		"-hls_delete_threshold", "2",
+		"-hls_flags", "delete_segments+independent_segments+temp_file+program_date_time",
+		"-hls_segment_filename", filepath.Join(hlsDir, "%v_%05d.ts"),
+		"-master_pl_name", "playlist.m3u8",
+		"-var_stream_map", strings.Join(streamMap, " "),
+		filepath.Join(hlsDir, "%v.m3u8"),
+		"-map", "0:v:0",
+		"-map", "0:a:0?",
+		"-c", "copy",
+		"-f", "mpegts",
+		archivePath,
+	)
+}
+
+// portPool hands out listener ports so concurrent sessions never collide.
+type portPool struct {
+	mu    sync.Mutex
+	start int
+	count int
+	used  map[int]bool
+}
+
+func newPortPool(start, count int) *portPool {
+	return &portPool{start: start, count: count, used: map[int]bool{}}
+}
+
+func (p *portPool) acquire() (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for port := p.start; port < p.start+p.count; port++ {
+		if !p.used[port] {
+			p.used[port] = true
+			return port, nil
+		}
+	}
+	return 0, pkgerrors.NewConflictError("no free live ingest port", nil)
+}
+
+func (p *portPool) release(port int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.used, port)
+}
+
+// tailBuffer keeps the end of ffmpeg's log, which is where the reason for a
+// failure is.
+type tailBuffer struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+const tailBufferSize = 4096
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > tailBufferSize {
+		b.buf = b.buf[len(b.buf)-tailBufferSize:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return strings.TrimSpace(string(b.buf))
+}
@@ -0,0 +1,108 @@
+package transcoding
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	pkgstorage "github.com/serdarburakguneri/hobby-streamer/backend/pkg/storage"
+)
+
+const liveMasterPlaylist = "playlist.m3u8"
+
+// liveSync mirrors a live HLS directory to storage. Segments go up before the
+// playlists that list them, and segments ffmpeg drops from the window are
+// deleted from storage as well, so storage holds roughly one window.
+type liveSync struct {
+	storage   *pkgstorage.Router
+	dest      pkgstorage.Location
+	dir       string
+	segments  map[string]bool
+	playlists map[string]time.Time
+}
+
+func newLiveSync(storage *pkgstorage.Router, dest pkgstorage.Location, dir string) *liveSync {
+	return &liveSync{
+		storage:   storage,
+		dest:      dest,
+		dir:       dir,
+		segments:  map[string]bool{},
+		playlists: map[string]time.Time{},
+	}
+}
+
+func (s *liveSync) run(ctx context.Context) error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	local := map[string]bool{}
+	var playlists []os.DirEntry
+	for _, e := range entries {
+		name := e.Name()
+		switch filepath.Ext(name) {
+		case ".ts":
+			local[name] = true
+			if !s.segments[name] {
+				if err := s.upload(ctx, name); err != nil {
+					return err
+				}
+				s.segments[name] = true
+			}
+		case ".m3u8":
+			playlists = append(playlists, e)
+		}
+	}
+
+	// Variant playlists first, so the master never points at one that is
+	// not there yet.
+	sort.Slice(playlists, func(i, j int) bool {
+		return playlists[j].Name() == liveMasterPlaylist && playlists[i].Name() != liveMasterPlaylist
+	})
+	for _, e := range playlists {
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		if s.playlists[e.Name()].Equal(info.ModTime()) {
+			continue
+		}
+		if err := s.upload(ctx, e.Name()); err != nil {
+			return err
+		}
+		s.playlists[e.Name()] = info.ModTime()
+	}
+
+	for name := range s.segments {
+		if local[name] {
+			continue
+		}
+		if err := s.storage.DeletePrefix(ctx, s.location(name)); err != nil {
+			return err
+		}
+		delete(s.segments, name)
+	}
+	return nil
+}
+
+// started reports whether viewers can play: the master playlist and at least
+// one segment are in storage.
+func (s *liveSync) started() bool {
+	_, ok := s.playlists[liveMasterPlaylist]
+	return ok && len(s.segments) > 0
+}
+
+func (s *liveSync) playbackURL() string {
+	return s.location(liveMasterPlaylist).String()
+}
+
+func (s *liveSync) upload(ctx context.Context, name string) error {
+	return s.storage.Upload(ctx, filepath.Join(s.dir, name), s.location(name))
+}
+
+func (s *liveSync) location(name string) pkgstorage.Location {
+	return s.dest.WithKey(strings.TrimSuffix(s.dest.Key, "/") + "/" + name)
+}
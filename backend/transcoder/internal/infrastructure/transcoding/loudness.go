@@ -0,0 +1,70 @@
+package transcoding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+// measureLoudness runs the first loudnorm pass over one source audio track
+// and returns what it measured against the spec's target.
+func measureLoudness(ctx context.Context, localPath string, track int, spec valueobjects.LoudnessSpec) (*valueobjects.Loudness, error) {
+	log, err := runFFmpegLog(ctx, loudnessArgs(localPath, track, spec), nil)
+	if err != nil {
+		return nil, fmt.Errorf("loudnorm pass on audio track %d: %w", track, err)
+	}
+	return parseLoudnormLog(log)
+}
+
+func loudnessArgs(localPath string, track int, spec valueobjects.LoudnessSpec) []string {
+	return []string{
+		"-hide_banner", "-nostats",
+		"-i", localPath,
+		"-map", fmt.Sprintf("0:a:%d", track),
+		"-af", fmt.Sprintf("loudnorm=I=%g:TP=%g:LRA=%g:print_format=json", spec.TargetI, spec.TruePeak, spec.LRA),
+		"-f", "null", "-",
+	}
+}
+
+// parseLoudnormLog reads the JSON block loudnorm prints at the end of the
+// log. It reports every value as a string.
+func parseLoudnormLog(log string) (*valueobjects.Loudness, error) {
+	start := strings.LastIndex(log, "{")
+	end := strings.LastIndex(log, "}")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no loudnorm summary in ffmpeg output")
+	}
+	var raw struct {
+		InputI      string `json:"input_i"`
+		InputTP     string `json:"input_tp"`
+		InputLRA    string `json:"input_lra"`
+		InputThresh string `json:"input_thresh"`
+		Offset      string `json:"target_offset"`
+	}
+	if err := json.Unmarshal([]byte(log[start:end+1]), &raw); err != nil {
+		return nil, fmt.Errorf("parse loudnorm summary: %w", err)
+	}
+	var l valueobjects.Loudness
+	for _, f := range []struct {
+		value string
+		dst   *float64
+	}{
+		{raw.InputI, &l.Integrated},
+		{raw.InputTP, &l.TruePeak},
+		{raw.InputLRA, &l.LRA},
+		{raw.InputThresh, &l.Threshold},
+		{raw.Offset, &l.Offset},
+	} {
+		v, err := strconv.ParseFloat(f.value, 64)
+		if err != nil {
+			// Silent tracks measure as -inf, which a second pass cannot use.
+			return nil, fmt.Errorf("unusable loudnorm value %q", f.value)
+		}
+		*f.dst = v
+	}
+	return &l, nil
+}
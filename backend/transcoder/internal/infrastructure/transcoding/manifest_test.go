@@ -0,0 +1,127 @@
+package transcoding
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+	return data
+}
+
+func TestParseMPD(t *testing.T) {
+	tests := []struct {
+		fixture    string
+		renditions []valueobjects.RenditionMetadata
+		duration   float64
+	}{
+		{
+			fixture: "dash_single_audio.mpd",
+			renditions: []valueobjects.RenditionMetadata{
+				{Name: "0", ContentType: "video", Width: 1920, Height: 1080, Bandwidth: 5000000, Codecs: "avc1.640028"},
+				{Name: "1", ContentType: "video", Width: 1280, Height: 720, Bandwidth: 2800000, Codecs: "avc1.64001f"},
+				{Name: "2", ContentType: "audio", Bandwidth: 128000, Codecs: "mp4a.40.2"},
+			},
+			duration: 3723.5,
+		},
+		{
+			// Codecs and content type fall back to the adaptation set, and
+			// then to the presence of a height.
+			fixture: "dash_multi_audio.mpd",
+			renditions: []valueobjects.RenditionMetadata{
+				{Name: "0", ContentType: "video", Width: 640, Height: 360, Bandwidth: 800000, Codecs: "avc1.64001e"},
+				{Name: "1", ContentType: "audio", Bandwidth: 96000, Codecs: "mp4a.40.2"},
+				{Name: "2", ContentType: "audio", Bandwidth: 96000, Codecs: "mp4a.40.2"},
+			},
+			duration: 45.12,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			renditions, duration, err := parseMPD(readFixture(t, tt.fixture))
+			if err != nil {
+				t.Fatalf("parseMPD() error: %v", err)
+			}
+			if !reflect.DeepEqual(renditions, tt.renditions) {
+				t.Errorf("renditions = %+v, want %+v", renditions, tt.renditions)
+			}
+			if duration != tt.duration {
+				t.Errorf("duration = %v, want %v", duration, tt.duration)
+			}
+		})
+	}
+
+	if _, _, err := parseMPD([]byte("<MPD><Period>")); err == nil {
+		t.Error("expected an error for a truncated manifest")
+	}
+}
+
+func TestParseISODuration(t *testing.T) {
+	tests := map[string]float64{
+		"PT10S":       10,
+		"PT1H2M3.5S":  3723.5,
+		"P1DT1S":      86401,
+		"PT0.0S":      0,
+		"10 seconds":  0,
+		"":            0,
+		"PT2M":        120,
+		"PT1.5H":      5400,
+		"P1DT2H3M4.5": 0,
+	}
+	for value, want := range tests {
+		if got := parseISODuration(value); got != want {
+			t.Errorf("parseISODuration(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
+
+func TestParseMasterPlaylist(t *testing.T) {
+	tests := []struct {
+		fixture    string
+		renditions []valueobjects.RenditionMetadata
+	}{
+		{
+			fixture: "hls_master_muxed.m3u8",
+			renditions: []valueobjects.RenditionMetadata{
+				{Name: "1080p", URI: "1080p.m3u8", Width: 1920, Height: 1080, Bandwidth: 5640800, Codecs: "avc1.640028,mp4a.40.2"},
+				{Name: "720p", URI: "720p.m3u8", Width: 1280, Height: 720, Bandwidth: 3220800, Codecs: "avc1.64001f,mp4a.40.2"},
+				{Name: "audio_128k", URI: "audio_128k.m3u8", ContentType: "audio", Bandwidth: 140800, Codecs: "mp4a.40.2"},
+			},
+		},
+		{
+			// Alternate audio renditions are EXT-X-MEDIA entries, not
+			// variants; a quoted attribute may hold commas.
+			fixture: "hls_master_audio_group.m3u8",
+			renditions: []valueobjects.RenditionMetadata{
+				{Name: "720p", URI: "video/720p.m3u8", Width: 1280, Height: 720, Bandwidth: 3220800, Codecs: "avc1.64001f,mp4a.40.2"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			renditions := parseMasterPlaylist(string(readFixture(t, tt.fixture)))
+			if !reflect.DeepEqual(renditions, tt.renditions) {
+				t.Errorf("renditions = %+v, want %+v", renditions, tt.renditions)
+			}
+		})
+	}
+}
+
+func TestParseMediaPlaylist(t *testing.T) {
+	segments, duration := parseMediaPlaylist(chunk1)
+	if want := []string{"720p_c001_000.ts", "720p_c001_001.ts"}; !reflect.DeepEqual(segments, want) {
+		t.Errorf("segments = %v, want %v", segments, want)
+	}
+	if duration < 14.59 || duration > 14.61 {
+		t.Errorf("duration = %v, want 14.6", duration)
+	}
+}
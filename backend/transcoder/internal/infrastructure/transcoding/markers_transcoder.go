@@ -0,0 +1,169 @@
+package transcoding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+	resilience "github.com/serdarburakguneri/hobby-streamer/backend/pkg/resilience"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+const markersFileName = "markers.json"
+
+var (
+	scenePattern        = regexp.MustCompile(`pts_time:\s*([0-9.]+)`)
+	blackPattern        = regexp.MustCompile(`black_start:\s*([0-9.]+)\s+black_end:\s*([0-9.]+)`)
+	silenceStartPattern = regexp.MustCompile(`silence_start:\s*(-?[0-9.]+)`)
+	silenceEndPattern   = regexp.MustCompile(`silence_end:\s*([0-9.]+)`)
+)
+
+// MarkersTranscoder runs ffmpeg's scene, black and silence detectors over the
+// source and writes the proposed chapters, intro and credits to markers.json.
+type MarkersTranscoder struct {
+	storage  job.Storage
+	progress job.ProgressReporter
+}
+
+func NewMarkersTranscoder(storage job.Storage, progress job.ProgressReporter) *MarkersTranscoder {
+	return &MarkersTranscoder{storage: storage, progress: progress}
+}
+
+func (t *MarkersTranscoder) ValidateInput(ctx context.Context, job *entity.Job, localPath string) error {
+	return nil
+}
+
+func (t *MarkersTranscoder) Transcode(ctx context.Context, job *entity.Job, localPath, outputDir string) (string, error) {
+	spec := jobMarkerSpec(job)
+	duration := job.SourceDuration()
+	if duration <= 0 {
+		_, _, duration = probeDimensions(ctx, localPath)
+	}
+
+	var log string
+	args := detectionArgs(localPath, spec)
+	retryFunc := func(ctx context.Context) error {
+		var err error
+		log, err = runFFmpegLog(ctx, args, jobProgress(ctx, t.progress, job))
+		return err
+	}
+	if err := resilience.RetryWithBackoff(ctx, retryFunc, 2); err != nil {
+		return "", pkgerrors.NewInternalError("marker detection failed", err)
+	}
+
+	scenes, blacks, silences := parseDetections(log, duration)
+	markers := spec.ProposeMarkers(duration, scenes, blacks, silences)
+	data, err := json.Marshal(markers)
+	if err != nil {
+		return "", pkgerrors.NewInternalError("failed to encode markers", err)
+	}
+	outputPath := filepath.Join(outputDir, markersFileName)
+	if err := os.WriteFile(outputPath, data, 0640); err != nil {
+		return "", pkgerrors.NewInternalError("failed to write markers", err)
+	}
+
+	if job.Type().IsTranscode() && t.storage.IsRemote(job.Output()) {
+		if err := t.storage.Upload(ctx, outputDir, job.Output()); err != nil {
+			return "", pkgerrors.NewExternalError("failed to upload markers", err)
+		}
+	}
+	return outputPath, nil
+}
+
+func jobMarkerSpec(job *entity.Job) valueobjects.MarkerSpec {
+	if spec := job.MarkerSpec(); !spec.IsZero() {
+		return spec
+	}
+	return valueobjects.DefaultMarkerSpec()
+}
+
+// Scene changes are selected and logged by showinfo after blackdetect has
+// seen every frame; silencedetect runs on the audio in the same pass.
+func detectionArgs(localPath string, spec valueobjects.MarkerSpec) []string {
+	videoFilter := fmt.Sprintf("blackdetect=d=0.5:pix_th=0.10,select='gt(scene,%s)',showinfo",
+		strconv.FormatFloat(spec.SceneThreshold, 'f', 2, 64))
+	return []string{"-hide_banner",
+		"-i", localPath,
+		"-vf", videoFilter,
+		"-af", "silencedetect=noise=-50dB:d=0.5",
+		"-f", "null",
+		"-",
+	}
+}
+
+// parseDetections reads the filter log. A silence still open at the end of
+// the input is closed at duration.
+func parseDetections(log string, duration float64) ([]float64, []valueobjects.Interval, []valueobjects.Interval) {
+	var scenes []float64
+	var blacks, silences []valueobjects.Interval
+	silenceStart := -1.0
+	for _, line := range strings.Split(log, "\n") {
+		switch {
+		case strings.Contains(line, "Parsed_showinfo"):
+			if m := scenePattern.FindStringSubmatch(line); m != nil {
+				if v, err := strconv.ParseFloat(m[1], 64); err == nil {
+					scenes = append(scenes, v)
+				}
+			}
+		case strings.Contains(line, "black_start"):
+			if m := blackPattern.FindStringSubmatch(line); m != nil {
+				start, _ := strconv.ParseFloat(m[1], 64)
+				end, _ := strconv.ParseFloat(m[2], 64)
+				blacks = append(blacks, valueobjects.Interval{Start: start, End: end})
+			}
+		case strings.Contains(line, "silence_start"):
+			if m := silenceStartPattern.FindStringSubmatch(line); m != nil {
+				if v, err := strconv.ParseFloat(m[1], 64); err == nil {
+					silenceStart = max(v, 0)
+				}
+			}
+		case strings.Contains(line, "silence_end"):
+			if m := silenceEndPattern.FindStringSubmatch(line); m != nil && silenceStart >= 0 {
+				if v, err := strconv.ParseFloat(m[1], 64); err == nil {
+					silences = append(silences, valueobjects.Interval{Start: silenceStart, End: v})
+				}
+				silenceStart = -1
+			}
+		}
+	}
+	if silenceStart >= 0 && duration > silenceStart {
+		silences = append(silences, valueobjects.Interval{Start: silenceStart, End: duration})
+	}
+	return scenes, blacks, silences
+}
+
+func (t *MarkersTranscoder) ValidateOutput(job *entity.Job) error {
+	return validateOutputURL(job)
+}
+
+func (t *MarkersTranscoder) ExtractMetadata(ctx context.Context, filePath string, job *entity.Job) (*valueobjects.TranscodeMetadata, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, pkgerrors.NewInternalError("failed to read markers", err)
+	}
+	var markers []valueobjects.Marker
+	if err := json.Unmarshal(data, &markers); err != nil {
+		return nil, pkgerrors.NewInternalError("failed to parse markers", err)
+	}
+	outputURL := job.Output()
+	loc := outputLocation(job)
+	bucket, key := loc.Bucket, loc.Key
+	return &valueobjects.TranscodeMetadata{
+		OutputURL:   outputURL,
+		Bucket:      bucket,
+		Key:         key,
+		Size:        int64(len(data)),
+		Duration:    job.SourceDuration(),
+		Format:      valueobjects.JobFormatMarkers.String(),
+		ContentType: "application/json",
+		Markers:     markers,
+	}, nil
+}
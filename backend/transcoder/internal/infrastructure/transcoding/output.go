@@ -0,0 +1,21 @@
+package transcoding
+
+import (
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+	pkgstorage "github.com/serdarburakguneri/hobby-streamer/backend/pkg/storage"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
+)
+
+// outputLocation is the storage location of a job's output. It is zero when
+// the output is a plain local path.
+func outputLocation(job *entity.Job) pkgstorage.Location {
+	loc, _ := pkgstorage.ParseURL(job.Output())
+	return loc
+}
+
+func validateOutputURL(job *entity.Job) error {
+	if _, err := pkgstorage.ParseURL(job.Output()); err != nil {
+		return pkgerrors.NewValidationError("output must be an s3://, file:// or mem:// URL: "+job.Output(), err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package transcoding
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+// Overlay geometry as fractions of the source frame height, so a logo or a
+// line of text covers the same share of the picture whatever the source
+// resolution is.
+const (
+	overlayLogoHeight = 0.1
+	overlayMargin     = 0.04
+	overlayTextSize   = 0.035
+	overlayTextGap    = 0.01
+)
+
+// overlayInput is a job's overlay ready for ffmpeg. The logo is read as the
+// input after the source; the text is read from a file so it never has to be
+// escaped for the filtergraph.
+type overlayInput struct {
+	spec     valueobjects.OverlaySpec
+	image    string
+	textFile string
+}
+
+// prepareOverlay downloads the logo and writes the text file. It returns nil
+// when the job has no overlay or encodes no video to draw it on.
+func prepareOverlay(ctx context.Context, storage job.Storage, job *entity.Job) (*overlayInput, func(), error) {
+	spec := job.Overlay()
+	if spec.IsZero() || jobLadder(job).IsEmpty() {
+		return nil, func() {}, nil
+	}
+	dir, err := os.MkdirTemp("", "overlay-")
+	if err != nil {
+		return nil, nil, pkgerrors.NewInternalError("failed to create overlay directory", err)
+	}
+	var downloaded string
+	cleanup := func() {
+		if downloaded != "" {
+			storage.Remove(downloaded)
+		}
+		os.RemoveAll(dir)
+	}
+	in := &overlayInput{spec: spec}
+	if spec.HasImage() {
+		local, err := storage.Download(ctx, spec.Image)
+		if err != nil {
+			cleanup()
+			return nil, nil, pkgerrors.NewExternalError("failed to download overlay image "+spec.Image, err)
+		}
+		if local != spec.Image {
+			downloaded = local
+		}
+		in.image = local
+	}
+	if spec.HasText() {
+		in.textFile = filepath.Join(dir, "text.txt")
+		if err := os.WriteFile(in.textFile, []byte(spec.Text), 0600); err != nil {
+			cleanup()
+			return nil, nil, pkgerrors.NewInternalError("failed to write overlay text", err)
+		}
+	}
+	return in, cleanup, nil
+}
+
+func (o *overlayInput) inputArgs() []string {
+	if o == nil || o.image == "" {
+		return nil
+	}
+	return []string{"-i", o.image}
+}
+
+// filter draws the overlay on the source video and labels the result out.
+// The logo is scaled against the source frame and faded to the opacity; text
+// shares the logo's corner, on the side of it facing the middle of the frame.
+func (o *overlayInput) filter(out string) string {
+	var b strings.Builder
+	base := "[0:v]"
+	if o.image != "" {
+		x, y := overlayPosition(o.spec.Position, "W", "H", "w", "h", 0)
+		fmt.Fprintf(&b, "[1:v]format=rgba,colorchannelmixer=aa=%g[ovlogo];", o.spec.Opacity)
+		// In scale2ref ih is the reference frame's height and mdar the
+		// logo's own aspect ratio.
+		fmt.Fprintf(&b, "[ovlogo][0:v]scale2ref=w=oh*mdar:h=ih*%g[ovscaled][ovbase];", overlayLogoHeight)
+		fmt.Fprintf(&b, "[ovbase][ovscaled]overlay=x=%s:y=%s", x, y)
+		base = ""
+	}
+	if o.textFile != "" {
+		shift := 0.0
+		if o.image != "" {
+			shift = overlayLogoHeight + overlayTextGap
+		}
+		x, y := overlayPosition(o.spec.Position, "w", "h", "tw", "th", shift)
+		if base == "" {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b,
+			"%sdrawtext=textfile='%s':expansion=none:fontsize=h*%g:fontcolor=white@%g:box=1:boxcolor=black@%g:boxborderw=8:x=%s:y=%s",
+			base, o.textFile, overlayTextSize, o.spec.Opacity, o.spec.Opacity/2, x, y,
+		)
+	}
+	fmt.Fprintf(&b, "[%s]", out)
+	return b.String()
+}
+
+// overlayPosition places an item of size w×h on a frame of size W×H, using
+// whatever names the filter gives those. shift moves the item towards the
+// middle of the frame by that share of the frame height.
+func overlayPosition(pos valueobjects.OverlayPosition, W, H, w, h string, shift float64) (string, string) {
+	margin := fmt.Sprintf("%s*%g", H, overlayMargin)
+	offset := ""
+	if shift > 0 {
+		offset = fmt.Sprintf("%s*%g", H, shift)
+	}
+	var x, y string
+	switch {
+	case pos.IsLeft():
+		x = margin
+	case pos.IsRight():
+		x = fmt.Sprintf("%s-%s-%s", W, w, margin)
+	default:
+		x = fmt.Sprintf("(%s-%s)/2", W, w)
+	}
+	switch {
+	case pos.IsTop():
+		y = margin
+		if offset != "" {
+			y += "+" + offset
+		}
+	case pos.IsBottom():
+		y = fmt.Sprintf("%s-%s-%s", H, h, margin)
+		if offset != "" {
+			y += "-" + offset
+		}
+	default:
+		y = fmt.Sprintf("(%s-%s)/2", H, h)
+		if offset != "" {
+			y += "+" + offset
+		}
+	}
+	return x, y
+}
@@ -0,0 +1,99 @@
+package transcoding
+
+import (
+	"context"
+	"encoding/json"
+	"os/exec"
+	"strconv"
+
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+func applySegmentProbe(ctx context.Context, segPath string, metadata *valueobjects.TranscodeMetadata) {
+	probe := exec.CommandContext(ctx, "ffprobe",
+		"-v", "quiet",
+		"-print_format", "json",
+		"-show_format",
+		"-show_streams",
+		segPath)
+	out, _ := probe.CombinedOutput()
+	type Stream struct {
+		CodecType    string `json:"codec_type"`
+		CodecName    string `json:"codec_name"`
+		Width        int    `json:"width"`
+		Height       int    `json:"height"`
+		SampleRate   string `json:"sample_rate"`
+		Channels     int    `json:"channels"`
+		RFrameRate   string `json:"r_frame_rate"`
+		AvgFrameRate string `json:"avg_frame_rate"`
+	}
+	var probeResult struct {
+		Format struct {
+			BitRate string `json:"bit_rate"`
+		} `json:"format"`
+		Streams []Stream `json:"streams"`
+	}
+	if len(out) == 0 || json.Unmarshal(out, &probeResult) != nil {
+		return
+	}
+	for _, s := range probeResult.Streams {
+		if s.CodecType == "video" {
+			if s.Width > 0 {
+				metadata.Width = s.Width
+			}
+			if s.Height > 0 {
+				metadata.Height = s.Height
+			}
+			if s.CodecName != "" {
+				metadata.VideoCodec = s.CodecName
+				metadata.Codec = s.CodecName
+			}
+			if s.AvgFrameRate != "" {
+				metadata.FrameRate = s.AvgFrameRate
+			}
+		} else if s.CodecType == "audio" {
+			if s.Channels > 0 {
+				metadata.AudioChannels = s.Channels
+			}
+			if s.SampleRate != "" {
+				if sr, err := strconv.Atoi(s.SampleRate); err == nil {
+					metadata.AudioSampleRate = sr
+				}
+			}
+			if s.CodecName != "" {
+				metadata.AudioCodec = s.CodecName
+			}
+		}
+	}
+	if probeResult.Format.BitRate != "" {
+		if b, err := strconv.Atoi(probeResult.Format.BitRate); err == nil {
+			metadata.Bitrate = b
+		}
+	}
+}
+
+func probeDimensions(ctx context.Context, path string) (int, int, float64) {
+	out, err := exec.CommandContext(ctx, "ffprobe",
+		"-v", "quiet",
+		"-print_format", "json",
+		"-select_streams", "v:0",
+		"-show_entries", "stream=width,height:format=duration",
+		path).Output()
+	if err != nil {
+		return 0, 0, 0
+	}
+	var result struct {
+		Streams []struct {
+			Width  int `json:"width"`
+			Height int `json:"height"`
+		} `json:"streams"`
+		Format struct {
+			Duration string `json:"duration"`
+		} `json:"format"`
+	}
+	if json.Unmarshal(out, &result) != nil || len(result.Streams) == 0 {
+		return 0, 0, 0
+	}
+	duration, _ := strconv.ParseFloat(result.Format.Duration, 64)
+	return result.Streams[0].Width, result.Streams[0].Height, duration
+}
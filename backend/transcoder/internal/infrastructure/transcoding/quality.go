@@ -0,0 +1,175 @@
+package transcoding
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/logger"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+// PSNR between identical frames is infinite; report it as this instead.
+const maxPSNR = 100.0
+
+var (
+	psnrPattern = regexp.MustCompile(`PSNR .*average:(inf|[0-9.]+)`)
+	ssimPattern = regexp.MustCompile(`SSIM .*All:([0-9.]+)`)
+	vmafPattern = regexp.MustCompile(`VMAF score[:=]\s*([0-9.]+)`)
+)
+
+// FFmpegQualityVerifier compares each HLS variant playlist in the output
+// directory with the source on the job's sample windows. Outputs are scaled
+// up to the source resolution before comparison.
+type FFmpegQualityVerifier struct {
+	vmafOnce sync.Once
+	hasVMAF  bool
+	logger   *logger.Logger
+}
+
+func NewQualityVerifier() *FFmpegQualityVerifier {
+	return &FFmpegQualityVerifier{logger: logger.WithService("quality-verifier")}
+}
+
+func (v *FFmpegQualityVerifier) Measure(ctx context.Context, job *entity.Job, sourcePath, outputPath string, metadata *valueobjects.TranscodeMetadata) error {
+	if !job.Encryption().IsZero() {
+		// The key is gone by now and the playlists point at streaming-api.
+		return pkgerrors.NewValidationError("encrypted renditions cannot be measured", nil)
+	}
+	duration := metadata.Duration
+	if duration <= 0 {
+		duration = job.SourceDuration()
+	}
+	windows := job.QualitySpec().Windows(duration)
+	if len(windows) == 0 {
+		return pkgerrors.NewValidationError("no duration to sample quality windows from", nil)
+	}
+
+	baseDir := filepath.Dir(outputPath)
+	measured := 0
+	for i := range metadata.Renditions {
+		r := &metadata.Renditions[i]
+		if r.ContentType == "audio" || !strings.HasSuffix(r.URI, ".m3u8") {
+			continue
+		}
+		scores, err := v.measureRendition(ctx, filepath.Join(baseDir, r.URI), sourcePath, windows)
+		if err != nil {
+			v.logger.WithError(err).Warn("Quality measurement failed", "job_id", job.ID().Value(), "rendition", r.Name)
+			return pkgerrors.NewInternalError("quality measurement failed for "+r.Name, err)
+		}
+		r.Quality = scores
+		measured++
+	}
+	if measured == 0 {
+		return pkgerrors.NewValidationError("no renditions with a local playlist to measure", nil)
+	}
+	v.logger.Info("Quality measured", "job_id", job.ID().Value(), "renditions", measured, "windows", len(windows), "vmaf", v.vmafAvailable(ctx))
+	return nil
+}
+
+func (v *FFmpegQualityVerifier) measureRendition(ctx context.Context, playlist, source string, windows []valueobjects.Interval) (*valueobjects.QualityScores, error) {
+	withVMAF := v.vmafAvailable(ctx)
+	var psnr, ssim, vmaf float64
+	for _, w := range windows {
+		log, err := runFFmpegLog(ctx, qualityArgs(playlist, source, w, withVMAF), nil)
+		if err != nil {
+			return nil, err
+		}
+		p, s, m, err := parseQualityLog(log, withVMAF)
+		if err != nil {
+			return nil, err
+		}
+		psnr += p
+		ssim += s
+		vmaf += m
+	}
+	n := float64(len(windows))
+	scores := &valueobjects.QualityScores{PSNR: psnr / n, SSIM: ssim / n}
+	if withVMAF {
+		avg := vmaf / n
+		scores.VMAF = &avg
+	}
+	return scores, nil
+}
+
+// qualityArgs decodes the same window from the rendition (distorted) and the
+// source (reference), scales the rendition to the source size and runs the
+// metric filters side by side in one pass.
+func qualityArgs(playlist, source string, window valueobjects.Interval, withVMAF bool) []string {
+	start := formatSeconds(window.Start)
+	length := formatSeconds(window.End - window.Start)
+	metrics := []string{"psnr", "ssim"}
+	if withVMAF {
+		metrics = append(metrics, "libvmaf")
+	}
+	n := len(metrics)
+
+	var graph strings.Builder
+	graph.WriteString("[0:v][1:v]scale2ref=flags=bicubic[dist][ref];")
+	fmt.Fprintf(&graph, "[dist]setpts=PTS-STARTPTS,split=%d", n)
+	for i := range metrics {
+		fmt.Fprintf(&graph, "[d%d]", i)
+	}
+	fmt.Fprintf(&graph, ";[ref]setpts=PTS-STARTPTS,split=%d", n)
+	for i := range metrics {
+		fmt.Fprintf(&graph, "[r%d]", i)
+	}
+	for i, metric := range metrics {
+		fmt.Fprintf(&graph, ";[d%d][r%d]%s", i, i, metric)
+	}
+
+	return []string{
+		"-hide_banner",
+		"-ss", start, "-t", length, "-i", playlist,
+		"-ss", start, "-t", length, "-i", source,
+		"-lavfi", graph.String(),
+		"-an", "-f", "null", "-",
+	}
+}
+
+func parseQualityLog(log string, withVMAF bool) (psnr, ssim, vmaf float64, err error) {
+	m := psnrPattern.FindStringSubmatch(log)
+	if m == nil {
+		return 0, 0, 0, fmt.Errorf("no PSNR summary in ffmpeg output")
+	}
+	if m[1] == "inf" {
+		psnr = maxPSNR
+	} else if psnr, err = strconv.ParseFloat(m[1], 64); err != nil {
+		return 0, 0, 0, err
+	}
+
+	m = ssimPattern.FindStringSubmatch(log)
+	if m == nil {
+		return 0, 0, 0, fmt.Errorf("no SSIM summary in ffmpeg output")
+	}
+	if ssim, err = strconv.ParseFloat(m[1], 64); err != nil {
+		return 0, 0, 0, err
+	}
+
+	if withVMAF {
+		m = vmafPattern.FindStringSubmatch(log)
+		if m == nil {
+			return 0, 0, 0, fmt.Errorf("no VMAF score in ffmpeg output")
+		}
+		if vmaf, err = strconv.ParseFloat(m[1], 64); err != nil {
+			return 0, 0, 0, err
+		}
+	}
+	return psnr, ssim, vmaf, nil
+}
+
+// vmafAvailable checks once whether this ffmpeg build has the libvmaf filter.
+func (v *FFmpegQualityVerifier) vmafAvailable(ctx context.Context) bool {
+	v.vmafOnce.Do(func() {
+		out, err := exec.CommandContext(ctx, "ffmpeg", "-hide_banner", "-filters").Output()
+		v.hasVMAF = err == nil && strings.Contains(string(out), " libvmaf ")
+	})
+	return v.hasVMAF
+}
@@ -1,6 +1,7 @@
 package transcoding
 
 import (
+	"github.com/serdarburakguneri/hobby-streamer/backend/pkg/keystore"
 	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job"
 )
 
@@ -8,12 +9,16 @@ type Registry struct {
 	strategies map[string]job.TranscodeStrategy
 }
 
-func NewRegistry(storage job.Storage) *Registry {
+func NewRegistry(storage job.Storage, progress job.ProgressReporter, keys keystore.Store) *Registry {
 	return &Registry{
 		strategies: map[string]job.TranscodeStrategy{
 			"analyze": NewAnalyzeTranscoder(),
-			"hls":     NewHLSTranscoder(storage),
-			"dash":    NewDASHTranscoder(storage),
+			"hls":     NewHLSTranscoder(storage, progress, keys),
+			"dash":    NewDASHTranscoder(storage, progress),
+			"cmaf":    NewCMAFTranscoder(storage, progress),
+
+			"thumbnails": NewThumbnailsTranscoder(storage, progress),
+			"markers":    NewMarkersTranscoder(storage, progress),
 		},
 	}
 }
@@ -0,0 +1,277 @@
+package transcoding
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+const (
+	subtitleGroupID = "subs"
+	// ffmpeg's mpegts muxer starts video timestamps at 1.4s, so WebVTT
+	// segments next to TS segments have to be mapped onto the same clock.
+	mpegtsTimestampMap = "X-TIMESTAMP-MAP=MPEGTS:126000,LOCAL:00:00:00.000"
+)
+
+type packagedSubtitle struct {
+	track    valueobjects.SubtitleTrack
+	vtt      string
+	playlist string
+}
+
+type vttCue struct {
+	start float64
+	end   float64
+	text  string
+}
+
+// packageSubtitles adds the job's subtitle tracks to an encoded output. An
+// empty hlsMaster or mpd skips that manifest.
+func packageSubtitles(ctx context.Context, storage job.Storage, job *entity.Job, outputDir, hlsMaster, mpd, timestampMap string) error {
+	if len(job.Subtitles()) == 0 {
+		return nil
+	}
+	subs, err := convertSubtitles(ctx, storage, job, outputDir)
+	if err != nil {
+		return err
+	}
+	if hlsMaster != "" {
+		if err := segmentSubtitles(outputDir, subs, job.SourceDuration(), timestampMap); err != nil {
+			return err
+		}
+		if err := addHLSSubtitles(hlsMaster, subs); err != nil {
+			return err
+		}
+	}
+	if mpd != "" {
+		return addDASHSubtitles(mpd, subs)
+	}
+	return nil
+}
+
+// convertSubtitles writes every subtitle track of the job to outputDir as a
+// single WebVTT file, converting SRT input on the way.
+func convertSubtitles(ctx context.Context, storage job.Storage, job *entity.Job, outputDir string) ([]packagedSubtitle, error) {
+	subs := make([]packagedSubtitle, 0, len(job.Subtitles()))
+	for _, track := range job.Subtitles() {
+		localPath, err := storage.Download(ctx, track.Input)
+		if err != nil {
+			return nil, pkgerrors.NewExternalError("failed to download subtitle "+track.Input, err)
+		}
+		vtt := track.Name() + ".vtt"
+		err = runFFmpeg(ctx, []string{"-y", "-i", localPath, "-f", "webvtt", filepath.Join(outputDir, vtt)}, nil)
+		if localPath != track.Input {
+			storage.Remove(localPath)
+		}
+		if err != nil {
+			return nil, pkgerrors.NewInternalError("failed to convert subtitle "+track.Input+" to WebVTT", err)
+		}
+		subs = append(subs, packagedSubtitle{track: track, vtt: vtt})
+	}
+	return subs, nil
+}
+
+// segmentSubtitles splits each WebVTT track into segmentDuration chunks with
+// an HLS media playlist. A cue spanning a boundary is repeated in both chunks.
+func segmentSubtitles(outputDir string, subs []packagedSubtitle, duration float64, timestampMap string) error {
+	for i := range subs {
+		data, err := os.ReadFile(filepath.Join(outputDir, subs[i].vtt))
+		if err != nil {
+			return pkgerrors.NewInternalError("failed to read WebVTT track", err)
+		}
+		cues := parseWebVTT(string(data))
+		total := duration
+		for _, c := range cues {
+			total = math.Max(total, c.end)
+		}
+		count := int(math.Ceil(total / segmentDuration))
+		if count == 0 {
+			count = 1
+		}
+
+		var playlist strings.Builder
+		fmt.Fprintf(&playlist, "#EXTM3U\n#EXT-X-VERSION:3\n#EXT-X-TARGETDURATION:%d\n#EXT-X-MEDIA-SEQUENCE:0\n#EXT-X-PLAYLIST-TYPE:VOD\n", segmentDuration)
+		for n := 0; n < count; n++ {
+			start := float64(n * segmentDuration)
+			end := math.Min(start+segmentDuration, total)
+			if count == 1 && end <= start {
+				end = start + segmentDuration
+			}
+			var segment strings.Builder
+			segment.WriteString("WEBVTT\n")
+			if timestampMap != "" {
+				segment.WriteString(timestampMap + "\n")
+			}
+			for _, c := range cues {
+				if c.start < end && c.end > start {
+					fmt.Fprintf(&segment, "\n%s --> %s\n%s\n", vttTimestamp(c.start), vttTimestamp(c.end), c.text)
+				}
+			}
+			name := fmt.Sprintf("%s_%03d.vtt", subs[i].track.Name(), n)
+			if err := os.WriteFile(filepath.Join(outputDir, name), []byte(segment.String()), 0640); err != nil {
+				return pkgerrors.NewInternalError("failed to write WebVTT segment", err)
+			}
+			fmt.Fprintf(&playlist, "#EXTINF:%.3f,\n%s\n", end-start, name)
+		}
+		playlist.WriteString("#EXT-X-ENDLIST\n")
+
+		subs[i].playlist = subs[i].track.Name() + ".m3u8"
+		if err := os.WriteFile(filepath.Join(outputDir, subs[i].playlist), []byte(playlist.String()), 0640); err != nil {
+			return pkgerrors.NewInternalError("failed to write subtitle playlist", err)
+		}
+	}
+	return nil
+}
+
+func parseWebVTT(data string) []vttCue {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	var cues []vttCue
+	for _, block := range strings.Split(data, "\n\n") {
+		lines := strings.Split(strings.Trim(block, "\n"), "\n")
+		for i, line := range lines {
+			if !strings.Contains(line, "-->") {
+				continue
+			}
+			parts := strings.SplitN(line, "-->", 2)
+			endFields := strings.Fields(parts[1])
+			if len(endFields) == 0 {
+				break
+			}
+			start, okStart := parseVTTTimestamp(parts[0])
+			end, okEnd := parseVTTTimestamp(endFields[0])
+			if okStart && okEnd && i+1 < len(lines) {
+				cues = append(cues, vttCue{start: start, end: end, text: strings.Join(lines[i+1:], "\n")})
+			}
+			break
+		}
+	}
+	return cues
+}
+
+func parseVTTTimestamp(value string) (float64, bool) {
+	fields := strings.Split(strings.TrimSpace(value), ":")
+	if len(fields) < 2 || len(fields) > 3 {
+		return 0, false
+	}
+	var total float64
+	for _, f := range fields {
+		v, err := strconv.ParseFloat(strings.Replace(f, ",", ".", 1), 64)
+		if err != nil {
+			return 0, false
+		}
+		total = total*60 + v
+	}
+	return total, true
+}
+
+// addHLSSubtitles adds an EXT-X-MEDIA entry per track to the master playlist
+// and points every variant at the subtitle group.
+func addHLSSubtitles(masterPath string, subs []packagedSubtitle) error {
+	if len(subs) == 0 {
+		return nil
+	}
+	data, err := os.ReadFile(masterPath)
+	if err != nil {
+		return pkgerrors.NewInternalError("failed to read HLS master playlist", err)
+	}
+	var media []string
+	for _, s := range subs {
+		media = append(media, hlsSubtitleMedia(s))
+	}
+
+	var out []string
+	inserted := false
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		if strings.HasPrefix(line, "#EXT-X-STREAM-INF:") {
+			if !inserted {
+				out = append(out, media...)
+				inserted = true
+			}
+			line += fmt.Sprintf(`,SUBTITLES="%s"`, subtitleGroupID)
+		}
+		out = append(out, line)
+	}
+	if err := os.WriteFile(masterPath, []byte(strings.Join(out, "\n")+"\n"), 0640); err != nil {
+		return pkgerrors.NewInternalError("failed to write HLS master playlist", err)
+	}
+	return nil
+}
+
+func hlsSubtitleMedia(s packagedSubtitle) string {
+	yesNo := func(b bool) string {
+		if b {
+			return "YES"
+		}
+		return "NO"
+	}
+	attrs := []string{
+		"TYPE=SUBTITLES",
+		fmt.Sprintf(`GROUP-ID="%s"`, subtitleGroupID),
+		fmt.Sprintf(`NAME="%s"`, strings.ReplaceAll(s.track.Label, `"`, "'")),
+		fmt.Sprintf(`LANGUAGE="%s"`, s.track.Language),
+		"DEFAULT=" + yesNo(s.track.Default),
+		"AUTOSELECT=YES",
+		"FORCED=" + yesNo(s.track.IsForced()),
+	}
+	if s.track.IsCaptions() {
+		attrs = append(attrs, `CHARACTERISTICS="public.accessibility.transcribes-spoken-dialog,public.accessibility.describes-music-and-sound"`)
+	}
+	attrs = append(attrs, fmt.Sprintf(`URI="%s"`, s.playlist))
+	return "#EXT-X-MEDIA:" + strings.Join(attrs, ",")
+}
+
+// addDASHSubtitles appends one text AdaptationSet per track to the last
+// Period of the MPD, each referencing the full WebVTT file.
+func addDASHSubtitles(mpdPath string, subs []packagedSubtitle) error {
+	if len(subs) == 0 {
+		return nil
+	}
+	data, err := os.ReadFile(mpdPath)
+	if err != nil {
+		return pkgerrors.NewInternalError("failed to read DASH manifest", err)
+	}
+	doc := string(data)
+	idx := strings.LastIndex(doc, "</Period>")
+	if idx < 0 {
+		return pkgerrors.NewInternalError("DASH manifest has no Period", nil)
+	}
+
+	var sets strings.Builder
+	for i, s := range subs {
+		role := map[string]string{
+			valueobjects.SubtitleKindSubtitles: "subtitle",
+			valueobjects.SubtitleKindCaptions:  "caption",
+			valueobjects.SubtitleKindForced:    "forced-subtitle",
+		}[s.track.Kind]
+		fmt.Fprintf(&sets, "\t\t<AdaptationSet id=\"%d\" contentType=\"text\" mimeType=\"text/vtt\" lang=\"%s\">\n", 100+i, xmlEscape(s.track.Language))
+		fmt.Fprintf(&sets, "\t\t\t<Role schemeIdUri=\"urn:mpeg:dash:role:2011\" value=\"%s\"/>\n", role)
+		if s.track.Default {
+			sets.WriteString("\t\t\t<Role schemeIdUri=\"urn:mpeg:dash:role:2011\" value=\"main\"/>\n")
+		}
+		fmt.Fprintf(&sets, "\t\t\t<Label>%s</Label>\n", xmlEscape(s.track.Label))
+		fmt.Fprintf(&sets, "\t\t\t<Representation id=\"%s\" bandwidth=\"256\">\n", xmlEscape(s.track.Name()))
+		fmt.Fprintf(&sets, "\t\t\t\t<BaseURL>%s</BaseURL>\n", xmlEscape(s.vtt))
+		sets.WriteString("\t\t\t</Representation>\n\t\t</AdaptationSet>\n\t")
+	}
+	doc = doc[:idx] + sets.String() + doc[idx:]
+	if err := os.WriteFile(mpdPath, []byte(doc), 0640); err != nil {
+		return pkgerrors.NewInternalError("failed to write DASH manifest", err)
+	}
+	return nil
+}
+
+func xmlEscape(value string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(value))
+	return b.String()
+}
@@ -0,0 +1,239 @@
+package transcoding
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pkgerrors "github.com/serdarburakguneri/hobby-streamer/backend/pkg/errors"
+	resilience "github.com/serdarburakguneri/hobby-streamer/backend/pkg/resilience"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/entity"
+	"github.com/serdarburakguneri/hobby-streamer/backend/transcoder/internal/domain/job/valueobjects"
+)
+
+const (
+	posterFileName    = "poster.jpg"
+	thumbnailTrackVTT = "thumbnails.vtt"
+)
+
+type ThumbnailsTranscoder struct {
+	storage  job.Storage
+	progress job.ProgressReporter
+}
+
+func NewThumbnailsTranscoder(storage job.Storage, progress job.ProgressReporter) *ThumbnailsTranscoder {
+	return &ThumbnailsTranscoder{storage: storage, progress: progress}
+}
+
+func (t *ThumbnailsTranscoder) ValidateInput(ctx context.Context, job *entity.Job, localPath string) error {
+	return nil
+}
+
+func (t *ThumbnailsTranscoder) Transcode(ctx context.Context, job *entity.Job, localPath, outputDir string) (string, error) {
+	spec := jobThumbnailSpec(job)
+	width, height, probed := probeDimensions(ctx, localPath)
+	duration := job.SourceDuration()
+	if duration <= 0 {
+		duration = probed
+	}
+	tileHeight := spec.TileHeight(width, height)
+
+	commands := [][]string{posterArgs(localPath, outputDir, spec.PosterOffset(duration))}
+	for i, offset := range spec.ScreenshotOffsets(duration) {
+		commands = append(commands, screenshotArgs(localPath, outputDir, i+1, offset))
+	}
+	for _, args := range commands {
+		retryFunc := func(ctx context.Context) error {
+			return runFFmpeg(ctx, args, nil)
+		}
+		if err := resilience.RetryWithBackoff(ctx, retryFunc, 2); err != nil {
+			return "", pkgerrors.NewInternalError("thumbnail extraction failed", err)
+		}
+	}
+
+	spriteArgs := spriteSheetArgs(localPath, outputDir, spec, tileHeight)
+	retryFunc := func(ctx context.Context) error {
+		return runFFmpeg(ctx, spriteArgs, jobProgress(ctx, t.progress, job))
+	}
+	if err := resilience.RetryWithBackoff(ctx, retryFunc, 2); err != nil {
+		return "", pkgerrors.NewInternalError("sprite sheet generation failed", err)
+	}
+	track := thumbnailTrack(spec.SpriteCues(duration, tileHeight))
+	if err := os.WriteFile(filepath.Join(outputDir, thumbnailTrackVTT), []byte(track), 0640); err != nil {
+		return "", pkgerrors.NewInternalError("failed to write thumbnail track", err)
+	}
+
+	if job.Type().IsTranscode() && t.storage.IsRemote(job.Output()) {
+		if err := t.storage.Upload(ctx, outputDir, job.Output()); err != nil {
+			return "", pkgerrors.NewExternalError("failed to upload thumbnails", err)
+		}
+	}
+	return filepath.Join(outputDir, posterFileName), nil
+}
+
+func jobThumbnailSpec(job *entity.Job) valueobjects.ThumbnailSpec {
+	if spec := job.ThumbnailSpec(); !spec.IsZero() {
+		return spec
+	}
+	return valueobjects.DefaultThumbnailSpec()
+}
+
+// The thumbnail filter picks the most representative frame of the next
+// hundred, which keeps fades and motion blur off the poster.
+func posterArgs(localPath, outputDir string, offset float64) []string {
+	return []string{"-y",
+		"-ss", formatSeconds(offset),
+		"-i", localPath,
+		"-vf", "thumbnail=100",
+		"-frames:v", "1",
+		"-q:v", "2",
+		filepath.Join(outputDir, posterFileName),
+	}
+}
+
+func screenshotArgs(localPath, outputDir string, index int, offset float64) []string {
+	return []string{"-y",
+		"-ss", formatSeconds(offset),
+		"-i", localPath,
+		"-frames:v", "1",
+		"-q:v", "2",
+		filepath.Join(outputDir, fmt.Sprintf("screenshot_%02d.jpg", index)),
+	}
+}
+
+func spriteSheetArgs(localPath, outputDir string, spec valueobjects.ThumbnailSpec, tileHeight int) []string {
+	filter := fmt.Sprintf("fps=1/%s,scale=%d:%d,tile=%dx%d",
+		formatSeconds(spec.SpriteInterval), spec.TileWidth, tileHeight, spec.Columns, spec.Rows)
+	return []string{"-y",
+		"-i", localPath,
+		"-an",
+		"-vf", filter,
+		"-q:v", "5",
+		filepath.Join(outputDir, "sprite_%03d.jpg"),
+	}
+}
+
+func thumbnailTrack(cues []valueobjects.SpriteCue) string {
+	var b strings.Builder
+	b.WriteString("WEBVTT\n")
+	for _, c := range cues {
+		fmt.Fprintf(&b, "\n%s --> %s\n%s#xywh=%d,%d,%d,%d\n",
+			vttTimestamp(c.Start), vttTimestamp(c.End), spriteFileName(c.Sheet), c.X, c.Y, c.Width, c.Height)
+	}
+	return b.String()
+}
+
+func spriteFileName(sheet int) string {
+	return fmt.Sprintf("sprite_%03d.jpg", sheet)
+}
+
+func vttTimestamp(seconds float64) string {
+	d := time.Duration(seconds * float64(time.Second)).Round(time.Millisecond)
+	h := d / time.Hour
+	m := (d % time.Hour) / time.Minute
+	s := (d % time.Minute) / time.Second
+	ms := (d % time.Second) / time.Millisecond
+	return fmt.Sprintf("%02d:%02d:%02d.%03d", h, m, s, ms)
+}
+
+func formatSeconds(seconds float64) string {
+	return strconv.FormatFloat(seconds, 'f', 3, 64)
+}
+
+func (t *ThumbnailsTranscoder) ValidateOutput(job *entity.Job) error {
+	return validateOutputURL(job)
+}
+
+func (t *ThumbnailsTranscoder) ExtractMetadata(ctx context.Context, filePath string, job *entity.Job) (*valueobjects.TranscodeMetadata, error) {
+	fileInfo, err := os.Stat(filePath)
+	if err != nil {
+		return nil, pkgerrors.NewInternalError("failed to stat poster frame", err)
+	}
+	outputURL := job.Output()
+	loc := outputLocation(job)
+	bucket, key := loc.Bucket, loc.Key
+	keyDir := path.Dir(key)
+	objectKey := func(name string) string {
+		if name == filepath.Base(key) {
+			return key
+		}
+		return path.Join(keyDir, name)
+	}
+
+	spec := jobThumbnailSpec(job)
+	offsets := spec.ScreenshotOffsets(job.SourceDuration())
+	baseDir := filepath.Dir(filePath)
+	entries, err := os.ReadDir(baseDir)
+	if err != nil {
+		return nil, pkgerrors.NewInternalError("failed to list thumbnails", err)
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	sort.Strings(names)
+
+	metadata := &valueobjects.TranscodeMetadata{
+		OutputURL:   outputURL,
+		Bucket:      bucket,
+		Key:         key,
+		Size:        fileInfo.Size(),
+		Duration:    job.SourceDuration(),
+		Format:      valueobjects.JobFormatThumbnails.String(),
+		ContentType: "image/jpeg",
+	}
+	for _, name := range names {
+		kind, index := thumbnailKind(name)
+		if kind == "" {
+			continue
+		}
+		localPath := filepath.Join(baseDir, name)
+		img := valueobjects.ImageMetadata{
+			Kind:        kind,
+			Index:       index,
+			Bucket:      bucket,
+			Key:         objectKey(name),
+			URL:         loc.WithKey(objectKey(name)).String(),
+			ContentType: "image/jpeg",
+		}
+		if info, err := os.Stat(localPath); err == nil {
+			img.Size = info.Size()
+		}
+		img.Width, img.Height, _ = probeDimensions(ctx, localPath)
+		if kind == "screenshot" && index-1 < len(offsets) {
+			img.Offset = offsets[index-1]
+		}
+		if kind == "poster" {
+			img.Offset = spec.PosterOffset(job.SourceDuration())
+			metadata.Width, metadata.Height = img.Width, img.Height
+		}
+		metadata.Images = append(metadata.Images, img)
+	}
+	metadata.TrackKey = objectKey(thumbnailTrackVTT)
+	metadata.TrackURL = loc.WithKey(metadata.TrackKey).String()
+	return metadata, nil
+}
+
+func thumbnailKind(name string) (string, int) {
+	if name == posterFileName {
+		return "poster", 0
+	}
+	for _, kind := range []string{"screenshot", "sprite"} {
+		if !strings.HasPrefix(name, kind+"_") || !strings.HasSuffix(name, ".jpg") {
+			continue
+		}
+		index, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, kind+"_"), ".jpg"))
+		if err != nil {
+			return "", 0
+		}
+		return kind, index
+	}
+	return "", 0
+}
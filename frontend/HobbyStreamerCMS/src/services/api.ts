@@ -1,7 +1,7 @@
 import { gql, useApolloClient } from '@apollo/client';
 import axios from 'axios';
 import AsyncStorage from '@react-native-async-storage/async-storage';
-import { Asset, AssetCreateDTO, AssetUpdateDTO, AssetPage, AssetInput, AssetType, Image, ImageType, BucketStatus } from '../types/asset';
+import { Asset, AssetCreateDTO, AssetUpdateDTO, AssetPage, AssetInput, AssetType, Image, ImageType, BucketStatus, Subtitle, SubtitleKind, AudioTrack, Marker, LiveProtocol, LiveStream, OverlayInput, AssetStatusAction, SeriesTree, Person, PersonInput, CreditInput, CreditRole, Collaborator, AssetSearchFilter, SearchFacets } from '../types/asset';
 import { API_CONFIG } from '../config/api';
 
 // GraphQL Fragments for reusable query parts
@@ -16,11 +16,19 @@ const ASSET_BASE_FIELDS = gql`
     genres
     tags
     status
+    statusHistory {
+      from
+      to
+      at
+      note
+    }
     createdAt
     updatedAt
     metadata
     ownerId
     parentId
+    seasonNumber
+    episodeNumber
   }
 `;
 
@@ -93,6 +101,7 @@ const VIDEO_FIELDS = gql`
       downloadUrl
       cdnPrefix
       url
+      hlsUrl
     }
     metadata
     status
@@ -135,6 +144,55 @@ const VIDEO_FIELDS = gql`
     frameRate
     audioChannels
     audioSampleRate
+    audioTracks {
+      index
+      language
+      codec
+      channels
+      channelLayout
+      sampleRate
+      title
+      isDefault
+      loudness {
+        integrated
+        truePeak
+        lra
+        threshold
+      }
+    }
+    markers {
+      kind
+      start
+      end
+      title
+    }
+    qualityScores {
+      rendition
+      psnr
+      ssim
+      vmaf
+    }
+    complexity
+    ladder {
+      name
+      width
+      height
+      videoBitrate
+      audioBitrate
+      baseVideoBitrate
+    }
+    ladderSavings
+    loudness {
+      integrated
+      truePeak
+      lra
+      threshold
+    }
+    inputRejections {
+      code
+      message
+    }
+    variant
   }
 `;
 
@@ -143,6 +201,18 @@ const ASSET_FULL_FIELDS = gql`
     ...AssetBaseFields
     ...AssetParentFields
     ...AssetPublishRuleFields
+    liveStream {
+      streamKey
+      protocol
+      status
+      sessionId
+      ingestUrl
+      playbackUrl
+      startedAt
+      endedAt
+      errorMessage
+      archiveVideoId
+    }
     parent {
       id
       title
@@ -161,6 +231,8 @@ const ASSET_FULL_FIELDS = gql`
       role
       name
       personId
+      character
+      order
     }
   }
 `;
@@ -509,9 +581,21 @@ const GET_ASSET = gql`
 `;
 
 const SEARCH_ASSETS = gql`
-  query SearchAssets($query: String!, $limit: Int, $offset: Int) {
-    searchAssets(query: $query, limit: $limit, offset: $offset) {
-      ...AssetFullFields
+  query SearchAssets($query: String!, $limit: Int, $offset: Int, $filter: AssetSearchFilter) {
+    searchAssets(query: $query, limit: $limit, offset: $offset, filter: $filter) {
+      hits {
+        asset {
+          ...AssetFullFields
+        }
+        score
+      }
+      total
+      hasMore
+      facets {
+        types { value count }
+        genres { value count }
+        years { value count }
+      }
     }
   }
   ${ASSET_FULL_FIELDS}
@@ -593,6 +677,53 @@ const CLEAR_ASSET_PUBLISH_RULE = gql`
   ${VIDEO_FIELDS}
 `;
 
+const assetStatusMutation = (action: AssetStatusAction) => gql`
+  mutation ChangeAssetStatus($id: ID!, $note: String) {
+    ${action}(id: $id, note: $note) {
+      ...AssetFullFields
+    }
+  }
+  ${ASSET_FULL_FIELDS}
+  ${ASSET_BASE_FIELDS}
+  ${ASSET_PARENT_FIELDS}
+  ${ASSET_PUBLISH_RULE_FIELDS}
+  ${IMAGE_FIELDS}
+  ${VIDEO_FIELDS}
+`;
+
+const SET_ASSET_NUMBERING = gql`
+  mutation SetAssetNumbering($id: ID!, $seasonNumber: Int, $episodeNumber: Int) {
+    setAssetNumbering(id: $id, seasonNumber: $seasonNumber, episodeNumber: $episodeNumber) {
+      ...AssetFullFields
+    }
+  }
+  ${ASSET_FULL_FIELDS}
+  ${ASSET_BASE_FIELDS}
+  ${ASSET_PARENT_FIELDS}
+  ${ASSET_PUBLISH_RULE_FIELDS}
+  ${IMAGE_FIELDS}
+  ${VIDEO_FIELDS}
+`;
+
+const GET_SERIES_TREE = gql`
+  query GetSeriesTree($id: ID!) {
+    seriesTree(id: $id) {
+      series {
+        ...AssetBaseFields
+      }
+      seasons {
+        season {
+          ...AssetBaseFields
+        }
+        episodes {
+          ...AssetBaseFields
+        }
+      }
+    }
+  }
+  ${ASSET_BASE_FIELDS}
+`;
+
 const DELETE_ASSET = gql`
   mutation DeleteAsset($id: ID!) {
     deleteAsset(id: $id)
@@ -638,6 +769,7 @@ const ADD_VIDEO = gql`
         downloadUrl
         cdnPrefix
         url
+        hlsUrl
       }
       metadata
       status
@@ -663,8 +795,128 @@ const ADD_VIDEO = gql`
 `;
 
 const REQUEST_TRANSCODE = gql`
-  mutation RequestTranscode($assetId: ID!, $videoId: ID!, $format: VideoFormat!) {
-    requestTranscode(assetId: $assetId, videoId: $videoId, format: $format)
+  mutation RequestTranscode($assetId: ID!, $videoId: ID!, $format: VideoFormat!, $overlay: OverlayInput) {
+    requestTranscode(assetId: $assetId, videoId: $videoId, format: $format, overlay: $overlay)
+  }
+`;
+
+const CANCEL_TRANSCODE = gql`
+  mutation CancelTranscode($assetId: ID!, $videoId: ID!, $format: VideoFormat!) {
+    cancelTranscode(assetId: $assetId, videoId: $videoId, format: $format)
+  }
+`;
+
+const SUBTITLE_RESULT = `
+  id
+  subtitles {
+    id
+    language
+    kind
+    label
+    isDefault
+    storageLocation {
+      bucket
+      key
+      url
+    }
+    contentType
+    createdAt
+    updatedAt
+  }
+`;
+
+const LIVE_STREAM_RESULT = `
+  id
+  liveStream {
+    streamKey
+    protocol
+    status
+    sessionId
+    ingestUrl
+    playbackUrl
+    startedAt
+    endedAt
+    errorMessage
+    archiveVideoId
+  }
+`;
+
+const CREATE_STREAM_KEY = gql`
+  mutation CreateStreamKey($assetId: ID!, $protocol: LiveProtocol!) {
+    createStreamKey(assetId: $assetId, protocol: $protocol) {
+      ${LIVE_STREAM_RESULT}
+    }
+  }
+`;
+
+const START_LIVE_EVENT = gql`
+  mutation StartLiveEvent($assetId: ID!) {
+    startLiveEvent(assetId: $assetId) {
+      ${LIVE_STREAM_RESULT}
+    }
+  }
+`;
+
+const STOP_LIVE_EVENT = gql`
+  mutation StopLiveEvent($assetId: ID!) {
+    stopLiveEvent(assetId: $assetId) {
+      ${LIVE_STREAM_RESULT}
+    }
+  }
+`;
+
+const ADD_SUBTITLE = gql`
+  mutation AddSubtitle($input: AddSubtitleInput!) {
+    addSubtitle(input: $input) {
+      ${SUBTITLE_RESULT}
+    }
+  }
+`;
+
+const DELETE_SUBTITLE = gql`
+  mutation DeleteSubtitle($assetId: ID!, $subtitleId: ID!) {
+    deleteSubtitle(assetId: $assetId, subtitleId: $subtitleId) {
+      ${SUBTITLE_RESULT}
+    }
+  }
+`;
+
+const SET_DEFAULT_AUDIO_LANGUAGE = gql`
+  mutation SetDefaultAudioLanguage($assetId: ID!, $videoId: ID!, $language: String!) {
+    setDefaultAudioLanguage(assetId: $assetId, videoId: $videoId, language: $language) {
+      id
+      audioTracks {
+        index
+        language
+        isDefault
+      }
+    }
+  }
+`;
+
+const REQUEST_MARKERS = gql`
+  mutation RequestMarkers($assetId: ID!, $videoId: ID!) {
+    requestMarkers(assetId: $assetId, videoId: $videoId)
+  }
+`;
+
+const SET_VIDEO_MARKERS = gql`
+  mutation SetVideoMarkers($assetId: ID!, $videoId: ID!, $markers: [MarkerInput!]!) {
+    setVideoMarkers(assetId: $assetId, videoId: $videoId, markers: $markers) {
+      id
+      markers {
+        kind
+        start
+        end
+        title
+      }
+    }
+  }
+`;
+
+const REQUEST_THUMBNAILS = gql`
+  mutation RequestThumbnails($assetId: ID!, $videoId: ID!) {
+    requestThumbnails(assetId: $assetId, videoId: $videoId)
   }
 `;
 
@@ -718,6 +970,87 @@ const REMOVE_ASSET_FROM_BUCKET = gql`
   }
 `;
 
+const PERSON_FIELDS = `
+  id
+  name
+  externalSource
+  externalId
+  biography
+  photoUrl
+  createdAt
+  updatedAt
+`;
+
+const GET_PEOPLE = gql`
+  query GetPeople($limit: Int, $offset: Int) {
+    people(limit: $limit, offset: $offset) {
+      ${PERSON_FIELDS}
+    }
+  }
+`;
+
+const SEARCH_PEOPLE = gql`
+  query SearchPeople($query: String!, $limit: Int, $offset: Int) {
+    searchPeople(query: $query, limit: $limit, offset: $offset) {
+      ${PERSON_FIELDS}
+    }
+  }
+`;
+
+const GET_ASSETS_BY_PERSON = gql`
+  query GetAssetsByPerson($personId: ID!, $role: CreditRole) {
+    assetsByPerson(personId: $personId, role: $role) {
+      ...AssetBaseFields
+    }
+  }
+  ${ASSET_BASE_FIELDS}
+`;
+
+const GET_COLLABORATORS = gql`
+  query GetCollaborators($personId: ID!, $limit: Int) {
+    collaborators(personId: $personId, limit: $limit) {
+      person {
+        ${PERSON_FIELDS}
+      }
+      sharedAssets
+    }
+  }
+`;
+
+const CREATE_PERSON = gql`
+  mutation CreatePerson($input: PersonInput!) {
+    createPerson(input: $input) {
+      ${PERSON_FIELDS}
+    }
+  }
+`;
+
+const UPDATE_PERSON = gql`
+  mutation UpdatePerson($id: ID!, $input: PersonInput!) {
+    updatePerson(id: $id, input: $input) {
+      ${PERSON_FIELDS}
+    }
+  }
+`;
+
+const DELETE_PERSON = gql`
+  mutation DeletePerson($id: ID!) {
+    deletePerson(id: $id)
+  }
+`;
+
+const ADD_CREDIT = gql`
+  mutation AddCredit($input: CreditInput!) {
+    addCredit(input: $input)
+  }
+`;
+
+const REMOVE_CREDIT = gql`
+  mutation RemoveCredit($personId: ID!, $assetId: ID!, $role: CreditRole!) {
+    removeCredit(personId: $personId, assetId: $assetId, role: $role)
+  }
+`;
+
 const ADD_IMAGE = gql`
   mutation AddImage($input: AddImageInput!) {
     addImage(input: $input) {
@@ -865,6 +1198,8 @@ export const useAssetService = () => {
         metadata: assetData.metadata ? JSON.stringify(assetData.metadata) : undefined,
         ownerId: assetData.ownerId,
         parentId: assetData.parentId,
+        seasonNumber: assetData.seasonNumber,
+        episodeNumber: assetData.episodeNumber,
       };
 
       console.log('AssetInput being sent to GraphQL:', input);
@@ -902,6 +1237,38 @@ export const useAssetService = () => {
     },
 
 
+    changeAssetStatus: async (id: string, action: AssetStatusAction, note?: string): Promise<Asset> => {
+      const response = await client.mutate({
+        mutation: assetStatusMutation(action),
+        variables: { id, note },
+      });
+      return convertAssetMetadata(response.data[action]);
+    },
+
+    setAssetNumbering: async (id: string, seasonNumber?: number, episodeNumber?: number): Promise<Asset> => {
+      const response = await client.mutate({
+        mutation: SET_ASSET_NUMBERING,
+        variables: { id, seasonNumber, episodeNumber },
+      });
+      return convertAssetMetadata(response.data.setAssetNumbering);
+    },
+
+    getSeriesTree: async (id: string): Promise<SeriesTree> => {
+      const response = await client.query({
+        query: GET_SERIES_TREE,
+        variables: { id },
+        fetchPolicy: 'no-cache',
+      });
+      const tree = response.data.seriesTree;
+      return {
+        series: convertAssetMetadata(tree.series),
+        seasons: tree.seasons.map((branch: any) => ({
+          season: convertAssetMetadata(branch.season),
+          episodes: branch.episodes.map(convertAssetMetadata),
+        })),
+      };
+    },
+
     deleteAsset: async (id: string): Promise<void> => {
       await client.mutate({
         mutation: DELETE_ASSET,
@@ -979,13 +1346,24 @@ export const useAssetService = () => {
     },
 
 
-    searchAssets: async (query: string, limit = 10, offset = 0): Promise<{ assets: any[] }> => {
+    searchAssets: async (
+      query: string,
+      limit = 10,
+      offset = 0,
+      filter?: AssetSearchFilter
+    ): Promise<{ assets: any[]; total: number; hasMore: boolean; facets: SearchFacets }> => {
       const response = await client.query({
         query: SEARCH_ASSETS,
-        variables: { query, limit, offset },
+        variables: { query, limit, offset, filter },
         fetchPolicy: 'no-cache',
       });
-      return { assets: response.data.searchAssets };
+      const result = response.data.searchAssets;
+      return {
+        assets: result.hits.map((hit: any) => hit.asset),
+        total: result.total,
+        hasMore: result.hasMore,
+        facets: result.facets,
+      };
     },
 
 
@@ -1231,21 +1609,194 @@ export const useAssetService = () => {
       return convertAssetMetadata(response.data.deleteImage);
     },
 
-    triggerHLSTranscode: async (assetId: string, videoId: string, _input: string): Promise<{ message: string }> => {
+    addSubtitleToAsset: async (
+      assetId: string,
+      subtitle: { language: string; kind: SubtitleKind; label?: string; isDefault?: boolean; fileName: string; url: string },
+    ): Promise<Subtitle[]> => {
+      const extension = subtitle.fileName.toLowerCase().endsWith('.srt') ? 'srt' : 'vtt';
+      const response = await client.mutate({
+        mutation: ADD_SUBTITLE,
+        variables: {
+          input: {
+            assetId,
+            language: subtitle.language,
+            kind: subtitle.kind,
+            label: subtitle.label,
+            isDefault: subtitle.isDefault ?? false,
+            bucket: 'content-east',
+            key: `${assetId}/subtitles/${subtitle.language}/${subtitle.fileName}`,
+            url: subtitle.url,
+            contentType: extension === 'srt' ? 'application/x-subrip' : 'text/vtt',
+          },
+        },
+      });
+      return response.data.addSubtitle.subtitles;
+    },
+
+    deleteSubtitleFromAsset: async (assetId: string, subtitleId: string): Promise<Subtitle[]> => {
+      const response = await client.mutate({
+        mutation: DELETE_SUBTITLE,
+        variables: { assetId, subtitleId },
+      });
+      return response.data.deleteSubtitle.subtitles;
+    },
+
+    createStreamKey: async (assetId: string, protocol: LiveProtocol): Promise<LiveStream> => {
+      const response = await client.mutate({
+        mutation: CREATE_STREAM_KEY,
+        variables: { assetId, protocol },
+      });
+      return response.data.createStreamKey.liveStream;
+    },
+
+    startLiveEvent: async (assetId: string): Promise<LiveStream> => {
+      const response = await client.mutate({
+        mutation: START_LIVE_EVENT,
+        variables: { assetId },
+      });
+      return response.data.startLiveEvent.liveStream;
+    },
+
+    stopLiveEvent: async (assetId: string): Promise<LiveStream> => {
+      const response = await client.mutate({
+        mutation: STOP_LIVE_EVENT,
+        variables: { assetId },
+      });
+      return response.data.stopLiveEvent.liveStream;
+    },
+
+    triggerHLSTranscode: async (assetId: string, videoId: string, _input: string, overlay?: OverlayInput): Promise<{ message: string }> => {
       await client.mutate({
         mutation: REQUEST_TRANSCODE,
-        variables: { assetId, videoId, format: 'hls' },
+        variables: { assetId, videoId, format: 'hls', overlay },
       });
       return { message: 'HLS transcode requested' };
     },
 
-    triggerDASHTranscode: async (assetId: string, videoId: string, _input: string): Promise<{ message: string }> => {
+    triggerDASHTranscode: async (assetId: string, videoId: string, _input: string, overlay?: OverlayInput): Promise<{ message: string }> => {
       await client.mutate({
         mutation: REQUEST_TRANSCODE,
-        variables: { assetId, videoId, format: 'dash' },
+        variables: { assetId, videoId, format: 'dash', overlay },
       });
       return { message: 'DASH transcode requested' };
     },
+
+    cancelTranscode: async (assetId: string, videoId: string, format: 'hls' | 'dash' | 'cmaf'): Promise<{ message: string }> => {
+      await client.mutate({
+        mutation: CANCEL_TRANSCODE,
+        variables: { assetId, videoId, format },
+      });
+      return { message: `${format.toUpperCase()} transcode cancel requested` };
+    },
+
+    requestThumbnails: async (assetId: string, videoId: string): Promise<{ message: string }> => {
+      await client.mutate({
+        mutation: REQUEST_THUMBNAILS,
+        variables: { assetId, videoId },
+      });
+      return { message: 'Thumbnails requested' };
+    },
+
+    setDefaultAudioLanguage: async (assetId: string, videoId: string, language: string): Promise<AudioTrack[]> => {
+      const response = await client.mutate({
+        mutation: SET_DEFAULT_AUDIO_LANGUAGE,
+        variables: { assetId, videoId, language },
+      });
+      return response.data.setDefaultAudioLanguage.audioTracks;
+    },
+
+    requestMarkers: async (assetId: string, videoId: string): Promise<{ message: string }> => {
+      await client.mutate({
+        mutation: REQUEST_MARKERS,
+        variables: { assetId, videoId },
+      });
+      return { message: 'Marker detection requested' };
+    },
+
+    setVideoMarkers: async (assetId: string, videoId: string, markers: Marker[]): Promise<Marker[]> => {
+      const response = await client.mutate({
+        mutation: SET_VIDEO_MARKERS,
+        variables: { assetId, videoId, markers },
+      });
+      return response.data.setVideoMarkers.markers;
+    },
+
+    getPeople: async (limit?: number, offset?: number): Promise<Person[]> => {
+      const response = await client.query({
+        query: GET_PEOPLE,
+        variables: { limit, offset },
+        fetchPolicy: 'no-cache',
+      });
+      return response.data.people;
+    },
+
+    searchPeople: async (query: string, limit?: number, offset?: number): Promise<Person[]> => {
+      const response = await client.query({
+        query: SEARCH_PEOPLE,
+        variables: { query, limit, offset },
+        fetchPolicy: 'no-cache',
+      });
+      return response.data.searchPeople;
+    },
+
+    getAssetsByPerson: async (personId: string, role?: CreditRole): Promise<Asset[]> => {
+      const response = await client.query({
+        query: GET_ASSETS_BY_PERSON,
+        variables: { personId, role },
+        fetchPolicy: 'no-cache',
+      });
+      return response.data.assetsByPerson.map(convertAssetMetadata);
+    },
+
+    getCollaborators: async (personId: string, limit?: number): Promise<Collaborator[]> => {
+      const response = await client.query({
+        query: GET_COLLABORATORS,
+        variables: { personId, limit },
+        fetchPolicy: 'no-cache',
+      });
+      return response.data.collaborators;
+    },
+
+    // createPerson returns the existing person when the name and external ID match one.
+    createPerson: async (input: PersonInput): Promise<Person> => {
+      const response = await client.mutate({
+        mutation: CREATE_PERSON,
+        variables: { input },
+      });
+      return response.data.createPerson;
+    },
+
+    updatePerson: async (id: string, input: PersonInput): Promise<Person> => {
+      const response = await client.mutate({
+        mutation: UPDATE_PERSON,
+        variables: { id, input },
+      });
+      return response.data.updatePerson;
+    },
+
+    deletePerson: async (id: string): Promise<boolean> => {
+      const response = await client.mutate({
+        mutation: DELETE_PERSON,
+        variables: { id },
+      });
+      return response.data.deletePerson;
+    },
+
+    addCredit: async (input: CreditInput): Promise<boolean> => {
+      const response = await client.mutate({
+        mutation: ADD_CREDIT,
+        variables: { input },
+      });
+      return response.data.addCredit;
+    },
+
+    removeCredit: async (personId: string, assetId: string, role: CreditRole): Promise<boolean> => {
+      const response = await client.mutate({
+        mutation: REMOVE_CREDIT,
+        variables: { personId, assetId, role },
+      });
+      return response.data.removeCredit;
+    },
   };
 };
 
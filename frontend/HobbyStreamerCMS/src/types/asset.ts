@@ -7,17 +7,114 @@ export interface Asset {
   genre?: string;
   genres?: string[];
   tags?: string[];
-  status?: string;
+  status?: AssetStatus;
+  statusHistory?: AssetStatusChange[];
   createdAt: string;
   updatedAt: string;
   metadata?: Record<string, any>;
   ownerId?: string;
+  parentId?: string;
   parent?: Asset;
   children?: Asset[];
+  seasonNumber?: number;
+  episodeNumber?: number;
   buckets?: Bucket[];
   videos?: Video[];
   images?: Image[];
+  subtitles?: Subtitle[];
   publishRule?: PublishRule;
+  liveStream?: LiveStream;
+  credits?: Credit[];
+}
+
+export type AssetStatus = 'draft' | 'in_review' | 'approved' | 'scheduled' | 'published' | 'unpublished' | 'archived';
+
+export type CreditRole = 'actor' | 'director' | 'writer' | 'producer' | 'composer';
+
+export interface Credit {
+  role: CreditRole;
+  name: string;
+  personId: string;
+  character?: string;
+  order: number;
+}
+
+export interface Person {
+  id: string;
+  name: string;
+  externalSource?: string;
+  externalId?: string;
+  biography?: string;
+  photoUrl?: string;
+  createdAt: string;
+  updatedAt: string;
+}
+
+export interface PersonInput {
+  name?: string;
+  externalSource?: string;
+  externalId?: string;
+  biography?: string;
+  photoUrl?: string;
+}
+
+export interface CreditInput {
+  personId: string;
+  assetId: string;
+  role: CreditRole;
+  character?: string;
+  order?: number;
+}
+
+export interface Collaborator {
+  person: Person;
+  sharedAssets: number;
+}
+
+export interface SeriesTree {
+  series: Asset;
+  seasons: SeasonBranch[];
+}
+
+export interface SeasonBranch {
+  season: Asset;
+  episodes: Asset[];
+}
+
+export interface AssetStatusChange {
+  from: AssetStatus;
+  to: AssetStatus;
+  at: string;
+  note?: string;
+}
+
+export type AssetStatusAction =
+  | 'submitAssetForReview'
+  | 'approveAsset'
+  | 'rejectAsset'
+  | 'scheduleAsset'
+  | 'unscheduleAsset'
+  | 'publishAsset'
+  | 'unpublishAsset'
+  | 'returnAssetToDraft'
+  | 'archiveAsset'
+  | 'restoreAsset';
+
+export type LiveProtocol = 'RTMP' | 'SRT';
+
+export type LiveStatus = 'IDLE' | 'STARTING' | 'WAITING' | 'LIVE' | 'STOPPING' | 'ENDED' | 'FAILED';
+
+export interface LiveStream {
+  streamKey: string;
+  protocol: LiveProtocol;
+  status: LiveStatus;
+  sessionId?: string;
+  ingestUrl?: string;
+  playbackUrl?: string;
+  startedAt?: string;
+  endedAt?: string;
+  errorMessage?: string;
+  archiveVideoId?: string;
 }
 
 export interface PublishRule {
@@ -50,6 +147,7 @@ export interface StreamInfo {
   downloadUrl?: string;
   cdnPrefix?: string;
   url?: string;
+  hlsUrl?: string;
 }
 
 export interface Video {
@@ -68,10 +166,82 @@ export interface Video {
   metadata?: Record<string, any>;
   status?: string;
   thumbnail?: Image;
+  audioTracks?: AudioTrack[];
+  markers?: Marker[];
+  qualityScores?: RenditionQuality[];
+  complexity?: number;
+  ladder?: LadderRung[];
+  ladderSavings?: number;
+  loudness?: Loudness;
+  inputRejections?: InputRejection[];
+  variant: boolean;
   createdAt: string;
   updatedAt: string;
 }
 
+export interface AudioTrack {
+  index: number;
+  language?: string;
+  codec?: string;
+  channels?: number;
+  channelLayout?: string;
+  sampleRate?: number;
+  title?: string;
+  isDefault: boolean;
+  loudness?: Loudness;
+}
+
+export interface Loudness {
+  integrated: number;
+  truePeak: number;
+  lra: number;
+  threshold: number;
+}
+
+export interface InputRejection {
+  code: string;
+  message: string;
+}
+
+export type OverlayPosition = 'top_left' | 'top_right' | 'bottom_left' | 'bottom_right' | 'center';
+
+export interface OverlayInput {
+  label: string;
+  logoImageId?: string;
+  position?: OverlayPosition;
+  opacity?: number;
+  text?: string;
+}
+
+export enum MarkerKind {
+  CHAPTER = 'chapter',
+  INTRO = 'intro',
+  CREDITS = 'credits',
+}
+
+export interface Marker {
+  kind: MarkerKind;
+  start: number;
+  end?: number;
+  title?: string;
+}
+
+export interface LadderRung {
+  name: string;
+  width: number;
+  height: number;
+  videoBitrate: number;
+  audioBitrate: number;
+  baseVideoBitrate?: number;
+}
+
+export interface RenditionQuality {
+  rendition: string;
+  psnr: number;
+  ssim: number;
+  vmaf?: number;
+}
+
 export enum ImageType {
   THUMBNAIL = 'thumbnail',
   POSTER = 'poster',
@@ -98,6 +268,24 @@ export interface Image {
   updatedAt: string;
 }
 
+export enum SubtitleKind {
+  SUBTITLES = 'subtitles',
+  CAPTIONS = 'captions',
+  FORCED = 'forced'
+}
+
+export interface Subtitle {
+  id: string;
+  language: string;
+  kind: SubtitleKind;
+  label?: string;
+  isDefault: boolean;
+  storageLocation: S3Object;
+  contentType?: string;
+  createdAt: string;
+  updatedAt: string;
+}
+
 
 export enum AssetStatus {
   DRAFT = 'draft',
@@ -110,7 +298,9 @@ export enum VideoStatus {
   ANALYZING = 'analyzing',
   TRANSCODING = 'transcoding',
   READY = 'ready',
-  FAILED = 'failed'
+  FAILED = 'failed',
+  CANCELLED = 'cancelled',
+  FAILED_QC = 'failed_qc'
 }
 
 
@@ -185,6 +375,24 @@ export interface AssetPage {
   nextKey?: string;
 }
 
+export interface AssetSearchFilter {
+  type?: AssetType;
+  genre?: string;
+  year?: number;
+  status?: string;
+}
+
+export interface FacetCount {
+  value: string;
+  count: number;
+}
+
+export interface SearchFacets {
+  types: FacetCount[];
+  genres: FacetCount[];
+  years: FacetCount[];
+}
+
 export interface BucketPage {
   items: Bucket[];
   nextKey?: string;
@@ -201,6 +409,8 @@ export interface AssetInput {
   metadata?: string;
   ownerId?: string;
   parentId?: string;
+  seasonNumber?: number;
+  episodeNumber?: number;
 }
 
 export interface BucketInput {
@@ -222,6 +432,8 @@ export interface AssetCreateDTO {
   metadata?: Record<string, any>;
   ownerId?: string;
   parentId?: string;
+  seasonNumber?: number;
+  episodeNumber?: number;
 }
 
 export interface AssetUpdateDTO {
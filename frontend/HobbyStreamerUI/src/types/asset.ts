@@ -91,6 +91,19 @@ export interface Asset {
   videos: Video[];
   images?: Image[];
   publishRule?: PublishRule;
+  parentId?: string;
+  seasonNumber?: number;
+  episodeNumber?: number;
+  previousEpisode?: EpisodeLink;
+  nextEpisode?: EpisodeLink;
+}
+
+export interface EpisodeLink {
+  id: string;
+  slug: string;
+  title?: string;
+  seasonNumber?: number;
+  episodeNumber?: number;
 }
 
 export interface Bucket {
@@ -99,6 +99,30 @@ docker exec kafka kafka-topics \
   --config compression.type=snappy \
   --if-not-exists
 
+echo "[INFO] Creating cmaf.job.requested topic..."
+docker exec kafka kafka-topics \
+  --bootstrap-server localhost:9092 \
+  --create \
+  --topic cmaf.job.requested \
+  --partitions 4 \
+  --replication-factor 1 \
+  --config retention.ms=259200000 \
+  --config cleanup.policy=delete \
+  --config compression.type=snappy \
+  --if-not-exists
+
+echo "[INFO] Creating thumbnails.job.requested topic..."
+docker exec kafka kafka-topics \
+  --bootstrap-server localhost:9092 \
+  --create \
+  --topic thumbnails.job.requested \
+  --partitions 4 \
+  --replication-factor 1 \
+  --config retention.ms=259200000 \
+  --config cleanup.policy=delete \
+  --config compression.type=snappy \
+  --if-not-exists
+
 # Job Completion Topics (specific job types)
 echo "[INFO] Creating analyze.job.completed topic..."
 docker exec kafka kafka-topics \
@@ -136,6 +160,78 @@ docker exec kafka kafka-topics \
   --config compression.type=snappy \
   --if-not-exists
 
+echo "[INFO] Creating cmaf.job.completed topic..."
+docker exec kafka kafka-topics \
+  --bootstrap-server localhost:9092 \
+  --create \
+  --topic cmaf.job.completed \
+  --partitions 6 \
+  --replication-factor 1 \
+  --config retention.ms=604800000 \
+  --config cleanup.policy=delete \
+  --config compression.type=snappy \
+  --if-not-exists
+
+echo "[INFO] Creating thumbnails.job.completed topic..."
+docker exec kafka kafka-topics \
+  --bootstrap-server localhost:9092 \
+  --create \
+  --topic thumbnails.job.completed \
+  --partitions 6 \
+  --replication-factor 1 \
+  --config retention.ms=604800000 \
+  --config cleanup.policy=delete \
+  --config compression.type=snappy \
+  --if-not-exists
+
+echo "[INFO] Creating markers.job.requested topic..."
+docker exec kafka kafka-topics \
+  --bootstrap-server localhost:9092 \
+  --create \
+  --topic markers.job.requested \
+  --partitions 6 \
+  --replication-factor 1 \
+  --config retention.ms=604800000 \
+  --config cleanup.policy=delete \
+  --config compression.type=snappy \
+  --if-not-exists
+
+echo "[INFO] Creating markers.job.completed topic..."
+docker exec kafka kafka-topics \
+  --bootstrap-server localhost:9092 \
+  --create \
+  --topic markers.job.completed \
+  --partitions 6 \
+  --replication-factor 1 \
+  --config retention.ms=604800000 \
+  --config cleanup.policy=delete \
+  --config compression.type=snappy \
+  --if-not-exists
+
+echo "[INFO] Creating transcode.job.progress topic..."
+docker exec kafka kafka-topics \
+  --bootstrap-server localhost:9092 \
+  --create \
+  --topic transcode.job.progress \
+  --partitions 6 \
+  --replication-factor 1 \
+  --config retention.ms=604800000 \
+  --config cleanup.policy=delete \
+  --config compression.type=snappy \
+  --if-not-exists
+
+echo "[INFO] Creating transcode.job.cancel topic..."
+docker exec kafka kafka-topics \
+  --bootstrap-server localhost:9092 \
+  --create \
+  --topic transcode.job.cancel \
+  --partitions 6 \
+  --replication-factor 1 \
+  --config retention.ms=604800000 \
+  --config cleanup.policy=delete \
+  --config compression.type=snappy \
+  --if-not-exists
+
 # Video Upload Topic
 echo "[INFO] Creating raw-video-uploaded topic..."
 docker exec kafka kafka-topics \
@@ -204,7 +300,7 @@ docker exec kafka kafka-topics \
   --list
 
 echo "[INFO] Topic configurations:"
-for topic in asset-events bucket-events analyze.job.requested hls.job.requested analyze.job.completed hls.job.completed dash.job.completed raw-video-uploaded content-analysis content.analysis.requested content.analysis.completed content.analysis.failed; do
+for topic in asset-events bucket-events analyze.job.requested hls.job.requested cmaf.job.requested thumbnails.job.requested analyze.job.completed hls.job.completed dash.job.completed cmaf.job.completed thumbnails.job.completed markers.job.requested markers.job.completed transcode.job.progress transcode.job.cancel raw-video-uploaded content-analysis content.analysis.requested content.analysis.completed content.analysis.failed; do
   echo "[INFO] Configuration for $topic:"
   docker exec kafka kafka-topics \
     --bootstrap-server localhost:9092 \
@@ -240,9 +336,17 @@ echo "  - asset-events: 6 partitions, 7 days retention"
 echo "  - bucket-events: 3 partitions, 7 days retention"
 echo "  - analyze.job.requested: 4 partitions, 3 days retention"
 echo "  - hls.job.requested: 4 partitions, 3 days retention"
+echo "  - cmaf.job.requested: 4 partitions, 3 days retention"
+echo "  - thumbnails.job.requested: 4 partitions, 3 days retention"
 echo "  - analyze.job.completed: 6 partitions, 7 days retention"
 echo "  - hls.job.completed: 6 partitions, 7 days retention"
 echo "  - dash.job.completed: 6 partitions, 7 days retention"
+echo "  - cmaf.job.completed: 6 partitions, 7 days retention"
+echo "  - thumbnails.job.completed: 6 partitions, 7 days retention"
+echo "  - markers.job.requested: 6 partitions, 7 days retention"
+echo "  - markers.job.completed: 6 partitions, 7 days retention"
+echo "  - transcode.job.progress: 6 partitions, 7 days retention"
+echo "  - transcode.job.cancel: 6 partitions, 7 days retention"
 echo "  - raw-video-uploaded: 4 partitions, 7 days retention"
 echo "  - content-analysis: 4 partitions, 30 days retention"
 echo "  - content.analysis.requested: 4 partitions, 3 days retention"